@@ -0,0 +1,27 @@
+check "api_health" {
+  data "utilities_assert_http" "api" {
+    url = "https://api.example.com/health"
+
+    check {
+      name        = "available"
+      status_code = 200
+    }
+
+    check {
+      name         = "json"
+      header       = "Content-Type"
+      header_regex = "^application/json"
+    }
+
+    check {
+      name        = "version"
+      json_path   = "version"
+      json_equals = "1.2.3"
+    }
+  }
+
+  assert {
+    condition     = data.utilities_assert_http.api.passed
+    error_message = join("\n", data.utilities_assert_http.api.failures)
+  }
+}
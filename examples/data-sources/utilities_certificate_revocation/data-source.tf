@@ -0,0 +1,10 @@
+data "utilities_certificate_revocation" "api" {
+  address = "api.example.com:443"
+}
+
+check "api_certificate" {
+  assert {
+    condition     = data.utilities_certificate_revocation.api.status != "revoked"
+    error_message = "The certificate of api.example.com has been revoked: ${coalesce(data.utilities_certificate_revocation.api.revocation_reason, "unknown reason")}."
+  }
+}
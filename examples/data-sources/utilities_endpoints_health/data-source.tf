@@ -0,0 +1,23 @@
+data "utilities_endpoints_health" "fleet" {
+  endpoints = {
+    for name, service in var.services : name => "https://${service.host}/healthz"
+  }
+  request_timeout_ms = 2000
+
+  retry {
+    attempts     = 3
+    min_delay_ms = 1000
+    max_delay_ms = 5000
+  }
+}
+
+resource "utilities_output" "rollout" {
+  value = var.release_version
+
+  lifecycle {
+    precondition {
+      condition     = data.utilities_endpoints_health.fleet.all_healthy
+      error_message = "Unhealthy endpoints: ${join(", ", data.utilities_endpoints_health.fleet.unhealthy)}"
+    }
+  }
+}
@@ -0,0 +1,19 @@
+data "utilities_redis" "feature_flags" {
+  address  = "redis.example.com:6380"
+  password = var.redis_password
+  tls      = true
+  command  = "SCAN"
+  key      = "flags/"
+}
+
+data "utilities_redis" "api" {
+  address  = "redis.example.com:6380"
+  password = var.redis_password
+  tls      = true
+  command  = "HGETALL"
+  key      = "services/api"
+}
+
+output "api_endpoint" {
+  value = "${data.utilities_redis.api.values["host"]}:${data.utilities_redis.api.values["port"]}"
+}
@@ -0,0 +1,22 @@
+data "utilities_snmp_get" "switch" {
+  target    = "10.0.0.2"
+  community = var.snmp_community
+  oids = [
+    "1.3.6.1.2.1.1.5.0", # sysName
+    "1.3.6.1.2.1.2.1.0", # ifNumber
+  ]
+}
+
+data "utilities_snmp_get" "router" {
+  target  = "10.0.0.1:161"
+  version = "3"
+  oids    = ["1.3.6.1.2.1.47.1.1.1.1.11.1"] # entPhysicalSerialNum
+
+  usm {
+    username        = "terraform"
+    auth_protocol   = "SHA256"
+    auth_passphrase = var.snmp_auth_passphrase
+    priv_protocol   = "AES"
+    priv_passphrase = var.snmp_priv_passphrase
+  }
+}
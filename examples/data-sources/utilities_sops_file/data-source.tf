@@ -0,0 +1,9 @@
+data "utilities_sops_file" "secrets" {
+  path = "${path.module}/secrets.enc.yaml"
+}
+
+provider "postgresql" {
+  host     = data.utilities_sops_file.secrets.data.database.host
+  username = data.utilities_sops_file.secrets.data.database.user
+  password = data.utilities_sops_file.secrets.data.database.password
+}
@@ -0,0 +1,10 @@
+data "utilities_sql_query" "regions" {
+  driver = "postgres"
+  dsn    = var.inventory_dsn
+  query  = "SELECT name, cidr FROM regions WHERE environment = $1 ORDER BY name"
+  args   = ["production"]
+}
+
+output "region_cidrs" {
+  value = { for row in data.utilities_sql_query.regions.rows : row.name => row.cidr }
+}
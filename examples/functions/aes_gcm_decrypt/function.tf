@@ -0,0 +1,10 @@
+variable "aes_key" {
+  description = "The base64 encoded 32-byte key."
+  type        = string
+  sensitive   = true
+  ephemeral   = true
+}
+
+locals {
+  api_token = provider::utilities::aes_gcm_decrypt(file("${path.module}/secrets/api_token.enc"), var.aes_key)
+}
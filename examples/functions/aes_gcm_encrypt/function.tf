@@ -0,0 +1,5 @@
+# Run once with `terraform console` and commit the result, the ciphertext
+# changes with each call.
+output "sealed" {
+  value = provider::utilities::aes_gcm_encrypt("s3cr3t", var.aes_key)
+}
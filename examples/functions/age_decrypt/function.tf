@@ -0,0 +1,9 @@
+variable "age_identity" {
+  type      = string
+  sensitive = true
+  ephemeral = true
+}
+
+locals {
+  database_password = provider::utilities::age_decrypt(file("${path.module}/secrets/database.age"), var.age_identity)
+}
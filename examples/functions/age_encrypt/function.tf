@@ -0,0 +1,8 @@
+# Run once with `terraform console` and commit the result, the ciphertext
+# changes with each call.
+output "sealed" {
+  value = provider::utilities::age_encrypt(
+    "s3cr3t",
+    "age1ql3z7hjy54pw3hyww5ayyfg7zqgvc7w3j2elw8zmrj2kg5sfn9aqmcac8p",
+  )
+}
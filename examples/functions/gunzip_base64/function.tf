@@ -0,0 +1,4 @@
+# Payload returned compressed by an API.
+output "config" {
+  value = provider::utilities::gunzip_base64(data.utilities_http.config.response_body_base64)
+}
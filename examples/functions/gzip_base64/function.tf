@@ -0,0 +1,6 @@
+# User data submitted compressed, e.g. to stay below the size limit of the API.
+locals {
+  user_data = provider::utilities::gzip_base64(templatefile("${path.module}/cloud-init.yaml", {
+    hostname = var.hostname
+  }))
+}
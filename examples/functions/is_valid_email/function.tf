@@ -0,0 +1,8 @@
+variable "owner_email" {
+  type = string
+
+  validation {
+    condition     = provider::utilities::is_valid_email(var.owner_email)
+    error_message = "The owner_email must be an email address, e.g. jane.doe@example.com."
+  }
+}
@@ -0,0 +1,8 @@
+variable "hostname" {
+  type = string
+
+  validation {
+    condition     = provider::utilities::is_valid_hostname(var.hostname)
+    error_message = "The hostname must be a valid RFC 1123 hostname."
+  }
+}
@@ -0,0 +1,8 @@
+variable "mac_address" {
+  type = string
+
+  validation {
+    condition     = provider::utilities::is_valid_mac(var.mac_address)
+    error_message = "The mac_address must be a 48-bit MAC address, e.g. 00:11:22:33:44:55."
+  }
+}
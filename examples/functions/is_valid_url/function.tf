@@ -0,0 +1,8 @@
+variable "endpoint" {
+  type = string
+
+  validation {
+    condition     = provider::utilities::is_valid_url(var.endpoint, "https")
+    error_message = "The endpoint must be an https URL."
+  }
+}
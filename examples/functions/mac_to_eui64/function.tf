@@ -0,0 +1,4 @@
+# Returns "2001:db8::211:22ff:fe33:4455".
+output "ipv6_address" {
+  value = provider::utilities::mac_to_eui64("00:11:22:33:44:55", "2001:db8::/64")
+}
@@ -0,0 +1,4 @@
+# Returns "xn--bcher-kva.example".
+output "hostname" {
+  value = provider::utilities::normalize_hostname("Bücher.Example.")
+}
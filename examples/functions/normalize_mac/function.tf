@@ -0,0 +1,9 @@
+locals {
+  # Inventory data in mixed formats, e.g. "0011.2233.4455" or "00-11-22-33-44-55".
+  dhcp_reservations = {
+    for host in var.inventory : host.name => {
+      mac = provider::utilities::normalize_mac(host.mac, "colon")
+      ip  = host.ip
+    }
+  }
+}
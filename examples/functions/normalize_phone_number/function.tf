@@ -0,0 +1,4 @@
+# Returns "+41446681800".
+output "on_call_phone" {
+  value = provider::utilities::normalize_phone_number("044 668 18 00", "CH")
+}
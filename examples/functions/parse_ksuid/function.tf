@@ -0,0 +1,4 @@
+# Returns "2017-10-10T04:00:47Z".
+output "created_at" {
+  value = provider::utilities::parse_ksuid("0ujtsYcgvSTl8PAuAdqWYSMnLOv").timestamp
+}
@@ -0,0 +1,4 @@
+# Returns "2016-07-30T23:54:10.259Z".
+output "created_at" {
+  value = provider::utilities::parse_ulid("01ARZ3NDEKTSV4RRFFQ69G5FAV").timestamp
+}
@@ -0,0 +1,4 @@
+# Returns "2024-06-14T10:14:09.3Z".
+output "created_at" {
+  value = provider::utilities::parse_uuid("0190163d-8694-739b-aea5-966c26f8ad91").timestamp
+}
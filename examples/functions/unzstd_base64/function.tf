@@ -0,0 +1,3 @@
+output "settings" {
+  value = jsondecode(provider::utilities::unzstd_base64(var.compressed_settings))
+}
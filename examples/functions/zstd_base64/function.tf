@@ -0,0 +1,3 @@
+locals {
+  payload = provider::utilities::zstd_base64(jsonencode(var.settings))
+}
@@ -1 +1,39 @@
 provider "utilities" {}
+
+# Fail fast the HTTP requests to a host after 3 consecutive failures, for 1 minute.
+provider "utilities" {
+  alias = "circuit_breaker"
+
+  circuit_breaker {
+    failure_threshold = 3
+    cooldown_ms       = 60000
+  }
+}
+
+# Retry the HTTP requests to each host at most 20 times during the run.
+provider "utilities" {
+  alias = "retry_budget"
+
+  retry_budget = 20
+}
+
+# Make at most 4 HTTP requests at once, whatever the -parallelism.
+provider "utilities" {
+  alias = "max_concurrent_requests"
+
+  max_concurrent_requests = 4
+}
+
+# Send a new X-Request-Id with every attempt of the HTTP requests.
+provider "utilities" {
+  alias = "request_id_header"
+
+  request_id_header = "X-Request-Id"
+}
+
+# Warn about the attributes storing fetched data in plaintext in the state.
+provider "utilities" {
+  alias = "sensitive_audit"
+
+  sensitive_audit = true
+}
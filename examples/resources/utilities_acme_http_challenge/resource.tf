@@ -0,0 +1,16 @@
+# Serve the challenge from the machine running Terraform
+resource "utilities_acme_http_challenge" "serve" {
+  token             = var.challenge_token
+  key_authorization = var.challenge_key_authorization
+  listen_address    = ":80"
+}
+
+# Upload the challenge to the bucket backing the web server
+resource "utilities_acme_http_challenge" "upload" {
+  token             = var.challenge_token
+  key_authorization = var.challenge_key_authorization
+  upload_url        = "https://storage.example.com/www/.well-known/acme-challenge/${var.challenge_token}"
+  upload_headers = {
+    Authorization = "Bearer ${var.storage_token}"
+  }
+}
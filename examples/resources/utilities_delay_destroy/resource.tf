@@ -0,0 +1,9 @@
+# Wait for the connections to drain once the instances are deregistered from
+# the load balancer, before the resources the delay depends on are destroyed
+resource "utilities_delay_destroy" "drain" {
+  delay_seconds = 300
+
+  keepers = {
+    instances = join(",", var.instance_ids)
+  }
+}
@@ -0,0 +1,21 @@
+resource "utilities_graphql" "this" {
+  endpoint = "https://api.example.com/graphql"
+  query    = <<-EOT
+    mutation CreateProject($name: String!) {
+      createProject(name: $name) {
+        id
+      }
+    }
+  EOT
+  variables = {
+    name = var.project_name
+  }
+  headers = {
+    Authorization = "Bearer ${var.api_token}"
+  }
+}
+
+# The identifier of the project created
+output "project_id" {
+  value = utilities_graphql.this.data.createProject.id
+}
@@ -0,0 +1,11 @@
+resource "utilities_grpc" "unary" {
+  target       = "localhost:50051"
+  plaintext    = true
+  method       = "grpc.health.v1.Health/Check"
+  request_json = jsonencode({ service = "" })
+}
+
+resource "utilities_grpc" "health" {
+  target = "api.example.com:443"
+  mode   = "health"
+}
@@ -0,0 +1,10 @@
+resource "utilities_grpc_health" "api" {
+  target      = "api.example.com:443"
+  service     = "example.v1.OrderService"
+  timeout_ms  = 300000
+  interval_ms = 5000
+
+  ca_cert_pem     = var.ca_cert_pem
+  client_cert_pem = var.client_cert_pem
+  client_key_pem  = var.client_key_pem
+}
@@ -0,0 +1,30 @@
+resource "utilities_http" "team" {
+  url = "https://api.example.com/teams"
+  request_headers = {
+    Authorization = "Bearer ${var.api_token}"
+    Content-Type  = "application/json"
+  }
+  success_status_codes = [200, 201, 204]
+
+  create {
+    method       = "POST"
+    request_body = jsonencode({ name = "platform" })
+    id_attribute = "id"
+  }
+
+  read {
+    method = "GET"
+    url    = "https://api.example.com/teams/{id}"
+  }
+
+  update {
+    method       = "PUT"
+    url          = "https://api.example.com/teams/{id}"
+    request_body = jsonencode({ name = "platform" })
+  }
+
+  destroy {
+    method = "DELETE"
+    url    = "https://api.example.com/teams/{id}"
+  }
+}
@@ -0,0 +1,14 @@
+# A token for the bootstrap job, issued again a week before it expires
+resource "utilities_jwt" "bootstrap" {
+  algorithm               = "ES256"
+  key_pem                 = file("${path.module}/signing-key.pem")
+  key_id                  = "bootstrap-2024"
+  expiry_seconds          = 90 * 24 * 3600
+  rotation_window_seconds = 7 * 24 * 3600
+
+  claims = {
+    iss = "terraform"
+    sub = "bootstrap"
+    aud = "https://api.example.com"
+  }
+}
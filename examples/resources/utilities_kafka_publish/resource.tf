@@ -0,0 +1,13 @@
+resource "utilities_kafka_publish" "this" {
+  brokers = ["kafka.example.com:9093"]
+  topic   = "infrastructure-events"
+  key     = "production"
+  value   = jsonencode({ event = "deployed" })
+  tls     = true
+
+  sasl {
+    mechanism = "SCRAM-SHA-512"
+    username  = "terraform"
+    password  = var.kafka_password
+  }
+}
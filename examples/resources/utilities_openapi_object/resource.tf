@@ -0,0 +1,12 @@
+resource "utilities_openapi_object" "this" {
+  spec_url = "https://api.example.com/openapi.yaml"
+  path     = "/pets"
+  schema   = "Pet"
+  body = {
+    name = "rex"
+    tag  = "dog"
+  }
+  request_headers = {
+    Authorization = "Bearer ${var.api_token}"
+  }
+}
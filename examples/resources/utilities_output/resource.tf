@@ -0,0 +1,16 @@
+# Hand the endpoints of the network module over to the other modules
+resource "utilities_output" "endpoints" {
+  value = {
+    api   = module.network.api_endpoint
+    ports = [80, 443]
+  }
+}
+
+# Restart the workers only when the endpoints actually change
+resource "terraform_data" "workers" {
+  input = utilities_output.endpoints.value
+
+  lifecycle {
+    replace_triggered_by = [utilities_output.endpoints.version]
+  }
+}
@@ -0,0 +1,5 @@
+resource "utilities_tcp_send" "this" {
+  address    = "device.example.com:4000"
+  payload    = "STATUS\r\n"
+  read_until = "\r\n"
+}
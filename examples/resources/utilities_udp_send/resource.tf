@@ -0,0 +1,4 @@
+resource "utilities_udp_send" "statsd" {
+  address = "statsd.example.com:8125"
+  payload = "deployments:1|c"
+}
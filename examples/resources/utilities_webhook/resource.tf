@@ -0,0 +1,10 @@
+resource "utilities_webhook" "deployment" {
+  url             = var.slack_webhook_url
+  payload         = jsonencode({ text = "Environment ${var.environment} created at {timestamp}" })
+  destroy_payload = jsonencode({ text = "Environment ${var.environment} destroyed at {timestamp}" })
+  retry_attempts  = 3
+
+  keepers = {
+    version = var.release_version
+  }
+}
@@ -0,0 +1,13 @@
+resource "utilities_websocket" "this" {
+  url          = "wss://echo.example.com/socket"
+  message      = "ping"
+  max_messages = 1
+}
+
+# Wait for the gateway to acknowledge the subscription
+resource "utilities_websocket" "subscription" {
+  url            = "wss://gateway.example.com/realtime"
+  message        = jsonencode({ type = "subscribe", channel = "orders" })
+  response_regex = "\"type\":\"subscribed\""
+  max_messages   = 0
+}
@@ -0,0 +1,314 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package acme
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	defaultTimeout = 10000
+
+	// challengePathPrefix is the path the HTTP-01 challenges are served at, as
+	// defined in https://datatracker.ietf.org/doc/html/rfc8555#section-8.3.
+	challengePathPrefix = "/.well-known/acme-challenge/"
+)
+
+var tokenRegexp = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+var _ resource.Resource = (*acmeHttpChallengeResource)(nil)
+
+func NewAcmeHttpChallengeResource() resource.Resource {
+	return &acmeHttpChallengeResource{}
+}
+
+type acmeHttpChallengeResource struct{}
+type acmeHttpChallengeResourceModel struct {
+	ID               types.String `tfsdk:"id"`
+	Token            types.String `tfsdk:"token"`
+	KeyAuthorization types.String `tfsdk:"key_authorization"`
+	ListenAddress    types.String `tfsdk:"listen_address"`
+	UploadURL        types.String `tfsdk:"upload_url"`
+	UploadMethod     types.String `tfsdk:"upload_method"`
+	UploadHeaders    types.Map    `tfsdk:"upload_headers"`
+	RequestTimeout   types.Int64  `tfsdk:"request_timeout_ms"`
+	Path             types.String `tfsdk:"path"`
+}
+
+func (r *acmeHttpChallengeResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_acme_http_challenge"
+}
+
+func (r *acmeHttpChallengeResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: `
+The ` + "`acme_http_challenge`" + ` resource publishes the key authorization of an
+[ACME HTTP-01 challenge](https://datatracker.ietf.org/doc/html/rfc8555#section-8.3) upon creation,
+and removes it upon destruction. It complements the certificate issuance done by other providers.
+
+The key authorization is either:
+
+- served by the provider at ` + "`/.well-known/acme-challenge/<token>`" + ` on ` + "`listen_address`" + `. The server
+  runs in the provider process and stops when Terraform closes the provider, after all the resources
+  of the provider have been applied.
+- uploaded to ` + "`upload_url`" + `, e.g. to the bucket backing the web server of the domain. The
+  uploaded object is deleted with the same URL upon destruction.
+`,
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The token of the challenge.",
+				Computed:    true,
+			},
+
+			"token": schema.StringAttribute{
+				Description: "The token of the challenge, as given by the ACME server.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(tokenRegexp, "must only contain base64url characters"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
+			"key_authorization": schema.StringAttribute{
+				Description: "The key authorization of the challenge, i.e. the token and the thumbprint of the account key " +
+					"joined by a `.`.",
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
+			"listen_address": schema.StringAttribute{
+				Description: "The address to serve the challenge on, in the `host:port` form, e.g. `:80`. " +
+					"Challenges sharing an address are served by the same server.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("upload_url")),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
+			"upload_url": schema.StringAttribute{
+				Description: "The URL to upload the key authorization to.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
+			"upload_method": schema.StringAttribute{
+				Description: "The HTTP method used to upload the key authorization, either `PUT` or `POST`. Defaults to `PUT`.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(http.MethodPut, http.MethodPost),
+					stringvalidator.AlsoRequires(path.MatchRoot("upload_url")),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
+			"upload_headers": schema.MapAttribute{
+				Description: "A map of request header field names and values sent with the upload and deletion requests, " +
+					"e.g. to authenticate them.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Sensitive:   true,
+			},
+
+			"request_timeout_ms": schema.Int64Attribute{
+				Description: fmt.Sprintf("The time allowed to start serving or to upload the key authorization in milliseconds. Defaults to `%d`.", defaultTimeout),
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(defaultTimeout),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+
+			"path": schema.StringAttribute{
+				Description: "The path the ACME server requests the challenge at.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *acmeHttpChallengeResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+}
+
+func (r *acmeHttpChallengeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var model acmeHttpChallengeResourceModel
+	diags := req.State.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *acmeHttpChallengeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var model acmeHttpChallengeResourceModel
+	diags := req.Plan.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	model.publish(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *acmeHttpChallengeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var model acmeHttpChallengeResourceModel
+	diags := req.Plan.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Changing the published challenge requires a replacement, only the
+	// request options may be updated.
+	model.ID = model.Token
+	model.Path = types.StringValue(challengePathPrefix + model.Token.ValueString())
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *acmeHttpChallengeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var model acmeHttpChallengeResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	model.unpublish(ctx, &resp.Diagnostics)
+}
+
+func (model *acmeHttpChallengeResourceModel) publish(ctx context.Context, diagnostics *diag.Diagnostics) {
+	token := model.Token.ValueString()
+	keyAuthorization := model.KeyAuthorization.ValueString()
+
+	if !model.ListenAddress.IsNull() {
+		if err := serveChallenge(model.ListenAddress.ValueString(), token, keyAuthorization); err != nil {
+			diagnostics.AddError(
+				"Error serving challenge",
+				fmt.Sprintf("Error serving challenge: %s", err),
+			)
+			return
+		}
+	} else {
+		method := http.MethodPut
+		if !model.UploadMethod.IsNull() {
+			method = model.UploadMethod.ValueString()
+		}
+
+		if err := model.request(ctx, method, []byte(keyAuthorization)); err != nil {
+			diagnostics.AddError(
+				"Error uploading challenge",
+				fmt.Sprintf("Error uploading challenge: %s", err),
+			)
+			return
+		}
+	}
+
+	model.ID = model.Token
+	model.Path = types.StringValue(challengePathPrefix + token)
+}
+
+func (model *acmeHttpChallengeResourceModel) unpublish(ctx context.Context, diagnostics *diag.Diagnostics) {
+	if !model.ListenAddress.IsNull() {
+		// The server is gone when the challenge was published by a previous
+		// provider process.
+		stopChallenge(model.ListenAddress.ValueString(), model.Token.ValueString())
+		return
+	}
+
+	if err := model.request(ctx, http.MethodDelete, nil); err != nil {
+		diagnostics.AddError(
+			"Error deleting challenge",
+			fmt.Sprintf("Error deleting challenge: %s", err),
+		)
+	}
+}
+
+// request sends a request to the upload URL. Deleting an object which does
+// not exist is not an error.
+func (model *acmeHttpChallengeResourceModel) request(ctx context.Context, method string, body []byte) error {
+	timeout := time.Duration(model.RequestTimeout.ValueInt64()) * time.Millisecond
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	request, err := http.NewRequestWithContext(ctx, method, model.UploadURL.ValueString(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	if body != nil {
+		request.Header.Set("Content-Type", "text/plain")
+	}
+
+	var headers map[string]string
+	if diags := model.UploadHeaders.ElementsAs(ctx, &headers, false); diags.HasError() {
+		return fmt.Errorf("invalid upload headers")
+	}
+
+	for name, value := range headers {
+		request.Header.Set(name, value)
+	}
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	_, _ = io.Copy(io.Discard, response.Body)
+
+	if method == http.MethodDelete && (response.StatusCode == http.StatusNotFound || response.StatusCode == http.StatusGone) {
+		return nil
+	}
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf("unexpected HTTP status %s", response.Status)
+	}
+
+	return nil
+}
@@ -0,0 +1,185 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package acme_test
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+// freeAddress returns a local address nothing listens on.
+func freeAddress(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("error listening: %s", err)
+	}
+	defer listener.Close()
+
+	return listener.Addr().String()
+}
+
+func getChallenge(address, token string) (int, string, error) {
+	response, err := http.Get(fmt.Sprintf("http://%s/.well-known/acme-challenge/%s", address, token))
+	if err != nil {
+		return 0, "", err
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	return response.StatusCode, string(body), err
+}
+
+func TestAcmeHttpChallengeResource_Serve(t *testing.T) {
+	address := freeAddress(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							resource "utilities_acme_http_challenge" "first" {
+								token             = "first-token"
+								key_authorization = "first-token.thumbprint"
+								listen_address    = %[1]q
+							}
+
+							resource "utilities_acme_http_challenge" "second" {
+								token             = "second_token"
+								key_authorization = "second_token.thumbprint"
+								listen_address    = %[1]q
+							}`, address),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("utilities_acme_http_challenge.first", "path", "/.well-known/acme-challenge/first-token"),
+					func(*terraform.State) error {
+						for token, expected := range map[string]string{
+							"first-token":  "first-token.thumbprint",
+							"second_token": "second_token.thumbprint",
+						} {
+							status, body, err := getChallenge(address, token)
+							if err != nil {
+								return err
+							}
+							if status != http.StatusOK || body != expected {
+								return fmt.Errorf("expected %q, got %d %q", expected, status, body)
+							}
+						}
+
+						status, _, err := getChallenge(address, "unknown")
+						if err != nil {
+							return err
+						}
+						if status != http.StatusNotFound {
+							return fmt.Errorf("expected unknown tokens not to be found, got %d", status)
+						}
+
+						return nil
+					},
+				),
+			},
+		},
+		CheckDestroy: func(*terraform.State) error {
+			if _, _, err := getChallenge(address, "first-token"); err == nil {
+				return fmt.Errorf("expected the server to be stopped")
+			}
+			return nil
+		},
+	})
+}
+
+func TestAcmeHttpChallengeResource_Upload(t *testing.T) {
+	var mu sync.Mutex
+	objects := make(map[string]string)
+
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodPut:
+			body, _ := io.ReadAll(r.Body)
+			objects[r.URL.Path] = string(body)
+			w.WriteHeader(http.StatusCreated)
+		case http.MethodDelete:
+			delete(objects, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}))
+	defer svr.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							resource "utilities_acme_http_challenge" "test" {
+								token             = "token"
+								key_authorization = "token.thumbprint"
+								upload_url        = "%s/bucket/.well-known/acme-challenge/token"
+								upload_headers    = {
+									Authorization = "Bearer secret"
+								}
+							}`, svr.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("utilities_acme_http_challenge.test", "id", "token"),
+					func(*terraform.State) error {
+						mu.Lock()
+						defer mu.Unlock()
+
+						if body := objects["/bucket/.well-known/acme-challenge/token"]; body != "token.thumbprint" {
+							return fmt.Errorf("expected the key authorization to be uploaded, got %q", body)
+						}
+						return nil
+					},
+				),
+			},
+		},
+		CheckDestroy: func(*terraform.State) error {
+			mu.Lock()
+			defer mu.Unlock()
+
+			if len(objects) != 0 {
+				return fmt.Errorf("expected the key authorization to be deleted, got %v", objects)
+			}
+			return nil
+		},
+	})
+}
+
+func TestAcmeHttpChallengeResource_UploadError(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer svr.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							resource "utilities_acme_http_challenge" "test" {
+								token             = "token"
+								key_authorization = "token.thumbprint"
+								upload_url        = "%s/token"
+							}`, svr.URL),
+				ExpectError: regexp.MustCompile("unexpected HTTP status 403 Forbidden"),
+			},
+		},
+	})
+}
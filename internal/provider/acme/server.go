@@ -0,0 +1,99 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package acme
+
+import (
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	challengeServersMu sync.Mutex
+	// challengeServers are the running servers, by listen address.
+	challengeServers = make(map[string]*challengeServer)
+)
+
+// challengeServer serves the key authorizations of the HTTP-01 challenges
+// published on an address.
+type challengeServer struct {
+	server *http.Server
+
+	mu                sync.RWMutex
+	keyAuthorizations map[string]string
+}
+
+func (s *challengeServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	token, ok := strings.CutPrefix(r.URL.Path, challengePathPrefix)
+	if !ok || (r.Method != http.MethodGet && r.Method != http.MethodHead) {
+		http.NotFound(w, r)
+		return
+	}
+
+	s.mu.RLock()
+	keyAuthorization, ok := s.keyAuthorizations[token]
+	s.mu.RUnlock()
+
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain")
+	_, _ = w.Write([]byte(keyAuthorization))
+}
+
+// serveChallenge serves the key authorization of the token on the address,
+// starting a server when none is running on it yet.
+func serveChallenge(address, token, keyAuthorization string) error {
+	challengeServersMu.Lock()
+	defer challengeServersMu.Unlock()
+
+	s, ok := challengeServers[address]
+	if !ok {
+		listener, err := net.Listen("tcp", address)
+		if err != nil {
+			return err
+		}
+
+		s = &challengeServer{keyAuthorizations: make(map[string]string)}
+		s.server = &http.Server{
+			Handler:           s,
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+		go func() { _ = s.server.Serve(listener) }()
+
+		challengeServers[address] = s
+	}
+
+	s.mu.Lock()
+	s.keyAuthorizations[token] = keyAuthorization
+	s.mu.Unlock()
+
+	return nil
+}
+
+// stopChallenge stops serving the token on the address, and stops the server
+// once it has no challenge left.
+func stopChallenge(address, token string) {
+	challengeServersMu.Lock()
+	defer challengeServersMu.Unlock()
+
+	s, ok := challengeServers[address]
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	delete(s.keyAuthorizations, token)
+	empty := len(s.keyAuthorizations) == 0
+	s.mu.Unlock()
+
+	if empty {
+		_ = s.server.Close()
+		delete(challengeServers, address)
+	}
+}
@@ -0,0 +1,335 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package certificate
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const defaultTimeout = 10000
+
+var _ datasource.DataSource = (*certificateRevocationDataSource)(nil)
+
+func NewCertificateRevocationDataSource() datasource.DataSource {
+	return &certificateRevocationDataSource{}
+}
+
+type certificateRevocationDataSource struct{}
+type certificateRevocationDataSourceModel struct {
+	ID               types.String `tfsdk:"id"`
+	CertificatePEM   types.String `tfsdk:"certificate_pem"`
+	IssuerPEM        types.String `tfsdk:"issuer_pem"`
+	Address          types.String `tfsdk:"address"`
+	ServerName       types.String `tfsdk:"server_name"`
+	Method           types.String `tfsdk:"method"`
+	RequestTimeout   types.Int64  `tfsdk:"request_timeout_ms"`
+	SerialNumber     types.String `tfsdk:"serial_number"`
+	NotAfter         types.String `tfsdk:"not_after"`
+	Expired          types.Bool   `tfsdk:"expired"`
+	Status           types.String `tfsdk:"status"`
+	CheckedWith      types.String `tfsdk:"checked_with"`
+	RevokedAt        types.String `tfsdk:"revoked_at"`
+	RevocationReason types.String `tfsdk:"revocation_reason"`
+}
+
+func (d *certificateRevocationDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_certificate_revocation"
+}
+
+func (d *certificateRevocationDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: `
+The ` + "`certificate_revocation`" + ` data source checks the revocation status of a certificate with
+its OCSP responder or its CRL distribution point, and exports it alongside its expiry.
+
+The certificate is either given in PEM format or retrieved from a TLS server. Expired and revoked
+certificates do not raise errors, use the ` + "`expired`" + ` and ` + "`status`" + ` attributes to tell them apart.
+`,
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The serial number of the certificate.",
+				Computed:    true,
+			},
+
+			"certificate_pem": schema.StringAttribute{
+				Description: "The certificate to check in [PEM (RFC 1421)](https://datatracker.ietf.org/doc/html/rfc1421) format. " +
+					"When it contains a chain, the second certificate is used as the issuer.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("address")),
+				},
+			},
+
+			"issuer_pem": schema.StringAttribute{
+				Description: "The certificate of the issuer in [PEM (RFC 1421)](https://datatracker.ietf.org/doc/html/rfc1421) format. " +
+					"It is required for OCSP checks and used to verify the signature of CRLs.",
+				Optional: true,
+			},
+
+			"address": schema.StringAttribute{
+				Description: "The address of a TLS server, in the `host:port` form, to retrieve the certificate and its issuer from. " +
+					"The certificate chain is not verified.",
+				Optional: true,
+			},
+
+			"server_name": schema.StringAttribute{
+				Description: "The server name sent in the TLS handshake. Defaults to the host of `address`.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("address")),
+				},
+			},
+
+			"method": schema.StringAttribute{
+				Description: fmt.Sprintf("The method used to check the revocation status, one of `%s`, `%s` or `%s`. "+
+					"With `%s`, OCSP is preferred and the CRL is used when the certificate has no OCSP responder or the responder fails. "+
+					"Defaults to `%s`.", MethodAuto, MethodOCSP, MethodCRL, MethodAuto, MethodAuto),
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(MethodAuto, MethodOCSP, MethodCRL),
+				},
+			},
+
+			"request_timeout_ms": schema.Int64Attribute{
+				Description: fmt.Sprintf("The time allowed to retrieve the certificate and check its revocation status in milliseconds. Defaults to `%d`.", defaultTimeout),
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+
+			"serial_number": schema.StringAttribute{
+				Description: "The serial number of the certificate, in hexadecimal.",
+				Computed:    true,
+			},
+
+			"not_after": schema.StringAttribute{
+				Description: "The expiry date of the certificate in [RFC 3339](https://datatracker.ietf.org/doc/html/rfc3339) format.",
+				Computed:    true,
+			},
+
+			"expired": schema.BoolAttribute{
+				Description: "Whether the certificate has expired.",
+				Computed:    true,
+			},
+
+			"status": schema.StringAttribute{
+				Description: fmt.Sprintf("The revocation status of the certificate, one of `%s`, `%s` or `%s`.", StatusGood, StatusRevoked, StatusUnknown),
+				Computed:    true,
+			},
+
+			"checked_with": schema.StringAttribute{
+				Description: fmt.Sprintf("The method the revocation status was checked with, either `%s` or `%s`.", MethodOCSP, MethodCRL),
+				Computed:    true,
+			},
+
+			"revoked_at": schema.StringAttribute{
+				Description: "The revocation date of the certificate in [RFC 3339](https://datatracker.ietf.org/doc/html/rfc3339) format, when revoked.",
+				Computed:    true,
+			},
+
+			"revocation_reason": schema.StringAttribute{
+				Description: "The [reason](https://datatracker.ietf.org/doc/html/rfc5280#section-5.3.1) of the revocation, e.g. `keyCompromise`, when revoked.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *certificateRevocationDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+}
+
+func (d *certificateRevocationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model certificateRevocationDataSourceModel
+	diags := req.Config.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	model.read(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (model *certificateRevocationDataSourceModel) read(ctx context.Context, diagnostics *diag.Diagnostics) {
+	timeout := time.Duration(defaultTimeout) * time.Millisecond
+	if !model.RequestTimeout.IsNull() {
+		timeout = time.Duration(model.RequestTimeout.ValueInt64()) * time.Millisecond
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var chain []*x509.Certificate
+	if !model.CertificatePEM.IsNull() {
+		certs, err := parseCertificates(model.CertificatePEM.ValueString())
+		if err != nil {
+			diagnostics.AddAttributeError(
+				path.Root("certificate_pem"),
+				"Invalid certificate",
+				fmt.Sprintf("Error parsing certificate: %s", err),
+			)
+			return
+		}
+		chain = certs
+	} else {
+		certs, err := model.peerCertificates(ctx)
+		if err != nil {
+			diagnostics.AddError(
+				"Error retrieving certificate",
+				fmt.Sprintf("Error retrieving certificate: %s", err),
+			)
+			return
+		}
+		chain = certs
+	}
+
+	cert := chain[0]
+
+	var issuer *x509.Certificate
+	if len(chain) > 1 {
+		issuer = chain[1]
+	}
+
+	if !model.IssuerPEM.IsNull() {
+		certs, err := parseCertificates(model.IssuerPEM.ValueString())
+		if err != nil {
+			diagnostics.AddAttributeError(
+				path.Root("issuer_pem"),
+				"Invalid certificate",
+				fmt.Sprintf("Error parsing issuer certificate: %s", err),
+			)
+			return
+		}
+		issuer = certs[0]
+	}
+
+	method := MethodAuto
+	if !model.Method.IsNull() {
+		method = model.Method.ValueString()
+	}
+
+	revocation, err := CheckRevocation(ctx, &http.Client{}, cert, issuer, method)
+	if err != nil {
+		diagnostics.AddError(
+			"Error checking revocation status",
+			fmt.Sprintf("Error checking revocation status: %s", err),
+		)
+		return
+	}
+
+	serialNumber := fmt.Sprintf("%x", cert.SerialNumber)
+
+	model.ID = types.StringValue(serialNumber)
+	model.SerialNumber = types.StringValue(serialNumber)
+	model.NotAfter = types.StringValue(cert.NotAfter.UTC().Format(time.RFC3339))
+	model.Expired = types.BoolValue(time.Now().After(cert.NotAfter))
+	model.Status = types.StringValue(revocation.Status)
+	model.CheckedWith = types.StringValue(revocation.Method)
+	model.RevokedAt = types.StringNull()
+	model.RevocationReason = types.StringNull()
+
+	if revocation.Status == StatusRevoked {
+		model.RevokedAt = types.StringValue(revocation.RevokedAt.UTC().Format(time.RFC3339))
+		model.RevocationReason = types.StringValue(revocation.Reason)
+	}
+}
+
+// peerCertificates returns the certificate chain presented by the server.
+func (model *certificateRevocationDataSourceModel) peerCertificates(ctx context.Context) ([]*x509.Certificate, error) {
+	address := model.Address.ValueString()
+
+	serverName := model.ServerName.ValueString()
+	if serverName == "" {
+		host, _, err := net.SplitHostPort(address)
+		if err != nil {
+			return nil, err
+		}
+		serverName = host
+	}
+
+	dialer := &tls.Dialer{
+		Config: &tls.Config{
+			ServerName: serverName,
+			// The chain is inspected, not trusted: expired and revoked
+			// certificates must still be retrieved.
+			InsecureSkipVerify: true,
+		},
+	}
+
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	tlsConn, ok := conn.(*tls.Conn)
+	if !ok {
+		return nil, fmt.Errorf("unexpected connection type %T", conn)
+	}
+
+	certs := tlsConn.ConnectionState().PeerCertificates
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("the server did not present any certificate")
+	}
+
+	return certs, nil
+}
+
+// parseCertificates parses all the certificates of a PEM document.
+func parseCertificates(data string) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+
+	rest := []byte(strings.TrimSpace(data))
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("no PEM encoded certificate found")
+	}
+
+	return certs, nil
+}
@@ -0,0 +1,270 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package certificate_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"golang.org/x/crypto/ocsp"
+)
+
+// testPKI is a certificate authority serving an OCSP responder and a CRL, in
+// which the certificates with an odd serial number are revoked.
+type testPKI struct {
+	server *httptest.Server
+	ca     *x509.Certificate
+	caKey  *ecdsa.PrivateKey
+	caPEM  string
+}
+
+func newTestPKI(t *testing.T) *testPKI {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ca, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pki := &testPKI{
+		ca:    ca,
+		caKey: key,
+		caPEM: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ocsp", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		request, err := ocsp.ParseRequest(body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		template := ocsp.Response{
+			Status:       ocsp.Good,
+			SerialNumber: request.SerialNumber,
+			ThisUpdate:   time.Now().Add(-time.Minute),
+			NextUpdate:   time.Now().Add(time.Hour),
+		}
+		if request.SerialNumber.Bit(0) == 1 {
+			template.Status = ocsp.Revoked
+			template.RevokedAt = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+			template.RevocationReason = ocsp.KeyCompromise
+		}
+
+		response, err := ocsp.CreateResponse(pki.ca, pki.ca, template, pki.caKey)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/ocsp-response")
+		_, _ = w.Write(response)
+	})
+	mux.HandleFunc("/crl", func(w http.ResponseWriter, r *http.Request) {
+		crl, err := x509.CreateRevocationList(rand.Reader, &x509.RevocationList{
+			Number:     big.NewInt(1),
+			ThisUpdate: time.Now().Add(-time.Minute),
+			NextUpdate: time.Now().Add(time.Hour),
+			RevokedCertificateEntries: []x509.RevocationListEntry{
+				{SerialNumber: big.NewInt(3), RevocationTime: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), ReasonCode: ocsp.Superseded},
+			},
+		}, pki.ca, pki.caKey)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		_, _ = w.Write(crl)
+	})
+
+	pki.server = httptest.NewServer(mux)
+	t.Cleanup(pki.server.Close)
+
+	return pki
+}
+
+// issue returns a certificate signed by the CA, with the OCSP responder
+// and CRL distribution point of the CA when requested.
+func (pki *testPKI) issue(t *testing.T, serial int64, withOCSP, withCRL bool) (*x509.Certificate, string, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	if withOCSP {
+		template.OCSPServer = []string{pki.server.URL + "/ocsp"}
+	}
+	if withCRL {
+		template.CRLDistributionPoints = []string{pki.server.URL + "/crl"}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, pki.ca, &key.PublicKey, pki.caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return cert, string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})), key
+}
+
+func TestCertificateRevocationDataSource_OCSP(t *testing.T) {
+	pki := newTestPKI(t)
+	_, goodPEM, _ := pki.issue(t, 2, true, false)
+	_, revokedPEM, _ := pki.issue(t, 3, true, false)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_certificate_revocation" "good" {
+								certificate_pem = %q
+								issuer_pem      = %q
+							}
+
+							data "utilities_certificate_revocation" "revoked" {
+								certificate_pem = %q
+								issuer_pem      = %q
+							}`, goodPEM, pki.caPEM, revokedPEM, pki.caPEM),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utilities_certificate_revocation.good", "status", "good"),
+					resource.TestCheckResourceAttr("data.utilities_certificate_revocation.good", "checked_with", "ocsp"),
+					resource.TestCheckResourceAttr("data.utilities_certificate_revocation.good", "expired", "false"),
+					resource.TestCheckResourceAttr("data.utilities_certificate_revocation.good", "serial_number", "2"),
+					resource.TestCheckNoResourceAttr("data.utilities_certificate_revocation.good", "revoked_at"),
+					resource.TestCheckResourceAttr("data.utilities_certificate_revocation.revoked", "status", "revoked"),
+					resource.TestCheckResourceAttr("data.utilities_certificate_revocation.revoked", "revoked_at", "2024-01-02T03:04:05Z"),
+					resource.TestCheckResourceAttr("data.utilities_certificate_revocation.revoked", "revocation_reason", "keyCompromise"),
+				),
+			},
+		},
+	})
+}
+
+func TestCertificateRevocationDataSource_CRL(t *testing.T) {
+	pki := newTestPKI(t)
+	_, goodPEM, _ := pki.issue(t, 2, false, true)
+	_, revokedPEM, _ := pki.issue(t, 3, false, true)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_certificate_revocation" "good" {
+								certificate_pem = %q
+							}
+
+							data "utilities_certificate_revocation" "revoked" {
+								certificate_pem = %q
+								issuer_pem      = %q
+								method          = "crl"
+							}`, goodPEM, revokedPEM, pki.caPEM),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utilities_certificate_revocation.good", "status", "good"),
+					resource.TestCheckResourceAttr("data.utilities_certificate_revocation.good", "checked_with", "crl"),
+					resource.TestCheckResourceAttr("data.utilities_certificate_revocation.revoked", "status", "revoked"),
+					resource.TestCheckResourceAttr("data.utilities_certificate_revocation.revoked", "revocation_reason", "superseded"),
+				),
+			},
+		},
+	})
+}
+
+func TestCertificateRevocationDataSource_Address(t *testing.T) {
+	pki := newTestPKI(t)
+	cert, _, key := pki.issue(t, 3, true, true)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.TLS = &tls.Config{
+		Certificates: []tls.Certificate{{
+			Certificate: [][]byte{cert.Raw, pki.ca.Raw},
+			PrivateKey:  key,
+		}},
+	}
+	server.StartTLS()
+	defer server.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_certificate_revocation" "test" {
+								address     = %q
+								server_name = "localhost"
+							}`, server.Listener.Addr().String()),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utilities_certificate_revocation.test", "status", "revoked"),
+					resource.TestCheckResourceAttr("data.utilities_certificate_revocation.test", "checked_with", "ocsp"),
+				),
+			},
+		},
+	})
+}
+
+func TestCertificateRevocationDataSource_MissingIssuer(t *testing.T) {
+	pki := newTestPKI(t)
+	_, certPEM, _ := pki.issue(t, 2, true, false)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_certificate_revocation" "test" {
+								certificate_pem = %q
+							}`, certPEM),
+				ExpectError: regexp.MustCompile("the issuer certificate is required"),
+			},
+		},
+	})
+}
@@ -0,0 +1,211 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package certificate
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/ocsp"
+)
+
+const (
+	MethodAuto = "auto"
+	MethodOCSP = "ocsp"
+	MethodCRL  = "crl"
+
+	StatusGood    = "good"
+	StatusRevoked = "revoked"
+	StatusUnknown = "unknown"
+)
+
+// maxResponseSize bounds the size of the OCSP responses and CRLs downloaded.
+const maxResponseSize = 32 << 20
+
+// revocationReasons are the names of the CRL reason codes defined in
+// https://datatracker.ietf.org/doc/html/rfc5280#section-5.3.1.
+var revocationReasons = map[int]string{
+	ocsp.Unspecified:          "unspecified",
+	ocsp.KeyCompromise:        "keyCompromise",
+	ocsp.CACompromise:         "cACompromise",
+	ocsp.AffiliationChanged:   "affiliationChanged",
+	ocsp.Superseded:           "superseded",
+	ocsp.CessationOfOperation: "cessationOfOperation",
+	ocsp.CertificateHold:      "certificateHold",
+	ocsp.RemoveFromCRL:        "removeFromCRL",
+	ocsp.PrivilegeWithdrawn:   "privilegeWithdrawn",
+	ocsp.AACompromise:         "aACompromise",
+}
+
+// Revocation is the revocation status of a certificate.
+type Revocation struct {
+	// Status is one of StatusGood, StatusRevoked or StatusUnknown.
+	Status string
+	// Method is the method the status was obtained with, MethodOCSP or MethodCRL.
+	Method    string
+	RevokedAt time.Time
+	Reason    string
+}
+
+// CheckRevocation returns the revocation status of the certificate, checked
+// with the given method. With MethodAuto, OCSP is preferred and CRLs are used
+// when the certificate has no OCSP responder or the responder fails.
+func CheckRevocation(ctx context.Context, client *http.Client, cert, issuer *x509.Certificate, method string) (*Revocation, error) {
+	switch method {
+	case MethodOCSP:
+		return checkOCSP(ctx, client, cert, issuer)
+	case MethodCRL:
+		return checkCRL(ctx, client, cert, issuer)
+	}
+
+	var errs []error
+
+	if len(cert.OCSPServer) > 0 {
+		revocation, err := checkOCSP(ctx, client, cert, issuer)
+		if err == nil {
+			return revocation, nil
+		}
+		errs = append(errs, err)
+	}
+
+	if len(cert.CRLDistributionPoints) > 0 {
+		revocation, err := checkCRL(ctx, client, cert, issuer)
+		if err == nil {
+			return revocation, nil
+		}
+		errs = append(errs, err)
+	}
+
+	if len(errs) == 0 {
+		return nil, fmt.Errorf("the certificate has no OCSP responder nor CRL distribution point")
+	}
+
+	return nil, errors.Join(errs...)
+}
+
+// CheckOCSPResponse returns the revocation status contained in a DER encoded
+// OCSP response, such as one stapled to a TLS handshake.
+func CheckOCSPResponse(response []byte, cert, issuer *x509.Certificate) (*Revocation, error) {
+	parsed, err := ocsp.ParseResponseForCert(response, cert, issuer)
+	if err != nil {
+		return nil, fmt.Errorf("invalid OCSP response: %w", err)
+	}
+
+	revocation := &Revocation{Method: MethodOCSP}
+	switch parsed.Status {
+	case ocsp.Good:
+		revocation.Status = StatusGood
+	case ocsp.Revoked:
+		revocation.Status = StatusRevoked
+		revocation.RevokedAt = parsed.RevokedAt
+		revocation.Reason = revocationReasons[parsed.RevocationReason]
+	default:
+		revocation.Status = StatusUnknown
+	}
+
+	return revocation, nil
+}
+
+func checkOCSP(ctx context.Context, client *http.Client, cert, issuer *x509.Certificate) (*Revocation, error) {
+	if len(cert.OCSPServer) == 0 {
+		return nil, fmt.Errorf("the certificate has no OCSP responder")
+	}
+
+	if issuer == nil {
+		return nil, fmt.Errorf("the issuer certificate is required to check the revocation status with OCSP")
+	}
+
+	request, err := ocsp.CreateRequest(cert, issuer, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating OCSP request: %w", err)
+	}
+
+	server := cert.OCSPServer[0]
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, server, bytes.NewReader(request))
+	if err != nil {
+		return nil, fmt.Errorf("error creating OCSP request: %w", err)
+	}
+	httpRequest.Header.Set("Content-Type", "application/ocsp-request")
+	httpRequest.Header.Set("Accept", "application/ocsp-response")
+
+	response, err := fetch(client, httpRequest)
+	if err != nil {
+		return nil, fmt.Errorf("error querying OCSP responder %s: %w", server, err)
+	}
+
+	return CheckOCSPResponse(response, cert, issuer)
+}
+
+func checkCRL(ctx context.Context, client *http.Client, cert, issuer *x509.Certificate) (*Revocation, error) {
+	var distributionPoint string
+	for _, point := range cert.CRLDistributionPoints {
+		if strings.HasPrefix(point, "http://") || strings.HasPrefix(point, "https://") {
+			distributionPoint = point
+			break
+		}
+	}
+
+	if distributionPoint == "" {
+		return nil, fmt.Errorf("the certificate has no HTTP CRL distribution point")
+	}
+
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodGet, distributionPoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating CRL request: %w", err)
+	}
+
+	der, err := fetch(client, httpRequest)
+	if err != nil {
+		return nil, fmt.Errorf("error downloading CRL %s: %w", distributionPoint, err)
+	}
+
+	if block, _ := pem.Decode(der); block != nil {
+		der = block.Bytes
+	}
+
+	crl, err := x509.ParseRevocationList(der)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CRL %s: %w", distributionPoint, err)
+	}
+
+	if issuer != nil {
+		if err := crl.CheckSignatureFrom(issuer); err != nil {
+			return nil, fmt.Errorf("invalid CRL %s signature: %w", distributionPoint, err)
+		}
+	}
+
+	revocation := &Revocation{Status: StatusGood, Method: MethodCRL}
+	for _, entry := range crl.RevokedCertificateEntries {
+		if entry.SerialNumber.Cmp(cert.SerialNumber) == 0 {
+			revocation.Status = StatusRevoked
+			revocation.RevokedAt = entry.RevocationTime
+			revocation.Reason = revocationReasons[entry.ReasonCode]
+			break
+		}
+	}
+
+	return revocation, nil
+}
+
+func fetch(client *http.Client, request *http.Request) ([]byte, error) {
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d", response.StatusCode)
+	}
+
+	return io.ReadAll(io.LimitReader(response.Body, maxResponseSize))
+}
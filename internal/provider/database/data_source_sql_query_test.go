@@ -0,0 +1,155 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package database_test
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	_ "modernc.org/sqlite"
+)
+
+// createSqliteDatabase creates a SQLite database holding a regions table and
+// returns its data source name.
+func createSqliteDatabase(t *testing.T) string {
+	dsn := "file:" + filepath.Join(t.TempDir(), "test.db")
+
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		t.Fatalf("error opening database: %s", err)
+	}
+	defer db.Close()
+
+	_, err = db.Exec(`
+		CREATE TABLE regions (name TEXT, zones INTEGER, ratio REAL, comment TEXT);
+		INSERT INTO regions VALUES ('eu-west', 3, 0.5, 'primary'), ('us-east', 6, 1.5, NULL);
+	`)
+	if err != nil {
+		t.Fatalf("error creating database: %s", err)
+	}
+
+	return dsn
+}
+
+func TestSqlQueryDataSource_Sqlite(t *testing.T) {
+	dsn := createSqliteDatabase(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_sql_query" "sql_test" {
+								driver = "sqlite"
+								dsn    = %q
+								query  = "SELECT name, zones, ratio, comment FROM regions ORDER BY name"
+							}`, dsn),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utilities_sql_query.sql_test", "columns.#", "4"),
+					resource.TestCheckResourceAttr("data.utilities_sql_query.sql_test", "columns.0", "name"),
+					resource.TestCheckResourceAttr("data.utilities_sql_query.sql_test", "rows.#", "2"),
+					resource.TestCheckResourceAttr("data.utilities_sql_query.sql_test", "rows.0.name", "eu-west"),
+					resource.TestCheckResourceAttr("data.utilities_sql_query.sql_test", "rows.0.zones", "3"),
+					resource.TestCheckResourceAttr("data.utilities_sql_query.sql_test", "rows.0.ratio", "0.5"),
+					resource.TestCheckResourceAttr("data.utilities_sql_query.sql_test", "rows.0.comment", "primary"),
+					resource.TestCheckResourceAttr("data.utilities_sql_query.sql_test", "rows.1.name", "us-east"),
+					resource.TestCheckNoResourceAttr("data.utilities_sql_query.sql_test", "rows.1.comment"),
+				),
+			},
+		},
+	})
+}
+
+func TestSqlQueryDataSource_Args(t *testing.T) {
+	dsn := createSqliteDatabase(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_sql_query" "sql_test" {
+								driver = "sqlite"
+								dsn    = %q
+								query  = "SELECT zones FROM regions WHERE name = ?"
+								args   = ["us-east"]
+							}`, dsn),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utilities_sql_query.sql_test", "rows.#", "1"),
+					resource.TestCheckResourceAttr("data.utilities_sql_query.sql_test", "rows.0.zones", "6"),
+				),
+			},
+		},
+	})
+}
+
+func TestSqlQueryDataSource_ReadOnly(t *testing.T) {
+	dsn := createSqliteDatabase(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_sql_query" "sql_test" {
+								driver = "sqlite"
+								dsn    = %q
+								query  = "DELETE FROM regions RETURNING name"
+							}`, dsn),
+				ExpectError: regexp.MustCompile("Error running query"),
+			},
+		},
+	})
+}
+
+func TestSqlQueryDataSource_InvalidQuery(t *testing.T) {
+	dsn := createSqliteDatabase(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_sql_query" "sql_test" {
+								driver = "sqlite"
+								dsn    = %q
+								query  = "SELECT * FROM missing"
+							}`, dsn),
+				ExpectError: regexp.MustCompile("no such table: missing"),
+			},
+		},
+	})
+}
+
+func TestSqlQueryDataSource_Postgres(t *testing.T) {
+	dsn := os.Getenv("UTILITIES_TEST_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("UTILITIES_TEST_POSTGRES_DSN must be set to run this test against a database")
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_sql_query" "sql_test" {
+								driver = "postgres"
+								dsn    = %q
+								query  = "SELECT $1::int + 1 AS next, NULL AS nothing"
+								args   = ["41"]
+							}`, dsn),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utilities_sql_query.sql_test", "rows.0.next", "42"),
+					resource.TestCheckNoResourceAttr("data.utilities_sql_query.sql_test", "rows.0.nothing"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,58 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package functions
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = (*aesGCMDecryptFunction)(nil)
+
+func NewAESGCMDecryptFunction() function.Function {
+	return &aesGCMDecryptFunction{}
+}
+
+type aesGCMDecryptFunction struct{}
+
+func (f *aesGCMDecryptFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "aes_gcm_decrypt"
+}
+
+func (f *aesGCMDecryptFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Decrypts an AES-GCM encrypted string",
+		Description: "Returns the string encrypted by `aes_gcm_encrypt`, the base64 encoded 12-byte nonce followed by the ciphertext and " +
+			"its tag. The key can be given by a variable or an ephemeral value so that it is not stored in the state. " +
+			"Fails when the ciphertext cannot be authenticated with the key.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "ciphertext",
+				Description: "The base64 encoded nonce, ciphertext and tag.",
+			},
+			function.StringParameter{
+				Name:        "key",
+				Description: "The base64 encoded key of 16, 24 or 32 bytes, for AES-128, AES-192 or AES-256.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *aesGCMDecryptFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var ciphertext, key string
+	resp.Error = req.Arguments.Get(ctx, &ciphertext, &key)
+	if resp.Error != nil {
+		return
+	}
+
+	plaintext, err := aesGCMDecrypt(ciphertext, key)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, plaintext)
+}
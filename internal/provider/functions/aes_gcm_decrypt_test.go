@@ -0,0 +1,54 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package functions_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAESGCMDecryptFunction(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					output "test" {
+						value = provider::utilities::aes_gcm_decrypt(
+							"YHETzLEI1Sh6a+pVjdI0pTIc1OxSZZlz4LN322Y+DTwYnQXG7Nsm/zs=",
+							"AAECAwQFBgcICQoLDA0ODxAREhMUFRYXGBkaGxwdHh8=",
+						)
+					}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test", knownvalue.StringExact("Hello, World!")),
+				},
+			},
+			{
+				Config: `
+					output "test" {
+						value = provider::utilities::aes_gcm_decrypt(
+							"YHETzLEI1Sh6a+pVjdI0pTIc1OxSZZlz4LN322Y+DTwYnQXG7Nsm/zs=",
+							"AAECAwQFBgcICQoLDA0ODxAREhMUFRYXGBkaGxwdHh4=",
+						)
+					}`,
+				ExpectError: regexp.MustCompile(`the ciphertext cannot be decrypted with the key`),
+			},
+			{
+				Config: `
+					output "test" {
+						value = provider::utilities::aes_gcm_decrypt("AAAA", "AAECAwQFBgcICQoLDA0ODxAREhMUFRYXGBkaGxwdHh8=")
+					}`,
+				ExpectError: regexp.MustCompile(`the ciphertext is too short`),
+			},
+		},
+	})
+}
@@ -0,0 +1,58 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package functions
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = (*aesGCMEncryptFunction)(nil)
+
+func NewAESGCMEncryptFunction() function.Function {
+	return &aesGCMEncryptFunction{}
+}
+
+type aesGCMEncryptFunction struct{}
+
+func (f *aesGCMEncryptFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "aes_gcm_encrypt"
+}
+
+func (f *aesGCMEncryptFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Encrypts a string with AES-GCM",
+		Description: "Returns the base64 encoded random 12-byte nonce followed by the AES-GCM ciphertext of the string and its tag. " +
+			"The result changes with each call, seal the secret once, e.g. with `terraform console`, and store the result rather " +
+			"than calling the function in the configuration.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "plaintext",
+				Description: "The string to encrypt.",
+			},
+			function.StringParameter{
+				Name:        "key",
+				Description: "The base64 encoded key of 16, 24 or 32 bytes, for AES-128, AES-192 or AES-256.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *aesGCMEncryptFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var plaintext, key string
+	resp.Error = req.Arguments.Get(ctx, &plaintext, &key)
+	if resp.Error != nil {
+		return
+	}
+
+	ciphertext, err := aesGCMEncrypt(plaintext, key)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, ciphertext)
+}
@@ -0,0 +1,52 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package functions_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAESGCMEncryptFunction(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					locals {
+						key        = "AAECAwQFBgcICQoLDA0ODxAREhMUFRYXGBkaGxwdHh8="
+						ciphertext = provider::utilities::aes_gcm_encrypt("Hello, World!", local.key)
+					}
+
+					output "ciphertext" {
+						value = local.ciphertext
+					}
+
+					output "round_trip" {
+						value = provider::utilities::aes_gcm_decrypt(local.ciphertext, local.key)
+					}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					// The 12-byte nonce, the 13-byte ciphertext and the 16-byte tag.
+					statecheck.ExpectKnownOutputValue("ciphertext", knownvalue.StringRegexp(regexp.MustCompile(`^[A-Za-z0-9+/]{55}=$`))),
+					statecheck.ExpectKnownOutputValue("round_trip", knownvalue.StringExact("Hello, World!")),
+				},
+			},
+			{
+				Config: `
+					output "test" {
+						value = provider::utilities::aes_gcm_encrypt("Hello, World!", "AAECAw==")
+					}`,
+				ExpectError: regexp.MustCompile(`invalid key: crypto/aes: invalid key size 4`),
+			},
+		},
+	})
+}
@@ -0,0 +1,58 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package functions
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = (*ageDecryptFunction)(nil)
+
+func NewAgeDecryptFunction() function.Function {
+	return &ageDecryptFunction{}
+}
+
+type ageDecryptFunction struct{}
+
+func (f *ageDecryptFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "age_decrypt"
+}
+
+func (f *ageDecryptFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Decrypts an age encrypted string",
+		Description: "Returns the string held by the ASCII armored [age](https://age-encryption.org) file, the counterpart of " +
+			"`age_encrypt`. The identity can be given by a variable or an ephemeral value so that it is not stored in the state. " +
+			"Fails when none of the identities can decrypt the file.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "ciphertext",
+				Description: "The ASCII armored age file.",
+			},
+			function.StringParameter{
+				Name:        "identity",
+				Description: "The identities in the format of an age identity file: `AGE-SECRET-KEY-1` keys, one per line, `#` comments being ignored.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *ageDecryptFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var ciphertext, identity string
+	resp.Error = req.Arguments.Get(ctx, &ciphertext, &identity)
+	if resp.Error != nil {
+		return
+	}
+
+	plaintext, err := ageDecrypt(ciphertext, identity)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, plaintext)
+}
@@ -0,0 +1,56 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package functions_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAgeDecryptFunction(t *testing.T) {
+	ciphertext := `
+						<<-EOT
+						-----BEGIN AGE ENCRYPTED FILE-----
+						YWdlLWVuY3J5cHRpb24ub3JnL3YxCi0+IFgyNTUxOSBweUhnMTRFLzhXZ2lKbzVU
+						NGQyRmFvcEorWVRrWDRha3Q0YTFIWkhheFVRCjNUcjBRRXl3cGZtT2orNzBCc3Vm
+						dk5UejF4Z3hJbUZma3h3OVJpQjl5M1kKLS0tIG95dVRQWlNOVlZkMTdwMGQvYjVC
+						TFlkbFpaVGZlaGFWVnJmY3UrcjVYanMKR4TL5vRF9jt8q6KJxFNCehx7SxITcamo
+						sz1GdSzDUosIdaVIg2LnApayCbYu
+						-----END AGE ENCRYPTED FILE-----
+						EOT`
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					output "test" {
+						value = provider::utilities::age_decrypt(` + ciphertext + `,
+							"# created: 2024-01-01T00:00:00Z\nAGE-SECRET-KEY-1KFNQ5GXR26PWZGM94ZLHG0QM8TZSD8SR3RYD0DTGJ70UQ4ELEW8QQADJT5\n",
+						)
+					}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test", knownvalue.StringExact("Hello, World!")),
+				},
+			},
+			{
+				Config: `
+					output "test" {
+						value = provider::utilities::age_decrypt(` + ciphertext + `,
+							"AGE-SECRET-KEY-1SCL998E3779RADPW00YRXN0CHAWTHJJNR9Y6L296HDJSSM4LL8KQVPV2C5",
+						)
+					}`,
+				ExpectError: regexp.MustCompile(`no identity matched any of the recipients`),
+			},
+		},
+	})
+}
@@ -0,0 +1,59 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package functions
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = (*ageEncryptFunction)(nil)
+
+func NewAgeEncryptFunction() function.Function {
+	return &ageEncryptFunction{}
+}
+
+type ageEncryptFunction struct{}
+
+func (f *ageEncryptFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "age_encrypt"
+}
+
+func (f *ageEncryptFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Encrypts a string with age",
+		Description: "Returns the ASCII armored [age](https://age-encryption.org) file holding the string, encrypted to the X25519 " +
+			"recipients, e.g. `age1ql3z7hjy54pw3hyww5ayyfg7zqgvc7w3j2elw8zmrj2kg5sfn9aqmcac8p`. The result changes with each call, " +
+			"seal the secret once, e.g. with `terraform console`, and store the result rather than calling the function in the configuration.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "plaintext",
+				Description: "The string to encrypt.",
+			},
+		},
+		VariadicParameter: function.StringParameter{
+			Name:        "recipients",
+			Description: "The X25519 recipients able to decrypt the result, at least one.",
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *ageEncryptFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var plaintext string
+	var recipients []string
+	resp.Error = req.Arguments.Get(ctx, &plaintext, &recipients)
+	if resp.Error != nil {
+		return
+	}
+
+	ciphertext, err := ageEncrypt(plaintext, recipients)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, ciphertext)
+}
@@ -0,0 +1,63 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package functions_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAgeEncryptFunction(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					locals {
+						ciphertext = provider::utilities::age_encrypt(
+							"Hello, World!",
+							"age1n3qxmvh46mlnu862pt0na808t9mjyy0va6szrtf4mmdpxrzn9gdsvf9uwa",
+						)
+					}
+
+					output "ciphertext" {
+						value = local.ciphertext
+					}
+
+					output "round_trip" {
+						value = provider::utilities::age_decrypt(
+							local.ciphertext,
+							"AGE-SECRET-KEY-1KFNQ5GXR26PWZGM94ZLHG0QM8TZSD8SR3RYD0DTGJ70UQ4ELEW8QQADJT5",
+						)
+					}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("ciphertext", knownvalue.StringRegexp(regexp.MustCompile(`^-----BEGIN AGE ENCRYPTED FILE-----\n`))),
+					statecheck.ExpectKnownOutputValue("round_trip", knownvalue.StringExact("Hello, World!")),
+				},
+			},
+			{
+				Config: `
+					output "test" {
+						value = provider::utilities::age_encrypt("Hello, World!")
+					}`,
+				ExpectError: regexp.MustCompile(`at least one recipient is required`),
+			},
+			{
+				Config: `
+					output "test" {
+						value = provider::utilities::age_encrypt("Hello, World!", "ssh-ed25519 AAAA")
+					}`,
+				ExpectError: regexp.MustCompile(`malformed recipient`),
+			},
+		},
+	})
+}
@@ -0,0 +1,80 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package functions
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"unicode/utf8"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// gzipCompress returns the gzip stream of data. The header holds no name nor
+// modification time, so that the result only depends on data.
+func gzipCompress(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write(data); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func gzipDecompress(data []byte) ([]byte, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	return io.ReadAll(reader)
+}
+
+func zstdCompress(data []byte) ([]byte, error) {
+	encoder, err := zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1))
+	if err != nil {
+		return nil, err
+	}
+	defer encoder.Close()
+
+	return encoder.EncodeAll(data, nil), nil
+}
+
+func zstdDecompress(data []byte) ([]byte, error) {
+	decoder, err := zstd.NewReader(nil, zstd.WithDecoderConcurrency(1))
+	if err != nil {
+		return nil, err
+	}
+	defer decoder.Close()
+
+	return decoder.DecodeAll(data, nil)
+}
+
+// decompressBase64 decodes the base64 string s and decompresses it, the
+// result must be UTF-8 to be returned as a string.
+func decompressBase64(s string, decompress func([]byte) ([]byte, error)) (string, error) {
+	data, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return "", fmt.Errorf("invalid base64: %w", err)
+	}
+
+	decompressed, err := decompress(data)
+	if err != nil {
+		return "", fmt.Errorf("invalid compressed data: %w", err)
+	}
+
+	if !utf8.Valid(decompressed) {
+		return "", fmt.Errorf("the decompressed data is not valid UTF-8")
+	}
+
+	return string(decompressed), nil
+}
@@ -0,0 +1,138 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package functions
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+)
+
+// ageEncrypt returns the ASCII armored age file holding the plaintext,
+// encrypted to the X25519 recipients.
+func ageEncrypt(plaintext string, recipients []string) (string, error) {
+	if len(recipients) == 0 {
+		return "", fmt.Errorf("at least one recipient is required")
+	}
+
+	parsed := make([]age.Recipient, 0, len(recipients))
+	for _, recipient := range recipients {
+		r, err := age.ParseX25519Recipient(strings.TrimSpace(recipient))
+		if err != nil {
+			return "", err
+		}
+		parsed = append(parsed, r)
+	}
+
+	var buf bytes.Buffer
+	armorWriter := armor.NewWriter(&buf)
+	writer, err := age.Encrypt(armorWriter, parsed...)
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.WriteString(writer, plaintext); err != nil {
+		return "", err
+	}
+	if err := writer.Close(); err != nil {
+		return "", err
+	}
+	if err := armorWriter.Close(); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+// ageDecrypt returns the plaintext of the ASCII armored age file, decrypted
+// with one of the identities, in the format of an age identity file.
+func ageDecrypt(ciphertext, identities string) (string, error) {
+	parsed, err := age.ParseIdentities(strings.NewReader(identities))
+	if err != nil {
+		return "", err
+	}
+
+	reader, err := age.Decrypt(armor.NewReader(strings.NewReader(strings.TrimSpace(ciphertext))), parsed...)
+	if err != nil {
+		return "", err
+	}
+
+	plaintext, err := io.ReadAll(reader)
+	if err != nil {
+		return "", err
+	}
+
+	if !utf8.Valid(plaintext) {
+		return "", fmt.Errorf("the plaintext is not valid UTF-8")
+	}
+
+	return string(plaintext), nil
+}
+
+// newGCM returns the AES-GCM cipher of the base64 encoded key of 16, 24 or 32
+// bytes.
+func newGCM(key string) (cipher.AEAD, error) {
+	data, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base64 key: %w", err)
+	}
+
+	block, err := aes.NewCipher(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key: %w", err)
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// aesGCMEncrypt returns the base64 encoded random nonce followed by the
+// ciphertext and its tag.
+func aesGCMEncrypt(plaintext, key string) (string, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(gcm.Seal(nonce, nonce, []byte(plaintext), nil)), nil
+}
+
+func aesGCMDecrypt(ciphertext, key string) (string, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return "", fmt.Errorf("invalid base64 ciphertext: %w", err)
+	}
+
+	if len(data) < gcm.NonceSize()+gcm.Overhead() {
+		return "", fmt.Errorf("the ciphertext is too short")
+	}
+
+	plaintext, err := gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], nil)
+	if err != nil {
+		return "", fmt.Errorf("the ciphertext cannot be decrypted with the key")
+	}
+
+	if !utf8.Valid(plaintext) {
+		return "", fmt.Errorf("the plaintext is not valid UTF-8")
+	}
+
+	return string(plaintext), nil
+}
@@ -0,0 +1,52 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package functions
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = (*gunzipBase64Function)(nil)
+
+func NewGunzipBase64Function() function.Function {
+	return &gunzipBase64Function{}
+}
+
+type gunzipBase64Function struct{}
+
+func (f *gunzipBase64Function) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "gunzip_base64"
+}
+
+func (f *gunzipBase64Function) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Decodes a base64 string and decompresses it with gzip",
+		Description: "Returns the string held by the base64 encoded gzip stream, the counterpart of `gzip_base64`. Fails when the input is not valid base64, not a gzip stream or does not decompress to UTF-8.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "input",
+				Description: "The base64 encoded gzip data to decompress.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *gunzipBase64Function) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var input string
+	resp.Error = req.Arguments.Get(ctx, &input)
+	if resp.Error != nil {
+		return
+	}
+
+	decompressed, err := decompressBase64(input, gzipDecompress)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, decompressed)
+}
@@ -0,0 +1,49 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package functions_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestGunzipBase64Function(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				// Compressed with `gzip -n`.
+				Config: `
+					output "test" {
+						value = provider::utilities::gunzip_base64("H4sIAAAAAAAAA/NIzcnJ11EIzy/KSVEEANDDSuwNAAAA")
+					}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test", knownvalue.StringExact("Hello, World!")),
+				},
+			},
+			{
+				Config: `
+					output "test" {
+						value = provider::utilities::gunzip_base64("not base64")
+					}`,
+				ExpectError: regexp.MustCompile(`invalid base64`),
+			},
+			{
+				Config: `
+					output "test" {
+						value = provider::utilities::gunzip_base64("aGVsbG8=")
+					}`,
+				ExpectError: regexp.MustCompile(`invalid compressed data`),
+			},
+		},
+	})
+}
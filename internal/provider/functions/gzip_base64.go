@@ -0,0 +1,53 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package functions
+
+import (
+	"context"
+	"encoding/base64"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = (*gzipBase64Function)(nil)
+
+func NewGzipBase64Function() function.Function {
+	return &gzipBase64Function{}
+}
+
+type gzipBase64Function struct{}
+
+func (f *gzipBase64Function) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "gzip_base64"
+}
+
+func (f *gzipBase64Function) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Compresses a string with gzip and encodes it in base64",
+		Description: "Returns the base64 encoded gzip stream of the UTF-8 bytes of the string. The result only depends on the string, the gzip header holding no name nor modification time.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "input",
+				Description: "The string to compress.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *gzipBase64Function) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var input string
+	resp.Error = req.Arguments.Get(ctx, &input)
+	if resp.Error != nil {
+		return
+	}
+
+	compressed, err := gzipCompress([]byte(input))
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, base64.StdEncoding.EncodeToString(compressed))
+}
@@ -0,0 +1,51 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+// Package functions implements the provider-defined functions.
+package functions
+
+import (
+	"strings"
+)
+
+const (
+	maxHostnameLength = 253
+	maxLabelLength    = 63
+)
+
+// isValidHostname reports whether s is a valid hostname as defined by
+// RFC 1123: dot-separated labels of at most 63 letters, digits and hyphens,
+// not starting or ending with a hyphen. A trailing dot, marking a fully
+// qualified name, is allowed.
+func isValidHostname(s string) bool {
+	s = strings.TrimSuffix(s, ".")
+	if s == "" || len(s) > maxHostnameLength {
+		return false
+	}
+
+	for _, label := range strings.Split(s, ".") {
+		if !isValidLabel(label) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isValidLabel(label string) bool {
+	if label == "" || len(label) > maxLabelLength {
+		return false
+	}
+	if label[0] == '-' || label[len(label)-1] == '-' {
+		return false
+	}
+
+	for i := 0; i < len(label); i++ {
+		c := label[i]
+		if !('a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' || c == '-') {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,82 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package functions
+
+import (
+	"context"
+	"net/mail"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+const (
+	maxEmailLength     = 254
+	maxLocalPartLength = 64
+)
+
+var _ function.Function = (*isValidEmailFunction)(nil)
+
+func NewIsValidEmailFunction() function.Function {
+	return &isValidEmailFunction{}
+}
+
+type isValidEmailFunction struct{}
+
+func (f *isValidEmailFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "is_valid_email"
+}
+
+func (f *isValidEmailFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Checks whether a string is a valid email address",
+		Description: "Returns whether the string is a valid email address, an `addr-spec` as defined by " +
+			"[RFC 5322](https://datatracker.ietf.org/doc/html/rfc5322#section-3.4.1) without display name nor angle " +
+			"brackets, e.g. `jane.doe@example.com`. The domain must be a valid hostname, see `is_valid_hostname`, and " +
+			"the address is limited to 254 characters as required by " +
+			"[RFC 5321](https://datatracker.ietf.org/doc/html/rfc5321#section-4.5.3.1).",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "email",
+				Description: "The email address to check.",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (f *isValidEmailFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var email string
+	resp.Error = req.Arguments.Get(ctx, &email)
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, isValidEmail(email))
+}
+
+func isValidEmail(email string) bool {
+	if len(email) > maxEmailLength {
+		return false
+	}
+
+	// The parser also accepts display names and comments, the address must
+	// be the whole string. It is compared in its canonical form, quoting the
+	// local part when needed.
+	address, err := mail.ParseAddress(email)
+	if err != nil || address.Name != "" {
+		return false
+	}
+	canonical := strings.TrimSuffix(strings.TrimPrefix((&mail.Address{Address: address.Address}).String(), "<"), ">")
+	if canonical != email {
+		return false
+	}
+
+	at := strings.LastIndex(email, "@")
+	if at > maxLocalPartLength {
+		return false
+	}
+
+	return isValidHostname(email[at+1:]) && !strings.HasSuffix(email, ".")
+}
@@ -0,0 +1,55 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package functions_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestIsValidEmailFunction(t *testing.T) {
+	for email, expected := range map[string]bool{
+		"jane.doe@example.com":                   true,
+		"jane+tag@sub.example.com":               true,
+		`"jane doe"@example.com`:                 true,
+		"jane@localhost":                         true,
+		"Jane <jane@example.com>":                false,
+		"<jane@example.com>":                     false,
+		"jane@":                                  false,
+		"@example.com":                           false,
+		"jane@example..com":                      false,
+		"jane@exa_mple.com":                      false,
+		"jane@example.com.":                      false,
+		"jane.@example.com":                      false,
+		"jane doe@example.com":                   false,
+		strings.Repeat("a", 65) + "@example.com": false,
+		strings.Repeat("a", 64) + "@example.com": true,
+	} {
+		t.Run(email, func(t *testing.T) {
+			resource.UnitTest(t, resource.TestCase{
+				TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+					tfversion.SkipBelow(tfversion.Version1_8_0),
+				},
+				ProtoV6ProviderFactories: protoV6ProviderFactories(),
+				Steps: []resource.TestStep{
+					{
+						Config: fmt.Sprintf(`
+							output "test" {
+								value = provider::utilities::is_valid_email(%q)
+							}`, email),
+						ConfigStateChecks: []statecheck.StateCheck{
+							statecheck.ExpectKnownOutputValue("test", knownvalue.Bool(expected)),
+						},
+					},
+				},
+			})
+		})
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package functions
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = (*isValidHostnameFunction)(nil)
+
+func NewIsValidHostnameFunction() function.Function {
+	return &isValidHostnameFunction{}
+}
+
+type isValidHostnameFunction struct{}
+
+func (f *isValidHostnameFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "is_valid_hostname"
+}
+
+func (f *isValidHostnameFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Checks whether a string is a valid hostname",
+		Description: "Returns whether the string is a valid hostname as defined by " +
+			"[RFC 1123](https://datatracker.ietf.org/doc/html/rfc1123#section-2.1): dot-separated labels of at most 63 " +
+			"letters, digits and hyphens, not starting or ending with a hyphen, for at most 253 characters. " +
+			"A trailing dot is allowed. Internationalized names must be in their ASCII form, see `normalize_hostname`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "hostname",
+				Description: "The hostname to check.",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (f *isValidHostnameFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var hostname string
+	resp.Error = req.Arguments.Get(ctx, &hostname)
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, isValidHostname(hostname))
+}
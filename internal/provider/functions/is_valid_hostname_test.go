@@ -0,0 +1,58 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package functions_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestIsValidHostnameFunction(t *testing.T) {
+	for hostname, expected := range map[string]bool{
+		"example.com":                       true,
+		"example.com.":                      true,
+		"EXAMPLE.com":                       true,
+		"xn--bcher-kva.example":             true,
+		"localhost":                         true,
+		"a-b.c-d.example":                   true,
+		strings.Repeat("a", 63) + ".com":    true,
+		strings.Repeat("a", 64) + ".com":    false,
+		"":                                  false,
+		".":                                 false,
+		"-example.com":                      false,
+		"example-.com":                      false,
+		"exa_mple.com":                      false,
+		"example..com":                      false,
+		"bücher.example":                    false,
+		"example.com:443":                   false,
+		strings.Repeat("a.", 127) + "com":   false,
+		strings.Repeat("ab.", 82) + "co.uk": true,
+	} {
+		t.Run(hostname, func(t *testing.T) {
+			resource.UnitTest(t, resource.TestCase{
+				TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+					tfversion.SkipBelow(tfversion.Version1_8_0),
+				},
+				ProtoV6ProviderFactories: protoV6ProviderFactories(),
+				Steps: []resource.TestStep{
+					{
+						Config: fmt.Sprintf(`
+							output "test" {
+								value = provider::utilities::is_valid_hostname(%q)
+							}`, hostname),
+						ConfigStateChecks: []statecheck.StateCheck{
+							statecheck.ExpectKnownOutputValue("test", knownvalue.Bool(expected)),
+						},
+					},
+				},
+			})
+		})
+	}
+}
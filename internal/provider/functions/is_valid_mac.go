@@ -0,0 +1,48 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package functions
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = (*isValidMACFunction)(nil)
+
+func NewIsValidMACFunction() function.Function {
+	return &isValidMACFunction{}
+}
+
+type isValidMACFunction struct{}
+
+func (f *isValidMACFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "is_valid_mac"
+}
+
+func (f *isValidMACFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Checks whether a string is a valid MAC address",
+		Description: "Returns whether the string is a 48-bit MAC address, separated by colons (`00:11:22:33:44:55`), " +
+			"hyphens (`00-11-22-33-44-55`) or dots (`0011.2233.4455`), or as 12 hexadecimal digits (`001122334455`).",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "mac",
+				Description: "The MAC address to check.",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (f *isValidMACFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var mac string
+	resp.Error = req.Arguments.Get(ctx, &mac)
+	if resp.Error != nil {
+		return
+	}
+
+	_, err := parseMAC(mac)
+	resp.Error = resp.Result.Set(ctx, err == nil)
+}
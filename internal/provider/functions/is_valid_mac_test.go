@@ -0,0 +1,49 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package functions_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestIsValidMACFunction(t *testing.T) {
+	for mac, expected := range map[string]bool{
+		"00:11:22:33:44:55":       true,
+		"00-11-22-33-44-55":       true,
+		"0011.2233.4455":          true,
+		"001122334455":            true,
+		"AA:BB:CC:DD:EE:FF":       true,
+		"00:11:22:33:44":          false,
+		"00:11:22:33:44:55:66:77": false,
+		"00:11:22:33:44:5g":       false,
+		"00112233445g":            false,
+		"":                        false,
+	} {
+		t.Run(mac, func(t *testing.T) {
+			resource.UnitTest(t, resource.TestCase{
+				TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+					tfversion.SkipBelow(tfversion.Version1_8_0),
+				},
+				ProtoV6ProviderFactories: protoV6ProviderFactories(),
+				Steps: []resource.TestStep{
+					{
+						Config: fmt.Sprintf(`
+							output "test" {
+								value = provider::utilities::is_valid_mac(%q)
+							}`, mac),
+						ConfigStateChecks: []statecheck.StateCheck{
+							statecheck.ExpectKnownOutputValue("test", knownvalue.Bool(expected)),
+						},
+					},
+				},
+			})
+		})
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package functions
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = (*isValidURLFunction)(nil)
+
+func NewIsValidURLFunction() function.Function {
+	return &isValidURLFunction{}
+}
+
+type isValidURLFunction struct{}
+
+func (f *isValidURLFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "is_valid_url"
+}
+
+func (f *isValidURLFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Checks whether a string is a valid absolute URL",
+		Description: "Returns whether the string is a valid absolute URL as defined by " +
+			"[RFC 3986](https://datatracker.ietf.org/doc/html/rfc3986): it has a scheme and a host, either a valid " +
+			"hostname, see `is_valid_hostname`, or an IP address, and an optional port between 1 and 65535. " +
+			"When schemes are given, the scheme of the URL must be one of them, e.g. `is_valid_url(var.endpoint, \"https\")`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "url",
+				Description: "The URL to check.",
+			},
+		},
+		VariadicParameter: function.StringParameter{
+			Name:        "schemes",
+			Description: "The allowed schemes, case insensitive. Any scheme is allowed when none is given.",
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (f *isValidURLFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var rawURL string
+	var schemes []string
+	resp.Error = req.Arguments.Get(ctx, &rawURL, &schemes)
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, isValidURL(rawURL, schemes))
+}
+
+func isValidURL(rawURL string, schemes []string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme == "" || u.Opaque != "" {
+		return false
+	}
+
+	if len(schemes) > 0 {
+		allowed := false
+		for _, scheme := range schemes {
+			allowed = allowed || strings.EqualFold(scheme, u.Scheme)
+		}
+		if !allowed {
+			return false
+		}
+	}
+
+	if port := u.Port(); port != "" {
+		number, err := strconv.Atoi(port)
+		if err != nil || number < 1 || number > 65535 {
+			return false
+		}
+	} else if strings.HasSuffix(u.Host, ":") {
+		return false
+	}
+
+	host := u.Hostname()
+	return net.ParseIP(host) != nil || isValidHostname(host)
+}
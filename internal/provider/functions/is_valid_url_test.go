@@ -0,0 +1,68 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package functions_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestIsValidURLFunction(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					output "https" {
+						value = provider::utilities::is_valid_url("https://example.com:8443/path?query=1#fragment")
+					}
+
+					output "ipv6" {
+						value = provider::utilities::is_valid_url("http://[::1]:8080/")
+					}
+
+					output "allowed_scheme" {
+						value = provider::utilities::is_valid_url("HTTPS://example.com", "http", "https")
+					}
+
+					output "denied_scheme" {
+						value = provider::utilities::is_valid_url("ftp://example.com", "http", "https")
+					}
+
+					output "relative" {
+						value = provider::utilities::is_valid_url("/path")
+					}
+
+					output "no_host" {
+						value = provider::utilities::is_valid_url("https://")
+					}
+
+					output "invalid_host" {
+						value = provider::utilities::is_valid_url("https://exa_mple.com")
+					}
+
+					output "invalid_port" {
+						value = provider::utilities::is_valid_url("https://example.com:65536")
+					}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("https", knownvalue.Bool(true)),
+					statecheck.ExpectKnownOutputValue("ipv6", knownvalue.Bool(true)),
+					statecheck.ExpectKnownOutputValue("allowed_scheme", knownvalue.Bool(true)),
+					statecheck.ExpectKnownOutputValue("denied_scheme", knownvalue.Bool(false)),
+					statecheck.ExpectKnownOutputValue("relative", knownvalue.Bool(false)),
+					statecheck.ExpectKnownOutputValue("no_host", knownvalue.Bool(false)),
+					statecheck.ExpectKnownOutputValue("invalid_host", knownvalue.Bool(false)),
+					statecheck.ExpectKnownOutputValue("invalid_port", knownvalue.Bool(false)),
+				},
+			},
+		},
+	})
+}
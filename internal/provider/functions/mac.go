@@ -0,0 +1,57 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package functions
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net"
+	"strings"
+)
+
+const (
+	macFormatColon  = "colon"
+	macFormatHyphen = "hyphen"
+	macFormatDot    = "dot"
+	macFormatBare   = "bare"
+)
+
+// parseMAC parses a 48-bit MAC address in any of the formats of net.ParseMAC,
+// e.g. `00:11:22:33:44:55`, `00-11-22-33-44-55` or `0011.2233.4455`, or as 12
+// hexadecimal digits.
+func parseMAC(s string) (net.HardwareAddr, error) {
+	if len(s) == 12 {
+		if mac, err := hex.DecodeString(s); err == nil {
+			return mac, nil
+		}
+	}
+
+	mac, err := net.ParseMAC(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid MAC address %q", s)
+	}
+	if len(mac) != 6 {
+		return nil, fmt.Errorf("invalid MAC address %q: expected 48 bits, got %d", s, len(mac)*8)
+	}
+
+	return mac, nil
+}
+
+// formatMAC formats the MAC address in lowercase in the given format.
+func formatMAC(mac net.HardwareAddr, format string) (string, error) {
+	digits := hex.EncodeToString(mac)
+
+	switch format {
+	case macFormatColon:
+		return mac.String(), nil
+	case macFormatHyphen:
+		return strings.ReplaceAll(mac.String(), ":", "-"), nil
+	case macFormatDot:
+		return digits[0:4] + "." + digits[4:8] + "." + digits[8:12], nil
+	case macFormatBare:
+		return digits, nil
+	default:
+		return "", fmt.Errorf("invalid format %q, expected one of %s, %s, %s or %s", format, macFormatColon, macFormatHyphen, macFormatDot, macFormatBare)
+	}
+}
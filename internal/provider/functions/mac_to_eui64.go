@@ -0,0 +1,82 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package functions
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = (*macToEUI64Function)(nil)
+
+func NewMACToEUI64Function() function.Function {
+	return &macToEUI64Function{}
+}
+
+type macToEUI64Function struct{}
+
+func (f *macToEUI64Function) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "mac_to_eui64"
+}
+
+func (f *macToEUI64Function) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Derives an IPv6 address from a MAC address",
+		Description: "Returns the IPv6 address of the prefix with the modified EUI-64 interface identifier of the MAC " +
+			"address, as defined by [RFC 4291](https://datatracker.ietf.org/doc/html/rfc4291#appendix-A) and used by " +
+			"stateless address autoconfiguration, e.g. `2001:db8::211:22ff:fe33:4455` for `00:11:22:33:44:55` and " +
+			"`2001:db8::/64`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "mac",
+				Description: "The 48-bit MAC address, in any of the formats accepted by `is_valid_mac`.",
+			},
+			function.StringParameter{
+				Name:        "prefix",
+				Description: "The IPv6 prefix in CIDR notation, with a length of at most 64 bits, e.g. `2001:db8::/64`.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *macToEUI64Function) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var mac, prefix string
+	resp.Error = req.Arguments.Get(ctx, &mac, &prefix)
+	if resp.Error != nil {
+		return
+	}
+
+	address, err := parseMAC(mac)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	network, err := netip.ParsePrefix(prefix)
+	if err != nil || !network.Addr().Is6() || network.Addr().Is4In6() || network.Bits() > 64 {
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("invalid prefix %q: expected an IPv6 prefix of at most 64 bits", prefix))
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, macToEUI64(address, network).String())
+}
+
+// macToEUI64 returns the address of the network with the modified EUI-64
+// interface identifier of the MAC address: `fffe` is inserted in its middle and
+// its universal/local bit is flipped.
+func macToEUI64(mac net.HardwareAddr, network netip.Prefix) netip.Addr {
+	bytes := network.Masked().Addr().As16()
+
+	bytes[8] = mac[0] ^ 0x02
+	bytes[9], bytes[10] = mac[1], mac[2]
+	bytes[11], bytes[12] = 0xff, 0xfe
+	bytes[13], bytes[14], bytes[15] = mac[3], mac[4], mac[5]
+
+	return netip.AddrFrom16(bytes)
+}
@@ -0,0 +1,58 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package functions_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestMACToEUI64Function(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					output "global" {
+						value = provider::utilities::mac_to_eui64("00:11:22:33:44:55", "2001:db8::/64")
+					}
+
+					output "local" {
+						value = provider::utilities::mac_to_eui64("02:11:22:33:44:55", "2001:db8:1:2::/64")
+					}
+
+					output "link_local" {
+						value = provider::utilities::mac_to_eui64("52:54:00:12:34:56", "fe80::/10")
+					}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("global", knownvalue.StringExact("2001:db8::211:22ff:fe33:4455")),
+					statecheck.ExpectKnownOutputValue("local", knownvalue.StringExact("2001:db8:1:2:11:22ff:fe33:4455")),
+					statecheck.ExpectKnownOutputValue("link_local", knownvalue.StringExact("fe80::5054:ff:fe12:3456")),
+				},
+			},
+			{
+				Config: `
+					output "test" {
+						value = provider::utilities::mac_to_eui64("00:11:22:33:44:55", "2001:db8::/96")
+					}`,
+				ExpectError: regexp.MustCompile(`invalid prefix "2001:db8::/96"`),
+			},
+			{
+				Config: `
+					output "test" {
+						value = provider::utilities::mac_to_eui64("00:11:22:33:44:55", "10.0.0.0/8")
+					}`,
+				ExpectError: regexp.MustCompile(`expected an IPv6 prefix`),
+			},
+		},
+	})
+}
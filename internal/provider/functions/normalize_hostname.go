@@ -0,0 +1,71 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package functions
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"golang.org/x/net/idna"
+)
+
+var _ function.Function = (*normalizeHostnameFunction)(nil)
+
+func NewNormalizeHostnameFunction() function.Function {
+	return &normalizeHostnameFunction{}
+}
+
+type normalizeHostnameFunction struct{}
+
+func (f *normalizeHostnameFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "normalize_hostname"
+}
+
+func (f *normalizeHostnameFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Normalizes a hostname",
+		Description: "Returns the hostname in lowercase and without its trailing dot. Internationalized names are " +
+			"converted to their ASCII form as defined by [IDNA](https://www.unicode.org/reports/tr46/), e.g. " +
+			"`Bücher.Example.` becomes `xn--bcher-kva.example`. Fails when the hostname is not valid.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "hostname",
+				Description: "The hostname to normalize.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *normalizeHostnameFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var hostname string
+	resp.Error = req.Arguments.Get(ctx, &hostname)
+	if resp.Error != nil {
+		return
+	}
+
+	normalized, err := normalizeHostname(hostname)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, normalized)
+}
+
+func normalizeHostname(hostname string) (string, error) {
+	normalized, err := idna.Lookup.ToASCII(strings.TrimSuffix(hostname, "."))
+	if err != nil {
+		return "", fmt.Errorf("invalid hostname %q: %w", hostname, err)
+	}
+
+	normalized = strings.ToLower(normalized)
+	if !isValidHostname(normalized) {
+		return "", fmt.Errorf("invalid hostname %q", hostname)
+	}
+
+	return normalized, nil
+}
@@ -0,0 +1,46 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package functions_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestNormalizeHostnameFunction(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					output "fqdn" {
+						value = provider::utilities::normalize_hostname("WWW.Example.COM.")
+					}
+
+					output "idn" {
+						value = provider::utilities::normalize_hostname("Bücher.Example")
+					}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("fqdn", knownvalue.StringExact("www.example.com")),
+					statecheck.ExpectKnownOutputValue("idn", knownvalue.StringExact("xn--bcher-kva.example")),
+				},
+			},
+			{
+				Config: `
+					output "test" {
+						value = provider::utilities::normalize_hostname("exa_mple.com")
+					}`,
+				ExpectError: regexp.MustCompile(`invalid hostname "exa_mple.com"`),
+			},
+		},
+	})
+}
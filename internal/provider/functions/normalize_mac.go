@@ -0,0 +1,64 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package functions
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = (*normalizeMACFunction)(nil)
+
+func NewNormalizeMACFunction() function.Function {
+	return &normalizeMACFunction{}
+}
+
+type normalizeMACFunction struct{}
+
+func (f *normalizeMACFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "normalize_mac"
+}
+
+func (f *normalizeMACFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Normalizes a MAC address",
+		Description: "Returns the 48-bit MAC address in lowercase in the given format: `colon` (`00:11:22:33:44:55`), " +
+			"`hyphen` (`00-11-22-33-44-55`), `dot` (`0011.2233.4455`) or `bare` (`001122334455`). The MAC address can be " +
+			"in any of these formats. Fails when it is not valid.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "mac",
+				Description: "The MAC address to normalize.",
+			},
+			function.StringParameter{
+				Name:        "format",
+				Description: "The format of the result, one of `colon`, `hyphen`, `dot` or `bare`.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *normalizeMACFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var mac, format string
+	resp.Error = req.Arguments.Get(ctx, &mac, &format)
+	if resp.Error != nil {
+		return
+	}
+
+	address, err := parseMAC(mac)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	normalized, err := formatMAC(address, format)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, normalized)
+}
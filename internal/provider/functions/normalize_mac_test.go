@@ -0,0 +1,63 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package functions_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestNormalizeMACFunction(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					output "colon" {
+						value = provider::utilities::normalize_mac("AA-BB-CC-DD-EE-FF", "colon")
+					}
+
+					output "hyphen" {
+						value = provider::utilities::normalize_mac("aabb.ccdd.eeff", "hyphen")
+					}
+
+					output "dot" {
+						value = provider::utilities::normalize_mac("aabbccddeeff", "dot")
+					}
+
+					output "bare" {
+						value = provider::utilities::normalize_mac("AA:BB:CC:DD:EE:FF", "bare")
+					}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("colon", knownvalue.StringExact("aa:bb:cc:dd:ee:ff")),
+					statecheck.ExpectKnownOutputValue("hyphen", knownvalue.StringExact("aa-bb-cc-dd-ee-ff")),
+					statecheck.ExpectKnownOutputValue("dot", knownvalue.StringExact("aabb.ccdd.eeff")),
+					statecheck.ExpectKnownOutputValue("bare", knownvalue.StringExact("aabbccddeeff")),
+				},
+			},
+			{
+				Config: `
+					output "test" {
+						value = provider::utilities::normalize_mac("aa:bb:cc:dd:ee", "colon")
+					}`,
+				ExpectError: regexp.MustCompile(`invalid MAC address "aa:bb:cc:dd:ee"`),
+			},
+			{
+				Config: `
+					output "test" {
+						value = provider::utilities::normalize_mac("aa:bb:cc:dd:ee:ff", "cisco")
+					}`,
+				ExpectError: regexp.MustCompile(`invalid format "cisco"`),
+			},
+		},
+	})
+}
@@ -0,0 +1,82 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package functions
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/nyaruka/phonenumbers"
+)
+
+var _ function.Function = (*normalizePhoneNumberFunction)(nil)
+
+func NewNormalizePhoneNumberFunction() function.Function {
+	return &normalizePhoneNumberFunction{}
+}
+
+type normalizePhoneNumberFunction struct{}
+
+func (f *normalizePhoneNumberFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "normalize_phone_number"
+}
+
+func (f *normalizePhoneNumberFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Normalizes a phone number to E.164",
+		Description: "Returns the phone number in [E.164](https://www.itu.int/rec/T-REC-E.164) format, e.g. `+41446681800` " +
+			"for `044 668 18 00` in the `CH` region. Numbers in international format, starting with `+`, are parsed " +
+			"regardless of the default region. Fails when the number is not a valid phone number, according to the " +
+			"numbering plan of its region as defined by [libphonenumber](https://github.com/google/libphonenumber).",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "number",
+				Description: "The phone number, in national or international format.",
+			},
+			function.StringParameter{
+				Name: "default_region",
+				Description: "The [ISO 3166-1 alpha-2](https://www.iso.org/iso-3166-country-codes.html) code of the region " +
+					"of the numbers in national format, e.g. `US` or `CH`.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *normalizePhoneNumberFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var number, defaultRegion string
+	resp.Error = req.Arguments.Get(ctx, &number, &defaultRegion)
+	if resp.Error != nil {
+		return
+	}
+
+	defaultRegion = strings.ToUpper(defaultRegion)
+	if !strings.HasPrefix(strings.TrimSpace(number), "+") && phonenumbers.GetCountryCodeForRegion(defaultRegion) == 0 {
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("unknown region %q", defaultRegion))
+		return
+	}
+
+	normalized, err := normalizePhoneNumber(number, defaultRegion)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, normalized)
+}
+
+func normalizePhoneNumber(number, defaultRegion string) (string, error) {
+	parsed, err := phonenumbers.Parse(number, defaultRegion)
+	if err != nil {
+		return "", fmt.Errorf("invalid phone number %q: %w", number, err)
+	}
+
+	if !phonenumbers.IsValidNumber(parsed) {
+		return "", fmt.Errorf("invalid phone number %q", number)
+	}
+
+	return phonenumbers.Format(parsed, phonenumbers.E164), nil
+}
@@ -0,0 +1,58 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package functions_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestNormalizePhoneNumberFunction(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					output "national" {
+						value = provider::utilities::normalize_phone_number("044 668 18 00", "CH")
+					}
+
+					output "international" {
+						value = provider::utilities::normalize_phone_number("+1 (650) 253-0000", "CH")
+					}
+
+					output "lowercase_region" {
+						value = provider::utilities::normalize_phone_number("(650) 253-0000", "us")
+					}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("national", knownvalue.StringExact("+41446681800")),
+					statecheck.ExpectKnownOutputValue("international", knownvalue.StringExact("+16502530000")),
+					statecheck.ExpectKnownOutputValue("lowercase_region", knownvalue.StringExact("+16502530000")),
+				},
+			},
+			{
+				Config: `
+					output "test" {
+						value = provider::utilities::normalize_phone_number("12", "US")
+					}`,
+				ExpectError: regexp.MustCompile(`invalid phone number "12"`),
+			},
+			{
+				Config: `
+					output "test" {
+						value = provider::utilities::normalize_phone_number("044 668 18 00", "XX")
+					}`,
+				ExpectError: regexp.MustCompile(`unknown region "XX"`),
+			},
+		},
+	})
+}
@@ -0,0 +1,112 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package functions
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	base62Alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+	// ksuidEpoch is the Unix time of the KSUID epoch, 2014-05-13T16:53:20Z.
+	ksuidEpoch = 1400000000
+)
+
+var ksuidAttrTypes = map[string]attr.Type{
+	"timestamp":  types.StringType,
+	"unix":       types.Int64Type,
+	"randomness": types.StringType,
+}
+
+var _ function.Function = (*parseKSUIDFunction)(nil)
+
+func NewParseKSUIDFunction() function.Function {
+	return &parseKSUIDFunction{}
+}
+
+type parseKSUIDFunction struct{}
+
+func (f *parseKSUIDFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_ksuid"
+}
+
+func (f *parseKSUIDFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Parses a KSUID",
+		Description: "Returns the components of a [KSUID](https://github.com/segmentio/ksuid): its `timestamp` in " +
+			"[RFC 3339](https://datatracker.ietf.org/doc/html/rfc3339) format, its `unix` timestamp in seconds since the " +
+			"Unix epoch, and its 128-bit random payload, `randomness`, in hexadecimal.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "ksuid",
+				Description: "The KSUID to parse, e.g. `0ujtsYcgvSTl8PAuAdqWYSMnLOv`.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: ksuidAttrTypes,
+		},
+	}
+}
+
+func (f *parseKSUIDFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var ksuid string
+	resp.Error = req.Arguments.Get(ctx, &ksuid)
+	if resp.Error != nil {
+		return
+	}
+
+	data, err := decodeKSUID(ksuid)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	unix := int64(binary.BigEndian.Uint32(data[:4])) + ksuidEpoch
+
+	result, diags := types.ObjectValue(ksuidAttrTypes, map[string]attr.Value{
+		"timestamp":  types.StringValue(formatTimestamp(time.Unix(unix, 0))),
+		"unix":       types.Int64Value(unix),
+		"randomness": types.StringValue(hex.EncodeToString(data[4:])),
+	})
+	resp.Error = function.FuncErrorFromDiags(ctx, diags)
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, result)
+}
+
+// decodeKSUID returns the 20 bytes of the KSUID, encoded in base62.
+func decodeKSUID(ksuid string) ([]byte, error) {
+	if len(ksuid) != 27 {
+		return nil, fmt.Errorf("invalid KSUID %q: expected 27 characters, got %d", ksuid, len(ksuid))
+	}
+
+	value := new(big.Int)
+	for _, c := range ksuid {
+		digit := strings.IndexRune(base62Alphabet, c)
+		if digit < 0 {
+			return nil, fmt.Errorf("invalid KSUID %q: invalid character %q", ksuid, c)
+		}
+		value.Mul(value, big.NewInt(62))
+		value.Add(value, big.NewInt(int64(digit)))
+	}
+
+	if value.BitLen() > 160 {
+		return nil, fmt.Errorf("invalid KSUID %q: value out of range", ksuid)
+	}
+
+	return value.FillBytes(make([]byte, 20)), nil
+}
@@ -0,0 +1,52 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package functions_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestParseKSUIDFunction(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					output "test" {
+						value = provider::utilities::parse_ksuid("0ujtsYcgvSTl8PAuAdqWYSMnLOv")
+					}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"timestamp":  knownvalue.StringExact("2017-10-10T04:00:47Z"),
+						"unix":       knownvalue.Int64Exact(1507608047),
+						"randomness": knownvalue.StringExact("b5a1cd34b5f99d1154fb6853345c9735"),
+					})),
+				},
+			},
+			{
+				Config: `
+					output "test" {
+						value = provider::utilities::parse_ksuid("aWgEPTl1tmebfsQzFP4bxwgy80W")
+					}`,
+				ExpectError: regexp.MustCompile(`value out of range`),
+			},
+			{
+				Config: `
+					output "test" {
+						value = provider::utilities::parse_ksuid("0ujtsYcgvSTl8PAuAdqWYSMnLO_")
+					}`,
+				ExpectError: regexp.MustCompile(`invalid character '_'`),
+			},
+		},
+	})
+}
@@ -0,0 +1,119 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package functions
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// crockfordAlphabet is the base32 alphabet of ULIDs, without I, L, O and U.
+const crockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+var ulidAttrTypes = map[string]attr.Type{
+	"timestamp":  types.StringType,
+	"unix_ms":    types.Int64Type,
+	"randomness": types.StringType,
+}
+
+var _ function.Function = (*parseULIDFunction)(nil)
+
+func NewParseULIDFunction() function.Function {
+	return &parseULIDFunction{}
+}
+
+type parseULIDFunction struct{}
+
+func (f *parseULIDFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_ulid"
+}
+
+func (f *parseULIDFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Parses a ULID",
+		Description: "Returns the components of a [ULID](https://github.com/ulid/spec): its `timestamp` in " +
+			"[RFC 3339](https://datatracker.ietf.org/doc/html/rfc3339) format, its `unix_ms` timestamp in milliseconds " +
+			"since the Unix epoch, and its 80 bits of `randomness` in hexadecimal. The ULID is case insensitive.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "ulid",
+				Description: "The ULID to parse, e.g. `01ARZ3NDEKTSV4RRFFQ69G5FAV`.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: ulidAttrTypes,
+		},
+	}
+}
+
+func (f *parseULIDFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var ulid string
+	resp.Error = req.Arguments.Get(ctx, &ulid)
+	if resp.Error != nil {
+		return
+	}
+
+	data, err := decodeULID(ulid)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	var ms int64
+	for _, b := range data[:6] {
+		ms = ms<<8 | int64(b)
+	}
+
+	result, diags := types.ObjectValue(ulidAttrTypes, map[string]attr.Value{
+		"timestamp":  types.StringValue(formatTimestamp(time.UnixMilli(ms))),
+		"unix_ms":    types.Int64Value(ms),
+		"randomness": types.StringValue(hex.EncodeToString(data[6:])),
+	})
+	resp.Error = function.FuncErrorFromDiags(ctx, diags)
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, result)
+}
+
+// decodeULID returns the 16 bytes of the ULID.
+func decodeULID(ulid string) ([]byte, error) {
+	if len(ulid) != 26 {
+		return nil, fmt.Errorf("invalid ULID %q: expected 26 characters, got %d", ulid, len(ulid))
+	}
+
+	// The 26 characters encode 130 bits, the first one holds the 3 most
+	// significant bits of the 128-bit value.
+	var hi, lo uint64
+	for i, c := range strings.ToUpper(ulid) {
+		value := strings.IndexRune(crockfordAlphabet, c)
+		if value < 0 || (i == 0 && value > 7) {
+			return nil, fmt.Errorf("invalid ULID %q: invalid character %q", ulid, c)
+		}
+
+		hi = hi<<5 | lo>>59
+		lo = lo<<5 | uint64(value)
+	}
+
+	data := make([]byte, 16)
+	for i := 0; i < 8; i++ {
+		data[i] = byte(hi >> (56 - 8*i))
+		data[8+i] = byte(lo >> (56 - 8*i))
+	}
+
+	return data, nil
+}
+
+// formatTimestamp formats the time in RFC 3339 format, in UTC.
+func formatTimestamp(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
@@ -0,0 +1,52 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package functions_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestParseULIDFunction(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					output "test" {
+						value = provider::utilities::parse_ulid("01arz3ndektsv4rrffq69g5fav")
+					}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"timestamp":  knownvalue.StringExact("2016-07-30T23:54:10.259Z"),
+						"unix_ms":    knownvalue.Int64Exact(1469922850259),
+						"randomness": knownvalue.StringExact("d6764c61efb99302bd5b"),
+					})),
+				},
+			},
+			{
+				Config: `
+					output "test" {
+						value = provider::utilities::parse_ulid("81ARZ3NDEKTSV4RRFFQ69G5FAV")
+					}`,
+				ExpectError: regexp.MustCompile(`invalid ULID "81ARZ3NDEKTSV4RRFFQ69G5FAV"`),
+			},
+			{
+				Config: `
+					output "test" {
+						value = provider::utilities::parse_ulid("01ARZ3NDEKTSV4RRFFQ69G5FA")
+					}`,
+				ExpectError: regexp.MustCompile(`expected 26 characters, got 25`),
+			},
+		},
+	})
+}
@@ -0,0 +1,119 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package functions
+
+import (
+	"context"
+	"encoding/hex"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var uuidAttrTypes = map[string]attr.Type{
+	"version":        types.Int64Type,
+	"variant":        types.StringType,
+	"timestamp":      types.StringType,
+	"unix_ms":        types.Int64Type,
+	"randomness":     types.StringType,
+	"clock_sequence": types.Int64Type,
+	"node":           types.StringType,
+}
+
+var _ function.Function = (*parseUUIDFunction)(nil)
+
+func NewParseUUIDFunction() function.Function {
+	return &parseUUIDFunction{}
+}
+
+type parseUUIDFunction struct{}
+
+func (f *parseUUIDFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_uuid"
+}
+
+func (f *parseUUIDFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Parses a UUID",
+		Description: "Returns the components of a [UUID](https://datatracker.ietf.org/doc/html/rfc9562): its `version`, " +
+			"its `variant`, and depending on its version:\n\n" +
+			"- version 1: its `timestamp` in [RFC 3339](https://datatracker.ietf.org/doc/html/rfc3339) format, its " +
+			"`unix_ms` timestamp in milliseconds since the Unix epoch, its `clock_sequence` and its `node` in hexadecimal.\n" +
+			"- version 4: its 122 random bits, `randomness`, in hexadecimal.\n" +
+			"- version 7: its `timestamp`, its `unix_ms` timestamp and its 74 random bits, `randomness`, in hexadecimal.\n\n" +
+			"The other attributes are null.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "uuid",
+				Description: "The UUID to parse, e.g. `0190163d-8694-739b-aea5-966c26f8ad91`.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: uuidAttrTypes,
+		},
+	}
+}
+
+func (f *parseUUIDFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value string
+	resp.Error = req.Arguments.Get(ctx, &value)
+	if resp.Error != nil {
+		return
+	}
+
+	id, err := uuid.Parse(value)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	attributes := map[string]attr.Value{
+		"version":        types.Int64Value(int64(id.Version())),
+		"variant":        types.StringValue(id.Variant().String()),
+		"timestamp":      types.StringNull(),
+		"unix_ms":        types.Int64Null(),
+		"randomness":     types.StringNull(),
+		"clock_sequence": types.Int64Null(),
+		"node":           types.StringNull(),
+	}
+
+	switch id.Version() {
+	case 1:
+		sec, nsec := id.Time().UnixTime()
+		timestamp := time.Unix(sec, nsec)
+		attributes["timestamp"] = types.StringValue(formatTimestamp(timestamp))
+		attributes["unix_ms"] = types.Int64Value(timestamp.UnixMilli())
+		attributes["clock_sequence"] = types.Int64Value(int64(id.ClockSequence()))
+		attributes["node"] = types.StringValue(hex.EncodeToString(id.NodeID()))
+	case 4:
+		attributes["randomness"] = types.StringValue(hex.EncodeToString(randomBits(id, 0)))
+	case 7:
+		sec, nsec := id.Time().UnixTime()
+		timestamp := time.Unix(sec, nsec)
+		attributes["timestamp"] = types.StringValue(formatTimestamp(timestamp))
+		attributes["unix_ms"] = types.Int64Value(timestamp.UnixMilli())
+		attributes["randomness"] = types.StringValue(hex.EncodeToString(randomBits(id, 6)))
+	}
+
+	result, diags := types.ObjectValue(uuidAttrTypes, attributes)
+	resp.Error = function.FuncErrorFromDiags(ctx, diags)
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, result)
+}
+
+// randomBits returns the bytes of the UUID from the offset, with its version
+// and variant bits cleared.
+func randomBits(id uuid.UUID, offset int) []byte {
+	data := id
+	data[6] &= 0x0f
+	data[8] &= 0x3f
+
+	return data[offset:]
+}
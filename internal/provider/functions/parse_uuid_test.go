@@ -0,0 +1,75 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package functions_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestParseUUIDFunction(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					output "v1" {
+						value = provider::utilities::parse_uuid("c232ab00-9414-11ec-b3c8-9f6bdeced846")
+					}
+
+					output "v4" {
+						value = provider::utilities::parse_uuid("f47ac10b-58cc-4372-a567-0e02b2c3d479")
+					}
+
+					output "v7" {
+						value = provider::utilities::parse_uuid("0190163d-8694-739b-aea5-966c26f8ad91")
+					}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("v1", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"version":        knownvalue.Int64Exact(1),
+						"variant":        knownvalue.StringExact("RFC4122"),
+						"timestamp":      knownvalue.StringExact("2022-02-22T19:22:22Z"),
+						"unix_ms":        knownvalue.Int64Exact(1645557742000),
+						"randomness":     knownvalue.Null(),
+						"clock_sequence": knownvalue.Int64Exact(13256),
+						"node":           knownvalue.StringExact("9f6bdeced846"),
+					})),
+					statecheck.ExpectKnownOutputValue("v4", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"version":        knownvalue.Int64Exact(4),
+						"variant":        knownvalue.StringExact("RFC4122"),
+						"timestamp":      knownvalue.Null(),
+						"unix_ms":        knownvalue.Null(),
+						"randomness":     knownvalue.StringExact("f47ac10b58cc037225670e02b2c3d479"),
+						"clock_sequence": knownvalue.Null(),
+						"node":           knownvalue.Null(),
+					})),
+					statecheck.ExpectKnownOutputValue("v7", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"version":        knownvalue.Int64Exact(7),
+						"variant":        knownvalue.StringExact("RFC4122"),
+						"timestamp":      knownvalue.StringExact("2024-06-14T10:14:09.3Z"),
+						"unix_ms":        knownvalue.Int64Exact(1718360049300),
+						"randomness":     knownvalue.StringExact("039b2ea5966c26f8ad91"),
+						"clock_sequence": knownvalue.Null(),
+						"node":           knownvalue.Null(),
+					})),
+				},
+			},
+			{
+				Config: `
+					output "test" {
+						value = provider::utilities::parse_uuid("not-a-uuid")
+					}`,
+				ExpectError: regexp.MustCompile(`invalid UUID length`),
+			},
+		},
+	})
+}
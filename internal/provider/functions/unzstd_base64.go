@@ -0,0 +1,52 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package functions
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = (*unzstdBase64Function)(nil)
+
+func NewUnzstdBase64Function() function.Function {
+	return &unzstdBase64Function{}
+}
+
+type unzstdBase64Function struct{}
+
+func (f *unzstdBase64Function) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "unzstd_base64"
+}
+
+func (f *unzstdBase64Function) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Decodes a base64 string and decompresses it with Zstandard",
+		Description: "Returns the string held by the base64 encoded Zstandard frames, the counterpart of `zstd_base64`. Fails when the input is not valid base64, not Zstandard data or does not decompress to UTF-8.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "input",
+				Description: "The base64 encoded Zstandard data to decompress.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *unzstdBase64Function) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var input string
+	resp.Error = req.Arguments.Get(ctx, &input)
+	if resp.Error != nil {
+		return
+	}
+
+	decompressed, err := decompressBase64(input, zstdDecompress)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, decompressed)
+}
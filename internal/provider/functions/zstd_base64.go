@@ -0,0 +1,53 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package functions
+
+import (
+	"context"
+	"encoding/base64"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = (*zstdBase64Function)(nil)
+
+func NewZstdBase64Function() function.Function {
+	return &zstdBase64Function{}
+}
+
+type zstdBase64Function struct{}
+
+func (f *zstdBase64Function) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "zstd_base64"
+}
+
+func (f *zstdBase64Function) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Compresses a string with Zstandard and encodes it in base64",
+		Description: "Returns the base64 encoded Zstandard frame of the UTF-8 bytes of the string.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "input",
+				Description: "The string to compress.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *zstdBase64Function) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var input string
+	resp.Error = req.Arguments.Get(ctx, &input)
+	if resp.Error != nil {
+		return
+	}
+
+	compressed, err := zstdCompress([]byte(input))
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, base64.StdEncoding.EncodeToString(compressed))
+}
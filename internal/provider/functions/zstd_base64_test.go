@@ -0,0 +1,38 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package functions_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestZstdBase64Function(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					output "compressed" {
+						value = provider::utilities::zstd_base64("Hello, World!")
+					}
+
+					output "round_trip" {
+						value = provider::utilities::unzstd_base64(provider::utilities::zstd_base64("Hello, World!"))
+					}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("compressed", knownvalue.StringExact("KLUv/QQAaQAASGVsbG8sIFdvcmxkIX/kDwg=")),
+					statecheck.ExpectKnownOutputValue("round_trip", knownvalue.StringExact("Hello, World!")),
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,362 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+const (
+	modeUnary  = "unary"
+	modeHealth = "health"
+)
+
+var _ resource.Resource = (*grpcResource)(nil)
+var _ resource.ResourceWithValidateConfig = (*grpcResource)(nil)
+
+func NewGrpcResource() resource.Resource {
+	return &grpcResource{}
+}
+
+type grpcResource struct{}
+type grpcResourceModel struct {
+	connectionModel
+
+	ID              types.String `tfsdk:"id"`
+	Mode            types.String `tfsdk:"mode"`
+	Method          types.String `tfsdk:"method"`
+	HealthService   types.String `tfsdk:"health_service"`
+	RequestJSON     types.String `tfsdk:"request_json"`
+	RequestMetadata types.Map    `tfsdk:"request_metadata"`
+	DescriptorSet   types.String `tfsdk:"descriptor_set_base64"`
+	Keepers         types.Map    `tfsdk:"keepers"`
+	ResponseJSON    types.String `tfsdk:"response_json"`
+	StatusCode      types.String `tfsdk:"status_code"`
+	ServingStatus   types.String `tfsdk:"serving_status"`
+}
+
+func (r *grpcResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_grpc"
+}
+
+func (r *grpcResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: `
+The ` + "`grpc`" + ` resource performs a gRPC call against the given target upon creation
+and exports information about the response.
+
+In ` + "`unary`" + ` mode, the request is given as JSON and transcoded to protobuf using either
+the provided descriptor set or, by default, the server reflection service
+(` + "`grpc.reflection.v1.ServerReflection`" + `). The response is transcoded back to JSON.
+
+In ` + "`health`" + ` mode, the standard ` + "`grpc.health.v1.Health/Check`" + ` method is called
+and the serving status is exported.
+`,
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The target used for the call.",
+				Computed:    true,
+			},
+
+			"target": schema.StringAttribute{
+				Description: "The target of the call, e.g. `localhost:50051` or `dns:///example.com:443`.",
+				Required:    true,
+			},
+
+			"mode": schema.StringAttribute{
+				Description: "The kind of call to perform, either `unary` or `health`. Defaults to `unary`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(modeUnary),
+				Validators: []validator.String{
+					stringvalidator.OneOf(modeUnary, modeHealth),
+				},
+			},
+
+			"method": schema.StringAttribute{
+				Description: "The fully qualified method to call in `unary` mode, e.g. `package.Service/Method`.",
+				Optional:    true,
+			},
+
+			"health_service": schema.StringAttribute{
+				Description: "The service name sent to the health check in `health` mode. " +
+					"Defaults to the empty string, which queries the overall server health.",
+				Optional: true,
+			},
+
+			"request_json": schema.StringAttribute{
+				Description: "The request message in its [JSON representation](https://protobuf.dev/programming-guides/json/). " +
+					"Defaults to an empty message.",
+				Optional: true,
+			},
+
+			"request_metadata": schema.MapAttribute{
+				Description: "A map of metadata keys and values sent along with the call.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+
+			"descriptor_set_base64": schema.StringAttribute{
+				Description: "A base64 encoded `FileDescriptorSet` describing the called service, including its imports " +
+					"(e.g. `protoc --include_imports --descriptor_set_out`). When not set, the server reflection service is used.",
+				Optional: true,
+			},
+
+			"request_timeout_ms": schema.Int64Attribute{
+				Description: "The call timeout in milliseconds.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+
+			"plaintext": schema.BoolAttribute{
+				Description: "Use an unencrypted connection instead of TLS. Defaults to `false`",
+				Optional:    true,
+			},
+
+			"ca_cert_pem": schema.StringAttribute{
+				Description: "Certificate Authority (CA) " +
+					"in [PEM (RFC 1421)](https://datatracker.ietf.org/doc/html/rfc1421) format.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("insecure")),
+				},
+			},
+
+			"client_cert_pem": schema.StringAttribute{
+				Description: "Client certificate " +
+					"in [PEM (RFC 1421)](https://datatracker.ietf.org/doc/html/rfc1421) format.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("client_key_pem")),
+				},
+			},
+
+			"client_key_pem": schema.StringAttribute{
+				Description: "Client key " +
+					"in [PEM (RFC 1421)](https://datatracker.ietf.org/doc/html/rfc1421) format.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("client_cert_pem")),
+				},
+			},
+
+			"insecure": schema.BoolAttribute{
+				Description: "Disables verification of the server's certificate chain and hostname. Defaults to `false`",
+				Optional:    true,
+			},
+
+			"response_json": schema.StringAttribute{
+				Description: "The response message in its JSON representation.",
+				Computed:    true,
+			},
+
+			"status_code": schema.StringAttribute{
+				Description: "The gRPC status code of the call, e.g. `OK`.",
+				Computed:    true,
+			},
+
+			"serving_status": schema.StringAttribute{
+				Description: "The serving status reported by the health check in `health` mode, e.g. `SERVING`.",
+				Computed:    true,
+			},
+
+			"keepers": schema.MapAttribute{
+				Description: "Arbitrary map of values that, when changed, will trigger recreation of " +
+					"resource.",
+				ElementType: types.StringType,
+				Optional:    true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplaceIfConfigured(),
+				},
+			},
+		},
+	}
+}
+
+func (r *grpcResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var model grpcResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if model.Mode.IsUnknown() || model.Method.IsUnknown() {
+		return
+	}
+
+	if model.Mode.ValueString() == modeHealth {
+		if !model.Method.IsNull() {
+			resp.Diagnostics.AddAttributeError(path.Root("method"), "Invalid Attribute Combination",
+				"The method attribute cannot be set in health mode.")
+		}
+		return
+	}
+
+	if model.Method.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("method"), "Missing Required Attribute",
+			"The method attribute is required in unary mode.")
+	}
+}
+
+func (r *grpcResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+}
+
+func (r *grpcResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var model grpcResourceModel
+	diags := req.State.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *grpcResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var model grpcResourceModel
+	diags := req.Plan.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	model.call(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *grpcResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var model grpcResourceModel
+	diags := req.Plan.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	model.call(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *grpcResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data grpcResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (model *grpcResourceModel) call(ctx context.Context, diagnostics *diag.Diagnostics) {
+	conn := model.dial(diagnostics)
+	if diagnostics.HasError() {
+		return
+	}
+	defer conn.Close()
+
+	if model.RequestTimeout.ValueInt64() > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(model.RequestTimeout.ValueInt64())*time.Millisecond)
+		defer cancel()
+	}
+
+	requestMetadata := make(map[string]string)
+	if !model.RequestMetadata.IsNull() {
+		diagnostics.Append(model.RequestMetadata.ElementsAs(ctx, &requestMetadata, false)...)
+		if diagnostics.HasError() {
+			return
+		}
+	}
+	callCtx := metadata.NewOutgoingContext(ctx, metadata.New(requestMetadata))
+
+	model.ID = model.Target
+	model.ResponseJSON = types.StringNull()
+	model.ServingStatus = types.StringNull()
+
+	if model.Mode.ValueString() == modeHealth {
+		var err error
+		model.StatusCode, model.ServingStatus, err = checkHealth(callCtx, conn, model.HealthService.ValueString())
+		if err != nil {
+			addStatusError(diagnostics, err)
+		}
+		return
+	}
+
+	service, method, err := splitMethod(model.Method.ValueString())
+	if err != nil {
+		diagnostics.AddAttributeError(path.Root("method"), "Invalid method", err.Error())
+		return
+	}
+
+	var files *protoregistry.Files
+	if !model.DescriptorSet.IsNull() {
+		files, err = filesFromDescriptorSet(model.DescriptorSet.ValueString())
+	} else {
+		files, err = filesFromReflection(ctx, conn, service)
+	}
+	if err != nil {
+		diagnostics.AddError(
+			"Error resolving service descriptor",
+			fmt.Sprintf("Error resolving the descriptor of service %q: %s", service, err),
+		)
+		return
+	}
+
+	response, err := invokeUnary(callCtx, conn, files, service, method, model.RequestJSON.ValueString())
+	if err != nil {
+		addStatusError(diagnostics, err)
+		return
+	}
+
+	model.StatusCode = types.StringValue(codes.OK.String())
+	model.ResponseJSON = types.StringValue(response)
+}
+
+func addStatusError(diagnostics *diag.Diagnostics, err error) {
+	if s, ok := status.FromError(err); ok {
+		diagnostics.AddError(
+			"Error making call",
+			fmt.Sprintf("The call failed with status %s: %s", s.Code(), s.Message()),
+		)
+		return
+	}
+
+	diagnostics.AddError(
+		"Error making call",
+		fmt.Sprintf("Error making call: %s", err),
+	)
+}
@@ -0,0 +1,340 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultHealthTimeout  = 60000
+	defaultHealthInterval = 1000
+)
+
+var _ resource.Resource = (*grpcHealthResource)(nil)
+
+func NewGrpcHealthResource() resource.Resource {
+	return &grpcHealthResource{}
+}
+
+type grpcHealthResource struct{}
+type grpcHealthResourceModel struct {
+	connectionModel
+
+	ID              types.String `tfsdk:"id"`
+	Service         types.String `tfsdk:"service"`
+	RequestMetadata types.Map    `tfsdk:"request_metadata"`
+	WaitForServing  types.Bool   `tfsdk:"wait_for_serving"`
+	Timeout         types.Int64  `tfsdk:"timeout_ms"`
+	Interval        types.Int64  `tfsdk:"interval_ms"`
+	Keepers         types.Map    `tfsdk:"keepers"`
+	StatusCode      types.String `tfsdk:"status_code"`
+	ServingStatus   types.String `tfsdk:"serving_status"`
+	Attempts        types.Int64  `tfsdk:"attempts"`
+}
+
+func (r *grpcHealthResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_grpc_health"
+}
+
+func (r *grpcHealthResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: `
+The ` + "`grpc_health`" + ` resource calls the standard ` + "`grpc.health.v1.Health/Check`" + ` method
+against the given target upon creation and exports the serving status.
+
+By default, the check is repeated every ` + "`interval_ms`" + ` until the service reports ` + "`SERVING`" + `,
+failing once ` + "`timeout_ms`" + ` has elapsed, which allows waiting for a freshly deployed service
+to become ready.
+`,
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The target used for the check.",
+				Computed:    true,
+			},
+
+			"target": schema.StringAttribute{
+				Description: "The target of the check, e.g. `localhost:50051` or `dns:///example.com:443`.",
+				Required:    true,
+			},
+
+			"service": schema.StringAttribute{
+				Description: "The service name sent to the health check. " +
+					"Defaults to the empty string, which queries the overall server health.",
+				Optional: true,
+			},
+
+			"request_metadata": schema.MapAttribute{
+				Description: "A map of metadata keys and values sent along with each check.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+
+			"wait_for_serving": schema.BoolAttribute{
+				Description: "Whether the check is repeated until the service reports `SERVING`. When `false`, a single " +
+					"check is made and the serving status it reports is exported, whatever it is. Defaults to `true`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
+
+			"timeout_ms": schema.Int64Attribute{
+				Description: fmt.Sprintf("The time allowed for the service to report `SERVING` in milliseconds. Defaults to `%d`.", defaultHealthTimeout),
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(defaultHealthTimeout),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+
+			"interval_ms": schema.Int64Attribute{
+				Description: fmt.Sprintf("The delay between two checks in milliseconds. Defaults to `%d`.", defaultHealthInterval),
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(defaultHealthInterval),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+
+			"request_timeout_ms": schema.Int64Attribute{
+				Description: "The timeout of each check in milliseconds.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+
+			"plaintext": schema.BoolAttribute{
+				Description: "Use an unencrypted connection instead of TLS. Defaults to `false`",
+				Optional:    true,
+			},
+
+			"ca_cert_pem": schema.StringAttribute{
+				Description: "Certificate Authority (CA) " +
+					"in [PEM (RFC 1421)](https://datatracker.ietf.org/doc/html/rfc1421) format.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("insecure")),
+				},
+			},
+
+			"client_cert_pem": schema.StringAttribute{
+				Description: "Client certificate " +
+					"in [PEM (RFC 1421)](https://datatracker.ietf.org/doc/html/rfc1421) format.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("client_key_pem")),
+				},
+			},
+
+			"client_key_pem": schema.StringAttribute{
+				Description: "Client key " +
+					"in [PEM (RFC 1421)](https://datatracker.ietf.org/doc/html/rfc1421) format.",
+				Optional:  true,
+				Sensitive: true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("client_cert_pem")),
+				},
+			},
+
+			"insecure": schema.BoolAttribute{
+				Description: "Disables verification of the server's certificate chain and hostname. Defaults to `false`",
+				Optional:    true,
+			},
+
+			"status_code": schema.StringAttribute{
+				Description: "The gRPC status code of the last check, e.g. `OK`.",
+				Computed:    true,
+			},
+
+			"serving_status": schema.StringAttribute{
+				Description: "The serving status reported by the last check, e.g. `SERVING`.",
+				Computed:    true,
+			},
+
+			"attempts": schema.Int64Attribute{
+				Description: "The number of checks made.",
+				Computed:    true,
+			},
+
+			"keepers": schema.MapAttribute{
+				Description: "Arbitrary map of values that, when changed, will trigger recreation of " +
+					"resource.",
+				ElementType: types.StringType,
+				Optional:    true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplaceIfConfigured(),
+				},
+			},
+		},
+	}
+}
+
+func (r *grpcHealthResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+}
+
+func (r *grpcHealthResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var model grpcHealthResourceModel
+	diags := req.State.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *grpcHealthResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var model grpcHealthResourceModel
+	diags := req.Plan.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	model.check(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *grpcHealthResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var model grpcHealthResourceModel
+	diags := req.Plan.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	model.check(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *grpcHealthResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data grpcHealthResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// check calls the health check, repeating it until the service reports
+// SERVING or the timeout elapses when wait_for_serving is set.
+func (model *grpcHealthResourceModel) check(ctx context.Context, diagnostics *diag.Diagnostics) {
+	conn := model.dial(diagnostics)
+	if diagnostics.HasError() {
+		return
+	}
+	defer conn.Close()
+
+	requestMetadata := make(map[string]string)
+	if !model.RequestMetadata.IsNull() {
+		diagnostics.Append(model.RequestMetadata.ElementsAs(ctx, &requestMetadata, false)...)
+		if diagnostics.HasError() {
+			return
+		}
+	}
+	ctx = metadata.NewOutgoingContext(ctx, metadata.New(requestMetadata))
+
+	deadline := time.Now().Add(time.Duration(model.Timeout.ValueInt64()) * time.Millisecond)
+	interval := time.Duration(model.Interval.ValueInt64()) * time.Millisecond
+	model.ID = model.Target
+
+	var attempts int64
+	for {
+		attempts++
+		var err error
+		model.StatusCode, model.ServingStatus, err = model.call(ctx, conn, deadline)
+		model.Attempts = types.Int64Value(attempts)
+
+		if !model.WaitForServing.ValueBool() {
+			if err != nil {
+				addStatusError(diagnostics, err)
+			}
+			return
+		}
+
+		if err == nil && model.ServingStatus.ValueString() == healthpb.HealthCheckResponse_SERVING.String() {
+			return
+		}
+
+		if time.Now().Add(interval).After(deadline) {
+			detail := fmt.Sprintf("The service did not report SERVING within %d ms after %d checks", model.Timeout.ValueInt64(), attempts)
+			if err != nil {
+				detail += fmt.Sprintf(", the last one failing with status %s: %s", status.Code(err), status.Convert(err).Message())
+			} else {
+				detail += fmt.Sprintf(", the last one reporting %s", model.ServingStatus.ValueString())
+			}
+			diagnostics.AddError("Service not serving", detail+".")
+			return
+		}
+
+		tflog.Debug(ctx, "Service not serving yet, checking again", map[string]interface{}{
+			"attempts":       attempts,
+			"status_code":    model.StatusCode.ValueString(),
+			"serving_status": model.ServingStatus.ValueString(),
+		})
+
+		select {
+		case <-ctx.Done():
+			diagnostics.AddError("Service not serving", fmt.Sprintf("Waiting for the service was interrupted: %s", ctx.Err()))
+			return
+		case <-time.After(interval):
+		}
+	}
+}
+
+// call makes a single health check, bounded by request_timeout_ms and the
+// deadline of the wait.
+func (model *grpcHealthResourceModel) call(ctx context.Context, conn *grpc.ClientConn, deadline time.Time) (statusCode, servingStatus types.String, err error) {
+	if model.WaitForServing.ValueBool() {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, deadline)
+		defer cancel()
+	}
+
+	if model.RequestTimeout.ValueInt64() > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(model.RequestTimeout.ValueInt64())*time.Millisecond)
+		defer cancel()
+	}
+
+	return checkHealth(ctx, conn, model.Service.ValueString())
+}
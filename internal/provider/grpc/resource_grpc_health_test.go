@@ -0,0 +1,128 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package grpc_test
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// startHealthServer starts a plaintext gRPC server whose "warming" service
+// only reports SERVING after the given delay, and returns its address.
+func startHealthServer(t *testing.T, delay time.Duration) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("error listening: %s", err)
+	}
+
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("warming", healthpb.HealthCheckResponse_NOT_SERVING)
+	healthServer.SetServingStatus("draining", healthpb.HealthCheckResponse_NOT_SERVING)
+	timer := time.AfterFunc(delay, func() {
+		healthServer.SetServingStatus("warming", healthpb.HealthCheckResponse_SERVING)
+	})
+	t.Cleanup(func() { timer.Stop() })
+
+	server := grpc.NewServer()
+	healthpb.RegisterHealthServer(server, healthServer)
+
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(server.Stop)
+
+	return listener.Addr().String()
+}
+
+func TestHealthResource_WaitForServing(t *testing.T) {
+	target := startHealthServer(t, 300*time.Millisecond)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							resource "utilities_grpc_health" "test" {
+								target      = "%s"
+								plaintext   = true
+								service     = "warming"
+								interval_ms = 100
+							}`, target),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("utilities_grpc_health.test", "serving_status", "SERVING"),
+					resource.TestCheckResourceAttr("utilities_grpc_health.test", "status_code", "OK"),
+					resource.TestCheckResourceAttrWith("utilities_grpc_health.test", "attempts", func(value string) error {
+						if value == "1" {
+							return fmt.Errorf("expected several checks, got %s", value)
+						}
+						return nil
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestHealthResource_NoWait(t *testing.T) {
+	target := startHealthServer(t, time.Hour)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							resource "utilities_grpc_health" "test" {
+								target           = "%s"
+								plaintext        = true
+								service          = "draining"
+								wait_for_serving = false
+							}`, target),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("utilities_grpc_health.test", "serving_status", "NOT_SERVING"),
+					resource.TestCheckResourceAttr("utilities_grpc_health.test", "attempts", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestHealthResource_Timeout(t *testing.T) {
+	target := startHealthServer(t, time.Hour)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							resource "utilities_grpc_health" "test" {
+								target      = "%s"
+								plaintext   = true
+								service     = "draining"
+								timeout_ms  = 300
+								interval_ms = 100
+							}`, target),
+				ExpectError: regexp.MustCompile(`(?s)did not report SERVING.*reporting NOT_SERVING`),
+			},
+			{
+				Config: fmt.Sprintf(`
+							resource "utilities_grpc_health" "test" {
+								target      = "%s"
+								plaintext   = true
+								service     = "unknown"
+								timeout_ms  = 300
+								interval_ms = 100
+							}`, target),
+				ExpectError: regexp.MustCompile(`(?s)did not report SERVING.*failing with status NotFound`),
+			},
+		},
+	})
+}
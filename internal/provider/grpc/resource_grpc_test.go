@@ -0,0 +1,123 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package grpc_test
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+// startServer starts a plaintext gRPC server exposing the health and
+// reflection services, and returns its address.
+func startServer(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("error listening: %s", err)
+	}
+
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("ready", healthpb.HealthCheckResponse_SERVING)
+	healthServer.SetServingStatus("draining", healthpb.HealthCheckResponse_NOT_SERVING)
+
+	server := grpc.NewServer()
+	healthpb.RegisterHealthServer(server, healthServer)
+	reflection.Register(server)
+
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(server.Stop)
+
+	return listener.Addr().String()
+}
+
+func TestResource_Unary(t *testing.T) {
+	target := startServer(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							resource "utilities_grpc" "grpc_test" {
+								target       = "%s"
+								plaintext    = true
+								method       = "grpc.health.v1.Health/Check"
+								request_json = jsonencode({ service = "ready" })
+							}`, target),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("utilities_grpc.grpc_test", "response_json", `{"status":"SERVING"}`),
+					resource.TestCheckResourceAttr("utilities_grpc.grpc_test", "status_code", "OK"),
+				),
+			},
+		},
+	})
+}
+
+func TestResource_UnaryError(t *testing.T) {
+	target := startServer(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							resource "utilities_grpc" "grpc_test" {
+								target       = "%s"
+								plaintext    = true
+								method       = "grpc.health.v1.Health/Check"
+								request_json = jsonencode({ service = "unknown" })
+							}`, target),
+				ExpectError: regexp.MustCompile(`The call failed with status NotFound`),
+			},
+		},
+	})
+}
+
+func TestResource_Health(t *testing.T) {
+	target := startServer(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							resource "utilities_grpc" "grpc_test" {
+								target         = "%s"
+								plaintext      = true
+								mode           = "health"
+								health_service = "draining"
+							}`, target),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("utilities_grpc.grpc_test", "serving_status", "NOT_SERVING"),
+					resource.TestCheckResourceAttr("utilities_grpc.grpc_test", "status_code", "OK"),
+					resource.TestCheckNoResourceAttr("utilities_grpc.grpc_test", "response_json"),
+				),
+			},
+		},
+	})
+}
+
+func TestResource_MethodRequiredInUnaryMode(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+							resource "utilities_grpc" "grpc_test" {
+								target = "localhost:50051"
+							}`,
+				ExpectError: regexp.MustCompile(`The method attribute is required in unary mode`),
+			},
+		},
+	})
+}
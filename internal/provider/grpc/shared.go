@@ -0,0 +1,247 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package grpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"terraform-provider-utilities/internal/provider/tlsclient"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// connectionModel holds the connection settings shared by the gRPC resources.
+type connectionModel struct {
+	Target         types.String `tfsdk:"target"`
+	RequestTimeout types.Int64  `tfsdk:"request_timeout_ms"`
+	Plaintext      types.Bool   `tfsdk:"plaintext"`
+	CaCertificate  types.String `tfsdk:"ca_cert_pem"`
+	ClientCert     types.String `tfsdk:"client_cert_pem"`
+	ClientKey      types.String `tfsdk:"client_key_pem"`
+	Insecure       types.Bool   `tfsdk:"insecure"`
+}
+
+// dial creates a client connection to the configured target. The connection
+// is established lazily on the first call.
+func (model *connectionModel) dial(diagnostics *diag.Diagnostics) *grpc.ClientConn {
+	var creds credentials.TransportCredentials
+
+	if model.Plaintext.ValueBool() {
+		creds = insecure.NewCredentials()
+	} else {
+		tlsModel := tlsclient.Model{
+			CaCertificate: model.CaCertificate,
+			ClientCert:    model.ClientCert,
+			ClientKey:     model.ClientKey,
+			Insecure:      model.Insecure,
+		}
+		tlsConfig := tlsModel.Config(diagnostics)
+		if diagnostics.HasError() {
+			return nil
+		}
+
+		creds = credentials.NewTLS(tlsConfig)
+	}
+
+	conn, err := grpc.NewClient(model.Target.ValueString(), grpc.WithTransportCredentials(creds))
+	if err != nil {
+		diagnostics.AddError(
+			"Error creating gRPC client",
+			fmt.Sprintf("Error creating gRPC client: %s", err),
+		)
+		return nil
+	}
+
+	return conn
+}
+
+// checkHealth calls the standard `grpc.health.v1.Health/Check` method for the
+// service, the empty string querying the overall server health. It returns
+// the status code of the call and the serving status, null when the call
+// fails.
+func checkHealth(ctx context.Context, conn *grpc.ClientConn, service string) (statusCode, servingStatus types.String, err error) {
+	response, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{
+		Service: service,
+	})
+	if err != nil {
+		return types.StringValue(status.Code(err).String()), types.StringNull(), err
+	}
+
+	return types.StringValue(codes.OK.String()), types.StringValue(response.GetStatus().String()), nil
+}
+
+// splitMethod splits a method name of the form `package.Service/Method` into
+// its service and method parts.
+func splitMethod(fullMethod string) (protoreflect.FullName, protoreflect.Name, error) {
+	service, method, ok := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	if !ok || service == "" || method == "" {
+		return "", "", fmt.Errorf("method %q must be of the form `package.Service/Method`", fullMethod)
+	}
+
+	return protoreflect.FullName(service), protoreflect.Name(method), nil
+}
+
+// filesFromDescriptorSet parses a base64 encoded `FileDescriptorSet`.
+func filesFromDescriptorSet(descriptorSet string) (*protoregistry.Files, error) {
+	data, err := base64.StdEncoding.DecodeString(descriptorSet)
+	if err != nil {
+		return nil, fmt.Errorf("descriptor set is not valid base64: %w", err)
+	}
+
+	var set descriptorpb.FileDescriptorSet
+	if err := proto.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("descriptor set could not be parsed: %w", err)
+	}
+
+	return protodesc.NewFiles(&set)
+}
+
+// filesFromReflection resolves the file declaring the given symbol, and all of
+// its dependencies, using the server reflection service.
+func filesFromReflection(ctx context.Context, conn *grpc.ClientConn, symbol protoreflect.FullName) (*protoregistry.Files, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string]*descriptorpb.FileDescriptorProto)
+	request := &reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_FileContainingSymbol{
+			FileContainingSymbol: string(symbol),
+		},
+	}
+	var pending []string
+
+	for request != nil {
+		if err := stream.Send(request); err != nil {
+			return nil, err
+		}
+
+		response, err := stream.Recv()
+		if err != nil {
+			return nil, err
+		}
+
+		if errResponse := response.GetErrorResponse(); errResponse != nil {
+			return nil, fmt.Errorf("server reflection error: %s", errResponse.GetErrorMessage())
+		}
+
+		for _, raw := range response.GetFileDescriptorResponse().GetFileDescriptorProto() {
+			var file descriptorpb.FileDescriptorProto
+			if err := proto.Unmarshal(raw, &file); err != nil {
+				return nil, fmt.Errorf("server reflection returned an invalid file descriptor: %w", err)
+			}
+
+			if _, ok := files[file.GetName()]; ok {
+				continue
+			}
+
+			files[file.GetName()] = &file
+			pending = append(pending, file.GetDependency()...)
+		}
+
+		request = nil
+		for len(pending) > 0 && request == nil {
+			name := pending[0]
+			pending = pending[1:]
+
+			if _, ok := files[name]; ok {
+				continue
+			}
+
+			request = &reflectionpb.ServerReflectionRequest{
+				MessageRequest: &reflectionpb.ServerReflectionRequest_FileByFilename{
+					FileByFilename: name,
+				},
+			}
+		}
+	}
+
+	if err := stream.CloseSend(); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	set := &descriptorpb.FileDescriptorSet{}
+	for _, file := range files {
+		set.File = append(set.File, file)
+	}
+
+	return protodesc.NewFiles(set)
+}
+
+// invokeUnary performs a unary call of the given method, transcoding the
+// request and the response from and to JSON.
+func invokeUnary(ctx context.Context, conn *grpc.ClientConn, files *protoregistry.Files, service protoreflect.FullName, method protoreflect.Name, requestJSON string) (string, error) {
+	descriptor, err := files.FindDescriptorByName(service)
+	if err != nil {
+		return "", fmt.Errorf("service %q not found: %w", service, err)
+	}
+
+	serviceDescriptor, ok := descriptor.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return "", fmt.Errorf("%q is not a service", service)
+	}
+
+	methodDescriptor := serviceDescriptor.Methods().ByName(method)
+	if methodDescriptor == nil {
+		return "", fmt.Errorf("method %q not found in service %q", method, service)
+	}
+
+	if methodDescriptor.IsStreamingClient() || methodDescriptor.IsStreamingServer() {
+		return "", fmt.Errorf("method %q is a streaming method, only unary methods are supported", method)
+	}
+
+	resolver := dynamicpb.NewTypes(files)
+
+	request := dynamicpb.NewMessage(methodDescriptor.Input())
+	if requestJSON != "" {
+		if err := (protojson.UnmarshalOptions{Resolver: resolver}).Unmarshal([]byte(requestJSON), request); err != nil {
+			return "", fmt.Errorf("request_json could not be transcoded to %s: %w", methodDescriptor.Input().FullName(), err)
+		}
+	}
+
+	response := dynamicpb.NewMessage(methodDescriptor.Output())
+	if err := conn.Invoke(ctx, fmt.Sprintf("/%s/%s", service, method), request, response); err != nil {
+		return "", err
+	}
+
+	data, err := (protojson.MarshalOptions{Resolver: resolver}).Marshal(response)
+	if err != nil {
+		return "", err
+	}
+
+	// protojson output is deliberately unstable, compact it so that the
+	// stored value only changes along with the response.
+	var compacted bytes.Buffer
+	if err := json.Compact(&compacted, data); err != nil {
+		return "", err
+	}
+
+	return compacted.String(), nil
+}
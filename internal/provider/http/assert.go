@@ -0,0 +1,138 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package http
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// checkResponseBody verifies the response body against the configured
+// assertions, so that a request can fail even with a successful status code.
+func (model *modelV0) checkResponseBody(body string, diagnostics *diag.Diagnostics) {
+	if !model.ExpectedResponseBody.IsNull() && body != model.ExpectedResponseBody.ValueString() {
+		diagnostics.AddAttributeError(
+			path.Root("expected_response_body"),
+			"Unexpected response body",
+			"The response body does not match the expected response body.",
+		)
+	}
+
+	if !model.ResponseBodyRegex.IsNull() {
+		re, err := regexp.Compile(model.ResponseBodyRegex.ValueString())
+		if err != nil {
+			diagnostics.AddAttributeError(
+				path.Root("response_body_regex"),
+				"Invalid regular expression",
+				fmt.Sprintf("The regular expression could not be compiled: %s", err),
+			)
+			return
+		}
+
+		if !re.MatchString(body) {
+			diagnostics.AddAttributeError(
+				path.Root("response_body_regex"),
+				"Unexpected response body",
+				fmt.Sprintf("The response body does not match the regular expression %q.", re.String()),
+			)
+		}
+	}
+}
+
+// checkContentType verifies the media type of the response against the
+// expected one, `*` matching any type or subtype, so that e.g. an HTML login
+// page is not taken for the expected JSON document.
+func (model *modelV0) checkContentType(contentType string, diagnostics *diag.Diagnostics) {
+	if model.ExpectedContentType.IsNull() {
+		return
+	}
+
+	expected := strings.ToLower(strings.TrimSpace(model.ExpectedContentType.ValueString()))
+	expectedType, expectedSubtype, _ := strings.Cut(expected, "/")
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err == nil {
+		actualType, actualSubtype, _ := strings.Cut(mediaType, "/")
+		if (expectedType == "*" || expectedType == actualType) && (expectedSubtype == "*" || expectedSubtype == actualSubtype) {
+			return
+		}
+	}
+
+	detail := fmt.Sprintf("The response content type %q does not match the expected content type %q.", contentType, expected)
+	if contentType == "" {
+		detail = fmt.Sprintf("The response has no content type, expected %q.", expected)
+	}
+
+	diagnostics.AddAttributeError(
+		path.Root("expected_content_type"),
+		"Unexpected response content type",
+		detail,
+	)
+}
+
+// bodyAssertionError is the error of a response body containing none of the
+// substrings of `success_body_contains`.
+type bodyAssertionError struct {
+	substrings []string
+}
+
+func (e *bodyAssertionError) Error() string {
+	quoted := make([]string, len(e.substrings))
+	for i, substring := range e.substrings {
+		quoted[i] = fmt.Sprintf("%q", substring)
+	}
+
+	return fmt.Sprintf("the response body contains none of %s", strings.Join(quoted, ", "))
+}
+
+// bodyContainsHandler returns the handler failing the successful responses
+// whose body contains none of the substrings, so that the attempt is retried
+// as a failed one. The body read is put back for the response to be read as
+// usual.
+func (model *modelV0) bodyContainsHandler(substrings []string, successStatusCodes []int) retryablehttp.ResponseHandlerFunc {
+	return func(response *http.Response) error {
+		if !isSuccessStatus(response.StatusCode, successStatusCodes) || response.StatusCode == http.StatusNotModified {
+			return nil
+		}
+
+		// The body past the limit is left to fail the request when read.
+		var reader io.Reader = response.Body
+		if !model.MaxResponseBodyBytes.IsNull() {
+			reader = io.LimitReader(response.Body, model.MaxResponseBodyBytes.ValueInt64())
+		}
+
+		data, err := io.ReadAll(reader)
+		if err != nil {
+			return fmt.Errorf("error reading response body: %w", err)
+		}
+		response.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(data), response.Body), response.Body}
+
+		body := data
+		if !response.Uncompressed {
+			if decompressed, err := decompressBody(data, response.Header.Values("Content-Encoding")); err == nil {
+				body = decompressed
+			}
+		}
+
+		for _, substring := range substrings {
+			if bytes.Contains(body, []byte(substring)) {
+				return nil
+			}
+		}
+
+		return &bodyAssertionError{substrings: substrings}
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// checkCEL evaluates the `validate_cel` expression against the response, the
+// expression failing the request when it is not true.
+func (model *modelV0) checkCEL(response *http.Response, body []byte, diagnostics *diag.Diagnostics) {
+	if model.ValidateCEL.IsNull() {
+		return
+	}
+
+	expression := model.ValidateCEL.ValueString()
+
+	env, err := cel.NewEnv(
+		cel.Variable("status", cel.IntType),
+		cel.Variable("headers", cel.MapType(cel.StringType, cel.StringType)),
+		cel.Variable("body_json", cel.DynType),
+	)
+	if err != nil {
+		diagnostics.AddError(
+			"Error evaluating CEL expression",
+			fmt.Sprintf("Error creating the CEL environment: %s", err),
+		)
+		return
+	}
+
+	ast, issues := env.Compile(expression)
+	if issues.Err() != nil {
+		diagnostics.AddAttributeError(
+			path.Root("validate_cel"),
+			"Invalid CEL expression",
+			fmt.Sprintf("The CEL expression could not be compiled: %s", issues.Err()),
+		)
+		return
+	}
+
+	program, err := env.Program(ast)
+	if err != nil {
+		diagnostics.AddAttributeError(
+			path.Root("validate_cel"),
+			"Invalid CEL expression",
+			fmt.Sprintf("The CEL expression could not be compiled: %s", err),
+		)
+		return
+	}
+
+	headers := make(map[string]string, len(response.Header))
+	for k, v := range response.Header {
+		headers[k] = strings.Join(v, ", ")
+	}
+
+	// The body is null when it is not JSON.
+	var bodyJSON interface{}
+	if isJSONContentType(response.Header.Get("Content-Type")) {
+		_ = json.Unmarshal(body, &bodyJSON)
+	}
+
+	result, _, err := program.Eval(map[string]interface{}{
+		"status":    response.StatusCode,
+		"headers":   headers,
+		"body_json": bodyJSON,
+	})
+	if err != nil {
+		diagnostics.AddAttributeError(
+			path.Root("validate_cel"),
+			"Error evaluating CEL expression",
+			fmt.Sprintf("The CEL expression %q could not be evaluated against the response: %s", expression, err),
+		)
+		return
+	}
+
+	if valid, ok := result.Value().(bool); !ok || !valid {
+		diagnostics.AddAttributeError(
+			path.Root("validate_cel"),
+			"Unexpected response",
+			fmt.Sprintf("The CEL expression %q evaluated to %v instead of true.", expression, result.Value()),
+		)
+	}
+}
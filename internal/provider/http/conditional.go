@@ -0,0 +1,102 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// cacheValidatorsKey is the private state key holding the cache validators of
+// the last response.
+const cacheValidatorsKey = "cache_validators"
+
+// cacheValidators are the validators of a response, sent back as conditional
+// request headers to only retrieve the response again when it has changed.
+type cacheValidators struct {
+	URL          string `json:"url"`
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"last_modified,omitempty"`
+}
+
+// privateState is implemented by the private state of the resource requests
+// and responses.
+type privateState interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// isConditionalMethod reports whether conditional requests are sent with the
+// method. Other methods may not be safe to send again on refresh.
+func isConditionalMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead
+}
+
+// newCacheValidators returns the validators of a response, or nil when it has
+// none.
+func newCacheValidators(requestURL string, header http.Header) *cacheValidators {
+	etag, lastModified := header.Get("ETag"), header.Get("Last-Modified")
+	if etag == "" && lastModified == "" {
+		return nil
+	}
+
+	return &cacheValidators{
+		URL:          requestURL,
+		ETag:         etag,
+		LastModified: lastModified,
+	}
+}
+
+// setHeaders sets the conditional request headers.
+func (validators *cacheValidators) setHeaders(header http.Header) {
+	if validators.ETag != "" {
+		header.Set("If-None-Match", validators.ETag)
+	}
+
+	if validators.LastModified != "" {
+		header.Set("If-Modified-Since", validators.LastModified)
+	}
+}
+
+func getCacheValidators(ctx context.Context, private privateState) (*cacheValidators, diag.Diagnostics) {
+	value, diags := private.GetKey(ctx, cacheValidatorsKey)
+	if diags.HasError() || len(value) == 0 {
+		return nil, diags
+	}
+
+	var validators cacheValidators
+	if err := json.Unmarshal(value, &validators); err != nil {
+		diags.AddError(
+			"Error reading private state",
+			fmt.Sprintf("Error decoding cache validators: %s", err),
+		)
+		return nil, diags
+	}
+
+	return &validators, diags
+}
+
+// setCacheValidators stores the validators in the private state, or removes
+// them when nil.
+func setCacheValidators(ctx context.Context, private privateState, validators *cacheValidators) diag.Diagnostics {
+	if validators == nil {
+		return private.SetKey(ctx, cacheValidatorsKey, nil)
+	}
+
+	value, err := json.Marshal(validators)
+	if err != nil {
+		var diags diag.Diagnostics
+		diags.AddError(
+			"Error writing private state",
+			fmt.Sprintf("Error encoding cache validators: %s", err),
+		)
+		return diags
+	}
+
+	return private.SetKey(ctx, cacheValidatorsKey, value)
+}
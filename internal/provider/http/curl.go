@@ -0,0 +1,136 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package http
+
+import (
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+// redacted replaces the secrets in the curl command.
+const redacted = "REDACTED"
+
+// sensitiveNameRegexp matches the names of the headers, query parameters and
+// form fields whose values are redacted from the curl command.
+var sensitiveNameRegexp = regexp.MustCompile(`(?i)auth|cookie|token|secret|password|passwd|key|session|signature|credential`)
+
+// curlCommand returns the curl command line sending the request, the values
+// of the sensitive headers, query parameters and form fields, the password of
+// the URL and the write-only request body being redacted.
+func (model *modelV0) curlCommand(request *retryablehttp.Request, formData map[string]string) string {
+	args := []string{"curl", "-X", request.Method, shellQuote(redactURL(request.URL))}
+
+	names := make([]string, 0, len(request.Header))
+	for name := range request.Header {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		for _, value := range request.Header[name] {
+			if isSensitiveHeader(name, model.sensitiveHeaders) {
+				value = redacted
+			}
+			args = append(args, "-H", shellQuote(name+": "+value))
+		}
+	}
+
+	if model.Auth.ValueString() != "" {
+		args = append(args, "-H", shellQuote("Authorization: "+redacted))
+	}
+
+	switch {
+	case model.redactRequestBody:
+		args = append(args, "--data-raw", redacted)
+	case !model.RequestBodyFile.IsNull():
+		args = append(args, "--data-binary", shellQuote("@"+model.RequestBodyFile.ValueString()))
+	case formData != nil:
+		form := url.Values{}
+		for name, value := range formData {
+			if sensitiveNameRegexp.MatchString(name) {
+				value = redacted
+			}
+			form.Set(name, value)
+		}
+		args = append(args, "--data-raw", shellQuote(form.Encode()))
+	default:
+		if body, err := request.BodyBytes(); err == nil && len(body) > 0 {
+			args = append(args, "--data-raw", shellQuote(string(body)))
+		}
+	}
+
+	if model.Insecure.ValueBool() {
+		args = append(args, "--insecure")
+	}
+	if !model.CaCertFile.IsNull() {
+		args = append(args, "--cacert", shellQuote(model.CaCertFile.ValueString()))
+	}
+	if !model.ClientCertFile.IsNull() {
+		args = append(args, "--cert", shellQuote(model.ClientCertFile.ValueString()))
+	}
+	if !model.ClientKeyFile.IsNull() {
+		args = append(args, "--key", shellQuote(model.ClientKeyFile.ValueString()))
+	}
+	if !model.ProxyURL.IsNull() {
+		if proxyURL, err := url.Parse(model.ProxyURL.ValueString()); err == nil {
+			args = append(args, "--proxy", shellQuote(redactURL(proxyURL)))
+		}
+	}
+	if !model.UnixSocket.IsNull() {
+		args = append(args, "--unix-socket", shellQuote(model.UnixSocket.ValueString()))
+	}
+	switch model.IPVersion.ValueString() {
+	case ipVersion4:
+		args = append(args, "-4")
+	case ipVersion6:
+		args = append(args, "-6")
+	}
+	if !model.DNSOverHTTPS.IsNull() {
+		endpoint := model.DNSOverHTTPS.ValueString()
+		if known, ok := dohEndpoints[endpoint]; ok {
+			endpoint = known
+		}
+		args = append(args, "--doh-url", shellQuote(endpoint))
+	}
+
+	return strings.Join(args, " ")
+}
+
+// redactURL returns the URL with the password and the values of the
+// sensitive query parameters redacted.
+func redactURL(u *url.URL) string {
+	redactedURL := *u
+	if _, ok := u.User.Password(); ok {
+		redactedURL.User = url.UserPassword(u.User.Username(), redacted)
+	}
+
+	query, sensitive := u.Query(), false
+	for name := range query {
+		if sensitiveNameRegexp.MatchString(name) {
+			query[name] = []string{redacted}
+			sensitive = true
+		}
+	}
+	if sensitive {
+		redactedURL.RawQuery = query.Encode()
+	}
+
+	return redactedURL.String()
+}
+
+// shellQuote quotes s for a POSIX shell, the single quotes it holds being
+// escaped.
+func shellQuote(s string) string {
+	if s != "" && strings.IndexFunc(s, func(r rune) bool {
+		return !strings.ContainsRune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_./:@=,+", r)
+	}) < 0 {
+		return s
+	}
+
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
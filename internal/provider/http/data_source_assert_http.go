@@ -0,0 +1,493 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package http
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"terraform-provider-utilities/internal/provider/providerdata"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jmespath/go-jmespath"
+)
+
+var _ datasource.DataSource = (*assertHttpDataSource)(nil)
+var _ datasource.DataSourceWithConfigure = (*assertHttpDataSource)(nil)
+
+func NewAssertHttpDataSource() datasource.DataSource {
+	return &assertHttpDataSource{}
+}
+
+type assertHttpDataSource struct {
+	circuitBreaker *providerdata.CircuitBreaker
+	semaphore      *providerdata.Semaphore
+	metrics        *providerdata.Metrics
+	har            *providerdata.HAR
+}
+
+type assertHttpModel struct {
+	ID             types.String `tfsdk:"id"`
+	URL            types.String `tfsdk:"url"`
+	Method         types.String `tfsdk:"method"`
+	RequestHeaders types.Map    `tfsdk:"request_headers"`
+	RequestBody    types.String `tfsdk:"request_body"`
+	RequestTimeout types.Int64  `tfsdk:"request_timeout_ms"`
+	CaCertificate  types.String `tfsdk:"ca_cert_pem"`
+	Insecure       types.Bool   `tfsdk:"insecure"`
+	Checks         []checkModel `tfsdk:"check"`
+	StatusCode     types.Int64  `tfsdk:"status_code"`
+	Results        types.Map    `tfsdk:"results"`
+	Failures       types.List   `tfsdk:"failures"`
+	Passed         types.Bool   `tfsdk:"passed"`
+}
+
+type checkModel struct {
+	Name        types.String `tfsdk:"name"`
+	StatusCode  types.Int64  `tfsdk:"status_code"`
+	Header      types.String `tfsdk:"header"`
+	HeaderRegex types.String `tfsdk:"header_regex"`
+	BodyRegex   types.String `tfsdk:"body_regex"`
+	JSONPath    types.String `tfsdk:"json_path"`
+	JSONEquals  types.String `tfsdk:"json_equals"`
+}
+
+func (d *assertHttpDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_assert_http"
+}
+
+func (d *assertHttpDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: `
+The ` + "`assert_http`" + ` data source makes an HTTP request to the given URL and evaluates
+a list of named checks against the response, e.g. its status code, a header, the
+body or a field of a JSON body.
+
+A failed check does not fail the read: the result of each check is exported in
+` + "`results`" + ` and the data source is designed to be used in ` + "`check`" + ` blocks for
+policy checks and continuous validation. Only errors making the request, e.g.
+connection errors, are reported as errors. There are no retries.
+`,
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The URL used for the request.",
+				Computed:    true,
+			},
+
+			"url": schema.StringAttribute{
+				Description: "The URL for the request. Supported schemes are `http` and `https`.",
+				Required:    true,
+			},
+
+			"method": schema.StringAttribute{
+				Description: "The HTTP Method for the request, one of `GET`, `HEAD` or `POST`. Defaults to `GET`.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf([]string{
+						http.MethodGet,
+						http.MethodPost,
+						http.MethodHead,
+					}...),
+				},
+			},
+
+			"request_headers": schema.MapAttribute{
+				Description: "A map of request header field names and values.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+
+			"request_body": schema.StringAttribute{
+				Description: "The request body as a string.",
+				Optional:    true,
+			},
+
+			"request_timeout_ms": schema.Int64Attribute{
+				Description: "The request timeout in milliseconds.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+
+			"ca_cert_pem": schema.StringAttribute{
+				Description: "Certificate Authority (CA) in [PEM (RFC 1421)](https://datatracker.ietf.org/doc/html/rfc1421) format.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("insecure")),
+				},
+			},
+
+			"insecure": schema.BoolAttribute{
+				Description: "Disables verification of the server's certificate chain and hostname. Defaults to `false`",
+				Optional:    true,
+			},
+
+			"status_code": schema.Int64Attribute{
+				Description: "The HTTP response status code.",
+				Computed:    true,
+			},
+
+			"results": schema.MapAttribute{
+				Description: "A map of the check names to whether the check passed.",
+				ElementType: types.BoolType,
+				Computed:    true,
+			},
+
+			"failures": schema.ListAttribute{
+				Description: "The descriptions of the failed checks, empty when all the checks passed.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+
+			"passed": schema.BoolAttribute{
+				Description: "Whether all the checks passed.",
+				Computed:    true,
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"check": schema.ListNestedBlock{
+				Description: "A named check evaluated against the response. A check passes when all its conditions pass, " +
+					"at least one condition must be set.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "The name of the check, the key of its result in `results`.",
+							Required:    true,
+						},
+						"status_code": schema.Int64Attribute{
+							Description: "The expected HTTP response status code.",
+							Optional:    true,
+						},
+						"header": schema.StringAttribute{
+							Description: "The name of a response header that must be present.",
+							Optional:    true,
+						},
+						"header_regex": schema.StringAttribute{
+							Description: "A regular expression the value of `header` must match.",
+							Optional:    true,
+							Validators: []validator.String{
+								stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("header")),
+							},
+						},
+						"body_regex": schema.StringAttribute{
+							Description: "A regular expression the response body must match.",
+							Optional:    true,
+						},
+						"json_path": schema.StringAttribute{
+							Description: "A [JMESPath](https://jmespath.org) expression evaluated against the JSON response body.",
+							Optional:    true,
+							Validators: []validator.String{
+								stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("json_equals")),
+							},
+						},
+						"json_equals": schema.StringAttribute{
+							Description: "The expected result of `json_path`. A string result is compared as is, other results " +
+								"are compared to the value decoded from this JSON document, e.g. `true` or `3`.",
+							Optional: true,
+							Validators: []validator.String{
+								stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("json_path")),
+							},
+						},
+					},
+				},
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+func (d *assertHttpDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerdata.ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerdata.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.circuitBreaker = data.CircuitBreaker
+	d.semaphore = data.Semaphore
+	d.metrics = data.Metrics
+	d.har = data.HAR
+}
+
+func (d *assertHttpDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if req.ClientCapabilities.DeferralAllowed && !req.Config.Raw.IsFullyKnown() {
+		resp.Deferred = &datasource.Deferred{
+			Reason: datasource.DeferredReasonDataSourceConfigUnknown,
+		}
+		return
+	}
+
+	var model assertHttpModel
+	diags := req.Config.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	names := make(map[string]bool, len(model.Checks))
+	for i, check := range model.Checks {
+		if names[check.Name.ValueString()] {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("check").AtListIndex(i).AtName("name"),
+				"Duplicate check name",
+				fmt.Sprintf("The check name %q is used more than once.", check.Name.ValueString()),
+			)
+		}
+		names[check.Name.ValueString()] = true
+
+		if check.StatusCode.IsNull() && check.Header.IsNull() && check.BodyRegex.IsNull() && check.JSONPath.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("check").AtListIndex(i),
+				"Missing check condition",
+				"At least one of status_code, header, body_regex or json_path must be set.",
+			)
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	response, body, err := d.do(ctx, &model)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error making request",
+			fmt.Sprintf("Error making request: %s", err),
+		)
+		return
+	}
+
+	results := make(map[string]bool, len(model.Checks))
+	failures := []string{}
+	passed := true
+	for i, check := range model.Checks {
+		failure, err := check.evaluate(response, body)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("check").AtListIndex(i),
+				"Invalid check",
+				fmt.Sprintf("The check %q could not be evaluated: %s", check.Name.ValueString(), err),
+			)
+			continue
+		}
+
+		results[check.Name.ValueString()] = failure == ""
+		if failure != "" {
+			failures = append(failures, fmt.Sprintf("%s: %s", check.Name.ValueString(), failure))
+			passed = false
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	model.ID = model.URL
+	model.StatusCode = types.Int64Value(int64(response.StatusCode))
+	model.Passed = types.BoolValue(passed)
+
+	model.Results, diags = types.MapValueFrom(ctx, types.BoolType, results)
+	resp.Diagnostics.Append(diags...)
+	model.Failures, diags = types.ListValueFrom(ctx, types.StringType, failures)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+// do makes the request and returns the response with its body. Any status code
+// is returned without error, to be evaluated by the checks.
+func (d *assertHttpDataSource) do(ctx context.Context, model *assertHttpModel) (*http.Response, []byte, error) {
+	method := model.Method.ValueString()
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	var requestBody io.Reader
+	if !model.RequestBody.IsNull() {
+		requestBody = strings.NewReader(model.RequestBody.ValueString())
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, model.URL.ValueString(), requestBody)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	headers := make(map[string]string)
+	if !model.RequestHeaders.IsNull() {
+		diags := model.RequestHeaders.ElementsAs(ctx, &headers, false)
+		if diags.HasError() {
+			return nil, nil, diagsError(diags)
+		}
+	}
+	for name, value := range headers {
+		request.Header.Set(name, value)
+		if strings.EqualFold(name, "Host") {
+			request.Host = value
+		}
+	}
+
+	tr, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return nil, nil, fmt.Errorf("http.DefaultTransport is not an *http.Transport")
+	}
+	transport := tr.Clone()
+	transport.TLSClientConfig = &tls.Config{
+		InsecureSkipVerify: model.Insecure.ValueBool(),
+	}
+	if !model.CaCertificate.IsNull() {
+		rootCAs := x509.NewCertPool()
+		if !rootCAs.AppendCertsFromPEM([]byte(model.CaCertificate.ValueString())) {
+			return nil, nil, fmt.Errorf("the ca_cert_pem does not hold any valid certificate")
+		}
+		transport.TLSClientConfig.RootCAs = rootCAs
+	}
+
+	client := &http.Client{Transport: transport}
+
+	var recorder *harTransport
+	if d.har != nil {
+		recorder = newHARTransport(transport, false)
+		client.Transport = recorder
+	}
+	if model.RequestTimeout.ValueInt64() > 0 {
+		client.Timeout = time.Duration(model.RequestTimeout.ValueInt64()) * time.Millisecond
+	}
+
+	if err := d.circuitBreaker.Allow(request.URL.Host); err != nil {
+		return nil, nil, err
+	}
+
+	if err := d.semaphore.Acquire(ctx); err != nil {
+		return nil, nil, err
+	}
+	defer d.semaphore.Release()
+
+	var metrics providerdata.Request
+	defer func() { d.metrics.Record(ctx, "data.utilities_assert_http", metrics) }()
+
+	// The round trip is recorded once the response body is closed.
+	defer recorder.record(ctx, d.har)
+
+	metrics.Attempts = 1
+	response, err := client.Do(request)
+	d.circuitBreaker.Record(request.URL.Host, err == nil && response.StatusCode < http.StatusInternalServerError)
+	if err != nil {
+		metrics.Failed = true
+		return nil, nil, err
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	metrics.Bytes = int64(len(body))
+	if err != nil {
+		metrics.Failed = true
+		return nil, nil, fmt.Errorf("error reading the response body: %w", err)
+	}
+
+	return response, body, nil
+}
+
+// evaluate returns the description of the first failed condition of the
+// check, or an empty string when the check passed.
+func (check *checkModel) evaluate(response *http.Response, body []byte) (string, error) {
+	if !check.StatusCode.IsNull() && int64(response.StatusCode) != check.StatusCode.ValueInt64() {
+		return fmt.Sprintf("expected status code %d, got %d", check.StatusCode.ValueInt64(), response.StatusCode), nil
+	}
+
+	if !check.Header.IsNull() {
+		name := check.Header.ValueString()
+		values, ok := response.Header[http.CanonicalHeaderKey(name)]
+		if !ok {
+			return fmt.Sprintf("expected header %q to be present", name), nil
+		}
+
+		if !check.HeaderRegex.IsNull() {
+			re, err := regexp.Compile(check.HeaderRegex.ValueString())
+			if err != nil {
+				return "", fmt.Errorf("invalid header_regex: %w", err)
+			}
+			if value := strings.Join(values, ", "); !re.MatchString(value) {
+				return fmt.Sprintf("expected header %q to match %q, got %q", name, re.String(), value), nil
+			}
+		}
+	}
+
+	if !check.BodyRegex.IsNull() {
+		re, err := regexp.Compile(check.BodyRegex.ValueString())
+		if err != nil {
+			return "", fmt.Errorf("invalid body_regex: %w", err)
+		}
+		if !re.Match(body) {
+			return fmt.Sprintf("expected the response body to match %q", re.String()), nil
+		}
+	}
+
+	if !check.JSONPath.IsNull() {
+		expression := check.JSONPath.ValueString()
+
+		var data interface{}
+		if err := json.Unmarshal(body, &data); err != nil {
+			return fmt.Sprintf("expected a JSON response body: %s", err), nil
+		}
+
+		result, err := jmespath.Search(expression, data)
+		if err != nil {
+			return "", fmt.Errorf("invalid json_path: %w", err)
+		}
+
+		if !jsonEquals(result, check.JSONEquals.ValueString()) {
+			encoded, _ := json.Marshal(result)
+			return fmt.Sprintf("expected %s to equal %q, got %s", expression, check.JSONEquals.ValueString(), encoded), nil
+		}
+	}
+
+	return "", nil
+}
+
+// jsonEquals reports whether the result of a JMESPath expression equals the
+// expected value: strings are compared as is, other values to the decoded
+// JSON document.
+func jsonEquals(result interface{}, expected string) bool {
+	if s, ok := result.(string); ok {
+		return s == expected
+	}
+
+	var value interface{}
+	if err := json.Unmarshal([]byte(expected), &value); err != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(result, value)
+}
@@ -0,0 +1,154 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package http_test
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"terraform-provider-utilities/internal/testserver"
+)
+
+func TestAssertHttpDataSource(t *testing.T) {
+	svr := testserver.New(t, testserver.Config{
+		Routes: map[string]testserver.Route{
+			"GET /health": {
+				Headers: map[string]string{"Content-Type": "application/json"},
+				Body:    `{"status": "ok", "version": "1.2.3", "replicas": 3, "ready": true}`,
+			},
+			"GET /down": {
+				Status: http.StatusServiceUnavailable,
+				Body:   "maintenance",
+			},
+		},
+	})
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_assert_http" "test" {
+								url = "%s/health"
+
+								check {
+									name        = "status"
+									status_code = 200
+								}
+
+								check {
+									name         = "content_type"
+									header       = "Content-Type"
+									header_regex = "json"
+								}
+
+								check {
+									name       = "body"
+									body_regex = "\"status\":\\s*\"ok\""
+								}
+
+								check {
+									name        = "version"
+									json_path   = "version"
+									json_equals = "1.2.3"
+								}
+
+								check {
+									name        = "replicas"
+									json_path   = "replicas"
+									json_equals = "3"
+								}
+
+								check {
+									name        = "ready"
+									json_path   = "ready"
+									json_equals = "true"
+								}
+							}`, svr.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utilities_assert_http.test", "status_code", "200"),
+					resource.TestCheckResourceAttr("data.utilities_assert_http.test", "passed", "true"),
+					resource.TestCheckResourceAttr("data.utilities_assert_http.test", "failures.#", "0"),
+					resource.TestCheckResourceAttr("data.utilities_assert_http.test", "results.%", "6"),
+					resource.TestCheckResourceAttr("data.utilities_assert_http.test", "results.status", "true"),
+					resource.TestCheckResourceAttr("data.utilities_assert_http.test", "results.content_type", "true"),
+					resource.TestCheckResourceAttr("data.utilities_assert_http.test", "results.body", "true"),
+					resource.TestCheckResourceAttr("data.utilities_assert_http.test", "results.version", "true"),
+					resource.TestCheckResourceAttr("data.utilities_assert_http.test", "results.replicas", "true"),
+					resource.TestCheckResourceAttr("data.utilities_assert_http.test", "results.ready", "true"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_assert_http" "test" {
+								url = "%s/down"
+
+								check {
+									name        = "status"
+									status_code = 200
+								}
+
+								check {
+									name       = "body"
+									body_regex = "maintenance"
+								}
+
+								check {
+									name   = "header"
+									header = "X-Missing"
+								}
+							}`, svr.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utilities_assert_http.test", "status_code", "503"),
+					resource.TestCheckResourceAttr("data.utilities_assert_http.test", "passed", "false"),
+					resource.TestCheckResourceAttr("data.utilities_assert_http.test", "results.status", "false"),
+					resource.TestCheckResourceAttr("data.utilities_assert_http.test", "results.body", "true"),
+					resource.TestCheckResourceAttr("data.utilities_assert_http.test", "results.header", "false"),
+					resource.TestCheckResourceAttr("data.utilities_assert_http.test", "failures.#", "2"),
+					resource.TestCheckResourceAttr("data.utilities_assert_http.test", "failures.0", "status: expected status code 200, got 503"),
+				),
+			},
+		},
+	})
+}
+
+func TestAssertHttpDataSource_InvalidChecks(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+							data "utilities_assert_http" "test" {
+								url = "http://127.0.0.1"
+
+								check {
+									name = "empty"
+								}
+							}`,
+				ExpectError: regexp.MustCompile(`Missing check condition`),
+			},
+			{
+				Config: `
+							data "utilities_assert_http" "test" {
+								url = "http://127.0.0.1"
+
+								check {
+									name        = "status"
+									status_code = 200
+								}
+
+								check {
+									name        = "status"
+									status_code = 204
+								}
+							}`,
+				ExpectError: regexp.MustCompile(`Duplicate check name`),
+			},
+		},
+	})
+}
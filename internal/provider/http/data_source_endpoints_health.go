@@ -0,0 +1,529 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package http
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"terraform-provider-utilities/internal/provider/providerdata"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+const defaultEndpointsConcurrency = 10
+
+// endpointHealthAttrTypes are the attributes of the values of `results`.
+var endpointHealthAttrTypes = map[string]attr.Type{
+	"url":         types.StringType,
+	"status_code": types.Int64Type,
+	"latency_ms":  types.Int64Type,
+	"attempts":    types.Int64Type,
+	"success":     types.BoolType,
+	"error":       types.StringType,
+}
+
+var _ datasource.DataSource = (*endpointsHealthDataSource)(nil)
+var _ datasource.DataSourceWithConfigure = (*endpointsHealthDataSource)(nil)
+
+func NewEndpointsHealthDataSource() datasource.DataSource {
+	return &endpointsHealthDataSource{}
+}
+
+type endpointsHealthDataSource struct {
+	circuitBreaker *providerdata.CircuitBreaker
+	semaphore      *providerdata.Semaphore
+	metrics        *providerdata.Metrics
+	har            *providerdata.HAR
+}
+
+type endpointsHealthModel struct {
+	ID                 types.String `tfsdk:"id"`
+	Endpoints          types.Map    `tfsdk:"endpoints"`
+	Method             types.String `tfsdk:"method"`
+	RequestHeaders     types.Map    `tfsdk:"request_headers"`
+	RequestTimeout     types.Int64  `tfsdk:"request_timeout_ms"`
+	SuccessStatusCodes types.List   `tfsdk:"success_status_codes"`
+	MaxConcurrency     types.Int64  `tfsdk:"max_concurrency"`
+	CaCertificate      types.String `tfsdk:"ca_cert_pem"`
+	Insecure           types.Bool   `tfsdk:"insecure"`
+	Retry              types.Object `tfsdk:"retry"`
+	Results            types.Map    `tfsdk:"results"`
+	Unhealthy          types.List   `tfsdk:"unhealthy"`
+	AllHealthy         types.Bool   `tfsdk:"all_healthy"`
+}
+
+// endpointHealth is the outcome of the check of an endpoint.
+type endpointHealth struct {
+	url        string
+	statusCode int
+	latency    time.Duration
+	attempts   int
+	success    bool
+	err        error
+}
+
+func (d *endpointsHealthDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_endpoints_health"
+}
+
+func (d *endpointsHealthDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: `
+The ` + "`endpoints_health`" + ` data source makes a request to each of the given URLs concurrently
+and exports, per endpoint, the status code, the latency and whether the response was successful,
+e.g. to gate a deployment on the health of a fleet of services.
+
+An unhealthy endpoint does not fail the read: the data source is designed to be used in
+` + "`check`" + ` blocks or in the conditions of other resources, through ` + "`all_healthy`" + `
+and ` + "`unhealthy`" + `.
+`,
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The names of the endpoints, sorted and joined by commas.",
+				Computed:    true,
+			},
+
+			"endpoints": schema.MapAttribute{
+				Description: "A map of endpoint names to the URLs checked. Supported schemes are `http` and `https`.",
+				ElementType: types.StringType,
+				Required:    true,
+				Validators: []validator.Map{
+					mapvalidator.SizeAtLeast(1),
+				},
+			},
+
+			"method": schema.StringAttribute{
+				Description: "The HTTP Method of the requests, either `GET` or `HEAD`. Defaults to `GET`.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(http.MethodGet, http.MethodHead),
+				},
+			},
+
+			"request_headers": schema.MapAttribute{
+				Description: "A map of request header field names and values sent to every endpoint.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Sensitive:   true,
+			},
+
+			"request_timeout_ms": schema.Int64Attribute{
+				Description: "The timeout of each attempt in milliseconds.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+
+			"success_status_codes": schema.ListAttribute{
+				Description: "The list of status codes of a healthy endpoint. Defaults to the 2xx-range status codes.",
+				ElementType: types.Int64Type,
+				Optional:    true,
+			},
+
+			"max_concurrency": schema.Int64Attribute{
+				Description: fmt.Sprintf("The number of endpoints checked at once. Defaults to `%d`.", defaultEndpointsConcurrency),
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+
+			"ca_cert_pem": schema.StringAttribute{
+				Description: "Certificate Authority (CA) in [PEM (RFC 1421)](https://datatracker.ietf.org/doc/html/rfc1421) format.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("insecure")),
+				},
+			},
+
+			"insecure": schema.BoolAttribute{
+				Description: "Disables verification of the server's certificate chain and hostname. Defaults to `false`",
+				Optional:    true,
+			},
+
+			"results": schema.MapAttribute{
+				Description: "A map of the endpoint names to the outcome of their check: the `url` checked, the `status_code` of " +
+					"the last attempt, null when no response was received, the `latency_ms` from sending the last attempt to " +
+					"receiving its response headers, the number of `attempts`, retries included, whether it is a `success` " +
+					"according to `success_status_codes`, and the `error` of the last attempt, e.g. a connection error, null " +
+					"when a response was received.",
+				ElementType: types.ObjectType{AttrTypes: endpointHealthAttrTypes},
+				Computed:    true,
+			},
+
+			"unhealthy": schema.ListAttribute{
+				Description: "The sorted names of the unsuccessful endpoints, empty when all the endpoints are healthy.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+
+			"all_healthy": schema.BoolAttribute{
+				Description: "Whether all the endpoints are healthy.",
+				Computed:    true,
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"retry": schema.SingleNestedBlock{
+				Description: "Retry request configuration, applied to each endpoint. By default there are no retries. Configuring this block " +
+					"will result in retries if an error is returned by the client (e.g., connection errors) or if the status code is not " +
+					"one of `success_status_codes`.",
+				Attributes: map[string]schema.Attribute{
+					"attempts": schema.Int64Attribute{
+						Description: "The number of times the request is to be retried. For example, if 2 is specified, the request will be tried a maximum of 3 times.",
+						Optional:    true,
+						Validators: []validator.Int64{
+							int64validator.AtLeast(0),
+						},
+					},
+					"min_delay_ms": schema.Int64Attribute{
+						Description: "The minimum delay between retry requests in milliseconds.",
+						Optional:    true,
+						Validators: []validator.Int64{
+							int64validator.AtLeast(0),
+						},
+					},
+					"max_delay_ms": schema.Int64Attribute{
+						Description: "The maximum delay between retry requests in milliseconds.",
+						Optional:    true,
+						Validators: []validator.Int64{
+							int64validator.AtLeast(0),
+							int64validator.AtLeastSumOf(path.MatchRelative().AtParent().AtName("min_delay_ms")),
+						},
+					},
+					"backoff": schema.StringAttribute{
+						Description: "The strategy used to compute the delay between retry requests, one of `constant`, `linear` or `exponential`. " +
+							"The delay grows from `min_delay_ms` and is capped by `max_delay_ms`. Defaults to `exponential`.",
+						Optional: true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(backoffConstant, backoffLinear, backoffExponential),
+						},
+					},
+					"jitter": schema.BoolAttribute{
+						Description: "Randomize the delay between retry requests between `min_delay_ms` and the delay computed by the `backoff` strategy, " +
+							"to avoid many clients retrying in lockstep. Defaults to `false`",
+						Optional: true,
+					},
+					"respect_retry_after": schema.BoolAttribute{
+						Description: "Whether the delay requested by the `Retry-After` header of a 429 or 503 response is waited " +
+							"before the next retry request instead of the one of the `backoff` strategy, capped by `max_delay_ms`. Defaults to `true`.",
+						Optional: true,
+					},
+
+					"retry_on": schema.ListAttribute{
+						Description: "The classes of the errors retried, among `dns` (the host name cannot be resolved), `connect` " +
+							"(the connection is refused or reset, or fails otherwise), `tls` (the TLS handshake fails), `timeout` " +
+							"(the attempt times out) and `http_status` (the response status code is retried), e.g. `[\"connect\", " +
+							"\"http_status\"]` to keep retrying while a service starts but fail fast on certificate errors. The other " +
+							"errors fail the request right away. " +
+							"Defaults to all the classes.",
+						ElementType: types.StringType,
+						Optional:    true,
+						Validators: []validator.List{
+							listvalidator.ValueStringsAre(stringvalidator.OneOf(retryOnClasses...)),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *endpointsHealthDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerdata.ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerdata.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.circuitBreaker = data.CircuitBreaker
+	d.semaphore = data.Semaphore
+	d.metrics = data.Metrics
+	d.har = data.HAR
+}
+
+func (d *endpointsHealthDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if req.ClientCapabilities.DeferralAllowed && !req.Config.Raw.IsFullyKnown() {
+		resp.Deferred = &datasource.Deferred{
+			Reason: datasource.DeferredReasonDataSourceConfigUnknown,
+		}
+		return
+	}
+
+	var model endpointsHealthModel
+	diags := req.Config.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var endpoints map[string]string
+	resp.Diagnostics.Append(model.Endpoints.ElementsAs(ctx, &endpoints, false)...)
+
+	headers := make(map[string]string)
+	if !model.RequestHeaders.IsNull() {
+		resp.Diagnostics.Append(model.RequestHeaders.ElementsAs(ctx, &headers, false)...)
+	}
+
+	var successStatusCodes []int
+	if !model.SuccessStatusCodes.IsNull() {
+		resp.Diagnostics.Append(model.SuccessStatusCodes.ElementsAs(ctx, &successStatusCodes, false)...)
+	}
+
+	var retry retryModel
+	if !model.Retry.IsNull() {
+		resp.Diagnostics.Append(model.Retry.As(ctx, &retry, basetypes.ObjectAsOptions{})...)
+	}
+	classes := retry.retryOn(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tr, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Error configuring http transport",
+			"Error http: Can't configure http transport.",
+		)
+		return
+	}
+	transport := tr.Clone()
+	transport.TLSClientConfig = &tls.Config{
+		InsecureSkipVerify: model.Insecure.ValueBool(),
+	}
+	if !model.CaCertificate.IsNull() {
+		rootCAs := x509.NewCertPool()
+		if !rootCAs.AppendCertsFromPEM([]byte(model.CaCertificate.ValueString())) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("ca_cert_pem"),
+				"Error configuring TLS client",
+				"Error tls: Can't add the CA certificate to certificate pool. Only PEM encoded certificates are supported.",
+			)
+			return
+		}
+		transport.TLSClientConfig.RootCAs = rootCAs
+	}
+
+	concurrency := defaultEndpointsConcurrency
+	if !model.MaxConcurrency.IsNull() {
+		concurrency = int(model.MaxConcurrency.ValueInt64())
+	}
+
+	names := make([]string, 0, len(endpoints))
+	for name := range endpoints {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	health := make(map[string]endpointHealth, len(endpoints))
+	slots := make(chan struct{}, concurrency)
+	for _, name := range names {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			result := d.check(ctx, &model, transport, endpoints[name], headers, successStatusCodes, retry, classes)
+
+			mu.Lock()
+			health[name] = result
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	results := make(map[string]attr.Value, len(health))
+	unhealthy := []string{}
+	for _, name := range names {
+		result := health[name]
+
+		statusCode, errorMessage := types.Int64Null(), types.StringNull()
+		if result.err != nil {
+			errorMessage = types.StringValue(result.err.Error())
+		} else {
+			statusCode = types.Int64Value(int64(result.statusCode))
+		}
+
+		value, diags := types.ObjectValue(endpointHealthAttrTypes, map[string]attr.Value{
+			"url":         types.StringValue(result.url),
+			"status_code": statusCode,
+			"latency_ms":  types.Int64Value(result.latency.Milliseconds()),
+			"attempts":    types.Int64Value(int64(result.attempts)),
+			"success":     types.BoolValue(result.success),
+			"error":       errorMessage,
+		})
+		resp.Diagnostics.Append(diags...)
+		results[name] = value
+
+		if !result.success {
+			unhealthy = append(unhealthy, name)
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	model.ID = types.StringValue(strings.Join(names, ","))
+	model.AllHealthy = types.BoolValue(len(unhealthy) == 0)
+
+	model.Results, diags = types.MapValue(types.ObjectType{AttrTypes: endpointHealthAttrTypes}, results)
+	resp.Diagnostics.Append(diags...)
+	model.Unhealthy, diags = types.ListValueFrom(ctx, types.StringType, unhealthy)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+// check makes the request to the endpoint, retrying as configured, and
+// returns its outcome. Errors are part of the outcome, not failures.
+func (d *endpointsHealthDataSource) check(ctx context.Context, model *endpointsHealthModel, transport http.RoundTripper, endpointURL string, headers map[string]string, successStatusCodes []int, retry retryModel, classes retryOn) endpointHealth {
+	result := endpointHealth{url: endpointURL}
+
+	method := model.Method.ValueString()
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	request, err := retryablehttp.NewRequestWithContext(ctx, method, endpointURL, nil)
+	if err != nil {
+		result.err = err
+		return result
+	}
+	for name, value := range headers {
+		request.Header.Set(name, value)
+		if strings.EqualFold(name, "Host") {
+			request.Host = value
+		}
+	}
+
+	retryClient := retryablehttp.NewClient()
+	retryClient.HTTPClient.Transport = transport
+	retryClient.Logger = levelledLogger{ctx}
+	retryClient.RetryMax = int(retry.Attempts.ValueInt64())
+	if retry.MinDelay.ValueInt64() > 0 {
+		retryClient.RetryWaitMin = time.Duration(retry.MinDelay.ValueInt64()) * time.Millisecond
+	}
+	if retry.MaxDelay.ValueInt64() > 0 {
+		retryClient.RetryWaitMax = time.Duration(retry.MaxDelay.ValueInt64()) * time.Millisecond
+	}
+	retryClient.Backoff = makeBackoff(retry.Backoff.ValueString(), retry.Jitter.ValueBool())
+	if retry.RespectRetryAfter.IsNull() || retry.RespectRetryAfter.ValueBool() {
+		retryClient.Backoff = withRetryAfter(retryClient.Backoff)
+	}
+	if model.RequestTimeout.ValueInt64() > 0 {
+		retryClient.HTTPClient.Timeout = time.Duration(model.RequestTimeout.ValueInt64()) * time.Millisecond
+	}
+
+	var recorder *harTransport
+	if d.har != nil {
+		recorder = newHARTransport(transport, false)
+		retryClient.HTTPClient.Transport = recorder
+	}
+
+	host := request.URL.Host
+	var started time.Time
+	retryClient.RequestLogHook = func(_ retryablehttp.Logger, _ *http.Request, attempt int) {
+		result.attempts = attempt + 1
+		started = time.Now()
+	}
+	retryClient.CheckRetry = func(ctx context.Context, resp *http.Response, err error) (bool, error) {
+		result.latency = time.Since(started)
+		d.circuitBreaker.Record(host, err == nil && resp.StatusCode < http.StatusInternalServerError)
+		if ctx.Err() != nil {
+			return false, ctx.Err()
+		}
+		if err != nil {
+			retry, checkErr := retryablehttp.DefaultRetryPolicy(ctx, resp, err)
+			return retry && classes.allows(errorClass(err)), checkErr
+		}
+		return !isSuccessStatus(resp.StatusCode, successStatusCodes) && classes.allows(retryOnHTTPStatus), nil
+	}
+	// The last response is kept to report its status code.
+	retryClient.ErrorHandler = retryablehttp.PassthroughErrorHandler
+
+	if err := d.circuitBreaker.Allow(host); err != nil {
+		result.err = err
+		return result
+	}
+
+	if err := d.semaphore.Acquire(ctx); err != nil {
+		result.err = err
+		return result
+	}
+	defer d.semaphore.Release()
+
+	var metrics providerdata.Request
+	defer func() { d.metrics.Record(ctx, "data.utilities_endpoints_health", metrics) }()
+
+	// The round trips are recorded once the response body is closed.
+	defer recorder.record(ctx, d.har)
+
+	response, err := retryClient.Do(request)
+	metrics.Attempts = result.attempts
+	if err != nil {
+		metrics.Failed = true
+		result.err = unwrapURLError(err)
+		return result
+	}
+	defer response.Body.Close()
+
+	// The body is drained so that the connection can be reused.
+	n, _ := io.Copy(io.Discard, response.Body)
+	metrics.Bytes = n
+
+	result.statusCode = response.StatusCode
+	result.success = isSuccessStatus(response.StatusCode, successStatusCodes)
+	metrics.Failed = !result.success
+
+	return result
+}
+
+// unwrapURLError returns the cause of the error of a request, the method
+// and URL being already known.
+func unwrapURLError(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return urlErr.Err
+	}
+	return err
+}
@@ -0,0 +1,78 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package http_test
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"terraform-provider-utilities/internal/testserver"
+)
+
+func TestEndpointsHealthDataSource(t *testing.T) {
+	svr := testserver.New(t, testserver.Config{
+		Routes: map[string]testserver.Route{
+			"GET /api":    {Body: "ok"},
+			"GET /worker": {Body: "ok", FailFirst: 1},
+			"GET /down":   {Status: http.StatusServiceUnavailable},
+		},
+	})
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_endpoints_health" "test" {
+								endpoints = {
+									api    = "%[1]s/api"
+									worker = "%[1]s/worker"
+									down   = "%[1]s/down"
+									closed = "http://127.0.0.1:1"
+								}
+
+								retry {
+									attempts     = 1
+									min_delay_ms = 10
+									max_delay_ms = 10
+								}
+							}`, svr.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utilities_endpoints_health.test", "id", "api,closed,down,worker"),
+					resource.TestCheckResourceAttr("data.utilities_endpoints_health.test", "results.api.status_code", "200"),
+					resource.TestCheckResourceAttr("data.utilities_endpoints_health.test", "results.api.success", "true"),
+					resource.TestCheckResourceAttr("data.utilities_endpoints_health.test", "results.api.attempts", "1"),
+					resource.TestCheckResourceAttr("data.utilities_endpoints_health.test", "results.worker.success", "true"),
+					resource.TestCheckResourceAttr("data.utilities_endpoints_health.test", "results.worker.attempts", "2"),
+					resource.TestCheckResourceAttr("data.utilities_endpoints_health.test", "results.down.status_code", "503"),
+					resource.TestCheckResourceAttr("data.utilities_endpoints_health.test", "results.down.success", "false"),
+					resource.TestCheckNoResourceAttr("data.utilities_endpoints_health.test", "results.closed.status_code"),
+					resource.TestMatchResourceAttr("data.utilities_endpoints_health.test", "results.closed.error", regexp.MustCompile(`connection refused`)),
+					resource.TestCheckResourceAttr("data.utilities_endpoints_health.test", "unhealthy.#", "2"),
+					resource.TestCheckResourceAttr("data.utilities_endpoints_health.test", "unhealthy.0", "closed"),
+					resource.TestCheckResourceAttr("data.utilities_endpoints_health.test", "unhealthy.1", "down"),
+					resource.TestCheckResourceAttr("data.utilities_endpoints_health.test", "all_healthy", "false"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_endpoints_health" "test" {
+								endpoints = {
+									api  = "%[1]s/api"
+									down = "%[1]s/down"
+								}
+								success_status_codes = [200, 503]
+							}`, svr.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utilities_endpoints_health.test", "unhealthy.#", "0"),
+					resource.TestCheckResourceAttr("data.utilities_endpoints_health.test", "all_healthy", "true"),
+				),
+			},
+		},
+	})
+}
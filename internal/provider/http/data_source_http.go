@@ -5,38 +5,36 @@ package http
 
 import (
 	"context"
-	"crypto/tls"
-	"crypto/x509"
-	"encoding/base64"
-	"errors"
 	"fmt"
-	"io"
 	"net/http"
-	"net/url"
-	"strings"
-	"time"
-	"unicode/utf8"
 
-	"github.com/hashicorp/go-retryablehttp"
+	"terraform-provider-utilities/internal/provider/providerdata"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/boolvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/dynamicvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
-	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
-	"golang.org/x/net/http/httpproxy"
 )
 
 var _ datasource.DataSource = (*httpDataSource)(nil)
+var _ datasource.DataSourceWithConfigure = (*httpDataSource)(nil)
 
 func NewHttpDataSource() datasource.DataSource {
 	return &httpDataSource{}
 }
 
-type httpDataSource struct{}
+type httpDataSource struct {
+	providerData *providerdata.ProviderData
+}
 
 func (d *httpDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
 	// This data source name unconventionally is equal to the provider name,
@@ -77,6 +75,12 @@ a 5xx-range (except 501) status code is received. For further details see
 				Required:    true,
 			},
 
+			"enabled": schema.BoolAttribute{
+				Description: "Whether the request is made. When `false`, the request is skipped and the computed " +
+					"attributes are null. Defaults to `true`.",
+				Optional: true,
+			},
+
 			"method": schema.StringAttribute{
 				Description: "The HTTP Method for the request. " +
 					"Allowed methods are a subset of methods defined in [RFC7231](https://datatracker.ietf.org/doc/html/rfc7231#section-4.3) namely, " +
@@ -97,19 +101,170 @@ a 5xx-range (except 501) status code is received. For further details see
 				Optional:    true,
 			},
 
+			"sensitive_request_headers": schema.MapAttribute{
+				Description: "A map of request header field names and values merged with `request_headers`, taking precedence, " +
+					"for secrets such as API keys. The values are redacted from the logs, the diagnostics, `curl_command` and " +
+					"the HAR file.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Sensitive:   true,
+			},
+
+			"request_id_header": schema.StringAttribute{
+				Description: "The name of a request header set to a new UUID for every attempt, e.g. `X-Request-Id`, so that " +
+					"the attempts can be correlated with the logs of the server. The value set in `request_headers` is sent " +
+					"instead when the header is set there. Defaults to the `request_id_header` of the provider, no header being set when it is not set either.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"share_response": schema.BoolAttribute{
+				Description: "Whether to share the response with the other `utilities_http` resources and data sources with " +
+					"`share_response` set making an identical request during the run, so that a single request is made. Requests " +
+					"are identical when their method, URL, headers, body, credentials, TLS, proxy and network settings are. The " +
+					"shared response bodies are held in memory until Terraform exits, up to `max_response_body_bytes`, and can't be " +
+					"streamed with `forward_to`. Defaults to `false`.",
+				Optional: true,
+				Validators: []validator.Bool{
+					boolvalidator.ConflictsWith(path.MatchRoot("forward_to")),
+				},
+			},
+
+			"auth": schema.StringAttribute{
+				Description: "The identity of the environment the provider runs in the request is authenticated with, replacing the " +
+					"`Authorization` header of `request_headers`, one of `aws_iam`, `gcp_id_token` or `azure_msi`. " +
+					"With `aws_iam` the request is signed with Signature Version 4, the credentials and region being loaded as the AWS CLI does. " +
+					"With `gcp_id_token` an ID token of the default service account is obtained from the GCP metadata server. " +
+					"With `azure_msi` an access token of the managed identity is obtained from the workload identity federated token, " +
+					"the App Service identity endpoint or the Instance Metadata Service. " +
+					"Only the requests to the host of `url` are authenticated, not the redirects to other hosts.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(authAWSIAM, authGCPIDToken, authAzureMSI),
+				},
+			},
+
+			"auth_audience": schema.StringAttribute{
+				Description: "The service the request is signed for with `aws_iam`, defaults to `execute-api`; " +
+					"the audience of the ID token with `gcp_id_token` or the resource of the access token with `azure_msi`, " +
+					"defaults to the origin of `url`.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("auth")),
+				},
+			},
+
 			"request_body": schema.StringAttribute{
 				Description: "The request body as a string.",
 				Optional:    true,
 			},
 
+			"request_body_json": schema.DynamicAttribute{
+				Description: "The request body as a value of any type, e.g. `{ name = \"example\" }`, encoded in JSON with " +
+					"the object keys sorted. The `Content-Type` request header is set to `application/json` unless set in `request_headers`.",
+				Optional: true,
+				Validators: []validator.Dynamic{
+					dynamicvalidator.ConflictsWith(
+						path.MatchRoot("request_body"),
+						path.MatchRoot("request_body_file"),
+						path.MatchRoot("form_data"),
+						path.MatchRoot("graphql"),
+						path.MatchRoot("soap"),
+					),
+				},
+			},
+
+			"request_body_file": schema.StringAttribute{
+				Description: "The path to a file streamed as the request body, instead of keeping the payload " +
+					"in the configuration and the state.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("request_body")),
+				},
+			},
+
+			"form_data": schema.MapAttribute{
+				Description: "A map of form field names and values, sent url-encoded as the request body. " +
+					"The `Content-Type` request header is set to `application/x-www-form-urlencoded` unless set in `request_headers`.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.Map{
+					mapvalidator.ConflictsWith(
+						path.MatchRoot("request_body"),
+						path.MatchRoot("request_body_file"),
+					),
+				},
+			},
+
+			"accept_encoding": schema.StringAttribute{
+				Description: "The value of the `Accept-Encoding` request header, e.g. `gzip, deflate, br`. " +
+					"Responses compressed with `gzip`, `deflate` or `br` are decompressed before populating `response_body` " +
+					"and `response_body_base64`, whether the encoding was requested or not.",
+				Optional: true,
+			},
+
 			"request_timeout_ms": schema.Int64Attribute{
-				Description: "The request timeout in milliseconds.",
+				Description: "The request timeout in milliseconds, applied to each attempt. Prefer `attempt_timeout_ms`.",
 				Optional:    true,
 				Validators: []validator.Int64{
 					int64validator.AtLeast(1),
 				},
 			},
 
+			"attempt_timeout_ms": schema.Int64Attribute{
+				Description: "The timeout of each attempt of the request in milliseconds, the reading of the response body included.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+					int64validator.ConflictsWith(path.MatchRoot("request_timeout_ms")),
+				},
+			},
+
+			"total_timeout_ms": schema.Int64Attribute{
+				Description: "The timeout of the request in milliseconds, all the attempts, the delays between retries and " +
+					"the reading of the response body included.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+
+			"max_response_body_bytes": schema.Int64Attribute{
+				Description: "The maximum size in bytes of the response body, before and after decompression. " +
+					"The request fails when the body is larger, instead of reading it into memory and the state.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+					int64validator.ConflictsWith(path.MatchRoot("forward_to")),
+				},
+			},
+
+			"error_excerpt_bytes": schema.Int64Attribute{
+				Description: "The maximum number of bytes of the response body added to the error, along with the status " +
+					"and the `Content-Type`, `Retry-After`, `WWW-Authenticate`, `X-Request-Id` and `X-Correlation-Id` " +
+					fmt.Sprintf("headers of the response, when the request gives up on an unsuccessful response. Defaults to `%d`, ", defaultErrorExcerptBytes) +
+					"`0` leaving the body out.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+
+			"debug": schema.BoolAttribute{
+				Description: "Records the DNS, connection and TLS events and the retries of the request, " +
+					"logged at the `DEBUG` level and added to the error when the request fails. Defaults to `false`.",
+				Optional: true,
+			},
+
+			"curl_command": schema.StringAttribute{
+				Description: "The curl command line sending the request, to reproduce it outside of Terraform. " +
+					"The password of the URL, the write-only request body and the values of the headers, query parameters and " +
+					"form fields whose name looks sensitive, e.g. `Authorization` or `api_key`, are replaced by `REDACTED`.",
+				Computed: true,
+			},
+
 			"response_body": schema.StringAttribute{
 				Description: "The response body returned as a string.",
 				Computed:    true,
@@ -127,6 +282,119 @@ a 5xx-range (except 501) status code is received. For further details see
 				Computed:    true,
 			},
 
+			"compress_state_body": schema.BoolAttribute{
+				Description: "Whether the response body is stored in the state compressed, in `response_body_gzip_base64`, " +
+					"instead of in `response_body`, `body`, `response_body_base64` and `response_body_json`, which are `null`, " +
+					"to shrink the state of large text responses. Defaults to `false`.",
+				Optional: true,
+			},
+
+			"discard_response_body": schema.BoolAttribute{
+				Description: "Whether the response body is left out of the state: `response_body`, `body`, `response_body_base64` " +
+					"and `response_body_json` are `null`, the status code, the response headers and the `content_summary`, " +
+					"holding the SHA-256 hash of the body, being recorded. The assertions and queries are still evaluated " +
+					"against the body. Defaults to `false`.",
+				Optional: true,
+				Validators: []validator.Bool{
+					boolvalidator.ConflictsWith(path.MatchRoot("compress_state_body")),
+				},
+			},
+
+			"response_body_gzip_base64": schema.StringAttribute{
+				Description: "The response body compressed with gzip and encoded as base64 when `compress_state_body` is `true`, " +
+					"`null` otherwise. It is decompressed with the `gunzip_base64` function.",
+				Computed: true,
+			},
+
+			"content_summary": schema.ObjectAttribute{
+				Description: "A summary of the response body, readable in the plan output when the body is large: its `size` in bytes, " +
+					"its `sha256` hash in hexadecimal, the `content_type` of the response and a `preview` of its first 200 characters, " +
+					"`null` when the body is not UTF-8. It is null when the body is streamed to `forward_to`.",
+				AttributeTypes: contentSummaryAttrTypes,
+				Computed:       true,
+			},
+
+			"response_body_json": schema.DynamicAttribute{
+				Description: "The response body decoded as JSON when the response `Content-Type` is `application/json` " +
+					"or uses the `+json` structured syntax suffix, `null` otherwise.",
+				Computed: true,
+			},
+
+			"response_queries": schema.MapAttribute{
+				Description: "A map of names to [JMESPath](https://jmespath.org/) expressions evaluated against the response body " +
+					"decoded as JSON. The results are exported in `query_results`.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+
+			"query_results": schema.DynamicAttribute{
+				Description: "An object holding the result of each of the `response_queries`, keyed by name.",
+				Computed:    true,
+			},
+
+			"graphql_data": schema.DynamicAttribute{
+				Description: "The `data` member of the GraphQL response, when the `graphql` block is set.",
+				Computed:    true,
+			},
+
+			"graphql_errors": schema.DynamicAttribute{
+				Description: "The `errors` member of the GraphQL response, when the `graphql` block is set.",
+				Computed:    true,
+			},
+
+			"soap_body": schema.StringAttribute{
+				Description: "The content of the `Body` element of the SOAP response envelope, when the `soap` block is set.",
+				Computed:    true,
+			},
+
+			"soap_fault": schema.ObjectAttribute{
+				Description: "The `Fault` of the SOAP response, when the `soap` block is set and the response holds one: its `code`, " +
+					"e.g. `soap:Client`, the human readable `reason`, the `actor` that caused it and the content of its `detail` " +
+					"element, if given. A fault is usually sent with an unsuccessful status, which fails the request unless set in " +
+					"`success_status_codes`.",
+				AttributeTypes: soapFaultAttrTypes,
+				Computed:       true,
+			},
+
+			"response_body_regex": schema.StringAttribute{
+				Description: "A regular expression the response body must match, " +
+					"otherwise an error is raised regardless of the status code. " +
+					"The syntax is described in the [RE2 documentation](https://github.com/google/re2/wiki/Syntax).",
+				Optional: true,
+			},
+
+			"expected_response_body": schema.StringAttribute{
+				Description: "The exact response body expected, " +
+					"otherwise an error is raised regardless of the status code.",
+				Optional: true,
+			},
+
+			"expected_content_type": schema.StringAttribute{
+				Description: "The media type expected in the `Content-Type` of the response, its parameters being ignored, " +
+					"e.g. `application/json`, or `application/*` to match any subtype, otherwise an error is raised regardless of the status code.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(mediaRangeRegexp, "must be a media type, e.g. application/json or application/*"),
+				},
+			},
+
+			"validate_cel": schema.StringAttribute{
+				Description: "A [CEL](https://cel.dev) expression validating the response, otherwise an error is raised " +
+					"with the result of the expression. The expression is evaluated with `status` (the status code), " +
+					"`headers` (the response headers, as in `response_headers`) and `body_json` (the response body decoded " +
+					"as JSON, `null` when the response `Content-Type` is not JSON), " +
+					"e.g. `status == 200 && body_json.state == \"ready\"`.",
+				Optional: true,
+			},
+
+			"response_schema": schema.StringAttribute{
+				Description: "A [JSON Schema](https://json-schema.org) draft-07 the response body must match, otherwise an error is raised " +
+					"listing the paths of the response body failing the validation. Either the schema document itself " +
+					"or the `http://` or `https://` URL to fetch it from. References within the schema are supported, " +
+					"`format` being ignored.",
+				Optional: true,
+			},
+
 			"ca_cert_pem": schema.StringAttribute{
 				Description: "Certificate Authority (CA) " +
 					"in [PEM (RFC 1421)](https://datatracker.ietf.org/doc/html/rfc1421) format.",
@@ -154,11 +422,146 @@ a 5xx-range (except 501) status code is received. For further details see
 				},
 			},
 
+			"ca_cert_file": schema.StringAttribute{
+				Description: "The path of a file holding the Certificate Authority (CA) " +
+					"in [PEM (RFC 1421)](https://datatracker.ietf.org/doc/html/rfc1421) format, instead of `ca_cert_pem`.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("ca_cert_pem"), path.MatchRoot("insecure")),
+				},
+			},
+
+			"ca_cert_append": schema.BoolAttribute{
+				Description: "Whether the Certificate Authority (CA) of `ca_cert_pem` or `ca_cert_file` is appended to the " +
+					"system certificate pool, instead of replacing it, so that the servers with a publicly trusted certificate, " +
+					"e.g. the targets of redirects, are verified too. Defaults to `false`.",
+				Optional: true,
+			},
+
+			"client_cert_file": schema.StringAttribute{
+				Description: "The path of a file holding the client certificate " +
+					"in [PEM (RFC 1421)](https://datatracker.ietf.org/doc/html/rfc1421) format, instead of `client_cert_pem`.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("client_cert_pem")),
+					stringvalidator.AlsoRequires(path.MatchRoot("client_key_file")),
+				},
+			},
+
+			"client_key_file": schema.StringAttribute{
+				Description: "The path of a file holding the client key " +
+					"in [PEM (RFC 1421)](https://datatracker.ietf.org/doc/html/rfc1421) format, instead of `client_key_pem`.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("client_key_pem")),
+					stringvalidator.AlsoRequires(path.MatchRoot("client_cert_file")),
+				},
+			},
+
+			"client_key_password": schema.StringAttribute{
+				Description: "The password the `client_key_pem` or `client_key_file` key is encrypted with, " +
+					"either a PKCS#8 `ENCRYPTED PRIVATE KEY` or a legacy OpenSSL key with a `DEK-Info` header.",
+				Optional:  true,
+				Sensitive: true,
+				Validators: []validator.String{
+					stringvalidator.AtLeastOneOf(path.MatchRoot("client_key_pem"), path.MatchRoot("client_key_file")),
+				},
+			},
+
+			"client_pkcs12_base64": schema.StringAttribute{
+				Description: "The base64 encoded PKCS#12 (.p12 or .pfx) bundle holding the client certificate " +
+					"and its private key, instead of `client_cert_pem` and `client_key_pem`.",
+				Optional:  true,
+				Sensitive: true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(
+						path.MatchRoot("client_cert_pem"),
+						path.MatchRoot("client_key_pem"),
+						path.MatchRoot("client_cert_file"),
+						path.MatchRoot("client_key_file"),
+					),
+				},
+			},
+
+			"client_pkcs12_password": schema.StringAttribute{
+				Description: "The password of the `client_pkcs12_base64` bundle.",
+				Optional:    true,
+				Sensitive:   true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("client_pkcs12_base64")),
+				},
+			},
+
 			"insecure": schema.BoolAttribute{
 				Description: "Disables verification of the server's certificate chain and hostname. Defaults to `false`",
 				Optional:    true,
 			},
 
+			"pinned_cert_sha256": schema.ListAttribute{
+				Description: "The SHA-256 fingerprints, in hexadecimal, of the certificates or public keys the server is trusted with. " +
+					"When set, the certificate chain and hostname of the server are not verified, its certificate or public key " +
+					"must match one of the fingerprints instead.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ValueStringsAre(
+						stringvalidator.RegexMatches(fingerprintRegexp, "must be a SHA-256 fingerprint in hexadecimal"),
+					),
+					listvalidator.ConflictsWith(path.MatchRoot("insecure"), path.MatchRoot("ca_cert_pem"), path.MatchRoot("ca_cert_file")),
+				},
+			},
+
+			"check_revocation": schema.BoolAttribute{
+				Description: "Whether to fail when the certificate of the server is revoked. The revocation status is read from " +
+					"the OCSP response stapled to the TLS handshake or, when the server staples none, requested from the OCSP " +
+					"responder of the certificate, its CRL distribution points being used when it has no responder or the " +
+					"responder fails. The request also fails when the status cannot be checked. Defaults to `false`.",
+				Optional: true,
+			},
+
+			"proxy_url": schema.StringAttribute{
+				Description: "The URL of the proxy the request is sent through, with the `http` or `https` scheme. " +
+					"It overrides the proxy configured with the `HTTP_PROXY`, `HTTPS_PROXY` and `NO_PROXY` environment variables.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(proxyURLRegexp, "must be an http or https URL"),
+				},
+			},
+
+			"unix_socket": schema.StringAttribute{
+				Description: "The path of a Unix domain socket to send the request to, e.g. `/var/run/docker.sock`. " +
+					"The host of `url` is only used in the `Host` header.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("proxy_url")),
+				},
+			},
+
+			"ip_version": schema.StringAttribute{
+				Description: "The IP version of the connection, one of `any` (the default), `4` or `6`. " +
+					"The request fails when the host, or the proxy, has no address of the version, e.g. to check that a dual-stack endpoint responds over IPv6.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(ipVersionAny, ipVersion4, ipVersion6),
+					stringvalidator.ConflictsWith(path.MatchRoot("unix_socket")),
+				},
+			},
+
+			"dns_over_https": schema.StringAttribute{
+				Description: "The DNS-over-HTTPS endpoint the host of `url`, or of the proxy, is resolved with instead of the " +
+					"local resolver, `cloudflare`, `google` or the URL of an endpoint, e.g. `https://dns.quad9.net/dns-query`. " +
+					"Only the addresses of `ip_version` are looked up.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.Any(
+						stringvalidator.OneOf(dohCloudflare, dohGoogle),
+						stringvalidator.RegexMatches(proxyURLRegexp, "must be an http or https URL"),
+					),
+					stringvalidator.ConflictsWith(path.MatchRoot("unix_socket")),
+				},
+			},
+
 			"response_headers": schema.MapAttribute{
 				Description: `A map of response header field names and values.` +
 					` Duplicate headers are concatenated according to [RFC2616](https://www.w3.org/Protocols/rfc2616/rfc2616-sec4.html#sec4.2).`,
@@ -166,19 +569,283 @@ a 5xx-range (except 501) status code is received. For further details see
 				Computed:    true,
 			},
 
+			"response_headers_all": schema.MapAttribute{
+				Description: "A map of response header field names and the list of their values, " +
+					"the values of duplicate headers being kept apart instead of concatenated as in `response_headers`.",
+				ElementType: types.ListType{ElemType: types.StringType},
+				Computed:    true,
+			},
+
+			"tls_peer_certificates": schema.ListAttribute{
+				Description: "The certificate chain presented by the server, starting with its certificate. It is null when TLS is not used. " +
+					"Each certificate has a `subject`, an `issuer`, `sans` (its subject alternative names: DNS names, IP addresses, email addresses and URIs), " +
+					"`not_before` and `not_after` (its validity dates, in [RFC 3339](https://datatracker.ietf.org/doc/html/rfc3339) format) " +
+					"and `sha256` (its SHA-256 fingerprint, in hexadecimal).",
+				ElementType: types.ObjectType{AttrTypes: tlsCertificateAttrTypes},
+				Computed:    true,
+			},
+
 			"status_code": schema.Int64Attribute{
 				Description: `The HTTP response status code.`,
 				Computed:    true,
 			},
 
+			"request_id": schema.StringAttribute{
+				Description: "The request ID of the last attempt, null when no request ID header is set.",
+				Computed:    true,
+			},
+
+			"is_success": schema.BoolAttribute{
+				Description: "Whether the status code is one of the `success_status_codes`, or in the 2xx range when they are not set.",
+				Computed:    true,
+			},
+
+			"status_class": schema.StringAttribute{
+				Description: "The class of the status code: `1xx`, `2xx`, `3xx`, `4xx` or `5xx`.",
+				Computed:    true,
+			},
+
+			"duration_ms": schema.Int64Attribute{
+				Description: "The duration of the request in milliseconds, from the first attempt to the end of the response body, retries included.",
+				Computed:    true,
+			},
+
+			"dns_ms": schema.Int64Attribute{
+				Description: "The duration of the DNS lookup of the last attempt in milliseconds, `0` when the host was not looked up.",
+				Computed:    true,
+			},
+
+			"connect_ms": schema.Int64Attribute{
+				Description: "The duration of the TCP connection of the last attempt in milliseconds, `0` when a connection was reused.",
+				Computed:    true,
+			},
+
+			"tls_ms": schema.Int64Attribute{
+				Description: "The duration of the TLS handshake of the last attempt in milliseconds, `0` when TLS is not used or a connection was reused.",
+				Computed:    true,
+			},
+
+			"first_byte_ms": schema.Int64Attribute{
+				Description: "The time to the first response byte of the last attempt in milliseconds, connection included.",
+				Computed:    true,
+			},
+
 			"success_status_codes": schema.ListAttribute{
 				Description: "The list of status codes that are considered successful.",
 				Optional:    true,
 				ElementType: types.Int64Type,
 			},
+
+			"success_body_contains": schema.ListAttribute{
+				Description: "The substrings of which at least one is expected in the body of a successful response, " +
+					"otherwise the attempt fails and is retried according to the `retry` block, e.g. until a health check reports a ready state.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ConflictsWith(path.MatchRoot("forward_to")),
+				},
+			},
+
+			"retry_status_codes": schema.ListAttribute{
+				Description: "The list of status codes that trigger a retry, in addition to connection errors and 5xx-range " +
+					"(except 501) status codes. Ignored without the `retry` block, as the requests are not retried. A status " +
+					"code can't be both in `success_status_codes` and `retry_status_codes`.",
+				Optional:    true,
+				ElementType: types.Int64Type,
+			},
 		},
 
 		Blocks: map[string]schema.Block{
+			"graphql": schema.SingleNestedBlock{
+				Description: "GraphQL request configuration. Configuring this block sends the query, its variables and " +
+					"operation name as a JSON document, with the `POST` method unless `method` is set. The decoded response " +
+					"is exported in `graphql_data` and `graphql_errors`.",
+				Attributes: map[string]schema.Attribute{
+					"query": schema.StringAttribute{
+						Description: "The GraphQL query or mutation.",
+						Required:    true,
+					},
+					"variables": schema.DynamicAttribute{
+						Description: "The variables of the query, e.g. `{ id = \"42\" }`.",
+						Optional:    true,
+					},
+					"operation_name": schema.StringAttribute{
+						Description: "The name of the operation to run, when the query contains several operations.",
+						Optional:    true,
+					},
+				},
+				Validators: []validator.Object{
+					objectvalidator.ConflictsWith(
+						path.MatchRoot("request_body"),
+						path.MatchRoot("request_body_file"),
+						path.MatchRoot("form_data"),
+					),
+				},
+			},
+			"soap": schema.SingleNestedBlock{
+				Description: "SOAP request configuration. Configuring this block wraps the body in a SOAP envelope and sets the " +
+					"`Content-Type` and action headers of the SOAP version, with the `POST` method unless `method` is set. The content " +
+					"of the response body is exported in `soap_body` and its fault in `soap_fault`.",
+				Attributes: map[string]schema.Attribute{
+					"action": schema.StringAttribute{
+						Description: "The SOAP action, sent in the `SOAPAction` header for SOAP 1.1 and as the `action` parameter " +
+							"of the `Content-Type` for SOAP 1.2.",
+						Optional: true,
+					},
+					"envelope_body": schema.StringAttribute{
+						Description: "The XML content of the `Body` element of the envelope, e.g. `<GetUser xmlns=\"urn:users\"><Id>42</Id></GetUser>`.",
+						Required:    true,
+					},
+					"envelope_header": schema.StringAttribute{
+						Description: "The XML content of the `Header` element of the envelope, e.g. a WS-Security header. " +
+							"The envelope has no `Header` element when not set.",
+						Optional: true,
+					},
+					"version": schema.StringAttribute{
+						Description: "The SOAP version, either `1.1` or `1.2`. Defaults to `1.1`.",
+						Optional:    true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(soap11, soap12),
+						},
+					},
+				},
+				Validators: []validator.Object{
+					objectvalidator.ConflictsWith(
+						path.MatchRoot("request_body"),
+						path.MatchRoot("request_body_file"),
+						path.MatchRoot("form_data"),
+						path.MatchRoot("graphql"),
+					),
+				},
+			},
+			"openapi": schema.SingleNestedBlock{
+				Description: "OpenAPI validation configuration. Configuring this block validates the method, the path and query " +
+					"parameters, the headers and the JSON body of the request against an operation of an " +
+					"[OpenAPI 3](https://spec.openapis.org/oas/v3.1.0) document before the request is made, " +
+					"the failed parts of the request being listed in the error.",
+				Attributes: map[string]schema.Attribute{
+					"spec": schema.StringAttribute{
+						Description: "The OpenAPI document, in JSON or YAML format, or the `http://` or `https://` URL to fetch it from, " +
+							"with the TLS and proxy settings of the request, and its headers when served by the same origin.",
+						Required: true,
+					},
+					"operation_id": schema.StringAttribute{
+						Description: "The `operationId` of the operation the request is validated against, e.g. `createPet`.",
+						Required:    true,
+					},
+				},
+				Validators: []validator.Object{
+					objectvalidator.ConflictsWith(
+						path.MatchRoot("graphql"),
+						path.MatchRoot("soap"),
+					),
+				},
+			},
+			"hmac_signature": schema.SingleNestedBlock{
+				Description: "HMAC signature configuration. Configuring this block signs the request with a shared secret, " +
+					"as GitHub, Slack or Stripe sign their webhooks, the signature being set in a request header. " +
+					"The signature is computed once, the retries sending the same one.",
+				Attributes: map[string]schema.Attribute{
+					"secret": schema.StringAttribute{
+						Description: "The shared secret the signature is computed with.",
+						Required:    true,
+						Sensitive:   true,
+					},
+					"algorithm": schema.StringAttribute{
+						Description: "The hash function of the HMAC, one of `sha1`, `sha256` or `sha512`. Defaults to `sha256`.",
+						Optional:    true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(hmacSHA1, hmacSHA256, hmacSHA512),
+						},
+					},
+					"header": schema.StringAttribute{
+						Description: "The name of the request header set to the signature, e.g. `X-Hub-Signature-256`.",
+						Required:    true,
+					},
+					"string_to_sign_template": schema.StringAttribute{
+						Description: "The string the signature is computed over, `{body}`, `{timestamp}`, `{method}` and `{path}` being " +
+							"replaced by the request body, the current Unix time in seconds, the method and the path and query of the URL, " +
+							"e.g. `v0:{timestamp}:{body}` for Slack or `{timestamp}.{body}` for Stripe. Defaults to `{body}`.",
+						Optional: true,
+					},
+					"header_value_template": schema.StringAttribute{
+						Description: "The value of the header, `{signature}` and `{timestamp}` being replaced by the signature encoded " +
+							"in hexadecimal and the Unix time, e.g. `sha256={signature}` for GitHub, `v0={signature}` for Slack or " +
+							"`t={timestamp},v1={signature}` for Stripe. Defaults to `{signature}`.",
+						Optional: true,
+					},
+					"timestamp_header": schema.StringAttribute{
+						Description: "The name of a request header set to the Unix time the signature covers, " +
+							"e.g. `X-Slack-Request-Timestamp`.",
+						Optional: true,
+					},
+				},
+			},
+			"forward_to": schema.SingleNestedBlock{
+				Description: "Forwarding configuration. Configuring this block streams the response body, as received, to another URL " +
+					"instead of exporting it: `response_body`, `response_body_base64` and `body` are null. Only successful (2xx) responses are forwarded.",
+				Attributes: map[string]schema.Attribute{
+					"url": schema.StringAttribute{
+						Description: "The URL the response body is sent to.",
+						Required:    true,
+					},
+					"method": schema.StringAttribute{
+						Description: "The HTTP method of the forward request, either `PUT` or `POST`. Defaults to `PUT`.",
+						Optional:    true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(http.MethodPut, http.MethodPost),
+						},
+					},
+					"request_headers": schema.MapAttribute{
+						Description: "A map of request header field names and values of the forward request. " +
+							"The `Content-Type` and `Content-Encoding` of the response are forwarded unless set here.",
+						ElementType: types.StringType,
+						Optional:    true,
+						Sensitive:   true,
+					},
+					"max_bytes": schema.Int64Attribute{
+						Description: "The maximum size of the forwarded body in bytes. Larger responses fail the request.",
+						Optional:    true,
+						Validators: []validator.Int64{
+							int64validator.AtLeast(1),
+						},
+					},
+					"status_code": schema.Int64Attribute{
+						Description: "The HTTP response status code of the forward request.",
+						Computed:    true,
+					},
+				},
+				Validators: []validator.Object{
+					objectvalidator.ConflictsWith(
+						path.MatchRoot("graphql"),
+						path.MatchRoot("soap"),
+						path.MatchRoot("response_queries"),
+						path.MatchRoot("response_body_regex"),
+						path.MatchRoot("expected_response_body"),
+					),
+				},
+			},
+			"rate_limit": schema.SingleNestedBlock{
+				Description: "Rate limit configuration. Configuring this block spaces the attempts of the request, retries included, " +
+					"according to a token bucket, so that retries do not exceed the rate limits of the upstream API.",
+				Attributes: map[string]schema.Attribute{
+					"requests_per_second": schema.Float64Attribute{
+						Description: "The number of attempts allowed per second, at least `0.001`. For example, `0.5` allows one attempt every two seconds.",
+						Required:    true,
+						Validators: []validator.Float64{
+							float64validator.AtLeast(0.001),
+						},
+					},
+					"burst": schema.Int64Attribute{
+						Description: "The number of attempts allowed at once before the rate applies. Defaults to `1`.",
+						Optional:    true,
+						Validators: []validator.Int64{
+							int64validator.AtLeast(1),
+						},
+					},
+				},
+			},
 			"retry": schema.SingleNestedBlock{
 				Description: "Retry request configuration. By default there are no retries. Configuring this block will result in " +
 					"retries if an error is returned by the client (e.g., connection errors) or if a 5xx-range (except 501) status code is received. " +
@@ -206,228 +873,88 @@ a 5xx-range (except 501) status code is received. For further details see
 							int64validator.AtLeastSumOf(path.MatchRelative().AtParent().AtName("min_delay_ms")),
 						},
 					},
+					"backoff": schema.StringAttribute{
+						Description: "The strategy used to compute the delay between retry requests, one of `constant`, `linear` or `exponential`. " +
+							"The delay grows from `min_delay_ms` and is capped by `max_delay_ms`. Defaults to `exponential`.",
+						Optional: true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(backoffConstant, backoffLinear, backoffExponential),
+						},
+					},
+					"jitter": schema.BoolAttribute{
+						Description: "Randomize the delay between retry requests between `min_delay_ms` and the delay computed by the `backoff` strategy, " +
+							"to avoid many clients retrying in lockstep. Defaults to `false`",
+						Optional: true,
+					},
+
+					"respect_retry_after": schema.BoolAttribute{
+						Description: "Whether the delay requested by the `Retry-After` header of a 429 or 503 response is waited " +
+							"before the next retry request instead of the one of the `backoff` strategy, capped by `max_delay_ms`. Defaults to `true`.",
+						Optional: true,
+					},
+
+					"retry_on": schema.ListAttribute{
+						Description: "The classes of the errors retried, among `dns` (the host name cannot be resolved), `connect` " +
+							"(the connection is refused or reset, or fails otherwise), `tls` (the TLS handshake fails), `timeout` " +
+							"(the attempt times out) and `http_status` (the response status code is retried, or the response " +
+							"body fails `success_body_contains`), e.g. `[\"connect\", " +
+							"\"http_status\"]` to keep retrying while a service starts but fail fast on certificate errors. The other " +
+							"errors fail the request right away. " +
+							"Defaults to all the classes.",
+						ElementType: types.StringType,
+						Optional:    true,
+						Validators: []validator.List{
+							listvalidator.ValueStringsAre(stringvalidator.OneOf(retryOnClasses...)),
+						},
+					},
 				},
 			},
 		},
 	}
 }
 
-func (d *httpDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	var model modelV0
-	diags := req.Config.Get(ctx, &model)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
+func (d *httpDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
 		return
 	}
 
-	requestURL := model.URL.ValueString()
-	method := model.Method.ValueString()
-	requestHeaders := model.RequestHeaders
-
-	if method == "" {
-		method = "GET"
-	}
-
-	caCertificate := model.CaCertificate
-
-	tr, ok := http.DefaultTransport.(*http.Transport)
+	data, ok := req.ProviderData.(*providerdata.ProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
-			"Error configuring http transport",
-			"Error http: Can't configure http transport.",
-		)
-		return
-	}
-
-	// Prevent issues with multiple data source configurations modifying the shared transport.
-	clonedTr := tr.Clone()
-
-	// Prevent issues with tests caching the proxy configuration.
-	clonedTr.Proxy = func(req *http.Request) (*url.URL, error) {
-		return httpproxy.FromEnvironment().ProxyFunc()(req.URL)
-	}
-
-	if clonedTr.TLSClientConfig == nil {
-		clonedTr.TLSClientConfig = &tls.Config{}
-	}
-
-	if !model.Insecure.IsNull() {
-		if clonedTr.TLSClientConfig == nil {
-			clonedTr.TLSClientConfig = &tls.Config{}
-		}
-		clonedTr.TLSClientConfig.InsecureSkipVerify = model.Insecure.ValueBool()
-	}
-
-	// Use `ca_cert_pem` cert pool
-	if !caCertificate.IsNull() {
-		caCertPool := x509.NewCertPool()
-		if ok := caCertPool.AppendCertsFromPEM([]byte(caCertificate.ValueString())); !ok {
-			resp.Diagnostics.AddError(
-				"Error configuring TLS client",
-				"Error tls: Can't add the CA certificate to certificate pool. Only PEM encoded certificates are supported.",
-			)
-			return
-		}
-
-		if clonedTr.TLSClientConfig == nil {
-			clonedTr.TLSClientConfig = &tls.Config{}
-		}
-		clonedTr.TLSClientConfig.RootCAs = caCertPool
-	}
-
-	if !model.ClientCert.IsNull() && !model.ClientKey.IsNull() {
-		cert, err := tls.X509KeyPair([]byte(model.ClientCert.ValueString()), []byte(model.ClientKey.ValueString()))
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"error creating x509 key pair",
-				fmt.Sprintf("error creating x509 key pair from provided pem blocks\n\nError: %s", err),
-			)
-			return
-		}
-		clonedTr.TLSClientConfig.Certificates = []tls.Certificate{cert}
-	}
-
-	var retry retryModel
-
-	if !model.Retry.IsNull() && !model.Retry.IsUnknown() {
-		diags = model.Retry.As(ctx, &retry, basetypes.ObjectAsOptions{})
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-	}
-
-	retryClient := retryablehttp.NewClient()
-	retryClient.HTTPClient.Transport = clonedTr
-
-	var timeout time.Duration
-
-	if model.RequestTimeout.ValueInt64() > 0 {
-		timeout = time.Duration(model.RequestTimeout.ValueInt64()) * time.Millisecond
-		retryClient.HTTPClient.Timeout = timeout
-	}
-
-	retryClient.Logger = levelledLogger{ctx}
-	retryClient.RetryMax = int(retry.Attempts.ValueInt64())
-
-	var successStatusCodes []int
-	if !model.SuccessStatusCodes.IsNull() && !model.SuccessStatusCodes.IsUnknown() {
-		diags := model.SuccessStatusCodes.ElementsAs(ctx, &successStatusCodes, false)
-		resp.Diagnostics.Append(diags...)
-	}
-
-	if !retry.MinDelay.IsNull() && !retry.MinDelay.IsUnknown() && retry.MinDelay.ValueInt64() >= 0 {
-		retryClient.RetryWaitMin = time.Duration(retry.MinDelay.ValueInt64()) * time.Millisecond
-	}
-
-	if !retry.MaxDelay.IsNull() && !retry.MaxDelay.IsUnknown() && retry.MaxDelay.ValueInt64() >= 0 {
-		retryClient.RetryWaitMax = time.Duration(retry.MaxDelay.ValueInt64()) * time.Millisecond
-	}
-
-	retryClient.CheckRetry = makeCustomRetryPolicy(successStatusCodes)
-	request, err := retryablehttp.NewRequestWithContext(ctx, method, requestURL, nil)
-
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error creating request",
-			fmt.Sprintf("Error creating request: %s", err),
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerdata.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	if !model.RequestBody.IsNull() {
-		err = request.SetBody(strings.NewReader(model.RequestBody.ValueString()))
-
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error Setting Request Body",
-				"An unexpected error occurred while setting the request body: "+err.Error(),
-			)
-
-			return
-		}
-	}
-
-	for name, value := range requestHeaders.Elements() {
-		var header string
-		diags = tfsdk.ValueAs(ctx, value, &header)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-
-		request.Header.Set(name, header)
-		if strings.ToLower(name) == "host" {
-			request.Host = header
-		}
-	}
+	d.providerData = data
+}
 
-	response, err := retryClient.Do(request)
-	if err != nil {
-		target := &url.Error{}
-		if errors.As(err, &target) {
-			if target.Timeout() {
-				detail := fmt.Sprintf("timeout error: %s", err)
-
-				if timeout > 0 {
-					detail = fmt.Sprintf("request exceeded the specified timeout: %s, err: %s", timeout.String(), err)
-				}
-
-				resp.Diagnostics.AddError(
-					"Error making request",
-					detail,
-				)
-				return
-			}
+func (d *httpDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	// The request cannot be made before the configuration is known, it is
+	// deferred when Terraform supports deferred actions.
+	if req.ClientCapabilities.DeferralAllowed && !req.Config.Raw.IsFullyKnown() {
+		resp.Deferred = &datasource.Deferred{
+			Reason: datasource.DeferredReasonDataSourceConfigUnknown,
 		}
-
-		resp.Diagnostics.AddError(
-			"Error making request",
-			fmt.Sprintf("Error making request: %s", err),
-		)
 		return
 	}
 
-	defer response.Body.Close()
-
-	bytes, err := io.ReadAll(response.Body)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error reading response body",
-			fmt.Sprintf("Error reading response body: %s", err),
-		)
+	var model modelV0
+	diags := req.Config.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if !utf8.Valid(bytes) {
-		resp.Diagnostics.AddWarning(
-			"Response body is not recognized as UTF-8",
-			"Terraform may not properly handle the response_body if the contents are binary.",
-		)
-	}
-
-	responseBody := string(bytes)
-	responseBodyBase64Std := base64.StdEncoding.EncodeToString(bytes)
-
-	responseHeaders := make(map[string]string)
-	for k, v := range response.Header {
-		// Concatenate according to RFC9110 https://www.rfc-editor.org/rfc/rfc9110.html#section-5.2
-		responseHeaders[k] = strings.Join(v, ", ")
-	}
-
-	respHeadersState, diags := types.MapValueFrom(ctx, types.StringType, responseHeaders)
-	resp.Diagnostics.Append(diags...)
+	model.applyProviderData(d.providerData)
+	model.typeName = "data.utilities_http"
+	model.read(ctx, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	model.ID = types.StringValue(requestURL)
-	model.ResponseHeaders = respHeadersState
-	model.ResponseBody = types.StringValue(responseBody)
-	model.Body = types.StringValue(responseBody)
-	model.ResponseBodyBase64 = types.StringValue(responseBodyBase64Std)
-	model.StatusCode = types.Int64Value(int64(response.StatusCode))
-
 	diags = resp.State.Set(ctx, model)
 	resp.Diagnostics.Append(diags...)
 }
@@ -4,24 +4,51 @@
 package http_test
 
 import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
 	"encoding/pem"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"net/http/httputil"
 	"net/url"
+	"os"
+	"path/filepath"
 	"regexp"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/andybalholm/brotli"
 	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"golang.org/x/crypto/ocsp"
+	"golang.org/x/net/dns/dnsmessage"
+	"software.sslmate.com/src/go-pkcs12"
+
+	utilitieshttp "terraform-provider-utilities/internal/provider/http"
+	"terraform-provider-utilities/internal/testserver"
 )
 
 func TestDataSource_200(t *testing.T) {
@@ -90,6 +117,35 @@ func TestDataSource_200_SlashInPath(t *testing.T) {
 	})
 }
 
+func TestDataSource_ResponseHeadersAll(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.Header().Add("Set-Cookie", "session=abc; Expires=Wed, 21 Oct 2015 07:28:00 GMT")
+		w.Header().Add("Set-Cookie", "theme=dark")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer testServer.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+								url = "%s"
+							}`, testServer.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utilities_http.http_test", "response_headers_all.Content-Type.#", "1"),
+					resource.TestCheckResourceAttr("data.utilities_http.http_test", "response_headers_all.Content-Type.0", "text/plain"),
+					resource.TestCheckResourceAttr("data.utilities_http.http_test", "response_headers_all.Set-Cookie.#", "2"),
+					resource.TestCheckResourceAttr("data.utilities_http.http_test", "response_headers_all.Set-Cookie.0", "session=abc; Expires=Wed, 21 Oct 2015 07:28:00 GMT"),
+					resource.TestCheckResourceAttr("data.utilities_http.http_test", "response_headers_all.Set-Cookie.1", "theme=dark"),
+				),
+			},
+		},
+	})
+}
+
 func TestDataSource_404(t *testing.T) {
 	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "text/plain")
@@ -114,6 +170,44 @@ func TestDataSource_404(t *testing.T) {
 	})
 }
 
+func TestDataSource_StatusClass(t *testing.T) {
+	svr := testserver.New(t, testserver.Config{
+		Routes: map[string]testserver.Route{
+			"GET /ok":      {Body: "OK"},
+			"GET /missing": {Status: http.StatusNotFound},
+		},
+	})
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_http" "ok" {
+								url = "%[1]s/ok"
+							}
+
+							data "utilities_http" "missing" {
+								url = "%[1]s/missing"
+							}
+
+							data "utilities_http" "expected_missing" {
+								url                  = "%[1]s/missing"
+								success_status_codes = [404]
+							}`, svr.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utilities_http.ok", "is_success", "true"),
+					resource.TestCheckResourceAttr("data.utilities_http.ok", "status_class", "2xx"),
+					resource.TestCheckResourceAttr("data.utilities_http.missing", "is_success", "false"),
+					resource.TestCheckResourceAttr("data.utilities_http.missing", "status_class", "4xx"),
+					resource.TestCheckResourceAttr("data.utilities_http.expected_missing", "is_success", "true"),
+					resource.TestCheckResourceAttr("data.utilities_http.expected_missing", "status_class", "4xx"),
+				),
+			},
+		},
+	})
+}
+
 func TestDataSource_withAuthorizationRequestHeader_200(t *testing.T) {
 	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Header.Get("Authorization") == "Zm9vOmJhcg==" {
@@ -233,6 +327,38 @@ func TestDataSource_utf16_200(t *testing.T) {
 	})
 }
 
+func TestDataSource_ContentSummary(t *testing.T) {
+	body := strings.Repeat("é", 250)
+	checksum := sha256.Sum256([]byte(body))
+
+	svr := testserver.New(t, testserver.Config{
+		Routes: map[string]testserver.Route{
+			"GET /": {
+				Headers: map[string]string{"Content-Type": "text/plain; charset=utf-8"},
+				Body:    body,
+			},
+		},
+	})
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+								url = "%s"
+							}`, svr.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utilities_http.http_test", "content_summary.size", "500"),
+					resource.TestCheckResourceAttr("data.utilities_http.http_test", "content_summary.sha256", hex.EncodeToString(checksum[:])),
+					resource.TestCheckResourceAttr("data.utilities_http.http_test", "content_summary.content_type", "text/plain; charset=utf-8"),
+					resource.TestCheckResourceAttr("data.utilities_http.http_test", "content_summary.preview", strings.Repeat("é", 200)),
+				),
+			},
+		},
+	})
+}
+
 func TestDataSource_x509cert(t *testing.T) {
 	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/x-x509-ca-cert")
@@ -421,6 +547,33 @@ EOF
 	})
 }
 
+func TestDataSource_WithCACertificateAppended(t *testing.T) {
+	testServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+	}))
+	defer testServer.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+  								url = "%s"
+
+  								ca_cert_pem = <<EOF
+%s
+EOF
+  								ca_cert_append = true
+							}`, testServer.URL, certToPEM(testServer.Certificate())),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utilities_http.http_test", "status_code", "200"),
+				),
+			},
+		},
+	})
+}
+
 func TestDataSource_WithClientCert(t *testing.T) {
 	// Fire up a test server that requires a self-signed client certificate
 	testServer := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -475,6 +628,140 @@ data "utilities_http" "http_test" {
 	})
 }
 
+func TestDataSource_WithClientCertFiles(t *testing.T) {
+	testServer := testserver.New(t, testserver.Config{
+		ClientAuth: true,
+		Routes: map[string]testserver.Route{
+			"GET /": {Body: "OK\n"},
+		},
+	})
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+data "utilities_http" "http_test" {
+  url = "%s"
+  ca_cert_file = %q
+  client_cert_file = %q
+  client_key_file = %q
+}
+`, testServer.URL, testServer.Certificate.CertFile, testServer.ClientCertificate.CertFile, testServer.ClientCertificate.KeyFile),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utilities_http.http_test", "status_code", "200"),
+					resource.TestCheckResourceAttr("data.utilities_http.http_test", "response_body", "OK\n"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+data "utilities_http" "http_test" {
+  url = "%s"
+  ca_cert_file = %q
+}
+`, testServer.URL, filepath.Join(t.TempDir(), "missing.pem")),
+				ExpectError: regexp.MustCompile(`Error reading PEM file`),
+			},
+		},
+	})
+}
+
+func TestDataSource_WithClientPKCS12(t *testing.T) {
+	testServer := testserver.New(t, testserver.Config{
+		ClientAuth: true,
+		Routes: map[string]testserver.Route{
+			"GET /": {Body: "OK\n"},
+		},
+	})
+
+	client := testServer.ClientCertificate.TLSCertificate()
+	bundle, err := pkcs12.Modern.Encode(client.PrivateKey, client.Leaf, nil, "secret")
+	if err != nil {
+		t.Fatalf("failed to encode PKCS#12 bundle: %v", err)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+data "utilities_http" "http_test" {
+  url = "%s"
+  ca_cert_file = %q
+  client_pkcs12_base64 = %q
+  client_pkcs12_password = "secret"
+}
+`, testServer.URL, testServer.Certificate.CertFile, base64.StdEncoding.EncodeToString(bundle)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utilities_http.http_test", "status_code", "200"),
+					resource.TestCheckResourceAttr("data.utilities_http.http_test", "response_body", "OK\n"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+data "utilities_http" "http_test" {
+  url = "%s"
+  ca_cert_file = %q
+  client_pkcs12_base64 = %q
+  client_pkcs12_password = "wrong"
+}
+`, testServer.URL, testServer.Certificate.CertFile, base64.StdEncoding.EncodeToString(bundle)),
+				ExpectError: regexp.MustCompile(`Error loading PKCS#12 bundle`),
+			},
+		},
+	})
+}
+
+func TestDataSource_WithEncryptedClientKey(t *testing.T) {
+	testServer := testserver.New(t, testserver.Config{
+		ClientAuth: true,
+		Routes: map[string]testserver.Route{
+			"GET /": {Body: "OK\n"},
+		},
+	})
+
+	block, _ := pem.Decode([]byte(testServer.ClientCertificate.KeyPEM))
+	//nolint:staticcheck // The legacy encryption is the one supported by the standard library.
+	encrypted, err := x509.EncryptPEMBlock(rand.Reader, block.Type, block.Bytes, []byte("secret"), x509.PEMCipherAES256)
+	if err != nil {
+		t.Fatalf("failed to encrypt client key: %v", err)
+	}
+	keyPEM := string(pem.EncodeToMemory(encrypted))
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+data "utilities_http" "http_test" {
+  url = "%s"
+  ca_cert_file = %q
+  client_cert_pem = file(%q)
+  client_key_pem = %q
+  client_key_password = "secret"
+}
+`, testServer.URL, testServer.Certificate.CertFile, testServer.ClientCertificate.CertFile, keyPEM),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utilities_http.http_test", "status_code", "200"),
+					resource.TestCheckResourceAttr("data.utilities_http.http_test", "response_body", "OK\n"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+data "utilities_http" "http_test" {
+  url = "%s"
+  ca_cert_file = %q
+  client_cert_pem = file(%q)
+  client_key_pem = %q
+  client_key_password = "wrong"
+}
+`, testServer.URL, testServer.Certificate.CertFile, testServer.ClientCertificate.CertFile, keyPEM),
+				ExpectError: regexp.MustCompile(`the password is incorrect`),
+			},
+		},
+	})
+}
+
 func TestDataSource_WithCACertificateFalse(t *testing.T) {
 	testServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 	}))
@@ -520,100 +807,242 @@ func TestDataSource_InsecureTrue(t *testing.T) {
 	})
 }
 
-func TestDataSource_InsecureFalse(t *testing.T) {
+func TestDataSource_PinnedCertSHA256(t *testing.T) {
 	testServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "text/plain")
 	}))
 	defer testServer.Close()
 
+	certFingerprint := sha256.Sum256(testServer.Certificate().Raw)
+	keyFingerprint := sha256.Sum256(testServer.Certificate().RawSubjectPublicKeyInfo)
+
 	resource.ParallelTest(t, resource.TestCase{
 		ProtoV6ProviderFactories: protoV6ProviderFactories(),
 		Steps: []resource.TestStep{
 			{
 				Config: fmt.Sprintf(`
-							data "utilities_http" "http_test" {
-  								url = "%s"
-
-  								insecure = false
-							}`, testServer.URL),
-				ExpectError: regexp.MustCompile(
-					fmt.Sprintf(
-						"Error making request: GET %s giving up after 1\n"+
-							"attempt\\(s\\): Get \"%s\": ",
-						testServer.URL,
-						testServer.URL,
-					),
+					data "utilities_http" "http_test" {
+						url                = %q
+						pinned_cert_sha256 = [%q]
+					}`, testServer.URL, hex.EncodeToString(certFingerprint[:])),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utilities_http.http_test", "status_code", "200"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+					data "utilities_http" "http_test" {
+						url                = %q
+						pinned_cert_sha256 = [%q]
+					}`, testServer.URL, strings.ToUpper(hex.EncodeToString(keyFingerprint[:]))),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utilities_http.http_test", "status_code", "200"),
 				),
 			},
+			{
+				Config: fmt.Sprintf(`
+					data "utilities_http" "http_test" {
+						url                = %q
+						pinned_cert_sha256 = [%q]
+					}`, testServer.URL, strings.Repeat("ab:", 31)+"ab"),
+				ExpectError: regexp.MustCompile("does not match any pinned fingerprint"),
+			},
+			{
+				Config: fmt.Sprintf(`
+					data "utilities_http" "http_test" {
+						url                = %q
+						pinned_cert_sha256 = ["abcd"]
+					}`, testServer.URL),
+				ExpectError: regexp.MustCompile("must be a SHA-256 fingerprint in hexadecimal"),
+			},
 		},
 	})
 }
 
-func TestDataSource_InsecureUnconfigured(t *testing.T) {
-	testServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "text/plain")
-	}))
-	defer testServer.Close()
+func TestDataSource_CheckRevocation(t *testing.T) {
+	good, revoked := ocsp.Good, ocsp.Revoked
+	goodServer := testserver.New(t, testserver.Config{
+		Routes:     map[string]testserver.Route{"GET /": {Body: "OK"}},
+		OCSPStatus: &good,
+	})
+	revokedServer := testserver.New(t, testserver.Config{
+		Routes:     map[string]testserver.Route{"GET /": {Body: "OK"}},
+		OCSPStatus: &revoked,
+	})
 
-	resource.ParallelTest(t, resource.TestCase{
+	resource.UnitTest(t, resource.TestCase{
 		ProtoV6ProviderFactories: protoV6ProviderFactories(),
 		Steps: []resource.TestStep{
 			{
 				Config: fmt.Sprintf(`
 							data "utilities_http" "http_test" {
-  								url = "%s"
-							}`, testServer.URL),
-				ExpectError: regexp.MustCompile(
-					fmt.Sprintf(
-						"Error making request: GET %s giving up after 1\n"+
-							"attempt\\(s\\): Get \"%s\": ",
-						testServer.URL,
-						testServer.URL,
-					),
-				),
+								url              = "%s"
+								ca_cert_pem      = %q
+								check_revocation = true
+							}`, goodServer.URL, goodServer.Certificate.CertPEM),
+				Check: resource.TestCheckResourceAttr("data.utilities_http.http_test", "status_code", "200"),
+			},
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+								url              = "%s"
+								ca_cert_pem      = %q
+								check_revocation = true
+							}`, revokedServer.URL, revokedServer.Certificate.CertPEM),
+				ExpectError: regexp.MustCompile(`The server certificate .* was revoked at`),
 			},
 		},
 	})
 }
 
-func TestDataSource_UnsupportedInsecureCaCert(t *testing.T) {
+func TestDataSource_TLSPeerCertificates(t *testing.T) {
 	testServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
 	}))
 	defer testServer.Close()
 
+	cert := testServer.Certificate()
+	fingerprint := sha256.Sum256(cert.Raw)
+
 	resource.ParallelTest(t, resource.TestCase{
 		ProtoV6ProviderFactories: protoV6ProviderFactories(),
 		Steps: []resource.TestStep{
 			{
 				Config: fmt.Sprintf(`
-							data "utilities_http" "http_test" {
- 								url = "%s"
-								insecure = true
-								ca_cert_pem = "invalid"
-							}`, testServer.URL),
-				ExpectError: regexp.MustCompile(`Attribute "insecure" cannot be specified when "ca_cert_pem" is specified`),
+					data "utilities_http" "http_test" {
+						url      = %q
+						insecure = true
+					}`, testServer.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utilities_http.http_test", "tls_peer_certificates.#", "1"),
+					resource.TestCheckResourceAttr("data.utilities_http.http_test", "tls_peer_certificates.0.subject", cert.Subject.String()),
+					resource.TestCheckResourceAttr("data.utilities_http.http_test", "tls_peer_certificates.0.issuer", cert.Issuer.String()),
+					resource.TestCheckTypeSetElemAttr("data.utilities_http.http_test", "tls_peer_certificates.0.sans.*", "example.com"),
+					resource.TestCheckTypeSetElemAttr("data.utilities_http.http_test", "tls_peer_certificates.0.sans.*", "127.0.0.1"),
+					resource.TestCheckResourceAttr("data.utilities_http.http_test", "tls_peer_certificates.0.not_after", cert.NotAfter.UTC().Format(time.RFC3339)),
+					resource.TestCheckResourceAttr("data.utilities_http.http_test", "tls_peer_certificates.0.sha256", hex.EncodeToString(fingerprint[:])),
+				),
 			},
 		},
 	})
 }
 
-func TestDataSource_HostRequestHeaderOverride_200(t *testing.T) {
-	altHost := "alt-test-host"
-
+func TestDataSource_TLSPeerCertificatesWithoutTLS(t *testing.T) {
 	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if r.Host != altHost {
-			w.WriteHeader(400)
-			return
-		}
-
 		w.Header().Set("Content-Type", "text/plain")
-		w.Header().Set("X-Single", "foobar")
-		w.Header().Add("X-Double", "1")
-		w.Header().Add("X-Double", "2")
-		_, err := w.Write([]byte("1.0.0"))
-		if err != nil {
-			t.Errorf("error writing body: %s", err)
-		}
+	}))
+	defer testServer.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					data "utilities_http" "http_test" {
+						url = %q
+					}`, testServer.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("data.utilities_http.http_test", "tls_peer_certificates.#"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSource_InsecureFalse(t *testing.T) {
+	testServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+	}))
+	defer testServer.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+  								url = "%s"
+
+  								insecure = false
+							}`, testServer.URL),
+				ExpectError: regexp.MustCompile(
+					fmt.Sprintf(
+						"Error making request: GET %s giving up after 1\n"+
+							"attempt\\(s\\): Get \"%s\": ",
+						testServer.URL,
+						testServer.URL,
+					),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSource_InsecureUnconfigured(t *testing.T) {
+	testServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+	}))
+	defer testServer.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+  								url = "%s"
+							}`, testServer.URL),
+				ExpectError: regexp.MustCompile(
+					fmt.Sprintf(
+						"Error making request: GET %s giving up after 1\n"+
+							"attempt\\(s\\): Get \"%s\": ",
+						testServer.URL,
+						testServer.URL,
+					),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSource_UnsupportedInsecureCaCert(t *testing.T) {
+	testServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	}))
+	defer testServer.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+ 								url = "%s"
+								insecure = true
+								ca_cert_pem = "invalid"
+							}`, testServer.URL),
+				ExpectError: regexp.MustCompile(`Attribute "insecure" cannot be specified when "ca_cert_pem" is specified`),
+			},
+		},
+	})
+}
+
+func TestDataSource_HostRequestHeaderOverride_200(t *testing.T) {
+	altHost := "alt-test-host"
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Host != altHost {
+			w.WriteHeader(400)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/plain")
+		w.Header().Set("X-Single", "foobar")
+		w.Header().Add("X-Double", "1")
+		w.Header().Add("X-Double", "2")
+		_, err := w.Write([]byte("1.0.0"))
+		if err != nil {
+			t.Errorf("error writing body: %s", err)
+		}
 	}))
 	defer testServer.Close()
 
@@ -699,68 +1128,103 @@ func TestDataSource_HTTPViaProxyWithEnv(t *testing.T) {
 	})
 }
 
-func TestDataSource_Timeout(t *testing.T) {
-	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		time.Sleep(time.Duration(10) * time.Millisecond)
+func TestDataSource_HTTPViaProxyURL(t *testing.T) {
+	proxyRequests := 0
+	serverRequests := 0
+	envProxyRequests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		serverRequests++
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(http.StatusOK)
 	}))
-	defer svr.Close()
+	defer server.Close()
 
-	resource.ParallelTest(t, resource.TestCase{
+	serverURL, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatalf("error parsing server URL: %s", err)
+	}
+
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxyRequests++
+		httputil.NewSingleHostReverseProxy(serverURL).ServeHTTP(w, r)
+	}))
+	defer proxy.Close()
+
+	envProxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		envProxyRequests++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer envProxy.Close()
+
+	t.Setenv("HTTP_PROXY", envProxy.URL)
+	t.Setenv("HTTPS_PROXY", envProxy.URL)
+
+	resource.Test(t, resource.TestCase{
 		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+
 		Steps: []resource.TestStep{
 			{
 				Config: fmt.Sprintf(`
-							data "utilities_http" "http_test" {
-  								url = "%s"
-								request_timeout_ms = 5
-							}`, svr.URL),
-				ExpectError: regexp.MustCompile(`request exceeded the specified timeout: 5ms`),
+					data "utilities_http" "http_test" {
+						url       = "%s"
+						proxy_url = "%s"
+					}
+				`, testProxiedURL, proxy.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utilities_http.http_test", "status_code", "200"),
+					checkServerAndProxyRequestCount(&proxyRequests, &serverRequests),
+					func(_ *terraform.State) error {
+						if envProxyRequests != 0 {
+							return fmt.Errorf("expected the environment proxy not to be used, got %d requests", envProxyRequests)
+						}
+						return nil
+					},
+				),
 			},
 		},
 	})
 }
 
-func TestDataSource_Retry(t *testing.T) {
-	uid := uuid.New()
-
-	resource.ParallelTest(t, resource.TestCase{
+func TestDataSource_ProxyURLInvalidScheme(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
 		ProtoV6ProviderFactories: protoV6ProviderFactories(),
 		Steps: []resource.TestStep{
 			{
-				Config: fmt.Sprintf(`
-							data "utilities_http" "http_test" {
-  								url = "https://%s.com"
-								retry {
-									attempts = 1
-								}
-							}`, uid.String()),
-				ExpectError: regexp.MustCompile(
-					fmt.Sprintf(
-						"Error making request: GET https://%s.com\n"+
-							"giving up after 2 attempt\\(s\\): Get\n"+
-							"\"https://%s.com\": dial tcp: lookup",
-						uid.String(), uid.String(),
-					),
-				),
+				Config: `
+					data "utilities_http" "http_test" {
+						url       = "http://example.com"
+						proxy_url = "socks5://127.0.0.1:1080"
+					}`,
+				ExpectError: regexp.MustCompile("must be an http or https URL"),
 			},
 		},
 	})
 }
 
-func TestDataSource_MinDelay(t *testing.T) {
-	var timeOfFirstRequest, timeOfSecondRequest int64
-	minDelay := 200
+func TestDataSource_UnixSocket(t *testing.T) {
+	// Unix socket paths are limited in length, the test temporary directory
+	// may be too long.
+	dir, err := os.MkdirTemp("", "utilities")
+	if err != nil {
+		t.Fatalf("error creating directory: %s", err)
+	}
+	defer os.RemoveAll(dir)
 
-	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "text/plain")
+	listener, err := net.Listen("unix", filepath.Join(dir, "http.sock"))
+	if err != nil {
+		t.Fatalf("error listening: %s", err)
+	}
 
-		if timeOfFirstRequest == 0 {
-			timeOfFirstRequest = time.Now().UnixNano() / int64(time.Millisecond)
-			w.WriteHeader(http.StatusBadGateway)
-		} else {
-			timeOfSecondRequest = time.Now().UnixNano() / int64(time.Millisecond)
-		}
-	}))
+	svr := &http.Server{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/plain")
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(r.Host + r.URL.Path))
+		}),
+		ReadHeaderTimeout: time.Second,
+	}
+	go func() { _ = svr.Serve(listener) }()
 	defer svr.Close()
 
 	resource.ParallelTest(t, resource.TestCase{
@@ -768,92 +1232,1193 @@ func TestDataSource_MinDelay(t *testing.T) {
 		Steps: []resource.TestStep{
 			{
 				Config: fmt.Sprintf(`
-							data "utilities_http" "http_test" {
-  								url = "%s"
-								retry {
-									attempts = 1
-									min_delay_ms = %d
-								}
-							}`, svr.URL, minDelay),
+					data "utilities_http" "http_test" {
+						url         = "http://docker/v1.45/info"
+						unix_socket = %q
+					}`, listener.Addr().String()),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("data.utilities_http.http_test", "status_code", "200"),
-					checkMinDelay(&timeOfFirstRequest, &timeOfSecondRequest, minDelay),
+					resource.TestCheckResourceAttr("data.utilities_http.http_test", "response_body", "docker/v1.45/info"),
 				),
 			},
 		},
 	})
 }
 
-// TestDataSource_MaxDelay does not evaluate the maximum delay between requests owing to the
-// non-deterministic behaviour of request duration in different environments (e.g., CI).
-func TestDataSource_MaxDelay(t *testing.T) {
-	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "text/plain")
-	}))
-	defer svr.Close()
+func TestDataSource_IPVersion(t *testing.T) {
+	svr := testserver.New(t, testserver.Config{
+		Routes: map[string]testserver.Route{
+			"GET /": {Body: "OK"},
+		},
+	})
 
-	resource.ParallelTest(t, resource.TestCase{
+	resource.UnitTest(t, resource.TestCase{
 		ProtoV6ProviderFactories: protoV6ProviderFactories(),
 		Steps: []resource.TestStep{
 			{
 				Config: fmt.Sprintf(`
 							data "utilities_http" "http_test" {
-  								url = "%s"
-								retry {
-									attempts = 1
-									max_delay_ms = 300
-								}
+								url        = "%s"
+								ip_version = "4"
 							}`, svr.URL),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("data.utilities_http.http_test", "status_code", "200"),
-					resource.TestCheckResourceAttr("data.utilities_http.http_test", "retry.max_delay_ms", "300"),
+					resource.TestCheckResourceAttr("data.utilities_http.http_test", "response_body", "OK"),
 				),
 			},
-		},
-	})
-}
-
-func TestDataSource_MaxDelayAtLeastEqualToMinDelay(t *testing.T) {
-	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-	}))
-	defer svr.Close()
-
-	resource.ParallelTest(t, resource.TestCase{
-		ProtoV6ProviderFactories: protoV6ProviderFactories(),
-		Steps: []resource.TestStep{
 			{
+				// The test server only listens on 127.0.0.1.
 				Config: fmt.Sprintf(`
 							data "utilities_http" "http_test" {
-  								url = "%s"
-								retry {
-									attempts = 1
-									min_delay_ms = 300
-									max_delay_ms = 200
-								}
+								url        = "%s"
+								ip_version = "6"
 							}`, svr.URL),
-				ExpectError: regexp.MustCompile("Attribute retry.max_delay_ms value must be at least sum of <.min_delay_ms,\ngot: 200"),
+				ExpectError: regexp.MustCompile(`address 127\.0\.0\.1: no suitable address`),
 			},
 		},
 	})
 }
 
-func TestDataSource_SuccessStatusCodes(t *testing.T) {
-	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "text/plain")
-		w.WriteHeader(http.StatusBadGateway)
-	}))
-	defer testServer.Close()
+func TestDataSource_DNSOverHTTPS(t *testing.T) {
+	svr := testserver.New(t, testserver.Config{
+		Routes: map[string]testserver.Route{
+			"GET /": {Body: "OK"},
+		},
+	})
 
-	resource.ParallelTest(t, resource.TestCase{
+	serverURL, err := url.Parse(svr.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The resolver only knows utilities.test, at the address of the test server.
+	var queries atomic.Int32
+	resolver := testserver.New(t, testserver.Config{
+		Routes: map[string]testserver.Route{
+			"GET /dns-query": {Handler: func(w http.ResponseWriter, r *http.Request) {
+				queries.Add(1)
+				w.Header().Set("Content-Type", "application/dns-message")
+				_, _ = w.Write(dohAnswer(t, r, "utilities.test.", net.ParseIP(serverURL.Hostname())))
+			}},
+		},
+	})
+
+	resource.UnitTest(t, resource.TestCase{
 		ProtoV6ProviderFactories: protoV6ProviderFactories(),
 		Steps: []resource.TestStep{
 			{
 				Config: fmt.Sprintf(`
 							data "utilities_http" "http_test" {
-								url = "%s"
-								success_status_codes = [502]
-							}`, testServer.URL),
-				Check: resource.ComposeTestCheckFunc(
+								url            = "http://utilities.test:%s"
+								dns_over_https = "%s/dns-query"
+							}`, serverURL.Port(), resolver.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utilities_http.http_test", "status_code", "200"),
+					resource.TestCheckResourceAttr("data.utilities_http.http_test", "response_body", "OK"),
+					func(s *terraform.State) error {
+						if queries.Load() == 0 {
+							return fmt.Errorf("the host was not resolved with the DNS-over-HTTPS endpoint")
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+								url            = "http://unknown.test:%s"
+								dns_over_https = "%s/dns-query"
+							}`, serverURL.Port(), resolver.URL),
+				ExpectError: regexp.MustCompile(`lookup unknown\.test with .*: no such host`),
+			},
+			{
+				Config: `
+							data "utilities_http" "http_test" {
+								url            = "http://utilities.test"
+								dns_over_https = "quad9"
+							}`,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Value`),
+			},
+		},
+	})
+}
+
+func TestDataSource_Auth(t *testing.T) {
+	svr := testserver.New(t, testserver.Config{
+		Routes: map[string]testserver.Route{
+			"GET /": {Handler: func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(r.Header.Get("Authorization")))
+			}},
+		},
+	})
+
+	metadata := testserver.New(t, testserver.Config{
+		Routes: map[string]testserver.Route{
+			"GET /computeMetadata/v1/instance/service-accounts/default/identity": {Handler: func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("Metadata-Flavor") != "Google" {
+					w.WriteHeader(http.StatusForbidden)
+					return
+				}
+				_, _ = w.Write([]byte("gcp-token-for-" + r.URL.Query().Get("audience")))
+			}},
+			"GET /msi/token": {Handler: func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("X-Identity-Header") != "secret" {
+					w.WriteHeader(http.StatusForbidden)
+					return
+				}
+				_ = json.NewEncoder(w).Encode(map[string]string{"access_token": "azure-token-for-" + r.URL.Query().Get("resource")})
+			}},
+		},
+	})
+
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIDEXAMPLE")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY")
+	t.Setenv("AWS_REGION", "eu-west-1")
+	t.Setenv("AWS_CONFIG_FILE", filepath.Join(t.TempDir(), "config"))
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", filepath.Join(t.TempDir(), "credentials"))
+	t.Setenv("AWS_EC2_METADATA_DISABLED", "true")
+	t.Setenv("GCE_METADATA_HOST", strings.TrimPrefix(metadata.URL, "http://"))
+	t.Setenv("IDENTITY_ENDPOINT", metadata.URL+"/msi/token")
+	t.Setenv("IDENTITY_HEADER", "secret")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+								url  = "%s"
+								auth = "aws_iam"
+							}`, svr.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utilities_http.http_test", "status_code", "200"),
+					resource.TestMatchResourceAttr("data.utilities_http.http_test", "response_body",
+						regexp.MustCompile(`^AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/\d{8}/eu-west-1/execute-api/aws4_request, SignedHeaders=\S+, Signature=[0-9a-f]{64}$`)),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+								url  = "%s"
+								auth = "gcp_id_token"
+							}`, svr.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utilities_http.http_test", "response_body", "Bearer gcp-token-for-"+svr.URL),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+								url           = "%s"
+								auth          = "azure_msi"
+								auth_audience = "api://utilities"
+							}`, svr.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utilities_http.http_test", "response_body", "Bearer azure-token-for-api://utilities"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+								url           = "%s"
+								auth_audience = "api://utilities"
+							}`, svr.URL),
+				ExpectError: regexp.MustCompile(`Attribute "auth" must be specified when "auth_audience" is specified`),
+			},
+		},
+	})
+}
+
+func TestDataSource_DeferredConfigUnknown(t *testing.T) {
+	ctx := context.Background()
+	d := utilitieshttp.NewHttpDataSource()
+
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+	schemaType := schemaResp.Schema.Type().TerraformType(ctx)
+
+	req := datasource.ReadRequest{
+		Config: tfsdk.Config{
+			Schema: schemaResp.Schema,
+			Raw:    objectValue(t, schemaType, map[string]tftypes.Value{"url": tftypes.NewValue(tftypes.String, tftypes.UnknownValue)}),
+		},
+		ClientCapabilities: datasource.ReadClientCapabilities{DeferralAllowed: true},
+	}
+	resp := datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	d.Read(ctx, req, &resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	if resp.Deferred == nil || resp.Deferred.Reason != datasource.DeferredReasonDataSourceConfigUnknown {
+		t.Errorf("expected the read to be deferred, got %v", resp.Deferred)
+	}
+}
+
+func TestDataSource_CircuitBreaker(t *testing.T) {
+	svr := testserver.New(t, testserver.Config{
+		Routes: map[string]testserver.Route{
+			"GET /": {Status: http.StatusServiceUnavailable},
+		},
+	})
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							provider "utilities" {
+								circuit_breaker {
+									failure_threshold = 1
+									cooldown_ms       = 60000
+								}
+							}
+
+							# The 503 is recorded as a failure without failing the read.
+							data "utilities_http" "first" {
+								url                  = "%[1]s"
+								success_status_codes = [503]
+							}
+
+							data "utilities_http" "second" {
+								url        = "%[1]s"
+								depends_on = [data.utilities_http.first]
+							}`, svr.URL),
+				ExpectError: regexp.MustCompile(`the circuit breaker is open for 127\.0\.0\.1:\d+ after 1 consecutive\s+failures`),
+			},
+		},
+	})
+}
+
+func TestDataSource_RetryBudget(t *testing.T) {
+	svr := testserver.New(t, testserver.Config{
+		Routes: map[string]testserver.Route{
+			"GET /flaky": {Body: "ok", FailFirst: 1},
+			"GET /down":  {Status: http.StatusServiceUnavailable},
+		},
+	})
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							provider "utilities" {
+								retry_budget = 1
+							}
+
+							# The retry spends the whole budget of the host.
+							data "utilities_http" "first" {
+								url = "%[1]s/flaky"
+								retry {
+									attempts = 1
+								}
+							}
+
+							data "utilities_http" "second" {
+								url        = "%[1]s/down"
+								depends_on = [data.utilities_http.first]
+								retry {
+									attempts = 3
+								}
+							}`, svr.URL),
+				ExpectError: regexp.MustCompile(`giving up after 1 attempt\(s\): the retry budget of\s+127\.0\.0\.1:\d+ is exhausted`),
+			},
+		},
+	})
+}
+
+func TestDataSource_MaxConcurrentRequests(t *testing.T) {
+	var (
+		mu                sync.Mutex
+		inFlight, maxSeen int
+	)
+
+	svr := testserver.New(t, testserver.Config{
+		Routes: map[string]testserver.Route{
+			"GET /": {Handler: func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				inFlight++
+				maxSeen = max(maxSeen, inFlight)
+				mu.Unlock()
+
+				time.Sleep(50 * time.Millisecond)
+
+				mu.Lock()
+				inFlight--
+				mu.Unlock()
+			}},
+		},
+	})
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							provider "utilities" {
+								max_concurrent_requests = 1
+							}
+
+							data "utilities_http" "http_test" {
+								count = 4
+								url   = %q
+							}`, svr.URL),
+				Check: resource.TestCheckResourceAttr("data.utilities_http.http_test.3", "status_code", "200"),
+			},
+		},
+	})
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if maxSeen != 1 {
+		t.Errorf("expected at most 1 request in flight, got %d", maxSeen)
+	}
+}
+
+func TestDataSource_MaxResponseBodyBytes(t *testing.T) {
+	var compressed bytes.Buffer
+	writer := gzip.NewWriter(&compressed)
+	_, _ = writer.Write(make([]byte, 1024))
+	_ = writer.Close()
+
+	svr := testserver.New(t, testserver.Config{
+		Routes: map[string]testserver.Route{
+			"GET /small": {Body: "1234567890"},
+			"GET /compressed": {
+				Headers: map[string]string{"Content-Encoding": "gzip"},
+				Body:    compressed.String(),
+			},
+		},
+	})
+
+	config := func(path string, maxBytes int) string {
+		return fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+								url                     = "%s%s"
+								max_response_body_bytes = %d
+							}`, svr.URL, path, maxBytes)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: config("/small", 10),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utilities_http.http_test", "response_body", "1234567890"),
+				),
+			},
+			{
+				Config:      config("/small", 9),
+				ExpectError: regexp.MustCompile(`the response body exceeds 9 bytes`),
+			},
+			{
+				Config:      config("/compressed", 100),
+				ExpectError: regexp.MustCompile(`the response body exceeds 100 bytes`),
+			},
+		},
+	})
+}
+
+func TestDataSource_MetricsFile(t *testing.T) {
+	svr := testserver.New(t, testserver.Config{
+		Routes: map[string]testserver.Route{
+			"GET /": {Body: "1234567890"},
+		},
+	})
+	metricsFile := filepath.Join(t.TempDir(), "metrics.json")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							provider "utilities" {
+								metrics_file = %q
+							}
+
+							data "utilities_http" "http_test" {
+								url = %q
+							}`, metricsFile, svr.URL),
+				Check: func(*terraform.State) error {
+					data, err := os.ReadFile(metricsFile)
+					if err != nil {
+						return err
+					}
+
+					var metrics map[string]struct {
+						Requests int64 `json:"requests"`
+						Bytes    int64 `json:"bytes"`
+					}
+					if err := json.Unmarshal(data, &metrics); err != nil {
+						return err
+					}
+
+					summary := metrics["data.utilities_http"]
+					if summary.Requests < 1 || summary.Bytes != 10*summary.Requests {
+						return fmt.Errorf("unexpected metrics: %s", data)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestDataSource_HARFile(t *testing.T) {
+	svr := testserver.New(t, testserver.Config{
+		Routes: map[string]testserver.Route{
+			"GET /": {Body: "1234567890", FailFirst: 1},
+		},
+	})
+	harFile := filepath.Join(t.TempDir(), "requests.har")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							provider "utilities" {
+								har_file = %q
+							}
+
+							data "utilities_http" "http_test" {
+								url             = "%s/?api_key=secret"
+								request_headers = {
+									Authorization = "Bearer secret"
+								}
+								retry {
+									attempts     = 1
+									min_delay_ms = 10
+									max_delay_ms = 10
+								}
+							}`, harFile, svr.URL),
+				Check: func(*terraform.State) error {
+					data, err := os.ReadFile(harFile)
+					if err != nil {
+						return err
+					}
+
+					if strings.Contains(string(data), "secret") {
+						return fmt.Errorf("the HAR file holds secrets: %s", data)
+					}
+
+					var har struct {
+						Log struct {
+							Entries []struct {
+								Response struct {
+									Status  int `json:"status"`
+									Content struct {
+										Text string `json:"text"`
+									} `json:"content"`
+								} `json:"response"`
+							} `json:"entries"`
+						} `json:"log"`
+					}
+					if err := json.Unmarshal(data, &har); err != nil {
+						return err
+					}
+
+					// The retry of the first read is recorded.
+					entries := har.Log.Entries
+					if len(entries) < 2 || entries[0].Response.Status != http.StatusServiceUnavailable ||
+						entries[1].Response.Status != http.StatusOK || entries[1].Response.Content.Text != "1234567890" {
+						return fmt.Errorf("unexpected HAR file: %s", data)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestDataSource_ErrorExcerpt(t *testing.T) {
+	svr := testserver.New(t, testserver.Config{
+		Routes: map[string]testserver.Route{
+			"GET /": {
+				Status:  http.StatusUnprocessableEntity,
+				Headers: map[string]string{"Content-Type": "application/json", "X-Request-Id": "req-42"},
+				Body:    `{"error":"invalid name"}`,
+			},
+		},
+	})
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+								url                  = %q
+								success_status_codes = [200]
+								retry {
+									attempts = 0
+								}
+							}`, svr.URL),
+				ExpectError: regexp.MustCompile(`(?s)unexpected HTTP status 422 Unprocessable Entity.*Response status: 422\s+Unprocessable Entity.*Content-Type: application/json.*X-Request-Id: req-42.*Response\s+body:.*\{"error":"invalid name"\}`),
+			},
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+								url                  = %q
+								success_status_codes = [200]
+								error_excerpt_bytes  = 9
+								retry {
+									attempts = 0
+								}
+							}`, svr.URL),
+				ExpectError: regexp.MustCompile(`Response body \(first 9 bytes\):\s+\{"error":`),
+			},
+		},
+	})
+}
+
+func TestDataSource_Debug(t *testing.T) {
+	svr := testserver.New(t, testserver.Config{
+		Routes: map[string]testserver.Route{
+			"GET /": {FailFirst: 2},
+		},
+	})
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+								url   = %q
+								debug = true
+								retry {
+									attempts     = 1
+									min_delay_ms = 10
+									max_delay_ms = 10
+								}
+							}`, svr.URL),
+				ExpectError: regexp.MustCompile(`(?s)Request trace:.*connected to tcp.*response status 503 Service\s+Unavailable.*attempt 2: GET`),
+			},
+		},
+	})
+}
+
+func TestDataSource_CurlCommand(t *testing.T) {
+	svr := testserver.New(t, testserver.Config{
+		Routes: map[string]testserver.Route{
+			"POST /items": {Status: http.StatusCreated},
+		},
+	})
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+								url          = "%s/items?api_key=secret&page=2"
+								method       = "POST"
+								request_body = "{\"name\": \"it's\"}"
+								request_headers = {
+									Authorization = "Bearer secret"
+									Content-Type  = "application/json"
+								}
+							}`, svr.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utilities_http.http_test", "status_code", "201"),
+					resource.TestCheckResourceAttr("data.utilities_http.http_test", "curl_command",
+						fmt.Sprintf(`curl -X POST '%s/items?api_key=REDACTED&page=2' -H 'Authorization: REDACTED' -H 'Content-Type: application/json' --data-raw '{"name": "it'\''s"}'`, svr.URL)),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+								url    = "%s/items"
+								method = "POST"
+								form_data = {
+									user     = "admin"
+									password = "secret"
+								}
+							}`, svr.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utilities_http.http_test", "curl_command",
+						fmt.Sprintf(`curl -X POST %s/items -H 'Content-Type: application/x-www-form-urlencoded' --data-raw 'password=REDACTED&user=admin'`, svr.URL)),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSource_Timings(t *testing.T) {
+	svr := testserver.New(t, testserver.Config{
+		Routes: map[string]testserver.Route{
+			"GET /": {Body: "OK", Latency: 100 * time.Millisecond},
+		},
+	})
+
+	atLeast := func(minimum int64) resource.CheckResourceAttrWithFunc {
+		return func(value string) error {
+			ms, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return err
+			}
+			if ms < minimum {
+				return fmt.Errorf("expected at least %d ms, got %d ms", minimum, ms)
+			}
+			return nil
+		}
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+								url = "%s"
+							}`, svr.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrWith("data.utilities_http.http_test", "duration_ms", atLeast(100)),
+					resource.TestCheckResourceAttrWith("data.utilities_http.http_test", "first_byte_ms", atLeast(100)),
+					// The URL holds an IP address and TLS is not used.
+					resource.TestCheckResourceAttr("data.utilities_http.http_test", "dns_ms", "0"),
+					resource.TestCheckResourceAttr("data.utilities_http.http_test", "tls_ms", "0"),
+					resource.TestCheckResourceAttrSet("data.utilities_http.http_test", "connect_ms"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSource_Disabled(t *testing.T) {
+	svr := testserver.New(t, testserver.Config{
+		Routes: map[string]testserver.Route{
+			"GET /": {Body: "1.0.0"},
+		},
+	})
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+								url     = %q
+								enabled = false
+							}`, svr.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr("data.utilities_http.http_test", "response_body"),
+					resource.TestCheckNoResourceAttr("data.utilities_http.http_test", "status_code"),
+					func(*terraform.State) error {
+						if requests := svr.Requests("GET /"); requests != 0 {
+							return fmt.Errorf("expected no request, got %d", requests)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+								url     = %q
+								enabled = true
+							}`, svr.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utilities_http.http_test", "response_body", "1.0.0"),
+					resource.TestCheckResourceAttr("data.utilities_http.http_test", "status_code", "200"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSource_RateLimit(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		attempts []time.Time
+	)
+
+	svr := testserver.New(t, testserver.Config{
+		Routes: map[string]testserver.Route{
+			"GET /": {Handler: func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				attempts = append(attempts, time.Now())
+				mu.Unlock()
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}},
+		},
+	})
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+								url = %q
+								retry {
+									attempts     = 2
+									min_delay_ms = 0
+									max_delay_ms = 0
+								}
+								rate_limit {
+									requests_per_second = 5
+								}
+							}`, svr.URL),
+				ExpectError: regexp.MustCompile(`giving up after 3 attempt\(s\)`),
+			},
+		},
+	})
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if len(attempts) != 3 {
+		t.Fatalf("expected 3 attempts, got %d", len(attempts))
+	}
+	for i := 1; i < len(attempts); i++ {
+		if gap := attempts[i].Sub(attempts[i-1]); gap < 150*time.Millisecond {
+			t.Errorf("attempt %d was made %s after the previous one, expected at least 200ms", i+1, gap)
+		}
+	}
+}
+
+func TestDataSource_Timeout(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(time.Duration(10) * time.Millisecond)
+	}))
+	defer svr.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+  								url = "%s"
+								request_timeout_ms = 5
+							}`, svr.URL),
+				ExpectError: regexp.MustCompile(`request exceeded the specified timeout: 5ms`),
+			},
+		},
+	})
+}
+
+func TestDataSource_AttemptTimeout(t *testing.T) {
+	var calls atomic.Int32
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Only the first attempt times out.
+		if calls.Add(1) == 1 {
+			time.Sleep(100 * time.Millisecond)
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer svr.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+								url                = "%s"
+								attempt_timeout_ms = 20
+								retry {
+									attempts     = 1
+									min_delay_ms = 1
+									max_delay_ms = 1
+								}
+							}`, svr.URL),
+				Check: resource.TestCheckResourceAttr("data.utilities_http.http_test", "response_body", "ok"),
+			},
+		},
+	})
+}
+
+func TestDataSource_TotalTimeout(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer svr.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+								url              = "%s"
+								total_timeout_ms = 50
+								retry {
+									attempts     = 10
+									min_delay_ms = 20
+									max_delay_ms = 20
+									backoff      = "constant"
+								}
+							}`, svr.URL),
+				ExpectError: regexp.MustCompile(`request exceeded the specified total timeout: 50ms`),
+			},
+		},
+	})
+}
+
+func TestDataSource_Retry(t *testing.T) {
+	uid := uuid.New()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+  								url = "https://%s.com"
+								retry {
+									attempts = 1
+								}
+							}`, uid.String()),
+				ExpectError: regexp.MustCompile(
+					fmt.Sprintf(
+						"Error making request: GET https://%s.com\n"+
+							"giving up after 2 attempt\\(s\\): Get\n"+
+							"\"https://%s.com\": dial tcp: lookup",
+						uid.String(), uid.String(),
+					),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSource_RetryStatusCodes(t *testing.T) {
+	svr := testserver.New(t, testserver.Config{
+		Routes: map[string]testserver.Route{
+			"GET /": {
+				Headers:    map[string]string{"Content-Type": "text/plain"},
+				Body:       "done",
+				FailFirst:  2,
+				FailStatus: http.StatusConflict,
+			},
+		},
+	})
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+								url                = "%s"
+								retry_status_codes = [409]
+								retry {
+									attempts     = 3
+									min_delay_ms = 10
+									max_delay_ms = 10
+								}
+							}`, svr.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utilities_http.http_test", "status_code", "200"),
+					resource.TestCheckResourceAttr("data.utilities_http.http_test", "response_body", "done"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSource_RetryStatusCodesWithoutRetry(t *testing.T) {
+	var requestCount int
+
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount++
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(http.StatusConflict)
+	}))
+	defer svr.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+								url                = "%s"
+								retry_status_codes = [409]
+							}`, svr.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utilities_http.http_test", "status_code", "409"),
+					func(_ *terraform.State) error {
+						if requestCount != 1 {
+							return fmt.Errorf("expected a single request without the retry block, got %d", requestCount)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestDataSource_RetryStatusCodesOverlap(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+	}))
+	defer svr.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+								url                  = "%s"
+								success_status_codes = [200, 409]
+								retry_status_codes   = [409]
+								retry {
+									attempts = 3
+								}
+							}`, svr.URL),
+				ExpectError: regexp.MustCompile(`The status code 409 can't be both in`),
+			},
+		},
+	})
+}
+
+func TestDataSource_MinDelay(t *testing.T) {
+	var timeOfFirstRequest, timeOfSecondRequest int64
+	minDelay := 200
+
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+
+		if timeOfFirstRequest == 0 {
+			timeOfFirstRequest = time.Now().UnixNano() / int64(time.Millisecond)
+			w.WriteHeader(http.StatusBadGateway)
+		} else {
+			timeOfSecondRequest = time.Now().UnixNano() / int64(time.Millisecond)
+		}
+	}))
+	defer svr.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+  								url = "%s"
+								retry {
+									attempts = 1
+									min_delay_ms = %d
+								}
+							}`, svr.URL, minDelay),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utilities_http.http_test", "status_code", "200"),
+					checkMinDelay(&timeOfFirstRequest, &timeOfSecondRequest, minDelay),
+				),
+			},
+		},
+	})
+}
+
+// TestDataSource_MaxDelay does not evaluate the maximum delay between requests owing to the
+// non-deterministic behaviour of request duration in different environments (e.g., CI).
+func TestDataSource_MaxDelay(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+	}))
+	defer svr.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+  								url = "%s"
+								retry {
+									attempts = 1
+									max_delay_ms = 300
+								}
+							}`, svr.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utilities_http.http_test", "status_code", "200"),
+					resource.TestCheckResourceAttr("data.utilities_http.http_test", "retry.max_delay_ms", "300"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSource_MaxDelayAtLeastEqualToMinDelay(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	}))
+	defer svr.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+  								url = "%s"
+								retry {
+									attempts = 1
+									min_delay_ms = 300
+									max_delay_ms = 200
+								}
+							}`, svr.URL),
+				ExpectError: regexp.MustCompile("Attribute retry.max_delay_ms value must be at least sum of <.min_delay_ms,\ngot: 200"),
+			},
+		},
+	})
+}
+
+func TestDataSource_RetryBackoff(t *testing.T) {
+	var requestCount int
+
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount++
+		w.Header().Set("Content-Type", "text/plain")
+
+		if requestCount < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer svr.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+								url = "%s"
+								retry {
+									attempts     = 3
+									min_delay_ms = 10
+									max_delay_ms = 50
+									backoff      = "linear"
+									jitter       = true
+								}
+							}`, svr.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utilities_http.http_test", "status_code", "200"),
+					resource.TestCheckResourceAttr("data.utilities_http.http_test", "retry.backoff", "linear"),
+					resource.TestCheckResourceAttr("data.utilities_http.http_test", "retry.jitter", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSource_RetryBackoffInvalid(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+							data "utilities_http" "http_test" {
+								url = "http://localhost"
+								retry {
+									backoff = "fibonacci"
+								}
+							}`,
+				ExpectError: regexp.MustCompile(`Attribute retry.backoff value must be one of`),
+			},
+		},
+	})
+}
+
+func TestDataSource_RetryAfter(t *testing.T) {
+	var requestCount atomic.Int32
+	var timeOfFirstRequest, timeOfSecondRequest int64
+
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requestCount.Add(1)%2 == 1 {
+			timeOfFirstRequest = time.Now().UnixMilli()
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		timeOfSecondRequest = time.Now().UnixMilli()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer svr.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+								url = "%s"
+								retry {
+									attempts     = 1
+									min_delay_ms = 10
+									max_delay_ms = 5000
+								}
+							}`, svr.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utilities_http.http_test", "status_code", "200"),
+					checkMinDelay(&timeOfFirstRequest, &timeOfSecondRequest, 1000),
+				),
+			},
+			{
+				// The delay is capped by max_delay_ms.
+				Config: fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+								url = "%s"
+								retry {
+									attempts     = 1
+									min_delay_ms = 10
+									max_delay_ms = 100
+								}
+							}`, svr.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utilities_http.http_test", "status_code", "200"),
+					checkMaxDelay(&timeOfFirstRequest, &timeOfSecondRequest, 900),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+								url = "%s"
+								retry {
+									attempts            = 1
+									min_delay_ms        = 10
+									max_delay_ms        = 5000
+									respect_retry_after = false
+								}
+							}`, svr.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utilities_http.http_test", "status_code", "200"),
+					resource.TestCheckResourceAttr("data.utilities_http.http_test", "retry.respect_retry_after", "false"),
+					checkMaxDelay(&timeOfFirstRequest, &timeOfSecondRequest, 900),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSource_SuccessStatusCodes(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer testServer.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+								url = "%s"
+								success_status_codes = [502]
+							}`, testServer.URL),
+				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr("data.utilities_http.http_test", "response_body", ""),
 					resource.TestCheckResourceAttr("data.utilities_http.http_test", "status_code", "502"),
 				),
@@ -862,125 +2427,1615 @@ func TestDataSource_SuccessStatusCodes(t *testing.T) {
 	})
 }
 
-// Reference: https://github.com/hashicorp/terraform-provider-http/issues/388
-func TestDataSource_RequestBody(t *testing.T) {
-	t.Parallel()
+// Reference: https://github.com/hashicorp/terraform-provider-http/issues/388
+func TestDataSource_RequestBody(t *testing.T) {
+	t.Parallel()
+
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+
+		requestBody, err := io.ReadAll(r.Body)
+
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`Request Body Read Error: ` + err.Error()))
+
+			return
+		}
+
+		// If the request body is empty or a test string, return a 200 OK with a response body.
+		if len(requestBody) == 0 || string(requestBody) == "test" {
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`test response body`))
+
+			return
+		}
+
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`request body (` + string(requestBody) + `) was not empty or "test"`))
+	}))
+	defer svr.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					data "utilities_http" "test" {
+						url = "%s"
+					}`, svr.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utilities_http.test", "status_code", "200"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+					data "utilities_http" "test" {
+						request_body = "test"
+						url          = %q
+					}`, svr.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utilities_http.test", "status_code", "200"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+					data "utilities_http" "test" {
+						request_body = "not-test"
+						url          = %q
+					}`, svr.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utilities_http.test", "status_code", "400"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSource_RequestBodyFile(t *testing.T) {
+	t.Parallel()
+
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+
+		requestBody, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(fmt.Sprintf("%d:%s", r.ContentLength, requestBody)))
+	}))
+	defer svr.Close()
+
+	filename := filepath.Join(t.TempDir(), "body.txt")
+	if err := os.WriteFile(filename, []byte("file content"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					data "utilities_http" "test" {
+						url               = %q
+						method            = "POST"
+						request_body_file = %q
+					}`, svr.URL, filename),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utilities_http.test", "status_code", "200"),
+					resource.TestCheckResourceAttr("data.utilities_http.test", "response_body", "12:file content"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+					data "utilities_http" "test" {
+						url               = %q
+						method            = "POST"
+						request_body_file = %q
+					}`, svr.URL, filepath.Join(t.TempDir(), "missing.txt")),
+				ExpectError: regexp.MustCompile("Error reading request body file"),
+			},
+			{
+				Config: fmt.Sprintf(`
+					data "utilities_http" "test" {
+						url               = %q
+						method            = "POST"
+						request_body      = "test"
+						request_body_file = %q
+					}`, svr.URL, filename),
+				ExpectError: regexp.MustCompile(`Attribute "request_body" cannot be specified when "request_body_file" is specified`),
+			},
+		},
+	})
+}
+
+func TestDataSource_FormData(t *testing.T) {
+	t.Parallel()
+
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+
+		if err := r.ParseForm(); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(r.Header.Get("Content-Type") + ";" + r.PostForm.Get("grant_type") + ";" + r.PostForm.Get("scope")))
+	}))
+	defer svr.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					data "utilities_http" "test" {
+						url       = %q
+						method    = "POST"
+						form_data = {
+							grant_type = "client_credentials"
+							scope      = "read write"
+						}
+					}`, svr.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utilities_http.test", "status_code", "200"),
+					resource.TestCheckResourceAttr("data.utilities_http.test", "response_body", "application/x-www-form-urlencoded;client_credentials;read write"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+					data "utilities_http" "test" {
+						url             = %q
+						method          = "POST"
+						request_headers = {
+							Content-Type = "application/x-www-form-urlencoded; charset=utf-8"
+						}
+						form_data = {
+							grant_type = "client_credentials"
+						}
+					}`, svr.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utilities_http.test", "response_body", "application/x-www-form-urlencoded; charset=utf-8;client_credentials;"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+					data "utilities_http" "test" {
+						url          = %q
+						method       = "POST"
+						request_body = "test"
+						form_data = {
+							grant_type = "client_credentials"
+						}
+					}`, svr.URL),
+				ExpectError: regexp.MustCompile(`Attribute "request_body" cannot be specified when "form_data" is specified`),
+			},
+		},
+	})
+}
+
+func TestDataSource_RequestBodyJSON(t *testing.T) {
+	t.Parallel()
+
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(r.Header.Get("Content-Type") + ";" + string(body)))
+	}))
+	defer svr.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					data "utilities_http" "test" {
+						url               = %q
+						method            = "POST"
+						request_body_json = {
+							name    = "example"
+							enabled = true
+							tags    = ["a", "b"]
+							count   = 2
+						}
+					}`, svr.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utilities_http.test", "status_code", "200"),
+					resource.TestCheckResourceAttr("data.utilities_http.test", "response_body", `application/json;{"count":2,"enabled":true,"name":"example","tags":["a","b"]}`),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+					data "utilities_http" "test" {
+						url             = %q
+						method          = "POST"
+						request_headers = {
+							Content-Type = "application/merge-patch+json"
+						}
+						request_body_json = "text"
+					}`, svr.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utilities_http.test", "response_body", `application/merge-patch+json;"text"`),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+					data "utilities_http" "test" {
+						url               = %q
+						method            = "POST"
+						request_body      = "test"
+						request_body_json = { name = "example" }
+					}`, svr.URL),
+				ExpectError: regexp.MustCompile(`Attribute "request_body" cannot be specified when "request_body_json" is\s+specified`),
+			},
+		},
+	})
+}
+
+func TestDataSource_GraphQL(t *testing.T) {
+	t.Parallel()
+
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Query         string            `json:"query"`
+			Variables     map[string]string `json:"variables"`
+			OperationName string            `json:"operationName"`
+		}
+
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if request.Variables["id"] != "42" {
+			_, _ = w.Write([]byte(`{"data":null,"errors":[{"message":"user not found"}]}`))
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"data":{"user":{"name":"` + request.OperationName + `"}}}`))
+	}))
+	defer svr.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					data "utilities_http" "test" {
+						url = %q
+
+						graphql {
+							query          = "query GetUser($id: ID!) { user(id: $id) { name } }"
+							variables      = { id = "42" }
+							operation_name = "GetUser"
+						}
+					}`, svr.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utilities_http.test", "status_code", "200"),
+					resource.TestCheckResourceAttr("data.utilities_http.test", "graphql_data.user.name", "GetUser"),
+					resource.TestCheckNoResourceAttr("data.utilities_http.test", "graphql_errors"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+					data "utilities_http" "test" {
+						url = %q
+
+						graphql {
+							query     = "query GetUser($id: ID!) { user(id: $id) { name } }"
+							variables = { id = "0" }
+						}
+					}`, svr.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("data.utilities_http.test", "graphql_data"),
+					resource.TestCheckResourceAttr("data.utilities_http.test", "graphql_errors.0.message", "user not found"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSource_SOAP(t *testing.T) {
+	t.Parallel()
+
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "text/xml; charset=utf-8" ||
+			r.Header.Get("SOAPAction") != `"urn:users/GetUser"` ||
+			!strings.Contains(string(body), `<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/">`) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/xml; charset=utf-8")
+
+		if !strings.Contains(string(body), "<soap:Body><GetUser><Id>42</Id></GetUser></soap:Body>") {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`<?xml version="1.0"?>
+<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/">
+  <soap:Body>
+    <soap:Fault>
+      <faultcode>soap:Client</faultcode>
+      <faultstring>user not found</faultstring>
+      <detail><Id>0</Id></detail>
+    </soap:Fault>
+  </soap:Body>
+</soap:Envelope>`))
+			return
+		}
+
+		_, _ = w.Write([]byte(`<?xml version="1.0"?>
+<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/">
+  <soap:Body>
+    <GetUserResponse><Name>Jane</Name></GetUserResponse>
+  </soap:Body>
+</soap:Envelope>`))
+	}))
+	defer svr.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					data "utilities_http" "test" {
+						url = %q
+
+						soap {
+							action        = "urn:users/GetUser"
+							envelope_body = "<GetUser><Id>42</Id></GetUser>"
+						}
+					}`, svr.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utilities_http.test", "status_code", "200"),
+					resource.TestCheckResourceAttr("data.utilities_http.test", "soap_body", "<GetUserResponse><Name>Jane</Name></GetUserResponse>"),
+					resource.TestCheckNoResourceAttr("data.utilities_http.test", "soap_fault"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+					data "utilities_http" "test" {
+						url = %q
+
+						soap {
+							action        = "urn:users/GetUser"
+							envelope_body = "<GetUser><Id>0</Id></GetUser>"
+						}
+					}`, svr.URL),
+				ExpectError: regexp.MustCompile(`SOAP fault soap:Client: user not found`),
+			},
+			{
+				Config: fmt.Sprintf(`
+					data "utilities_http" "test" {
+						url                  = %q
+						success_status_codes = [200, 500]
+
+						soap {
+							action        = "urn:users/GetUser"
+							envelope_body = "<GetUser><Id>0</Id></GetUser>"
+						}
+					}`, svr.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utilities_http.test", "status_code", "500"),
+					resource.TestCheckResourceAttr("data.utilities_http.test", "soap_fault.code", "soap:Client"),
+					resource.TestCheckResourceAttr("data.utilities_http.test", "soap_fault.reason", "user not found"),
+					resource.TestCheckResourceAttr("data.utilities_http.test", "soap_fault.detail", "<Id>0</Id>"),
+					resource.TestCheckNoResourceAttr("data.utilities_http.test", "soap_fault.actor"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSource_AcceptEncoding(t *testing.T) {
+	t.Parallel()
+
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body bytes.Buffer
+		var writer io.WriteCloser
+
+		// The response is compressed regardless of the request headers.
+		switch r.URL.Query().Get("encoding") {
+		case "gzip":
+			writer = gzip.NewWriter(&body)
+		case "deflate":
+			writer = zlib.NewWriter(&body)
+		case "br":
+			writer = brotli.NewWriter(&body)
+		}
+
+		_, _ = writer.Write([]byte(r.Header.Get("Accept-Encoding")))
+		_ = writer.Close()
+
+		w.Header().Set("Content-Type", "text/plain")
+		w.Header().Set("Content-Encoding", r.URL.Query().Get("encoding"))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(body.Bytes())
+	}))
+	defer svr.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					data "utilities_http" "gzip" {
+						url = "%s?encoding=gzip"
+					}
+
+					data "utilities_http" "deflate" {
+						url = "%s?encoding=deflate"
+					}
+
+					data "utilities_http" "br" {
+						url             = "%s?encoding=br"
+						accept_encoding = "gzip, deflate, br"
+					}`, svr.URL, svr.URL, svr.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utilities_http.gzip", "response_body", "gzip"),
+					resource.TestCheckResourceAttr("data.utilities_http.deflate", "response_body", "gzip"),
+					resource.TestCheckResourceAttr("data.utilities_http.br", "response_body", "gzip, deflate, br"),
+					resource.TestCheckResourceAttr("data.utilities_http.br", "response_body_base64", "Z3ppcCwgZGVmbGF0ZSwgYnI="),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSource_HMACSignature(t *testing.T) {
+	t.Parallel()
+
+	sign := func(message string) string {
+		mac := hmac.New(sha256.New, []byte("s3cr3t"))
+		mac.Write([]byte(message))
+		return hex.EncodeToString(mac.Sum(nil))
+	}
+
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		// GitHub signs the body, Slack the timestamp and the body.
+		expected := "sha256=" + sign(string(body))
+		if timestamp := r.Header.Get("X-Slack-Request-Timestamp"); timestamp != "" {
+			expected = "v0=" + sign("v0:"+timestamp+":"+string(body))
+		}
+
+		if r.Header.Get("X-Signature") != expected {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer svr.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					data "utilities_http" "test" {
+						url          = %q
+						method       = "POST"
+						request_body = "{\"action\":\"opened\"}"
+						hmac_signature {
+							secret                = "s3cr3t"
+							header                = "X-Signature"
+							header_value_template = "sha256={signature}"
+						}
+					}`, svr.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utilities_http.test", "status_code", "200"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+					data "utilities_http" "test" {
+						url          = %q
+						method       = "POST"
+						request_body = "token=abc"
+						hmac_signature {
+							secret                  = "s3cr3t"
+							algorithm               = "sha256"
+							header                  = "X-Signature"
+							string_to_sign_template = "v0:{timestamp}:{body}"
+							header_value_template   = "v0={signature}"
+							timestamp_header        = "X-Slack-Request-Timestamp"
+						}
+					}`, svr.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utilities_http.test", "status_code", "200"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+					data "utilities_http" "test" {
+						url = %q
+						hmac_signature {
+							secret    = "s3cr3t"
+							algorithm = "md5"
+							header    = "X-Signature"
+						}
+					}`, svr.URL),
+				ExpectError: regexp.MustCompile(`Attribute hmac_signature.algorithm value must be one of`),
+			},
+		},
+	})
+}
+
+func TestDataSource_ForwardTo(t *testing.T) {
+	t.Parallel()
+
+	source := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("artifact content"))
+	}))
+	defer source.Close()
+
+	var forwarded []string
+	sink := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		forwarded = append(forwarded, fmt.Sprintf("%s %s %s %s", r.Method, r.Header.Get("Content-Type"), r.Header.Get("Authorization"), body))
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer sink.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					data "utilities_http" "test" {
+						url = %q
+
+						forward_to {
+							url             = "%s/mirror/artifact"
+							request_headers = {
+								Authorization = "Bearer token"
+							}
+						}
+					}`, source.URL, sink.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utilities_http.test", "status_code", "200"),
+					resource.TestCheckResourceAttr("data.utilities_http.test", "forward_to.status_code", "201"),
+					resource.TestCheckNoResourceAttr("data.utilities_http.test", "response_body"),
+					func(*terraform.State) error {
+						expected := "PUT application/octet-stream Bearer token artifact content"
+						if len(forwarded) == 0 || forwarded[len(forwarded)-1] != expected {
+							return fmt.Errorf("expected %q to be forwarded, got %q", expected, forwarded)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+					data "utilities_http" "test" {
+						url = %q
+
+						forward_to {
+							url       = %q
+							max_bytes = 8
+						}
+					}`, source.URL, sink.URL),
+				ExpectError: regexp.MustCompile("the response body exceeds 8 bytes"),
+			},
+		},
+	})
+}
+
+func TestDataSource_ResponseBodyText(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`你好世界`)) // Hello world
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer svr.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+								url = "%s"
+							}`, svr.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utilities_http.http_test", "response_body", "你好世界"),
+					resource.TestCheckResourceAttr("data.utilities_http.http_test", "response_body_base64", "5L2g5aW95LiW55WM"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSource_ResponseBodyBinary(t *testing.T) {
+	// 1 x 1 transparent gif pixel.
+	const transPixel = "\x47\x49\x46\x38\x39\x61\x01\x00\x01\x00\x80\x00\x00\x00\x00\x00\x00\x00\x00\x21\xF9\x04\x01\x00\x00\x00\x00\x2C\x00\x00\x00\x00\x01\x00\x01\x00\x00\x02\x02\x44\x01\x00\x3B"
+
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/gif")
+		_, _ = w.Write([]byte(transPixel))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer svr.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			//test fails in TF 0.14.x due to quirk in behavior
+			//where a warning results in nothing being written to output.
+			tfversion.SkipBetween(tfversion.Version0_14_0, tfversion.Version0_15_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+								url = "%s"
+							}`, svr.URL),
+				Check: resource.ComposeTestCheckFunc(
+					// Note the replacement character in the string representation in `response_body`.
+					resource.TestCheckResourceAttr("data.utilities_http.http_test", "response_body", "GIF89a\x01\x00\x01\x00�\x00\x00\x00\x00\x00\x00\x00\x00!�\x04\x01\x00\x00\x00\x00,\x00\x00\x00\x00\x01\x00\x01\x00\x00\x02\x02D\x01\x00;"),
+					resource.TestCheckResourceAttr("data.utilities_http.http_test", "response_body_base64", "R0lGODlhAQABAIAAAAAAAAAAACH5BAEAAAAALAAAAAABAAEAAAICRAEAOw=="),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSource_CompressStateBody(t *testing.T) {
+	body := strings.Repeat("The quick brown fox jumps over the lazy dog. ", 100)
+	svr := testserver.New(t, testserver.Config{
+		Routes: map[string]testserver.Route{
+			"GET /": {Headers: map[string]string{"Content-Type": "application/json"}, Body: fmt.Sprintf("%q", body)},
+		},
+	})
+
+	var compressed bytes.Buffer
+	writer := gzip.NewWriter(&compressed)
+	_, _ = writer.Write([]byte(fmt.Sprintf("%q", body)))
+	_ = writer.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+								url                 = "%s"
+								compress_state_body = true
+							}`, svr.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utilities_http.http_test", "status_code", "200"),
+					resource.TestCheckResourceAttr("data.utilities_http.http_test", "response_body_gzip_base64", base64.StdEncoding.EncodeToString(compressed.Bytes())),
+					resource.TestCheckNoResourceAttr("data.utilities_http.http_test", "response_body"),
+					resource.TestCheckNoResourceAttr("data.utilities_http.http_test", "body"),
+					resource.TestCheckNoResourceAttr("data.utilities_http.http_test", "response_body_base64"),
+					resource.TestCheckNoResourceAttr("data.utilities_http.http_test", "response_body_json"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+								url = "%s"
+							}`, svr.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utilities_http.http_test", "response_body", fmt.Sprintf("%q", body)),
+					resource.TestCheckNoResourceAttr("data.utilities_http.http_test", "response_body_gzip_base64"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSource_DiscardResponseBody(t *testing.T) {
+	body := strings.Repeat("OK\n", 1000)
+	svr := testserver.New(t, testserver.Config{
+		Routes: map[string]testserver.Route{
+			"GET /": {Headers: map[string]string{"Content-Type": "text/plain", "X-Single": "foobar"}, Body: body},
+		},
+	})
+
+	checksum := sha256.Sum256([]byte(body))
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+								url                   = "%s"
+								discard_response_body = true
+							}`, svr.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utilities_http.http_test", "status_code", "200"),
+					resource.TestCheckResourceAttr("data.utilities_http.http_test", "response_headers.X-Single", "foobar"),
+					resource.TestCheckResourceAttr("data.utilities_http.http_test", "content_summary.sha256", hex.EncodeToString(checksum[:])),
+					resource.TestCheckResourceAttr("data.utilities_http.http_test", "content_summary.size", "3000"),
+					resource.TestCheckNoResourceAttr("data.utilities_http.http_test", "response_body"),
+					resource.TestCheckNoResourceAttr("data.utilities_http.http_test", "body"),
+					resource.TestCheckNoResourceAttr("data.utilities_http.http_test", "response_body_base64"),
+					resource.TestCheckNoResourceAttr("data.utilities_http.http_test", "response_body_gzip_base64"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+								url                   = "%s"
+								discard_response_body = true
+								compress_state_body   = true
+							}`, svr.URL),
+				ExpectError: regexp.MustCompile(`Attribute "compress_state_body" cannot be specified when\s+"discard_response_body" is specified`),
+			},
+		},
+	})
+}
+
+func TestDataSource_ResponseBodyJSON(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"name": "test", "count": 2, "tags": ["a", "b"], "nested": {"enabled": true}}`))
+	}))
+	defer svr.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+								url = "%s"
+							}
+
+							output "name" {
+								value = data.utilities_http.http_test.response_body_json.name
+							}
+
+							output "count" {
+								value = data.utilities_http.http_test.response_body_json.count
+							}
+
+							output "second_tag" {
+								value = data.utilities_http.http_test.response_body_json.tags[1]
+							}
+
+							output "enabled" {
+								value = data.utilities_http.http_test.response_body_json.nested.enabled
+							}`, svr.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckOutput("name", "test"),
+					resource.TestCheckOutput("count", "2"),
+					resource.TestCheckOutput("second_tag", "b"),
+					resource.TestCheckOutput("enabled", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSource_ResponseBodyJSON_NotJSON(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"name": "test"}`))
+	}))
+	defer svr.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+								url = "%s"
+							}`, svr.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr("data.utilities_http.http_test", "response_body_json"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSource_ResponseQueries(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"items": [{"name": "a", "size": 1}, {"name": "b", "size": 3}], "version": "1.2.3", "id": 9007199254740993}`))
+	}))
+	defer svr.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+								url = "%s"
+								response_queries = {
+									version = "version"
+									large   = "items[?size > `+"`2`"+`].name | [0]"
+									id      = "id"
+								}
+							}
+
+							output "id" {
+								value = data.utilities_http.http_test.query_results.id
+							}
+
+							output "version" {
+								value = data.utilities_http.http_test.query_results.version
+							}
+
+							output "large" {
+								value = data.utilities_http.http_test.query_results.large
+							}`, svr.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckOutput("version", "1.2.3"),
+					resource.TestCheckOutput("large", "b"),
+					resource.TestCheckOutput("id", "9007199254740993"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSource_ResponseQueriesInvalidBody(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`not json`))
+	}))
+	defer svr.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+								url = "%s"
+								response_queries = {
+									version = "version"
+								}
+							}`, svr.URL),
+				ExpectError: regexp.MustCompile(`the response body is not valid JSON`),
+			},
+		},
+	})
+}
+
+func TestDataSource_ResponseBodyAssertions(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`status: healthy`))
+	}))
+	defer svr.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+								url                    = "%s"
+								response_body_regex    = "^status: (healthy|degraded)$"
+								expected_response_body = "status: healthy"
+							}`, svr.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utilities_http.http_test", "response_body", "status: healthy"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+								url                 = "%s"
+								response_body_regex = "^status: ok$"
+							}`, svr.URL),
+				ExpectError: regexp.MustCompile(`The response body does not match the regular expression`),
+			},
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+								url                    = "%s"
+								expected_response_body = "status: ok"
+							}`, svr.URL),
+				ExpectError: regexp.MustCompile(`The response body does not match the expected response body`),
+			},
+		},
+	})
+}
+
+func TestDataSource_SuccessBodyContains(t *testing.T) {
+	var requestCount atomic.Int32
+
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/starting" || requestCount.Add(1)%3 != 0 {
+			_, _ = w.Write([]byte(`{"status": "starting"}`))
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"status": "ready"}`))
+	}))
+	defer svr.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+								url                   = "%s"
+								success_body_contains = ["\"ready\"", "\"healthy\""]
+								retry {
+									attempts     = 2
+									min_delay_ms = 10
+									max_delay_ms = 10
+								}
+							}`, svr.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utilities_http.http_test", "status_code", "200"),
+					resource.TestCheckResourceAttr("data.utilities_http.http_test", "response_body", `{"status": "ready"}`),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+								url                   = "%s/starting"
+								success_body_contains = ["ready"]
+							}`, svr.URL),
+				ExpectError: regexp.MustCompile(`the response body contains none of "ready"`),
+			},
+		},
+	})
+}
+
+func TestDataSource_ExpectedContentType(t *testing.T) {
+	svr := testserver.New(t, testserver.Config{
+		Routes: map[string]testserver.Route{
+			"GET /json":  {Headers: map[string]string{"Content-Type": "application/json; charset=utf-8"}, Body: `{}`},
+			"GET /login": {Headers: map[string]string{"Content-Type": "text/html"}, Body: `<html></html>`},
+		},
+	})
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_http" "exact" {
+								url                   = "%[1]s/json"
+								expected_content_type = "application/json"
+							}
+
+							data "utilities_http" "wildcard" {
+								url                   = "%[1]s/json"
+								expected_content_type = "Application/*"
+							}`, svr.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utilities_http.exact", "response_body", "{}"),
+					resource.TestCheckResourceAttr("data.utilities_http.wildcard", "response_body", "{}"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+								url                   = "%s/login"
+								expected_content_type = "application/json"
+							}`, svr.URL),
+				ExpectError: regexp.MustCompile(`The response content type "text/html" does not match the expected content\s+type "application/json"`),
+			},
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+								url                   = "%s/json"
+								expected_content_type = "json"
+							}`, svr.URL),
+				ExpectError: regexp.MustCompile(`must be a media type`),
+			},
+		},
+	})
+}
+
+func TestDataSource_ValidateCEL(t *testing.T) {
+	svr := testserver.New(t, testserver.Config{
+		Routes: map[string]testserver.Route{
+			"GET /": {
+				Headers: map[string]string{"Content-Type": "application/json"},
+				Body:    `{"state": "ready", "replicas": 3}`,
+			},
+		},
+	})
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+								url          = "%s"
+								validate_cel = "status == 200 && headers['Content-Type'] == 'application/json' && body_json.state == 'ready'"
+							}`, svr.URL),
+				Check: resource.TestCheckResourceAttr("data.utilities_http.http_test", "status_code", "200"),
+			},
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+								url          = "%s"
+								validate_cel = "body_json.replicas > 5"
+							}`, svr.URL),
+				ExpectError: regexp.MustCompile(`evaluated to false instead of true`),
+			},
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+								url          = "%s"
+								validate_cel = "body_json.state =="
+							}`, svr.URL),
+				ExpectError: regexp.MustCompile(`The CEL expression could not be compiled`),
+			},
+		},
+	})
+}
+
+func TestDataSource_ResponseSchema(t *testing.T) {
+	svr := testserver.New(t, testserver.Config{
+		Routes: map[string]testserver.Route{
+			"GET /": {
+				Headers: map[string]string{"Content-Type": "application/json"},
+				Body:    `{"state": "ready", "replicas": 3, "nodes": [{"name": "a"}, {"name": 1}]}`,
+			},
+			"GET /schema.json": {
+				Headers: map[string]string{"Content-Type": "application/schema+json"},
+				Body:    `{"type": "object", "required": ["state"], "properties": {"state": {"enum": ["ready", "pending"]}}}`,
+			},
+		},
+	})
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+								url             = "%s"
+								response_schema = "%s/schema.json"
+							}`, svr.URL, svr.URL),
+				Check: resource.TestCheckResourceAttr("data.utilities_http.http_test", "status_code", "200"),
+			},
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+								url             = "%s"
+								response_schema = jsonencode({
+									type     = "object"
+									required = ["state", "version"]
+									properties = {
+										replicas = { type = "integer", minimum = 5 }
+										nodes = {
+											type  = "array"
+											items = { "$ref" = "#/$defs/node" }
+										}
+									}
+									"$defs" = {
+										node = {
+											type       = "object"
+											properties = { name = { type = "string" } }
+										}
+									}
+								})
+							}`, svr.URL),
+				ExpectError: regexp.MustCompile(`(?s)/: missing required property "version".*/nodes/1/name: expected string, got number.*/replicas: expected at least 5, got 3`),
+			},
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+								url             = "%s"
+								response_schema = "{"
+							}`, svr.URL),
+				ExpectError: regexp.MustCompile(`Invalid JSON Schema`),
+			},
+		},
+	})
+}
+
+func TestDataSource_ResponseSchemaRecursive(t *testing.T) {
+	svr := testserver.New(t, testserver.Config{
+		Routes: map[string]testserver.Route{
+			"GET /": {
+				Headers: map[string]string{"Content-Type": "application/json"},
+				Body:    `{"name": "root", "children": [{"name": "a", "children": [{"name": 1}]}]}`,
+			},
+		},
+	})
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+								url             = "%s"
+								response_schema = jsonencode({
+									type = "object"
+									properties = {
+										name     = { type = "string" }
+										children = { type = "array", items = { "$ref" = "#" } }
+									}
+								})
+							}`, svr.URL),
+				ExpectError: regexp.MustCompile(`/children/0/children/0/name: expected string, got number`),
+			},
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+								url             = "%s"
+								response_schema = jsonencode({ "$ref" = "#" })
+							}`, svr.URL),
+				ExpectError: regexp.MustCompile(`/: circular reference "#"`),
+			},
+			{
+				// The keywords next to a reference are ignored.
+				Config: fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+								url             = "%s"
+								response_schema = jsonencode({
+									"$ref"  = "#/$defs/node"
+									type    = "string"
+									"$defs" = { node = { type = "object" } }
+								})
+							}`, svr.URL),
+				Check: resource.TestCheckResourceAttr("data.utilities_http.http_test", "status_code", "200"),
+			},
+		},
+	})
+}
+
+func TestDataSource_OpenAPI(t *testing.T) {
+	var requestCount int
+	svr := testserver.New(t, testserver.Config{
+		Routes: map[string]testserver.Route{
+			"POST /api/pets": {
+				Handler: func(w http.ResponseWriter, r *http.Request) {
+					requestCount++
+					w.WriteHeader(http.StatusCreated)
+				},
+			},
+			"GET /openapi.yaml": {
+				Body: `
+openapi: 3.0.0
+paths:
+  /pets:
+    post:
+      operationId: createPet
+      parameters:
+        - name: X-Request-Id
+          in: header
+          required: true
+          schema: { type: string }
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema: { $ref: "#/components/schemas/Pet" }
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [name]
+      properties:
+        name: { type: string }
+        age: { type: integer, minimum: 0 }
+`,
+			},
+		},
+	})
 
-	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "text/plain")
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+								url               = "%s/api/pets"
+								method            = "POST"
+								request_headers   = { X-Request-Id = "42" }
+								request_body_json = { name = "Rex", age = 3 }
 
-		requestBody, err := io.ReadAll(r.Body)
+								openapi {
+									spec         = "%s/openapi.yaml"
+									operation_id = "createPet"
+								}
+							}`, svr.URL, svr.URL),
+				Check: resource.TestCheckResourceAttr("data.utilities_http.http_test", "status_code", "201"),
+			},
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+								url               = "%s/api/pets"
+								request_body_json = { age = -1 }
 
-		if err != nil {
-			w.WriteHeader(http.StatusInternalServerError)
-			_, _ = w.Write([]byte(`Request Body Read Error: ` + err.Error()))
+								openapi {
+									spec         = "%s/openapi.yaml"
+									operation_id = "createPet"
+								}
+							}`, svr.URL, svr.URL),
+				ExpectError: regexp.MustCompile(`(?s)method: expected POST, got GET.*header.X-Request-Id: missing required parameter.*body: missing required property "name".*body/age: expected at least 0, got -1`),
+			},
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+								url = "%s/api/pets"
 
-			return
-		}
+								openapi {
+									spec         = "%s/openapi.yaml"
+									operation_id = "deletePet"
+								}
+							}`, svr.URL, svr.URL),
+				ExpectError: regexp.MustCompile(`no operation with the id "deletePet"`),
+			},
+		},
+		CheckDestroy: func(_ *terraform.State) error {
+			if requestCount != 1 {
+				return fmt.Errorf("expected 1 request to be made, got %d", requestCount)
+			}
+			return nil
+		},
+	})
+}
 
-		// If the request body is empty or a test string, return a 200 OK with a response body.
-		if len(requestBody) == 0 || string(requestBody) == "test" {
-			w.WriteHeader(http.StatusOK)
-			_, _ = w.Write([]byte(`test response body`))
+func TestDataSource_RequestIDHeader(t *testing.T) {
+	var mu sync.Mutex
+	var requestIDs []string
+	svr := testserver.New(t, testserver.Config{
+		Routes: map[string]testserver.Route{
+			"GET /": {
+				Handler: func(w http.ResponseWriter, r *http.Request) {
+					mu.Lock()
+					defer mu.Unlock()
 
-			return
-		}
+					// Every read is retried once when the header is set.
+					requestIDs = append(requestIDs, r.Header.Get("X-Request-Id"))
+					if requestIDs[len(requestIDs)-1] != "" && len(requestIDs)%2 == 1 {
+						w.WriteHeader(http.StatusServiceUnavailable)
+					}
+				},
+			},
+		},
+	})
 
-		w.WriteHeader(http.StatusBadRequest)
-		_, _ = w.Write([]byte(`request body (` + string(requestBody) + `) was not empty or "test"`))
-	}))
-	defer svr.Close()
+	lastRequestID := func(value string) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if len(requestIDs) < 2 || len(slices.Compact(slices.Sorted(slices.Values(requestIDs)))) != len(requestIDs) {
+			return fmt.Errorf("expected the attempts to have different request IDs, got %q", requestIDs)
+		}
+		last := requestIDs[len(requestIDs)-1]
+		if _, err := uuid.Parse(value); err != nil || value != last {
+			return fmt.Errorf("expected the request ID of the last attempt %q, got %q", last, value)
+		}
+		requestIDs = nil
+		return nil
+	}
 
 	resource.UnitTest(t, resource.TestCase{
 		ProtoV6ProviderFactories: protoV6ProviderFactories(),
 		Steps: []resource.TestStep{
 			{
 				Config: fmt.Sprintf(`
-					data "utilities_http" "test" {
-						url = "%s"
-					}`, svr.URL),
-				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("data.utilities_http.test", "status_code", "200"),
+							data "utilities_http" "http_test" {
+								url               = "%s"
+								request_id_header = "X-Request-Id"
+
+								retry {
+									attempts     = 1
+									min_delay_ms = 1
+								}
+							}`, svr.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utilities_http.http_test", "status_code", "200"),
+					resource.TestCheckResourceAttrWith("data.utilities_http.http_test", "request_id", lastRequestID),
 				),
 			},
 			{
 				Config: fmt.Sprintf(`
-					data "utilities_http" "test" {
-						request_body = "test"
-						url          = %q
-					}`, svr.URL),
-				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("data.utilities_http.test", "status_code", "200"),
+							data "utilities_http" "http_test" {
+								url = "%s"
+							}`, svr.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr("data.utilities_http.http_test", "request_id"),
+					func(_ *terraform.State) error {
+						mu.Lock()
+						defer mu.Unlock()
+
+						if len(requestIDs) == 0 || requestIDs[0] != "" {
+							return fmt.Errorf("expected no request ID header, got %q", requestIDs)
+						}
+						return nil
+					},
 				),
 			},
+		},
+	})
+}
+
+func TestDataSource_ShareResponse(t *testing.T) {
+	var requestCount atomic.Int64
+	svr := testserver.New(t, testserver.Config{
+		Routes: map[string]testserver.Route{
+			"GET /": {
+				Handler: func(w http.ResponseWriter, r *http.Request) {
+					_, _ = fmt.Fprintf(w, "request %d", requestCount.Add(1))
+				},
+			},
+		},
+	})
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
 			{
 				Config: fmt.Sprintf(`
-					data "utilities_http" "test" {
-						request_body = "not-test"
-						url          = %q
-					}`, svr.URL),
-				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("data.utilities_http.test", "status_code", "400"),
+							data "utilities_http" "first" {
+								url            = "%[1]s"
+								share_response = true
+							}
+
+							data "utilities_http" "second" {
+								url            = "%[1]s"
+								share_response = true
+							}
+
+							data "utilities_http" "not_shared" {
+								url = "%[1]s"
+							}
+
+							data "utilities_http" "other_transport" {
+								url            = "%[1]s"
+								share_response = true
+								ip_version     = "4"
+							}`, svr.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.utilities_http.first", "response_body", "data.utilities_http.second", "response_body"),
+					func(s *terraform.State) error {
+						shared := s.RootModule().Resources["data.utilities_http.first"].Primary.Attributes["response_body"]
+						for _, name := range []string{"not_shared", "other_transport"} {
+							body := s.RootModule().Resources["data.utilities_http."+name].Primary.Attributes["response_body"]
+							if shared == body {
+								return fmt.Errorf("expected the request of %s to be made, got %q", name, body)
+							}
+						}
+						return nil
+					},
 				),
 			},
 		},
 	})
 }
 
-func TestDataSource_ResponseBodyText(t *testing.T) {
-	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		_, _ = w.Write([]byte(`你好世界`)) // Hello world
-		w.WriteHeader(http.StatusOK)
-	}))
-	defer svr.Close()
+func TestDataSource_RetryOn(t *testing.T) {
+	svr := testserver.New(t, testserver.Config{
+		Routes: map[string]testserver.Route{
+			"GET /":      {Body: "ok", FailFirst: 1},
+			"GET /flaky": {Body: "ok", FailFirst: 1},
+		},
+	})
+	tlsServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer tlsServer.Close()
 
-	resource.ParallelTest(t, resource.TestCase{
+	resource.UnitTest(t, resource.TestCase{
 		ProtoV6ProviderFactories: protoV6ProviderFactories(),
 		Steps: []resource.TestStep{
 			{
 				Config: fmt.Sprintf(`
 							data "utilities_http" "http_test" {
 								url = "%s"
+
+								retry {
+									attempts     = 3
+									min_delay_ms = 1
+									retry_on     = ["connect"]
+								}
 							}`, svr.URL),
-				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttr("data.utilities_http.http_test", "response_body", "你好世界"),
-					resource.TestCheckResourceAttr("data.utilities_http.http_test", "response_body_base64", "5L2g5aW95LiW55WM"),
-				),
+				ExpectError: regexp.MustCompile(`giving up after 1 attempt\(s\): unexpected HTTP status 503`),
+			},
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+								url = "%s"
+
+								retry {
+									attempts     = 3
+									min_delay_ms = 1
+									retry_on     = ["connect", "http_status"]
+								}
+							}`, svr.URL+"/flaky"),
+				Check: resource.TestCheckResourceAttr("data.utilities_http.http_test", "status_code", "200"),
+			},
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+								url = "%s"
+
+								retry {
+									attempts     = 3
+									min_delay_ms = 1
+									retry_on     = ["connect", "http_status"]
+								}
+							}`, tlsServer.URL),
+				ExpectError: regexp.MustCompile(`giving up after 1 attempt\(s\)(.|\n)*certificate`),
+			},
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+								url = "%s"
+
+								retry {
+									retry_on = ["certificate"]
+								}
+							}`, svr.URL),
+				ExpectError: regexp.MustCompile(`value must be one of`),
 			},
 		},
 	})
 }
 
-func TestDataSource_ResponseBodyBinary(t *testing.T) {
-	// 1 x 1 transparent gif pixel.
-	const transPixel = "\x47\x49\x46\x38\x39\x61\x01\x00\x01\x00\x80\x00\x00\x00\x00\x00\x00\x00\x00\x21\xF9\x04\x01\x00\x00\x00\x00\x2C\x00\x00\x00\x00\x01\x00\x01\x00\x00\x02\x02\x44\x01\x00\x3B"
+func TestDataSource_RetryOnPinMismatch(t *testing.T) {
+	svr := testserver.New(t, testserver.Config{
+		Routes: map[string]testserver.Route{"GET /": {Body: "ok"}},
+		TLS:    true,
+	})
 
-	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "image/gif")
-		_, _ = w.Write([]byte(transPixel))
-		w.WriteHeader(http.StatusOK)
-	}))
-	defer svr.Close()
+	config := func(retryOn string) string {
+		return fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+								url                = "%s"
+								pinned_cert_sha256 = ["%s"]
 
-	resource.ParallelTest(t, resource.TestCase{
+								retry {
+									attempts     = 2
+									min_delay_ms = 1
+									retry_on     = [%q]
+								}
+							}`, svr.URL, strings.Repeat("00", 32), retryOn)
+	}
+
+	// The pin mismatch is a TLS error, not a connection error.
+	resource.UnitTest(t, resource.TestCase{
 		ProtoV6ProviderFactories: protoV6ProviderFactories(),
-		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
-			//test fails in TF 0.14.x due to quirk in behavior
-			//where a warning results in nothing being written to output.
-			tfversion.SkipBetween(tfversion.Version0_14_0, tfversion.Version0_15_0),
+		Steps: []resource.TestStep{
+			{
+				Config:      config("connect"),
+				ExpectError: regexp.MustCompile(`giving up after 1 attempt\(s\)(.|\n)*does not match any pinned fingerprint`),
+			},
+			{
+				Config:      config("tls"),
+				ExpectError: regexp.MustCompile(`giving up after 3 attempt\(s\)(.|\n)*does not match any pinned fingerprint`),
+			},
+		},
+	})
+}
+
+func TestDataSource_RetryOnBodyAssertion(t *testing.T) {
+	var mu sync.Mutex
+	requests := make(map[string]int)
+	svr := testserver.New(t, testserver.Config{
+		Routes: map[string]testserver.Route{
+			"GET /": {
+				Handler: func(w http.ResponseWriter, r *http.Request) {
+					mu.Lock()
+					defer mu.Unlock()
+
+					// Each path is ready from its second request.
+					requests[r.URL.Path]++
+					if requests[r.URL.Path] == 1 {
+						_, _ = w.Write([]byte("starting"))
+						return
+					}
+					_, _ = w.Write([]byte("ready"))
+				},
+			},
+		},
+	})
+
+	config := func(path string, retryOn string) string {
+		return fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+								url                   = "%s%s"
+								success_body_contains = ["ready"]
+
+								retry {
+									attempts     = 3
+									min_delay_ms = 1
+									retry_on     = [%q]
+								}
+							}`, svr.URL, path, retryOn)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config:      config("/connect", "connect"),
+				ExpectError: regexp.MustCompile(`giving up after 1 attempt\(s\)(.|\n)*the response body contains none of "ready"`),
+			},
+			{
+				Config: config("/status", "http_status"),
+				Check:  resource.TestCheckResourceAttr("data.utilities_http.http_test", "response_body", "ready"),
+			},
+		},
+	})
+}
+
+func TestDataSource_SensitiveRequestHeaders(t *testing.T) {
+	svr := testserver.New(t, testserver.Config{
+		Routes: map[string]testserver.Route{
+			"GET /": {
+				Handler: func(w http.ResponseWriter, r *http.Request) {
+					if r.URL.Path == "/fail" {
+						w.WriteHeader(http.StatusBadRequest)
+					}
+					_, _ = fmt.Fprintf(w, "key: %s, tenant: %s", r.Header.Get("X-Api-Key"), r.Header.Get("X-Tenant"))
+				},
+			},
 		},
+	})
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
 		Steps: []resource.TestStep{
 			{
 				Config: fmt.Sprintf(`
 							data "utilities_http" "http_test" {
-								url = "%s"
+								url             = "%s"
+								request_headers = {
+									"X-Api-Key" = "overridden"
+									"X-Tenant"  = "acme"
+								}
+								sensitive_request_headers = {
+									"x-api-key" = "s3cr3t"
+								}
 							}`, svr.URL),
 				Check: resource.ComposeTestCheckFunc(
-					// Note the replacement character in the string representation in `response_body`.
-					resource.TestCheckResourceAttr("data.utilities_http.http_test", "response_body", "GIF89a\x01\x00\x01\x00�\x00\x00\x00\x00\x00\x00\x00\x00!�\x04\x01\x00\x00\x00\x00,\x00\x00\x00\x00\x01\x00\x01\x00\x00\x02\x02D\x01\x00;"),
-					resource.TestCheckResourceAttr("data.utilities_http.http_test", "response_body_base64", "R0lGODlhAQABAIAAAAAAAAAAACH5BAEAAAAALAAAAAABAAEAAAICRAEAOw=="),
+					resource.TestCheckResourceAttr("data.utilities_http.http_test", "response_body", "key: s3cr3t, tenant: acme"),
+					resource.TestMatchResourceAttr("data.utilities_http.http_test", "curl_command", regexp.MustCompile(`'X-Api-Key: REDACTED'`)),
+					resource.TestMatchResourceAttr("data.utilities_http.http_test", "curl_command", regexp.MustCompile(`'X-Tenant: acme'`)),
 				),
 			},
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_http" "http_test" {
+								url = "%s/fail"
+								sensitive_request_headers = {
+									"X-Api-Key" = "s3cr3t"
+								}
+							}`, svr.URL),
+				ExpectError: regexp.MustCompile(`key: REDACTED`),
+			},
 		},
 	})
 }
@@ -996,6 +4051,39 @@ func checkServerAndProxyRequestCount(proxyRequestCount, serverRequestCount *int)
 }
 
 // certToPEM is a utility function returns a PEM encoded x509 Certificate.
+// dohAnswer answers the DNS-over-HTTPS query of the request with the address
+// of the name, or with NXDOMAIN for any other name.
+func dohAnswer(t *testing.T, r *http.Request, name string, ip net.IP) []byte {
+	t.Helper()
+
+	packed, err := base64.RawURLEncoding.DecodeString(r.URL.Query().Get("dns"))
+	if err != nil {
+		t.Errorf("invalid dns parameter: %s", err)
+	}
+
+	var msg dnsmessage.Message
+	if err := msg.Unpack(packed); err != nil {
+		t.Errorf("invalid DNS message: %s", err)
+	}
+
+	msg.Header.Response = true
+	if len(msg.Questions) != 1 || msg.Questions[0].Name.String() != name {
+		msg.Header.RCode = dnsmessage.RCodeNameError
+	} else if msg.Questions[0].Type == dnsmessage.TypeA {
+		header := dnsmessage.ResourceHeader{Name: msg.Questions[0].Name, Type: dnsmessage.TypeA, Class: dnsmessage.ClassINET}
+		msg.Answers = []dnsmessage.Resource{
+			{Header: header, Body: &dnsmessage.AResource{A: [4]byte(ip.To4())}},
+		}
+	}
+
+	answer, err := msg.Pack()
+	if err != nil {
+		t.Errorf("failed to pack DNS message: %s", err)
+	}
+
+	return answer
+}
+
 func certToPEM(cert *x509.Certificate) string {
 	certPem := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}))
 
@@ -1015,3 +4103,13 @@ func checkMinDelay(timeOfFirstRequest, timeOfSecondRequest *int64, minDelay int)
 		return nil
 	}
 }
+
+func checkMaxDelay(timeOfFirstRequest, timeOfSecondRequest *int64, maxDelay int) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		if diff := *timeOfSecondRequest - *timeOfFirstRequest; diff > int64(maxDelay) {
+			return fmt.Errorf("expected delay between requests to be at most: %dms, was actually: %dms", maxDelay, diff)
+		}
+
+		return nil
+	}
+}
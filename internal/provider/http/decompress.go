@@ -0,0 +1,67 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package http
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+)
+
+// decompressBody reverts the content codings listed in the Content-Encoding
+// header values, applied in order by the server. Empty bodies, such as the
+// ones of HEAD requests, are returned as is.
+func decompressBody(body []byte, contentEncodings []string) ([]byte, error) {
+	if len(body) == 0 {
+		return body, nil
+	}
+
+	var codings []string
+	for _, value := range contentEncodings {
+		for _, coding := range strings.Split(value, ",") {
+			if coding = strings.ToLower(strings.TrimSpace(coding)); coding != "" {
+				codings = append(codings, coding)
+			}
+		}
+	}
+
+	for i := len(codings) - 1; i >= 0; i-- {
+		var reader io.Reader
+		var err error
+
+		switch codings[i] {
+		case "identity":
+			continue
+		case "gzip", "x-gzip":
+			reader, err = gzip.NewReader(bytes.NewReader(body))
+		case "deflate":
+			// The deflate coding is the zlib format, but some servers send
+			// raw deflate data instead.
+			reader, err = zlib.NewReader(bytes.NewReader(body))
+			if err != nil {
+				reader, err = flate.NewReader(bytes.NewReader(body)), nil
+			}
+		case "br":
+			reader = brotli.NewReader(bytes.NewReader(body))
+		default:
+			return nil, fmt.Errorf("unsupported content coding %q", codings[i])
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error decoding %s content: %w", codings[i], err)
+		}
+
+		body, err = io.ReadAll(reader)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding %s content: %w", codings[i], err)
+		}
+	}
+
+	return body, nil
+}
@@ -0,0 +1,24 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package http
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+// deferUnknownConfig defers the change of a resource whose configuration is not
+// fully known yet, e.g. its URL depends on a resource not created yet, when
+// Terraform supports deferred actions.
+func deferUnknownConfig(req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Destroying the resource does not depend on its configuration.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	if req.ClientCapabilities.DeferralAllowed && !req.Config.Raw.IsFullyKnown() {
+		resp.Deferred = &resource.Deferred{
+			Reason: resource.DeferredReasonResourceConfigUnknown,
+		}
+	}
+}
@@ -0,0 +1,216 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package http
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+const (
+	dohCloudflare = "cloudflare"
+	dohGoogle     = "google"
+)
+
+// dohEndpoints are the DNS-over-HTTPS endpoints of the public resolvers known
+// by name.
+var dohEndpoints = map[string]string{
+	dohCloudflare: "https://cloudflare-dns.com/dns-query",
+	dohGoogle:     "https://dns.google/dns-query",
+}
+
+// errNoSuchHost is returned when the resolver answers that the host does not
+// exist.
+var errNoSuchHost = errors.New("no such host")
+
+// dohDialer dials the hosts resolved with a DNS-over-HTTPS endpoint
+// (RFC 8484), the IP addresses being used as is.
+type dohDialer struct {
+	endpoint  string
+	ipVersion string
+	dialer    *net.Dialer
+	client    *http.Client
+}
+
+// newDoHDialer returns the dialer resolving the hosts with the endpoint, the
+// name of a public resolver or a URL, restricted to the addresses of the IP
+// version.
+func newDoHDialer(endpoint, ipVersion string, dialer *net.Dialer) *dohDialer {
+	if known, ok := dohEndpoints[endpoint]; ok {
+		endpoint = known
+	}
+
+	return &dohDialer{
+		endpoint:  endpoint,
+		ipVersion: ipVersion,
+		dialer:    dialer,
+		client:    &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// DialContext resolves the host of the address, reporting the lookup to the
+// trace of the request, then dials its IP addresses in turn, the IPv4 ones
+// first, until a connection is made.
+func (d *dohDialer) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	if net.ParseIP(host) != nil {
+		return d.dialer.DialContext(ctx, network, addr)
+	}
+
+	trace := httptrace.ContextClientTrace(ctx)
+	if trace != nil && trace.DNSStart != nil {
+		trace.DNSStart(httptrace.DNSStartInfo{Host: host})
+	}
+
+	ips, err := d.lookup(ctx, host)
+
+	if trace != nil && trace.DNSDone != nil {
+		addrs := make([]net.IPAddr, 0, len(ips))
+		for _, ip := range ips {
+			addrs = append(addrs, net.IPAddr{IP: ip})
+		}
+		trace.DNSDone(httptrace.DNSDoneInfo{Addrs: addrs, Err: err})
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("lookup %s with %s: %w", host, d.endpoint, err)
+	}
+
+	var errs []error
+	for _, ip := range ips {
+		conn, err := d.dialer.DialContext(ctx, network, net.JoinHostPort(ip.String(), port))
+		if err == nil {
+			return conn, nil
+		}
+		errs = append(errs, err)
+	}
+
+	return nil, errors.Join(errs...)
+}
+
+// lookup returns the IP addresses of the host of the IP version.
+func (d *dohDialer) lookup(ctx context.Context, host string) ([]net.IP, error) {
+	var qtypes []dnsmessage.Type
+	switch d.ipVersion {
+	case ipVersion4:
+		qtypes = []dnsmessage.Type{dnsmessage.TypeA}
+	case ipVersion6:
+		qtypes = []dnsmessage.Type{dnsmessage.TypeAAAA}
+	default:
+		qtypes = []dnsmessage.Type{dnsmessage.TypeA, dnsmessage.TypeAAAA}
+	}
+
+	lookupCtx, cancel := untraced(ctx)
+	defer cancel()
+
+	var ips []net.IP
+	for _, qtype := range qtypes {
+		answers, err := d.query(lookupCtx, host, qtype)
+		if err != nil {
+			return nil, err
+		}
+		ips = append(ips, answers...)
+	}
+
+	if len(ips) == 0 {
+		return nil, errNoSuchHost
+	}
+
+	return ips, nil
+}
+
+// query sends the question to the endpoint with the GET method, the DNS
+// message being encoded in the `dns` query parameter.
+func (d *dohDialer) query(ctx context.Context, host string, qtype dnsmessage.Type) ([]net.IP, error) {
+	if !strings.HasSuffix(host, ".") {
+		host += "."
+	}
+
+	name, err := dnsmessage.NewName(host)
+	if err != nil {
+		return nil, err
+	}
+
+	// The ID is 0 so that the responses can be cached (RFC 8484, Section 4.1).
+	question := dnsmessage.Message{
+		Header: dnsmessage.Header{RecursionDesired: true},
+		Questions: []dnsmessage.Question{
+			{Name: name, Type: qtype, Class: dnsmessage.ClassINET},
+		},
+	}
+	packed, err := question.Pack()
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint, err := url.Parse(d.endpoint)
+	if err != nil {
+		return nil, err
+	}
+	query := endpoint.Query()
+	query.Set("dns", base64.RawURLEncoding.EncodeToString(packed))
+	endpoint.RawQuery = query.Encode()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Accept", "application/dns-message")
+
+	response, err := d.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected response status %s", response.Status)
+	}
+
+	// A DNS message is at most 65535 bytes long.
+	body, err := io.ReadAll(io.LimitReader(response.Body, 65535))
+	if err != nil {
+		return nil, err
+	}
+
+	var answer dnsmessage.Message
+	if err := answer.Unpack(body); err != nil {
+		return nil, fmt.Errorf("invalid DNS message: %w", err)
+	}
+
+	switch answer.RCode {
+	case dnsmessage.RCodeSuccess:
+	case dnsmessage.RCodeNameError:
+		return nil, errNoSuchHost
+	default:
+		return nil, fmt.Errorf("the resolver answered %s", answer.RCode)
+	}
+
+	var ips []net.IP
+	for _, resource := range answer.Answers {
+		switch record := resource.Body.(type) {
+		case *dnsmessage.AResource:
+			ips = append(ips, net.IP(record.A[:]))
+		case *dnsmessage.AAAAResource:
+			ips = append(ips, net.IP(record.AAAA[:]))
+		}
+	}
+
+	return ips, nil
+}
@@ -0,0 +1,103 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package http
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+)
+
+// defaultErrorExcerptBytes is the number of bytes of the response body
+// reported in the errors by default.
+const defaultErrorExcerptBytes = 1024
+
+// excerptHeaders are the response headers reported in the errors, the ones
+// most likely to tell why the request failed.
+var excerptHeaders = []string{
+	"Content-Type",
+	"Retry-After",
+	"Www-Authenticate",
+	"X-Request-Id",
+	"X-Correlation-Id",
+}
+
+// responseExcerpt describes the unsuccessful response a request gave up on.
+type responseExcerpt struct {
+	status    string
+	header    http.Header
+	body      []byte
+	size      int
+	truncated bool
+}
+
+// newResponseExcerpt reads the first maxBytes bytes of the response body, the
+// body being put back so that it can still be read in full.
+func newResponseExcerpt(resp *http.Response, maxBytes int64) *responseExcerpt {
+	excerpt := &responseExcerpt{
+		status: resp.Status,
+		header: resp.Header,
+	}
+
+	if maxBytes == 0 || resp.Body == nil {
+		return excerpt
+	}
+
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, maxBytes+1))
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(data), resp.Body), resp.Body}
+
+	// Only a whole body can be decompressed.
+	if int64(len(data)) <= maxBytes && !resp.Uncompressed {
+		if decompressed, err := decompressBody(data, resp.Header.Values("Content-Encoding")); err == nil {
+			data = decompressed
+		}
+	}
+
+	if int64(len(data)) > maxBytes {
+		data, excerpt.truncated = data[:maxBytes], true
+	}
+	excerpt.body, excerpt.size = data, len(data)
+
+	return excerpt
+}
+
+// String returns the status, the headers and the body of the response, as
+// appended to the error diagnostics.
+func (excerpt *responseExcerpt) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Response status: %s", excerpt.status)
+
+	for _, name := range excerptHeaders {
+		if value := excerpt.header.Get(name); value != "" {
+			fmt.Fprintf(&b, "\n%s: %s", name, value)
+		}
+	}
+
+	if excerpt.size == 0 {
+		return b.String()
+	}
+
+	body := excerpt.body
+	if excerpt.truncated {
+		// The excerpt may end in the middle of a character.
+		body = bytes.ToValidUTF8(body, nil)
+	}
+
+	switch {
+	case !utf8.Valid(body):
+		fmt.Fprintf(&b, "\n\nResponse body: %d bytes of binary data", excerpt.size)
+	case excerpt.truncated:
+		fmt.Fprintf(&b, "\n\nResponse body (first %d bytes):\n%s", excerpt.size, body)
+	default:
+		fmt.Fprintf(&b, "\n\nResponse body:\n%s", body)
+	}
+
+	return b.String()
+}
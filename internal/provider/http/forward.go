@@ -0,0 +1,115 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package http
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type forwardModel struct {
+	URL            types.String `tfsdk:"url"`
+	Method         types.String `tfsdk:"method"`
+	RequestHeaders types.Map    `tfsdk:"request_headers"`
+	MaxBytes       types.Int64  `tfsdk:"max_bytes"`
+	StatusCode     types.Int64  `tfsdk:"status_code"`
+}
+
+var forwardAttrTypes = map[string]attr.Type{
+	"url":             types.StringType,
+	"method":          types.StringType,
+	"request_headers": types.MapType{ElemType: types.StringType},
+	"max_bytes":       types.Int64Type,
+	"status_code":     types.Int64Type,
+}
+
+// bodyTooLargeError is returned when a response body exceeds its size limit.
+type bodyTooLargeError struct {
+	maxBytes int64
+}
+
+func (err bodyTooLargeError) Error() string {
+	return fmt.Sprintf("the response body exceeds %d bytes", err.maxBytes)
+}
+
+// cappedReader reads from r and fails once more than maxBytes were read.
+type cappedReader struct {
+	r         io.Reader
+	remaining int64
+	maxBytes  int64
+}
+
+func (c *cappedReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.remaining -= int64(n)
+	if c.remaining < 0 {
+		return n, bodyTooLargeError{c.maxBytes}
+	}
+	return n, err
+}
+
+// send streams the body of the response to the forward URL, and returns the
+// status code of the forward request.
+func (model *forwardModel) send(ctx context.Context, response *http.Response, timeout time.Duration) (int, error) {
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return 0, fmt.Errorf("the response status %s is not forwarded", response.Status)
+	}
+
+	var body io.Reader = response.Body
+	if !model.MaxBytes.IsNull() {
+		maxBytes := model.MaxBytes.ValueInt64()
+		if response.ContentLength > maxBytes {
+			return 0, bodyTooLargeError{maxBytes}
+		}
+		body = &cappedReader{r: response.Body, remaining: maxBytes, maxBytes: maxBytes}
+	}
+
+	method := http.MethodPut
+	if !model.Method.IsNull() {
+		method = model.Method.ValueString()
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, model.URL.ValueString(), body)
+	if err != nil {
+		return 0, err
+	}
+
+	// The body is forwarded as received.
+	request.ContentLength = response.ContentLength
+	for _, name := range []string{"Content-Type", "Content-Encoding"} {
+		if value := response.Header.Get(name); value != "" {
+			request.Header.Set(name, value)
+		}
+	}
+
+	var headers map[string]string
+	if diags := model.RequestHeaders.ElementsAs(ctx, &headers, false); diags.HasError() {
+		return 0, diagsError(diags)
+	}
+
+	for name, value := range headers {
+		request.Header.Set(name, value)
+	}
+
+	client := &http.Client{Timeout: timeout}
+	forwardResponse, err := client.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer forwardResponse.Body.Close()
+
+	_, _ = io.Copy(io.Discard, forwardResponse.Body)
+
+	if forwardResponse.StatusCode < 200 || forwardResponse.StatusCode > 299 {
+		return forwardResponse.StatusCode, fmt.Errorf("unexpected HTTP status %s", forwardResponse.Status)
+	}
+
+	return forwardResponse.StatusCode, nil
+}
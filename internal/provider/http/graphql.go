@@ -0,0 +1,98 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type graphqlModel struct {
+	Query         types.String  `tfsdk:"query"`
+	Variables     types.Dynamic `tfsdk:"variables"`
+	OperationName types.String  `tfsdk:"operation_name"`
+}
+
+// requestBody returns the JSON document sent to a GraphQL endpoint, as
+// described in https://graphql.org/learn/serving-over-http/#post-request.
+func (model *graphqlModel) requestBody() ([]byte, error) {
+	body := map[string]interface{}{
+		"query": model.Query.ValueString(),
+	}
+
+	if !model.Variables.IsNull() && !model.Variables.IsUnderlyingValueNull() {
+		variables, err := valueToJSON(model.Variables)
+		if err != nil {
+			return nil, err
+		}
+		body["variables"] = variables
+	}
+
+	if !model.OperationName.IsNull() {
+		body["operationName"] = model.OperationName.ValueString()
+	}
+
+	return json.Marshal(body)
+}
+
+// decodeGraphQLResponse returns the `data` and `errors` members of a GraphQL
+// response, null when absent.
+func decodeGraphQLResponse(body []byte) (attr.Value, attr.Value, error) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var response struct {
+		Data   interface{} `json:"data"`
+		Errors interface{} `json:"errors"`
+	}
+	if err := decoder.Decode(&response); err != nil {
+		return nil, nil, err
+	}
+
+	data, err := jsonToValue(response.Data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	errors, err := jsonToValue(response.Errors)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return data, errors, nil
+}
+
+// graphqlErrorMessages returns the messages of the `errors` member of a
+// GraphQL response, with the path of the field they relate to, if any.
+func graphqlErrorMessages(body []byte) []string {
+	var response struct {
+		Errors []struct {
+			Message string        `json:"message"`
+			Path    []interface{} `json:"path"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil
+	}
+
+	messages := make([]string, 0, len(response.Errors))
+	for _, graphqlError := range response.Errors {
+		message := graphqlError.Message
+		if len(graphqlError.Path) > 0 {
+			segments := make([]string, len(graphqlError.Path))
+			for i, segment := range graphqlError.Path {
+				segments[i] = fmt.Sprint(segment)
+			}
+			message = fmt.Sprintf("%s (at %s)", message, strings.Join(segments, "."))
+		}
+		messages = append(messages, message)
+	}
+
+	return messages
+}
@@ -0,0 +1,278 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package http
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/base64"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"slices"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"terraform-provider-utilities/internal/provider/providerdata"
+)
+
+// harMaxBodyBytes is the size of the request and response bodies recorded in
+// the HAR file at most.
+const harMaxBodyBytes = 1 << 20
+
+// harTransport records the round trips of a request, retries and redirects
+// included, for the HAR file of the run. The values of the sensitive headers,
+// query parameters and form fields are redacted, as in the curl command.
+type harTransport struct {
+	transport  http.RoundTripper
+	redactBody bool
+
+	// sensitiveHeaders are the headers of `sensitive_request_headers`.
+	sensitiveHeaders []string
+
+	mu      sync.Mutex
+	entries []*harEntry
+}
+
+// harEntry is a round trip, completed once its response body is read.
+type harEntry struct {
+	providerdata.HAREntry
+
+	wait time.Duration
+	body *harBody
+}
+
+// newHARTransport returns the transport recording the round trips, the
+// request body being redacted when redactBody is set.
+func newHARTransport(transport http.RoundTripper, redactBody bool) *harTransport {
+	return &harTransport{
+		transport:  transport,
+		redactBody: redactBody,
+	}
+}
+
+func (t *harTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	entry := &harEntry{}
+	entry.StartedDateTime = time.Now()
+	entry.Request = t.request(req)
+
+	t.mu.Lock()
+	t.entries = append(t.entries, entry)
+	t.mu.Unlock()
+
+	resp, err := t.transport.RoundTrip(req)
+	entry.wait = time.Since(entry.StartedDateTime)
+	if err != nil {
+		entry.Comment = err.Error()
+		return nil, err
+	}
+
+	entry.Response = providerdata.HARResponse{
+		Status:      resp.StatusCode,
+		StatusText:  http.StatusText(resp.StatusCode),
+		HTTPVersion: resp.Proto,
+		Cookies:     []providerdata.HARNameValue{},
+		Headers:     harHeaders(resp.Header, t.sensitiveHeaders),
+		RedirectURL: resp.Header.Get("Location"),
+		HeadersSize: -1,
+	}
+
+	entry.body = &harBody{ReadCloser: resp.Body, mimeType: resp.Header.Get("Content-Type"), started: time.Now()}
+	resp.Body = entry.body
+
+	return resp, nil
+}
+
+// request returns the HAR request of the round trip.
+func (t *harTransport) request(req *http.Request) providerdata.HARRequest {
+	query := []providerdata.HARNameValue{}
+	for name, values := range req.URL.Query() {
+		for _, value := range values {
+			if sensitiveNameRegexp.MatchString(name) {
+				value = redacted
+			}
+			query = append(query, providerdata.HARNameValue{Name: name, Value: value})
+		}
+	}
+	slices.SortStableFunc(query, func(a, b providerdata.HARNameValue) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	harRequest := providerdata.HARRequest{
+		Method:      req.Method,
+		URL:         redactURL(req.URL),
+		HTTPVersion: req.Proto,
+		Cookies:     []providerdata.HARNameValue{},
+		Headers:     harHeaders(req.Header, t.sensitiveHeaders),
+		QueryString: query,
+		HeadersSize: -1,
+		BodySize:    req.ContentLength,
+	}
+
+	if req.GetBody == nil || req.ContentLength == 0 {
+		return harRequest
+	}
+
+	mimeType := req.Header.Get("Content-Type")
+	harRequest.PostData = &providerdata.HARPostData{MimeType: mimeType, Text: redacted}
+	if t.redactBody {
+		return harRequest
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return harRequest
+	}
+	defer body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(body, harMaxBodyBytes))
+	if err != nil {
+		return harRequest
+	}
+
+	harRequest.PostData.Text = string(data)
+	if mediaType, _, _ := mime.ParseMediaType(mimeType); mediaType == "application/x-www-form-urlencoded" {
+		if form, err := url.ParseQuery(string(data)); err == nil {
+			for name := range form {
+				if sensitiveNameRegexp.MatchString(name) {
+					form[name] = []string{redacted}
+				}
+			}
+			harRequest.PostData.Text = form.Encode()
+		}
+	}
+
+	return harRequest
+}
+
+// record adds the round trips to the HAR file and forgets them, it does
+// nothing when the recorder is nil.
+func (t *harTransport) record(ctx context.Context, har *providerdata.HAR) {
+	if t == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	entries := make([]providerdata.HAREntry, 0, len(t.entries))
+	for _, entry := range t.entries {
+		entries = append(entries, entry.complete())
+	}
+	t.entries = nil
+
+	har.Record(ctx, entries...)
+}
+
+// complete returns the HAR entry with the timings and the response body.
+func (entry *harEntry) complete() providerdata.HAREntry {
+	var receive time.Duration
+	if entry.body != nil {
+		receive = entry.body.duration()
+		entry.Response.Content = entry.body.content()
+		entry.Response.BodySize = entry.body.size
+	} else {
+		entry.Response.Cookies = []providerdata.HARNameValue{}
+		entry.Response.Headers = []providerdata.HARNameValue{}
+		entry.Response.HeadersSize = -1
+		entry.Response.BodySize = -1
+	}
+
+	entry.Timings = providerdata.HARTimings{
+		Blocked: -1,
+		DNS:     -1,
+		Connect: -1,
+		SSL:     -1,
+		Wait:    harMilliseconds(entry.wait),
+		Receive: harMilliseconds(receive),
+	}
+	entry.Time = entry.Timings.Wait + entry.Timings.Receive
+
+	return entry.HAREntry
+}
+
+// harBody records the response body as it is read, up to harMaxBodyBytes.
+type harBody struct {
+	io.ReadCloser
+
+	mimeType string
+	started  time.Time
+	closed   time.Time
+	data     bytes.Buffer
+	size     int64
+}
+
+func (b *harBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.size += int64(n)
+	if room := harMaxBodyBytes - b.data.Len(); room > 0 {
+		b.data.Write(p[:min(n, room)])
+	}
+	return n, err
+}
+
+func (b *harBody) Close() error {
+	if b.closed.IsZero() {
+		b.closed = time.Now()
+	}
+	return b.ReadCloser.Close()
+}
+
+// duration returns the time spent reading the body, until it was closed.
+func (b *harBody) duration() time.Duration {
+	if b.closed.IsZero() {
+		return time.Since(b.started)
+	}
+	return b.closed.Sub(b.started)
+}
+
+// content returns the body as received, encoded as base64 when it is not
+// UTF-8, e.g. when it is compressed.
+func (b *harBody) content() providerdata.HARContent {
+	content := providerdata.HARContent{Size: b.size, MimeType: b.mimeType}
+
+	data := b.data.Bytes()
+	if utf8.Valid(data) {
+		content.Text = string(data)
+	} else {
+		content.Text = base64.StdEncoding.EncodeToString(data)
+		content.Encoding = "base64"
+	}
+
+	if b.size > int64(len(data)) {
+		content.Comment = "truncated to 1 MiB"
+	}
+
+	return content
+}
+
+// harHeaders returns the headers sorted by name, the values of the sensitive
+// ones being redacted.
+func harHeaders(header http.Header, sensitiveHeaders []string) []providerdata.HARNameValue {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	headers := []providerdata.HARNameValue{}
+	for _, name := range names {
+		for _, value := range header[name] {
+			if isSensitiveHeader(name, sensitiveHeaders) {
+				value = redacted
+			}
+			headers = append(headers, providerdata.HARNameValue{Name: name, Value: value})
+		}
+	}
+
+	return headers
+}
+
+// harMilliseconds returns the duration in milliseconds, as HAR timings are.
+func harMilliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,94 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package http
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"hash"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	hmacSHA1   = "sha1"
+	hmacSHA256 = "sha256"
+	hmacSHA512 = "sha512"
+
+	// defaultStringToSign signs the request body alone, as GitHub does.
+	defaultStringToSign = "{body}"
+	// defaultHeaderValue sets the header to the signature alone.
+	defaultHeaderValue = "{signature}"
+)
+
+type hmacSignatureModel struct {
+	Secret               types.String `tfsdk:"secret"`
+	Algorithm            types.String `tfsdk:"algorithm"`
+	Header               types.String `tfsdk:"header"`
+	StringToSignTemplate types.String `tfsdk:"string_to_sign_template"`
+	HeaderValueTemplate  types.String `tfsdk:"header_value_template"`
+	TimestampHeader      types.String `tfsdk:"timestamp_header"`
+}
+
+// sign sets the signature header of the request, the HMAC of the string to
+// sign encoded in hexadecimal. The string to sign and the header value are
+// built from their templates, `{body}`, `{timestamp}`, `{method}`, `{path}`
+// and `{signature}` being replaced by the request body, the Unix time, the
+// method, the path and query of the URL and the signature respectively.
+func (model hmacSignatureModel) sign(request *retryablehttp.Request, now time.Time) error {
+	body, err := request.BodyBytes()
+	if err != nil {
+		return err
+	}
+
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+
+	stringToSign := defaultStringToSign
+	if !model.StringToSignTemplate.IsNull() {
+		stringToSign = model.StringToSignTemplate.ValueString()
+	}
+	stringToSign = strings.NewReplacer(
+		"{body}", string(body),
+		"{timestamp}", timestamp,
+		"{method}", request.Method,
+		"{path}", request.URL.RequestURI(),
+	).Replace(stringToSign)
+
+	var newHash func() hash.Hash
+	switch model.Algorithm.ValueString() {
+	case hmacSHA1:
+		newHash = sha1.New
+	case hmacSHA512:
+		newHash = sha512.New
+	default:
+		newHash = sha256.New
+	}
+
+	mac := hmac.New(newHash, []byte(model.Secret.ValueString()))
+	mac.Write([]byte(stringToSign))
+	signature := hex.EncodeToString(mac.Sum(nil))
+
+	value := defaultHeaderValue
+	if !model.HeaderValueTemplate.IsNull() {
+		value = model.HeaderValueTemplate.ValueString()
+	}
+	value = strings.NewReplacer(
+		"{signature}", signature,
+		"{timestamp}", timestamp,
+	).Replace(value)
+
+	request.Header.Set(model.Header.ValueString(), value)
+	if !model.TimestampHeader.IsNull() {
+		request.Header.Set(model.TimestampHeader.ValueString(), timestamp)
+	}
+
+	return nil
+}
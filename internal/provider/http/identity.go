@@ -0,0 +1,300 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package http
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/aws-sdk-go-v2/config"
+)
+
+const (
+	authAWSIAM     = "aws_iam"
+	authGCPIDToken = "gcp_id_token"
+	authAzureMSI   = "azure_msi"
+
+	// defaultAWSService is the service the requests are signed for by default,
+	// the one of the API Gateway.
+	defaultAWSService = "execute-api"
+)
+
+const (
+	// gcpMetadataHostEnv overrides the host of the GCP metadata server, as the
+	// Google Cloud client libraries do.
+	gcpMetadataHostEnv     = "GCE_METADATA_HOST"
+	defaultGCPMetadataHost = "metadata.google.internal"
+
+	// The Azure Instance Metadata Service (IMDS), the App Service identity
+	// endpoint and the workload identity federated token, as set by Azure.
+	azureIMDSEndpoint          = "http://169.254.169.254/metadata/identity/oauth2/token"
+	azureIdentityEndpointEnv   = "IDENTITY_ENDPOINT"
+	azureIdentityHeaderEnv     = "IDENTITY_HEADER"
+	azureFederatedTokenFileEnv = "AZURE_FEDERATED_TOKEN_FILE"
+	azureClientIDEnv           = "AZURE_CLIENT_ID"
+	azureTenantIDEnv           = "AZURE_TENANT_ID"
+	azureAuthorityHostEnv      = "AZURE_AUTHORITY_HOST"
+	defaultAzureAuthorityHost  = "https://login.microsoftonline.com/"
+)
+
+// identityTransport authenticates the requests to the host with an identity
+// obtained from the environment the provider runs in, the requests to other
+// hosts, e.g. redirects, being sent as is.
+type identityTransport struct {
+	transport http.RoundTripper
+	mode      string
+	host      string
+	audience  string
+
+	// client sends the requests to the metadata services, without proxy.
+	client *http.Client
+
+	mu     sync.Mutex
+	token  string
+	awsCfg *aws.Config
+}
+
+// newIdentityTransport returns the transport authenticating the requests to
+// the host of the URL, the audience defaulting to the origin of the URL.
+func newIdentityTransport(transport http.RoundTripper, mode, audience, requestURL string) (*identityTransport, error) {
+	u, err := url.Parse(requestURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if audience == "" && mode != authAWSIAM {
+		audience = u.Scheme + "://" + u.Host
+	}
+
+	return &identityTransport{
+		transport: transport,
+		mode:      mode,
+		host:      u.Host,
+		audience:  audience,
+		client:    &http.Client{Transport: &http.Transport{}, Timeout: 30 * time.Second},
+	}, nil
+}
+
+func (t *identityTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Host != t.host {
+		return t.transport.RoundTrip(req)
+	}
+
+	ctx, cancel := untraced(req.Context())
+	defer cancel()
+
+	req = req.Clone(req.Context())
+	if t.mode == authAWSIAM {
+		if err := t.signAWS(ctx, req); err != nil {
+			return nil, fmt.Errorf("signing the request with the AWS credentials: %w", err)
+		}
+	} else {
+		token, err := t.bearerToken(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("obtaining the %s token: %w", t.mode, err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	return t.transport.RoundTrip(req)
+}
+
+// bearerToken returns the token of the identity, obtained once for all the
+// attempts of the request.
+func (t *identityTransport) bearerToken(ctx context.Context) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.token != "" {
+		return t.token, nil
+	}
+
+	var token string
+	var err error
+	switch t.mode {
+	case authGCPIDToken:
+		token, err = t.gcpIDToken(ctx)
+	case authAzureMSI:
+		token, err = t.azureToken(ctx)
+	default:
+		err = fmt.Errorf("unsupported authentication %q", t.mode)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	t.token = token
+	return token, nil
+}
+
+// signAWS signs the request with Signature Version 4, the credentials and the
+// region being loaded from the environment as the AWS CLI does: environment
+// variables, shared files, web identity token file, ECS or EC2 metadata.
+func (t *identityTransport) signAWS(ctx context.Context, req *http.Request) error {
+	t.mu.Lock()
+	if t.awsCfg == nil {
+		cfg, err := config.LoadDefaultConfig(ctx)
+		if err != nil {
+			t.mu.Unlock()
+			return err
+		}
+		t.awsCfg = &cfg
+	}
+	cfg := t.awsCfg
+	t.mu.Unlock()
+
+	if cfg.Region == "" {
+		return fmt.Errorf("no AWS region configured, set the AWS_REGION environment variable")
+	}
+
+	credentials, err := cfg.Credentials.Retrieve(ctx)
+	if err != nil {
+		return err
+	}
+
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	payloadHash := sha256.Sum256(body)
+
+	service := t.audience
+	if service == "" {
+		service = defaultAWSService
+	}
+
+	return v4.NewSigner().SignHTTP(ctx, credentials, req, hex.EncodeToString(payloadHash[:]), service, cfg.Region, time.Now())
+}
+
+// gcpIDToken returns an ID token of the default service account for the
+// audience, from the GCP metadata server.
+func (t *identityTransport) gcpIDToken(ctx context.Context) (string, error) {
+	host := os.Getenv(gcpMetadataHostEnv)
+	if host == "" {
+		host = defaultGCPMetadataHost
+	}
+
+	query := url.Values{"audience": {t.audience}, "format": {"full"}}
+	endpoint := "http://" + host + "/computeMetadata/v1/instance/service-accounts/default/identity?" + query.Encode()
+
+	body, err := t.fetch(ctx, http.MethodGet, endpoint, nil, http.Header{"Metadata-Flavor": {"Google"}})
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(body)), nil
+}
+
+// azureToken returns an access token of the managed identity for the audience,
+// from the workload identity federated token, the App Service identity
+// endpoint or the Instance Metadata Service, the first one available.
+func (t *identityTransport) azureToken(ctx context.Context) (string, error) {
+	var body []byte
+	var err error
+	switch {
+	case os.Getenv(azureFederatedTokenFileEnv) != "":
+		body, err = t.azureFederatedToken(ctx)
+	case os.Getenv(azureIdentityEndpointEnv) != "":
+		query := url.Values{"api-version": {"2019-08-01"}, "resource": {t.audience}}
+		if clientID := os.Getenv(azureClientIDEnv); clientID != "" {
+			query.Set("client_id", clientID)
+		}
+		header := http.Header{"X-Identity-Header": {os.Getenv(azureIdentityHeaderEnv)}}
+		body, err = t.fetch(ctx, http.MethodGet, os.Getenv(azureIdentityEndpointEnv)+"?"+query.Encode(), nil, header)
+	default:
+		query := url.Values{"api-version": {"2018-02-01"}, "resource": {t.audience}}
+		if clientID := os.Getenv(azureClientIDEnv); clientID != "" {
+			query.Set("client_id", clientID)
+		}
+		body, err = t.fetch(ctx, http.MethodGet, azureIMDSEndpoint+"?"+query.Encode(), nil, http.Header{"Metadata": {"true"}})
+	}
+	if err != nil {
+		return "", err
+	}
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.Unmarshal(body, &token); err != nil {
+		return "", fmt.Errorf("invalid token response: %w", err)
+	}
+	if token.AccessToken == "" {
+		return "", fmt.Errorf("the token response has no access_token")
+	}
+
+	return token.AccessToken, nil
+}
+
+// azureFederatedToken exchanges the federated token of the workload identity
+// for an access token of the client.
+func (t *identityTransport) azureFederatedToken(ctx context.Context) ([]byte, error) {
+	assertion, err := os.ReadFile(os.Getenv(azureFederatedTokenFileEnv))
+	if err != nil {
+		return nil, err
+	}
+
+	authorityHost := os.Getenv(azureAuthorityHostEnv)
+	if authorityHost == "" {
+		authorityHost = defaultAzureAuthorityHost
+	}
+	endpoint := strings.TrimSuffix(authorityHost, "/") + "/" + url.PathEscape(os.Getenv(azureTenantIDEnv)) + "/oauth2/v2.0/token"
+
+	form := url.Values{
+		"grant_type":            {"client_credentials"},
+		"client_id":             {os.Getenv(azureClientIDEnv)},
+		"scope":                 {strings.TrimSuffix(t.audience, "/") + "/.default"},
+		"client_assertion_type": {"urn:ietf:params:oauth:client-assertion-type:jwt-bearer"},
+		"client_assertion":      {strings.TrimSpace(string(assertion))},
+	}
+	header := http.Header{"Content-Type": {"application/x-www-form-urlencoded"}}
+
+	return t.fetch(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()), header)
+}
+
+// fetch sends a request to an identity endpoint and returns the body of its
+// successful response.
+func (t *identityTransport) fetch(ctx context.Context, method, endpoint string, body io.Reader, header http.Header) ([]byte, error) {
+	request, err := http.NewRequestWithContext(ctx, method, endpoint, body)
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range header {
+		request.Header[name] = values
+	}
+
+	response, err := t.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(response.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected response status %s: %s", response.Status, strings.TrimSpace(string(data)))
+	}
+
+	return data, nil
+}
@@ -0,0 +1,206 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"mime"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// isJSONContentType reports whether the given Content-Type header value
+// describes a JSON document, e.g. `application/json` or `application/problem+json`.
+func isJSONContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// decodeJSON decodes a JSON document into a Terraform value, mirroring the
+// types produced by the `jsondecode` function: objects become object values,
+// arrays become tuple values and numbers keep their arbitrary precision.
+func decodeJSON(data []byte) (attr.Value, error) {
+	raw, err := unmarshalJSON(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return jsonToValue(raw)
+}
+
+// unmarshalJSON decodes a JSON document, the numbers being kept as
+// json.Number so that they do not lose precision.
+func unmarshalJSON(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var raw interface{}
+	if err := decoder.Decode(&raw); err != nil {
+		return nil, err
+	}
+
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, fmt.Errorf("unexpected data after top-level value")
+	}
+
+	return raw, nil
+}
+
+func jsonToValue(raw interface{}) (attr.Value, error) {
+	switch v := raw.(type) {
+	case nil:
+		return types.DynamicNull(), nil
+	case bool:
+		return types.BoolValue(v), nil
+	case string:
+		return types.StringValue(v), nil
+	case json.Number:
+		number, _, err := big.ParseFloat(v.String(), 10, 512, big.ToNearestEven)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q: %w", v, err)
+		}
+
+		return types.NumberValue(number), nil
+	case float64:
+		return types.NumberValue(big.NewFloat(v)), nil
+	case []interface{}:
+		elemTypes := make([]attr.Type, 0, len(v))
+		elems := make([]attr.Value, 0, len(v))
+
+		for _, item := range v {
+			elem, err := jsonToValue(item)
+			if err != nil {
+				return nil, err
+			}
+
+			elemTypes = append(elemTypes, elem.Type(nil))
+			elems = append(elems, elem)
+		}
+
+		tuple, diags := types.TupleValue(elemTypes, elems)
+		if diags.HasError() {
+			return nil, diagsError(diags)
+		}
+
+		return tuple, nil
+	case map[string]interface{}:
+		attrTypes := make(map[string]attr.Type, len(v))
+		attrs := make(map[string]attr.Value, len(v))
+
+		for key, item := range v {
+			value, err := jsonToValue(item)
+			if err != nil {
+				return nil, err
+			}
+
+			attrTypes[key] = value.Type(nil)
+			attrs[key] = value
+		}
+
+		object, diags := types.ObjectValue(attrTypes, attrs)
+		if diags.HasError() {
+			return nil, diagsError(diags)
+		}
+
+		return object, nil
+	default:
+		return nil, fmt.Errorf("unsupported JSON value of type %T", raw)
+	}
+}
+
+// valueToJSON converts a Terraform value into a value encodable by the
+// encoding/json package, the inverse of jsonToValue.
+func valueToJSON(value attr.Value) (interface{}, error) {
+	if value.IsNull() {
+		return nil, nil
+	}
+
+	if value.IsUnknown() {
+		return nil, fmt.Errorf("unknown values cannot be encoded to JSON")
+	}
+
+	switch v := value.(type) {
+	case types.Dynamic:
+		if v.IsUnderlyingValueNull() {
+			return nil, nil
+		}
+		return valueToJSON(v.UnderlyingValue())
+	case types.Bool:
+		return v.ValueBool(), nil
+	case types.String:
+		return v.ValueString(), nil
+	case types.Number:
+		return json.Number(v.ValueBigFloat().Text('g', -1)), nil
+	case types.Int64:
+		return v.ValueInt64(), nil
+	case types.Float64:
+		return v.ValueFloat64(), nil
+	case types.List:
+		return elementsToJSON(v.Elements())
+	case types.Set:
+		return elementsToJSON(v.Elements())
+	case types.Tuple:
+		return elementsToJSON(v.Elements())
+	case types.Map:
+		return attributesToJSON(v.Elements())
+	case types.Object:
+		return attributesToJSON(v.Attributes())
+	default:
+		return nil, fmt.Errorf("unsupported value of type %s", value.Type(nil))
+	}
+}
+
+// encodeJSON encodes a Terraform value to JSON, the keys of the objects and
+// maps being sorted.
+func encodeJSON(value attr.Value) ([]byte, error) {
+	raw, err := valueToJSON(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(raw)
+}
+
+func elementsToJSON(elements []attr.Value) (interface{}, error) {
+	result := make([]interface{}, 0, len(elements))
+	for _, element := range elements {
+		item, err := valueToJSON(element)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, item)
+	}
+	return result, nil
+}
+
+func attributesToJSON(attributes map[string]attr.Value) (interface{}, error) {
+	result := make(map[string]interface{}, len(attributes))
+	for key, attribute := range attributes {
+		item, err := valueToJSON(attribute)
+		if err != nil {
+			return nil, err
+		}
+		result[key] = item
+	}
+	return result, nil
+}
+
+func diagsError(diags diag.Diagnostics) error {
+	for _, d := range diags.Errors() {
+		return fmt.Errorf("%s: %s", d.Summary(), d.Detail())
+	}
+
+	return nil
+}
@@ -0,0 +1,539 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// jsonSchema validates JSON documents against a JSON Schema draft-07. The
+// keywords describing the structure of a document are supported, as are the
+// `prefixItems` keyword of the later drafts, and the `nullable` keyword and
+// the boolean `exclusiveMinimum` and `exclusiveMaximum` of OpenAPI 3.0
+// schemas; `format`, `if` and the keywords referencing other documents are
+// ignored. References are JSON Pointers into the schema itself, e.g.
+// `#/$defs/item`, and the keywords next to a `$ref` are ignored, as draft-07
+// requires.
+type jsonSchema struct {
+	root     interface{}
+	patterns map[string]*regexp.Regexp
+
+	// refs are the references being followed at each value of the document,
+	// a reference followed again at the same value being circular.
+	refs map[jsonSchemaRef]bool
+}
+
+type jsonSchemaRef struct {
+	ref     string
+	pointer string
+}
+
+// jsonSchemaError is a failed validation, at the JSON Pointer of the invalid
+// value of the document.
+type jsonSchemaError struct {
+	Path    string
+	Message string
+}
+
+func (e jsonSchemaError) String() string {
+	if e.Path == "" {
+		return fmt.Sprintf("/: %s", e.Message)
+	}
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// newJSONSchema decodes the JSON Schema document.
+func newJSONSchema(data []byte) (*jsonSchema, error) {
+	root, err := decodeJSONNumbers(data)
+	if err != nil {
+		return nil, err
+	}
+
+	switch root.(type) {
+	case map[string]interface{}, bool:
+	default:
+		return nil, fmt.Errorf("a JSON Schema must be an object or a boolean")
+	}
+
+	return &jsonSchema{
+		root:     root,
+		patterns: make(map[string]*regexp.Regexp),
+		refs:     make(map[jsonSchemaRef]bool),
+	}, nil
+}
+
+// validate returns the validation errors of the JSON document, in the order
+// of the document.
+func (s *jsonSchema) validate(data []byte) ([]jsonSchemaError, error) {
+	document, err := decodeJSONNumbers(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var errs []jsonSchemaError
+	s.validateValue(s.root, document, "", &errs)
+	return errs, nil
+}
+
+func (s *jsonSchema) validateValue(schema interface{}, value interface{}, pointer string, errs *[]jsonSchemaError) {
+	fail := func(format string, args ...interface{}) {
+		*errs = append(*errs, jsonSchemaError{Path: pointer, Message: fmt.Sprintf(format, args...)})
+	}
+
+	keywords, ok := schema.(map[string]interface{})
+	if !ok {
+		if schema == false {
+			fail("no value is allowed")
+		}
+		return
+	}
+
+	if ref, ok := keywords["$ref"].(string); ok {
+		key := jsonSchemaRef{ref: ref, pointer: pointer}
+		if s.refs[key] {
+			fail("circular reference %q", ref)
+			return
+		}
+
+		resolved, err := s.resolve(ref)
+		if err != nil {
+			fail("%s", err)
+			return
+		}
+
+		s.refs[key] = true
+		s.validateValue(resolved, value, pointer, errs)
+		delete(s.refs, key)
+		return
+	}
+
+	if value == nil && keywords["nullable"] == true {
+		return
+	}
+
+	if types, ok := schemaTypes(keywords["type"]); ok {
+		actual := jsonType(value)
+		if !slices.Contains(types, actual) && !(actual == "integer" && slices.Contains(types, "number")) {
+			fail("expected %s, got %s", strings.Join(types, " or "), jsonTypeName(value))
+			return
+		}
+	}
+
+	if enum, ok := keywords["enum"].([]interface{}); ok {
+		if !slices.ContainsFunc(enum, func(allowed interface{}) bool { return jsonValuesEqual(allowed, value) }) {
+			fail("expected one of %s, got %s", encodeJSONValue(enum), encodeJSONValue(value))
+		}
+	}
+
+	if constant, ok := keywords["const"]; ok && !jsonValuesEqual(constant, value) {
+		fail("expected %s, got %s", encodeJSONValue(constant), encodeJSONValue(value))
+	}
+
+	switch value := value.(type) {
+	case map[string]interface{}:
+		s.validateObject(keywords, value, pointer, errs)
+	case []interface{}:
+		s.validateArray(keywords, value, pointer, errs)
+	case string:
+		s.validateString(keywords, value, pointer, errs)
+	case json.Number:
+		validateNumber(keywords, value, pointer, errs)
+	}
+
+	if allOf, ok := keywords["allOf"].([]interface{}); ok {
+		for _, subschema := range allOf {
+			s.validateValue(subschema, value, pointer, errs)
+		}
+	}
+
+	if anyOf, ok := keywords["anyOf"].([]interface{}); ok {
+		if s.countValid(anyOf, value, pointer) == 0 {
+			fail("does not match any of the anyOf schemas")
+		}
+	}
+
+	if oneOf, ok := keywords["oneOf"].([]interface{}); ok {
+		if n := s.countValid(oneOf, value, pointer); n != 1 {
+			fail("expected to match exactly one of the oneOf schemas, matches %d", n)
+		}
+	}
+
+	if not, ok := keywords["not"]; ok {
+		if s.countValid([]interface{}{not}, value, pointer) == 1 {
+			fail("must not match the not schema")
+		}
+	}
+}
+
+// countValid returns the number of schemas the value is valid against.
+func (s *jsonSchema) countValid(schemas []interface{}, value interface{}, pointer string) int {
+	n := 0
+	for _, subschema := range schemas {
+		var errs []jsonSchemaError
+		s.validateValue(subschema, value, pointer, &errs)
+		if len(errs) == 0 {
+			n++
+		}
+	}
+	return n
+}
+
+func (s *jsonSchema) validateObject(keywords map[string]interface{}, object map[string]interface{}, pointer string, errs *[]jsonSchemaError) {
+	fail := func(format string, args ...interface{}) {
+		*errs = append(*errs, jsonSchemaError{Path: pointer, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if required, ok := keywords["required"].([]interface{}); ok {
+		for _, name := range required {
+			if name, ok := name.(string); ok {
+				if _, present := object[name]; !present {
+					fail("missing required property %q", name)
+				}
+			}
+		}
+	}
+
+	if n, ok := schemaInt(keywords["minProperties"]); ok && len(object) < n {
+		fail("expected at least %d properties, got %d", n, len(object))
+	}
+	if n, ok := schemaInt(keywords["maxProperties"]); ok && len(object) > n {
+		fail("expected at most %d properties, got %d", n, len(object))
+	}
+
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	properties, _ := keywords["properties"].(map[string]interface{})
+	patternProperties, _ := keywords["patternProperties"].(map[string]interface{})
+	additionalProperties, hasAdditional := keywords["additionalProperties"]
+
+	for _, name := range names {
+		propertyPointer := pointer + "/" + escapeJSONPointer(name)
+		matched := false
+
+		if subschema, ok := properties[name]; ok {
+			matched = true
+			s.validateValue(subschema, object[name], propertyPointer, errs)
+		}
+
+		for pattern, subschema := range patternProperties {
+			re, err := s.pattern(pattern)
+			if err != nil {
+				fail("%s", err)
+				continue
+			}
+			if re.MatchString(name) {
+				matched = true
+				s.validateValue(subschema, object[name], propertyPointer, errs)
+			}
+		}
+
+		if !matched && hasAdditional {
+			if additionalProperties == false {
+				*errs = append(*errs, jsonSchemaError{Path: propertyPointer, Message: "additional property is not allowed"})
+				continue
+			}
+			s.validateValue(additionalProperties, object[name], propertyPointer, errs)
+		}
+	}
+}
+
+func (s *jsonSchema) validateArray(keywords map[string]interface{}, array []interface{}, pointer string, errs *[]jsonSchemaError) {
+	fail := func(format string, args ...interface{}) {
+		*errs = append(*errs, jsonSchemaError{Path: pointer, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if n, ok := schemaInt(keywords["minItems"]); ok && len(array) < n {
+		fail("expected at least %d items, got %d", n, len(array))
+	}
+	if n, ok := schemaInt(keywords["maxItems"]); ok && len(array) > n {
+		fail("expected at most %d items, got %d", n, len(array))
+	}
+
+	if keywords["uniqueItems"] == true {
+	unique:
+		for i := range array {
+			for j := i + 1; j < len(array); j++ {
+				if jsonValuesEqual(array[i], array[j]) {
+					fail("items %d and %d are equal", i, j)
+					break unique
+				}
+			}
+		}
+	}
+
+	// `prefixItems` and the array form of `items` validate the first items,
+	// `items` the following ones.
+	prefixItems, ok := keywords["prefixItems"].([]interface{})
+	items := keywords["items"]
+	if tuple, isTuple := items.([]interface{}); !ok && isTuple {
+		prefixItems, items = tuple, keywords["additionalItems"]
+	}
+
+	for i, item := range array {
+		itemPointer := pointer + "/" + strconv.Itoa(i)
+		switch {
+		case i < len(prefixItems):
+			s.validateValue(prefixItems[i], item, itemPointer, errs)
+		case items != nil:
+			s.validateValue(items, item, itemPointer, errs)
+		}
+	}
+}
+
+func (s *jsonSchema) validateString(keywords map[string]interface{}, value string, pointer string, errs *[]jsonSchemaError) {
+	fail := func(format string, args ...interface{}) {
+		*errs = append(*errs, jsonSchemaError{Path: pointer, Message: fmt.Sprintf(format, args...)})
+	}
+
+	length := utf8.RuneCountInString(value)
+	if n, ok := schemaInt(keywords["minLength"]); ok && length < n {
+		fail("expected at least %d characters, got %d", n, length)
+	}
+	if n, ok := schemaInt(keywords["maxLength"]); ok && length > n {
+		fail("expected at most %d characters, got %d", n, length)
+	}
+
+	if pattern, ok := keywords["pattern"].(string); ok {
+		re, err := s.pattern(pattern)
+		if err != nil {
+			fail("%s", err)
+		} else if !re.MatchString(value) {
+			fail("expected to match %q, got %q", pattern, value)
+		}
+	}
+}
+
+func validateNumber(keywords map[string]interface{}, number json.Number, pointer string, errs *[]jsonSchemaError) {
+	fail := func(format string, args ...interface{}) {
+		*errs = append(*errs, jsonSchemaError{Path: pointer, Message: fmt.Sprintf(format, args...)})
+	}
+
+	value, err := number.Float64()
+	if err != nil {
+		return
+	}
+
+	if minimum, ok := schemaFloat(keywords["minimum"]); ok {
+		if keywords["exclusiveMinimum"] == true && value <= minimum {
+			fail("expected more than %v, got %s", minimum, number)
+		} else if value < minimum {
+			fail("expected at least %v, got %s", minimum, number)
+		}
+	}
+	if minimum, ok := schemaFloat(keywords["exclusiveMinimum"]); ok && value <= minimum {
+		fail("expected more than %v, got %s", minimum, number)
+	}
+
+	if maximum, ok := schemaFloat(keywords["maximum"]); ok {
+		if keywords["exclusiveMaximum"] == true && value >= maximum {
+			fail("expected less than %v, got %s", maximum, number)
+		} else if value > maximum {
+			fail("expected at most %v, got %s", maximum, number)
+		}
+	}
+	if maximum, ok := schemaFloat(keywords["exclusiveMaximum"]); ok && value >= maximum {
+		fail("expected less than %v, got %s", maximum, number)
+	}
+
+	if multipleOf, ok := schemaFloat(keywords["multipleOf"]); ok && multipleOf > 0 {
+		if quotient := value / multipleOf; math.Abs(quotient-math.Round(quotient)) > 1e-9 {
+			fail("expected a multiple of %v, got %s", multipleOf, number)
+		}
+	}
+}
+
+// resolve returns the sub-schema a reference points to.
+func (s *jsonSchema) resolve(ref string) (interface{}, error) {
+	if !strings.HasPrefix(ref, "#") {
+		return nil, fmt.Errorf("unsupported reference %q, only references within the schema are supported", ref)
+	}
+
+	fragment, err := url.PathUnescape(ref[1:])
+	if err != nil {
+		return nil, fmt.Errorf("invalid reference %q: %w", ref, err)
+	}
+
+	schema := s.root
+	if fragment == "" {
+		return schema, nil
+	}
+
+	for _, token := range strings.Split(strings.TrimPrefix(fragment, "/"), "/") {
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+
+		switch node := schema.(type) {
+		case map[string]interface{}:
+			child, ok := node[token]
+			if !ok {
+				return nil, fmt.Errorf("unresolved reference %q", ref)
+			}
+			schema = child
+		case []interface{}:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(node) {
+				return nil, fmt.Errorf("unresolved reference %q", ref)
+			}
+			schema = node[i]
+		default:
+			return nil, fmt.Errorf("unresolved reference %q", ref)
+		}
+	}
+
+	return schema, nil
+}
+
+// pattern returns the compiled regular expression, the syntax being RE2.
+func (s *jsonSchema) pattern(pattern string) (*regexp.Regexp, error) {
+	if re, ok := s.patterns[pattern]; ok {
+		return re, nil
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern %q in the schema: %w", pattern, err)
+	}
+	s.patterns[pattern] = re
+
+	return re, nil
+}
+
+// decodeJSONNumbers decodes the JSON document, keeping the numbers as
+// json.Number.
+func decodeJSONNumbers(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+// schemaTypes returns the types allowed by the `type` keyword.
+func schemaTypes(keyword interface{}) ([]string, bool) {
+	switch keyword := keyword.(type) {
+	case string:
+		return []string{keyword}, true
+	case []interface{}:
+		types := make([]string, 0, len(keyword))
+		for _, t := range keyword {
+			if t, ok := t.(string); ok {
+				types = append(types, t)
+			}
+		}
+		return types, true
+	}
+	return nil, false
+}
+
+func schemaInt(keyword interface{}) (int, bool) {
+	number, ok := keyword.(json.Number)
+	if !ok {
+		return 0, false
+	}
+	n, err := strconv.Atoi(number.String())
+	return n, err == nil
+}
+
+func schemaFloat(keyword interface{}) (float64, bool) {
+	number, ok := keyword.(json.Number)
+	if !ok {
+		return 0, false
+	}
+	f, err := number.Float64()
+	return f, err == nil
+}
+
+// jsonType returns the JSON Schema type of the value, `integer` for the
+// numbers without a fractional part.
+func jsonType(value interface{}) string {
+	switch value := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	case json.Number:
+		if f, err := value.Float64(); err == nil && f == math.Trunc(f) {
+			return "integer"
+		}
+		return "number"
+	}
+	return "unknown"
+}
+
+// jsonTypeName returns the type of the value as reported in the errors,
+// integers being numbers.
+func jsonTypeName(value interface{}) string {
+	if t := jsonType(value); t != "integer" {
+		return t
+	}
+	return "number"
+}
+
+// jsonValuesEqual reports whether the decoded JSON values are equal, numbers
+// being compared by value.
+func jsonValuesEqual(a, b interface{}) bool {
+	switch a := a.(type) {
+	case json.Number:
+		b, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		x, errA := a.Float64()
+		y, errB := b.Float64()
+		return errA == nil && errB == nil && x == y
+	case []interface{}:
+		b, ok := b.([]interface{})
+		return ok && slices.EqualFunc(a, b, jsonValuesEqual)
+	case map[string]interface{}:
+		b, ok := b.(map[string]interface{})
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for name, value := range a {
+			other, ok := b[name]
+			if !ok || !jsonValuesEqual(value, other) {
+				return false
+			}
+		}
+		return true
+	default:
+		return a == b
+	}
+}
+
+func encodeJSONValue(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+// escapeJSONPointer escapes a reference token of a JSON Pointer, as described
+// in RFC 6901.
+func escapeJSONPointer(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
@@ -0,0 +1,237 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// objectIDPlaceholder is replaced by the identifier of the object in the URL
+// and the request body of the lifecycle operations.
+const objectIDPlaceholder = "{id}"
+
+// lifecycleRequestModel overrides the request of a lifecycle operation.
+type lifecycleRequestModel struct {
+	Method      types.String `tfsdk:"method"`
+	URL         types.String `tfsdk:"url"`
+	RequestBody types.String `tfsdk:"request_body"`
+}
+
+type createRequestModel struct {
+	lifecycleRequestModel
+
+	IDAttribute types.String `tfsdk:"id_attribute"`
+}
+
+// lifecycleFallbacks tells what the lifecycle operations do without their
+// block.
+var lifecycleFallbacks = map[string]string{
+	"create":  "Without this block, the request of the resource is made.",
+	"read":    "Without this block, the request of the resource is made again to refresh the response.",
+	"update":  "Without this block, the request of the resource is made again, not the one of the `create` block.",
+	"destroy": "Without this block, the resource is only removed from the state.",
+}
+
+// lifecycleBlock returns the schema of the block overriding the request of a
+// lifecycle operation.
+func lifecycleBlock(operation string, attributes map[string]schema.Attribute) schema.SingleNestedBlock {
+	block := schema.SingleNestedBlock{
+		Description: fmt.Sprintf("The request made to %s the object, the method, URL and request body "+
+			"replacing the ones of the resource when set. %s", operation, lifecycleFallbacks[operation]),
+		Attributes: map[string]schema.Attribute{
+			"method": schema.StringAttribute{
+				Description: "The HTTP Method for the request, one of `GET`, `HEAD`, `POST`, `PUT`, `PATCH` or `DELETE`.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf([]string{
+						http.MethodGet,
+						http.MethodHead,
+						http.MethodPost,
+						http.MethodPut,
+						http.MethodPatch,
+						http.MethodDelete,
+					}...),
+				},
+			},
+			"url": schema.StringAttribute{
+				Description: "The URL for the request. `{id}` is replaced by the `object_id`.",
+				Optional:    true,
+			},
+			"request_body": schema.StringAttribute{
+				Description: "The request body as a string, replacing `request_body`, `request_body_json`, `request_body_wo`, " +
+					"`request_body_file` and `form_data`. `{id}` is replaced by the `object_id`.",
+				Optional: true,
+			},
+		},
+	}
+
+	for name, attribute := range attributes {
+		block.Attributes[name] = attribute
+	}
+
+	return block
+}
+
+// lifecycleRequest returns the request of the lifecycle operation of the
+// attribute, or nil when the block is not set.
+func lifecycleRequest(ctx context.Context, operation types.Object, diagnostics *diag.Diagnostics) *lifecycleRequestModel {
+	if operation.IsNull() || operation.IsUnknown() {
+		return nil
+	}
+
+	var request lifecycleRequestModel
+	diags := operation.As(ctx, &request, basetypes.ObjectAsOptions{})
+	diagnostics.Append(diags...)
+	if diagnostics.HasError() {
+		return nil
+	}
+
+	return &request
+}
+
+// send makes the request with the overrides of the lifecycle operation, the
+// configured request when nil.
+func (model *httpResourceModel) send(ctx context.Context, request *lifecycleRequestModel, diagnostics *diag.Diagnostics) {
+	if request == nil {
+		model.read(ctx, diagnostics)
+		return
+	}
+
+	method, requestURL, requestBody := model.Method, model.URL, model.RequestBody
+	requestBodyWO, requestBodyFile, formData := model.RequestBodyWO, model.RequestBodyFile, model.FormData
+	requestBodyJSON := model.RequestBodyJSON
+	defer func() {
+		model.Method, model.URL, model.RequestBody = method, requestURL, requestBody
+		model.RequestBodyWO, model.RequestBodyFile, model.FormData = requestBodyWO, requestBodyFile, formData
+		model.RequestBodyJSON = requestBodyJSON
+	}()
+
+	if !request.Method.IsNull() {
+		model.Method = request.Method
+	}
+
+	if !request.URL.IsNull() {
+		expanded, err := model.expandObjectID(request.URL.ValueString(), url.PathEscape)
+		if err != nil {
+			diagnostics.AddError(
+				"Error creating request",
+				fmt.Sprintf("Error expanding the URL: %s", err),
+			)
+			return
+		}
+		model.URL = types.StringValue(expanded)
+	}
+
+	if !request.RequestBody.IsNull() {
+		expanded, err := model.expandObjectID(request.RequestBody.ValueString(), func(id string) string { return id })
+		if err != nil {
+			diagnostics.AddError(
+				"Error creating request",
+				fmt.Sprintf("Error expanding the request body: %s", err),
+			)
+			return
+		}
+		model.RequestBody = types.StringValue(expanded)
+		model.RequestBodyWO = types.StringNull()
+		model.RequestBodyFile = types.StringNull()
+		model.FormData = types.MapNull(types.StringType)
+		model.RequestBodyJSON = types.DynamicNull()
+	}
+
+	model.read(ctx, diagnostics)
+}
+
+// expandObjectID replaces the placeholders of s by the escaped object_id.
+func (model *httpResourceModel) expandObjectID(s string, escape func(string) string) (string, error) {
+	if !strings.Contains(s, objectIDPlaceholder) {
+		return s, nil
+	}
+
+	if model.ObjectID.IsNull() || model.ObjectID.IsUnknown() {
+		return "", fmt.Errorf("%s is used but the object_id is unknown, set the id_attribute of the create block", objectIDPlaceholder)
+	}
+
+	return strings.ReplaceAll(s, objectIDPlaceholder, escape(model.ObjectID.ValueString())), nil
+}
+
+// setObjectID sets the object_id from the response to the create request, it
+// is null when the response does not identify the object.
+func (model *httpResourceModel) setObjectID(request *createRequestModel) {
+	model.ObjectID = types.StringNull()
+	if request == nil {
+		return
+	}
+
+	body, ok := model.responseBody()
+	if !ok {
+		return
+	}
+
+	header := http.Header{}
+	if location, ok := model.ResponseHeaders.Elements()["Location"].(types.String); ok {
+		header.Set("Location", location.ValueString())
+	}
+
+	idAttribute := defaultOpenAPIIDAttribute
+	if !request.IDAttribute.IsNull() {
+		idAttribute = request.IDAttribute.ValueString()
+	}
+
+	if id, err := extractID(body, header, idAttribute); err == nil {
+		model.ObjectID = types.StringValue(id)
+	}
+}
+
+// createRequest returns the request of the create operation, or nil when the
+// block is not set.
+func (model *httpResourceModel) createRequest(ctx context.Context, diagnostics *diag.Diagnostics) *createRequestModel {
+	if model.Create.IsNull() || model.Create.IsUnknown() {
+		return nil
+	}
+
+	var request createRequestModel
+	diags := model.Create.As(ctx, &request, basetypes.ObjectAsOptions{})
+	diagnostics.Append(diags...)
+	if diagnostics.HasError() {
+		return nil
+	}
+
+	return &request
+}
+
+// isObjectGone reports whether the response to the read request tells that
+// the object no longer exists.
+func (model *httpResourceModel) isObjectGone() bool {
+	status := model.StatusCode.ValueInt64()
+	return status == http.StatusNotFound || status == http.StatusGone
+}
+
+// acceptObjectGone adds the status codes of isObjectGone to the configured
+// success status codes, so that the read request does not fail when the
+// object no longer exists. It returns the configured status codes.
+func (model *httpResourceModel) acceptObjectGone() types.List {
+	successStatusCodes := model.SuccessStatusCodes
+	if successStatusCodes.IsNull() || successStatusCodes.IsUnknown() {
+		return successStatusCodes
+	}
+
+	elements := append(successStatusCodes.Elements(),
+		types.Int64Value(http.StatusNotFound),
+		types.Int64Value(http.StatusGone),
+	)
+	model.SuccessStatusCodes = types.ListValueMust(types.Int64Type, elements)
+
+	return successStatusCodes
+}
@@ -0,0 +1,104 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package http
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// openAPIItemPathRegexp matches the path of a single item of a collection, the
+// collection path followed by a path parameter, e.g. `/pets/{petId}`.
+var openAPIItemPathRegexp = regexp.MustCompile(`^/\{[^/{}]+\}$`)
+
+// openAPISpec is the subset of an OpenAPI 3 document used to map the CRUD
+// operations of an object.
+type openAPISpec struct {
+	Servers []struct {
+		URL string `yaml:"url"`
+	} `yaml:"servers"`
+	Paths      map[string]map[string]yaml.Node `yaml:"paths"`
+	Components struct {
+		Schemas map[string]openAPISchema `yaml:"schemas"`
+	} `yaml:"components"`
+}
+
+type openAPISchema struct {
+	Properties map[string]struct {
+		ReadOnly  bool `yaml:"readOnly"`
+		WriteOnly bool `yaml:"writeOnly"`
+	} `yaml:"properties"`
+}
+
+// parseOpenAPISpec parses an OpenAPI 3 document in JSON or YAML format.
+func parseOpenAPISpec(data []byte) (*openAPISpec, error) {
+	var spec openAPISpec
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return nil, err
+	}
+
+	if len(spec.Paths) == 0 {
+		return nil, fmt.Errorf("the document has no paths")
+	}
+
+	return &spec, nil
+}
+
+// hasOperation reports whether the path has an operation for the method.
+func (spec *openAPISpec) hasOperation(path, method string) bool {
+	_, ok := spec.Paths[path][strings.ToLower(method)]
+	return ok
+}
+
+// itemPath returns the path of a single item of the collection path.
+func (spec *openAPISpec) itemPath(collectionPath string) (string, error) {
+	var paths []string
+	for path := range spec.Paths {
+		suffix, ok := strings.CutPrefix(path, strings.TrimSuffix(collectionPath, "/"))
+		if ok && openAPIItemPathRegexp.MatchString(suffix) {
+			paths = append(paths, path)
+		}
+	}
+
+	if len(paths) != 1 {
+		return "", fmt.Errorf("expected exactly one item path under %s, found %d, set item_path", collectionPath, len(paths))
+	}
+
+	return paths[0], nil
+}
+
+// baseURL returns the URL of the first server of the document, resolved
+// against the URL the document was retrieved from.
+func (spec *openAPISpec) baseURL(specURL string) (string, error) {
+	base, err := url.Parse(specURL)
+	if err != nil {
+		return "", err
+	}
+
+	server := "/"
+	if len(spec.Servers) > 0 {
+		server = spec.Servers[0].URL
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return "", fmt.Errorf("invalid server URL %q: %w", server, err)
+	}
+
+	return strings.TrimSuffix(base.ResolveReference(serverURL).String(), "/"), nil
+}
+
+// expandItemPath replaces the path parameter of the item path with the id.
+func expandItemPath(itemPath, id string) string {
+	start, end := strings.LastIndex(itemPath, "{"), strings.LastIndex(itemPath, "}")
+	if start < 0 || end < start {
+		return itemPath
+	}
+
+	return itemPath[:start] + url.PathEscape(id) + itemPath[end+1:]
+}
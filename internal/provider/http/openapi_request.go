@@ -0,0 +1,547 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"gopkg.in/yaml.v3"
+)
+
+// openAPIPathParameterRegexp matches the parameters of a path template, e.g.
+// `{petId}`.
+var openAPIPathParameterRegexp = regexp.MustCompile(`\{[^/{}]+\}`)
+
+// openAPIMethods are the fields of a path item holding an operation.
+var openAPIMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+type openAPIModel struct {
+	Spec        types.String `tfsdk:"spec"`
+	OperationID types.String `tfsdk:"operation_id"`
+}
+
+// openAPIRequest is the request validated against the operation.
+type openAPIRequest struct {
+	method string
+	url    *url.URL
+	header http.Header
+	body   []byte
+
+	// skipBody skips the validation of the body, which is not known yet.
+	skipBody bool
+}
+
+// openAPIOperation is an operation of an OpenAPI 3 document, its schemas
+// referencing the whole document.
+type openAPIOperation struct {
+	document   *jsonSchema
+	path       string
+	method     string
+	parameters []interface{}
+	body       interface{}
+}
+
+// checkOpenAPI validates the request against the operation of the `openapi`
+// block, fetching the document with the client when it is a URL.
+func (model *modelV0) checkOpenAPI(ctx context.Context, client *http.Client, request *openAPIRequest, diagnostics *diag.Diagnostics) {
+	if model.OpenAPI.IsNull() || model.OpenAPI.IsUnknown() {
+		return
+	}
+
+	var openAPI openAPIModel
+	diags := model.OpenAPI.As(ctx, &openAPI, basetypes.ObjectAsOptions{})
+	diagnostics.Append(diags...)
+	if diagnostics.HasError() {
+		return
+	}
+
+	source := openAPI.Spec.ValueString()
+
+	data := []byte(source)
+	if isSchemaURL(source) {
+		// The headers of the request, e.g. its credentials, are sent along
+		// when the document is served by the same origin.
+		var header http.Header
+		if specURL, err := url.Parse(source); err == nil && request.url != nil &&
+			specURL.Scheme == request.url.Scheme && specURL.Host == request.url.Host {
+			header = request.header
+		}
+
+		var err error
+		data, err = fetchSchema(ctx, client, source, header)
+		if err != nil {
+			diagnostics.AddAttributeError(
+				path.Root("openapi").AtName("spec"),
+				"Error fetching OpenAPI document",
+				fmt.Sprintf("The OpenAPI document could not be fetched from %s: %s", source, err),
+			)
+			return
+		}
+	}
+
+	document, err := parseOpenAPIDocument(data)
+	if err != nil {
+		diagnostics.AddAttributeError(
+			path.Root("openapi").AtName("spec"),
+			"Invalid OpenAPI document",
+			fmt.Sprintf("The OpenAPI document could not be parsed: %s", err),
+		)
+		return
+	}
+
+	operationID := openAPI.OperationID.ValueString()
+	operation, ok := document.operation(operationID)
+	if !ok {
+		diagnostics.AddAttributeError(
+			path.Root("openapi").AtName("operation_id"),
+			"Unknown OpenAPI operation",
+			fmt.Sprintf("The OpenAPI document has no operation with the id %q.", operationID),
+		)
+		return
+	}
+
+	if errs := operation.validate(request); len(errs) > 0 {
+		diagnostics.AddAttributeError(
+			path.Root("openapi"),
+			"Invalid request",
+			fmt.Sprintf("The request does not match the operation %q (%s %s) of the OpenAPI document:\n%s",
+				operationID, strings.ToUpper(operation.method), operation.path, formatSchemaErrors(errs)),
+		)
+	}
+}
+
+// plannedRequest returns the request described by the configuration, for it
+// to be validated before the request is made.
+func (model *modelV0) plannedRequest(ctx context.Context, diagnostics *diag.Diagnostics) *openAPIRequest {
+	requestURL, err := url.Parse(model.URL.ValueString())
+	if err != nil {
+		diagnostics.AddAttributeError(
+			path.Root("url"),
+			"Invalid URL",
+			fmt.Sprintf("The URL could not be parsed: %s", err),
+		)
+		return nil
+	}
+
+	method := model.Method.ValueString()
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	request := &openAPIRequest{
+		method: method,
+		url:    requestURL,
+		header: make(http.Header),
+	}
+
+	switch {
+	case !model.RequestBody.IsNull():
+		request.body = []byte(model.RequestBody.ValueString())
+	case !model.RequestBodyJSON.IsNull():
+		request.body, err = encodeJSON(model.RequestBodyJSON)
+		if err != nil {
+			diagnostics.AddAttributeError(
+				path.Root("request_body_json"),
+				"Error encoding request body",
+				fmt.Sprintf("Error encoding request body: %s", err),
+			)
+			return nil
+		}
+		request.header.Set("Content-Type", "application/json")
+	case !model.FormData.IsNull():
+		var formData map[string]string
+		diags := model.FormData.ElementsAs(ctx, &formData, false)
+		diagnostics.Append(diags...)
+		if diagnostics.HasError() {
+			return nil
+		}
+
+		form := url.Values{}
+		for name, value := range formData {
+			form.Set(name, value)
+		}
+		request.body = []byte(form.Encode())
+		request.header.Set("Content-Type", "application/x-www-form-urlencoded")
+	case !model.RequestBodyFile.IsNull():
+		// The file is read when the request is made.
+		request.skipBody = true
+	}
+
+	for name, value := range model.RequestHeaders.Elements() {
+		var header string
+		diags := tfsdk.ValueAs(ctx, value, &header)
+		diagnostics.Append(diags...)
+		if diagnostics.HasError() {
+			return nil
+		}
+
+		request.header.Set(name, header)
+	}
+
+	var sensitiveHeaders map[string]string
+	diags := model.SensitiveHeaders.ElementsAs(ctx, &sensitiveHeaders, false)
+	diagnostics.Append(diags...)
+	if diagnostics.HasError() {
+		return nil
+	}
+	for name, header := range sensitiveHeaders {
+		request.header.Set(name, header)
+	}
+
+	return request
+}
+
+// parseOpenAPIDocument parses an OpenAPI 3 document in JSON or YAML format
+// into a JSON Schema, for the schemas of the operations to resolve their
+// references against the document.
+func parseOpenAPIDocument(data []byte) (*jsonSchema, error) {
+	var document interface{}
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+
+	// YAML documents may have non-string keys, e.g. the status codes of the
+	// responses, which JSON does not allow.
+	data, err := json.Marshal(normalizeYAML(document))
+	if err != nil {
+		return nil, err
+	}
+
+	schema, err := newJSONSchema(data)
+	if err != nil {
+		return nil, err
+	}
+
+	root, _ := schema.root.(map[string]interface{})
+	if _, ok := root["paths"].(map[string]interface{}); !ok {
+		return nil, fmt.Errorf("the document has no paths")
+	}
+
+	return schema, nil
+}
+
+// normalizeYAML converts the mappings with non-string keys decoded from YAML
+// into maps with string keys.
+func normalizeYAML(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for k, v := range value {
+			value[k] = normalizeYAML(v)
+		}
+		return value
+	case map[interface{}]interface{}:
+		result := make(map[string]interface{}, len(value))
+		for k, v := range value {
+			result[fmt.Sprint(k)] = normalizeYAML(v)
+		}
+		return result
+	case []interface{}:
+		for i, v := range value {
+			value[i] = normalizeYAML(v)
+		}
+		return value
+	default:
+		return value
+	}
+}
+
+// operation returns the operation with the id.
+func (document *jsonSchema) operation(operationID string) (*openAPIOperation, bool) {
+	root, _ := document.root.(map[string]interface{})
+	paths, _ := root["paths"].(map[string]interface{})
+
+	templates := make([]string, 0, len(paths))
+	for template := range paths {
+		templates = append(templates, template)
+	}
+	slices.Sort(templates)
+
+	for _, template := range templates {
+		item, ok := document.deref(paths[template]).(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		for _, method := range openAPIMethods {
+			operation, ok := item[method].(map[string]interface{})
+			if !ok || operation["operationId"] != operationID {
+				continue
+			}
+
+			// The parameters of the operation override the ones of the path
+			// with the same name and location.
+			parameters, _ := item["parameters"].([]interface{})
+			operationParameters, _ := operation["parameters"].([]interface{})
+			parameters = slices.DeleteFunc(slices.Clone(parameters), func(parameter interface{}) bool {
+				return slices.ContainsFunc(operationParameters, func(other interface{}) bool {
+					return document.sameParameter(parameter, other)
+				})
+			})
+
+			return &openAPIOperation{
+				document:   document,
+				path:       template,
+				method:     method,
+				parameters: append(parameters, operationParameters...),
+				body:       document.deref(operation["requestBody"]),
+			}, true
+		}
+	}
+
+	return nil, false
+}
+
+// deref returns the object the value references with `$ref`, the value itself
+// when it is not a reference.
+func (document *jsonSchema) deref(value interface{}) interface{} {
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return value
+	}
+
+	ref, ok := object["$ref"].(string)
+	if !ok {
+		return value
+	}
+
+	resolved, err := document.resolve(ref)
+	if err != nil {
+		return nil
+	}
+
+	return resolved
+}
+
+func (document *jsonSchema) sameParameter(a, b interface{}) bool {
+	x, okX := document.deref(a).(map[string]interface{})
+	y, okY := document.deref(b).(map[string]interface{})
+	return okX && okY && x["name"] == y["name"] && x["in"] == y["in"]
+}
+
+// validate returns the errors of the request, at the location of the invalid
+// part of the request, e.g. `query.limit` or `body/name`.
+func (operation *openAPIOperation) validate(request *openAPIRequest) []jsonSchemaError {
+	var errs []jsonSchemaError
+	fail := func(location, format string, args ...interface{}) {
+		errs = append(errs, jsonSchemaError{Path: location, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if !strings.EqualFold(request.method, operation.method) {
+		fail("method", "expected %s, got %s", strings.ToUpper(operation.method), request.method)
+	}
+
+	pathValues, pathMatched := matchOpenAPIPath(operation.path, request.url.EscapedPath())
+	if !pathMatched {
+		fail("path", "expected to match %s, got %s", operation.path, request.url.EscapedPath())
+	}
+
+	query := request.url.Query()
+	for _, parameter := range operation.parameters {
+		parameter, ok := operation.document.deref(parameter).(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		name, _ := parameter["name"].(string)
+		in, _ := parameter["in"].(string)
+		location := in + "." + name
+
+		var values []string
+		switch in {
+		case "path":
+			// The path not matching is already reported.
+			if !pathMatched {
+				continue
+			}
+			if value, found := pathValues[name]; found {
+				values = []string{value}
+			}
+		case "query":
+			values = query[name]
+		case "header":
+			values = request.header.Values(name)
+		default:
+			continue
+		}
+
+		if len(values) == 0 {
+			if parameter["required"] == true || in == "path" {
+				fail(location, "missing required parameter")
+			}
+			continue
+		}
+
+		schema, ok := parameter["schema"]
+		if !ok {
+			continue
+		}
+
+		value := operation.parameterValue(schema, values)
+		operation.document.validateValue(schema, value, location, &errs)
+	}
+
+	if !request.skipBody {
+		errs = append(errs, operation.validateBody(request)...)
+	}
+
+	return errs
+}
+
+// parameterValue converts the values of a parameter to the type of its
+// schema, the values that cannot be converted being kept as strings for the
+// validation to fail.
+func (operation *openAPIOperation) parameterValue(schema interface{}, values []string) interface{} {
+	keywords, _ := operation.document.deref(schema).(map[string]interface{})
+	types, _ := schemaTypes(keywords["type"])
+
+	if slices.Contains(types, "array") {
+		if len(values) == 1 {
+			values = strings.Split(values[0], ",")
+		}
+		items := make([]interface{}, 0, len(values))
+		for _, value := range values {
+			items = append(items, operation.parameterValue(keywords["items"], []string{value}))
+		}
+		return items
+	}
+
+	value := values[0]
+	switch {
+	case slices.Contains(types, "integer"), slices.Contains(types, "number"):
+		if _, err := strconv.ParseFloat(value, 64); err == nil {
+			return json.Number(value)
+		}
+	case slices.Contains(types, "boolean"):
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	}
+
+	return value
+}
+
+func (operation *openAPIOperation) validateBody(request *openAPIRequest) []jsonSchemaError {
+	var errs []jsonSchemaError
+	fail := func(location, format string, args ...interface{}) {
+		errs = append(errs, jsonSchemaError{Path: location, Message: fmt.Sprintf(format, args...)})
+	}
+
+	requestBody, ok := operation.body.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	if len(request.body) == 0 {
+		if requestBody["required"] == true {
+			fail("body", "missing required request body")
+		}
+		return errs
+	}
+
+	content, ok := requestBody["content"].(map[string]interface{})
+	if !ok || len(content) == 0 {
+		return nil
+	}
+
+	contentType := request.header.Get("Content-Type")
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+
+	media, ok := matchOpenAPIMediaType(content, mediaType)
+	if !ok {
+		mediaTypes := make([]string, 0, len(content))
+		for mediaType := range content {
+			mediaTypes = append(mediaTypes, mediaType)
+		}
+		slices.Sort(mediaTypes)
+		fail("header.Content-Type", "expected one of %s, got %q", strings.Join(mediaTypes, ", "), contentType)
+		return errs
+	}
+
+	schema, ok := media["schema"]
+	if !ok || !isJSONContentType(contentType) {
+		return errs
+	}
+
+	body, err := decodeJSONNumbers(request.body)
+	if err != nil {
+		fail("body", "not valid JSON: %s", err)
+		return errs
+	}
+
+	var bodyErrs []jsonSchemaError
+	operation.document.validateValue(schema, body, "", &bodyErrs)
+	for _, err := range bodyErrs {
+		errs = append(errs, jsonSchemaError{Path: "body" + err.Path, Message: err.Message})
+	}
+
+	return errs
+}
+
+// matchOpenAPIMediaType returns the media type object of the content matching
+// the media type, the media ranges, e.g. `application/*`, matching too.
+func matchOpenAPIMediaType(content map[string]interface{}, mediaType string) (map[string]interface{}, bool) {
+	if media, ok := content[mediaType].(map[string]interface{}); ok {
+		return media, true
+	}
+
+	if kind, _, ok := strings.Cut(mediaType, "/"); ok {
+		if media, ok := content[kind+"/*"].(map[string]interface{}); ok {
+			return media, true
+		}
+	}
+
+	media, ok := content["*/*"].(map[string]interface{})
+	return media, ok
+}
+
+// matchOpenAPIPath matches the escaped path of the URL against the path
+// template of the operation, returning the values of its parameters. The
+// URL path may start with the base path of the server.
+func matchOpenAPIPath(template, urlPath string) (map[string]string, bool) {
+	var names []string
+	var pattern strings.Builder
+	last := 0
+	for _, loc := range openAPIPathParameterRegexp.FindAllStringIndex(template, -1) {
+		pattern.WriteString(regexp.QuoteMeta(template[last:loc[0]]))
+		pattern.WriteString(`([^/]+)`)
+		names = append(names, template[loc[0]+1:loc[1]-1])
+		last = loc[1]
+	}
+	pattern.WriteString(regexp.QuoteMeta(template[last:]))
+
+	re, err := regexp.Compile(`^(?:/.*)?` + pattern.String() + `/?$`)
+	if err != nil {
+		return nil, false
+	}
+
+	match := re.FindStringSubmatch(urlPath)
+	if match == nil {
+		return nil, false
+	}
+
+	values := make(map[string]string, len(names))
+	for i, name := range names {
+		value, err := url.PathUnescape(match[i+1])
+		if err != nil {
+			value = match[i+1]
+		}
+		values[name] = value
+	}
+
+	return values, true
+}
@@ -0,0 +1,63 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package http
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// fingerprintRegexp matches a SHA-256 fingerprint in hexadecimal, optionally
+// with its bytes separated by colons.
+var fingerprintRegexp = regexp.MustCompile(`^[0-9A-Fa-f]{2}(:?[0-9A-Fa-f]{2}){31}$`)
+
+// normalizeFingerprint returns the fingerprint in lower case, without colons.
+func normalizeFingerprint(fingerprint string) string {
+	return strings.ToLower(strings.ReplaceAll(fingerprint, ":", ""))
+}
+
+// pinMismatchError is returned when the certificate of the server matches
+// none of the pins.
+type pinMismatchError struct {
+	// fingerprint is the SHA-256 fingerprint of the certificate of the
+	// server, empty when it presented none.
+	fingerprint string
+}
+
+func (err pinMismatchError) Error() string {
+	if err.fingerprint == "" {
+		return "the server did not present any certificate"
+	}
+	return fmt.Sprintf("the certificate of the server does not match any pinned fingerprint, its SHA-256 fingerprint is %s", err.fingerprint)
+}
+
+// makePinnedCertificateVerifier returns a tls.Config.VerifyConnection callback
+// accepting the connection when the SHA-256 fingerprint of the certificate of
+// the server, or of its public key, is one of the pins.
+func makePinnedCertificateVerifier(pins []string) func(tls.ConnectionState) error {
+	pinned := make(map[string]bool, len(pins))
+	for _, pin := range pins {
+		pinned[normalizeFingerprint(pin)] = true
+	}
+
+	return func(state tls.ConnectionState) error {
+		if len(state.PeerCertificates) == 0 {
+			return pinMismatchError{}
+		}
+
+		cert := state.PeerCertificates[0]
+		certFingerprint := sha256.Sum256(cert.Raw)
+		keyFingerprint := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+
+		if pinned[hex.EncodeToString(certFingerprint[:])] || pinned[hex.EncodeToString(keyFingerprint[:])] {
+			return nil
+		}
+
+		return pinMismatchError{fingerprint: hex.EncodeToString(certFingerprint[:])}
+	}
+}
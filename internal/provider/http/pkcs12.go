@@ -0,0 +1,37 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package http
+
+import (
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+// decodePKCS12 returns the client certificate, its chain and its private key
+// held by the base64 encoded PKCS#12 bundle.
+func decodePKCS12(bundle, password string) (tls.Certificate, error) {
+	data, err := base64.StdEncoding.DecodeString(bundle)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("invalid base64: %w", err)
+	}
+
+	key, leaf, chain, err := pkcs12.DecodeChain(data, password)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	cert := tls.Certificate{
+		Certificate: [][]byte{leaf.Raw},
+		PrivateKey:  key,
+		Leaf:        leaf,
+	}
+	for _, ca := range chain {
+		cert.Certificate = append(cert.Certificate, ca.Raw)
+	}
+
+	return cert, nil
+}
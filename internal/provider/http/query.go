@@ -0,0 +1,77 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jmespath/go-jmespath"
+)
+
+// evaluateQueries evaluates each named JMESPath expression against the JSON
+// response body and returns the results as an object keyed by name.
+func evaluateQueries(body []byte, queries map[string]string) (attr.Value, error) {
+	data, err := unmarshalJSON(body)
+	if err != nil {
+		return nil, fmt.Errorf("the response body is not valid JSON: %w", err)
+	}
+	data = jmespathNumbers(data)
+
+	attrTypes := make(map[string]attr.Type, len(queries))
+	attrs := make(map[string]attr.Value, len(queries))
+
+	for name, expression := range queries {
+		result, err := jmespath.Search(expression, data)
+		if err != nil {
+			return nil, fmt.Errorf("query %q (%s) failed: %w", name, expression, err)
+		}
+
+		value, err := jsonToValue(result)
+		if err != nil {
+			return nil, fmt.Errorf("query %q (%s) failed: %w", name, expression, err)
+		}
+
+		attrTypes[name] = value.Type(nil)
+		attrs[name] = value
+	}
+
+	object, diags := types.ObjectValue(attrTypes, attrs)
+	if diags.HasError() {
+		return nil, diagsError(diags)
+	}
+
+	return object, nil
+}
+
+// jmespathNumbers converts the numbers exactly represented by a float64 to
+// float64, as JMESPath compares and computes numbers as float64. The other
+// numbers, e.g. large IDs, are kept as json.Number so that they are returned
+// as is.
+func jmespathNumbers(data interface{}) interface{} {
+	switch v := data.(type) {
+	case json.Number:
+		number, _, err := big.ParseFloat(v.String(), 10, 512, big.ToNearestEven)
+		if err != nil {
+			return v
+		}
+		if float, accuracy := number.Float64(); accuracy == big.Exact {
+			return float
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = jmespathNumbers(item)
+		}
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = jmespathNumbers(item)
+		}
+	}
+
+	return data
+}
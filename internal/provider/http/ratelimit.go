@@ -0,0 +1,42 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package http
+
+import (
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"golang.org/x/time/rate"
+)
+
+type rateLimitModel struct {
+	RequestsPerSecond types.Float64 `tfsdk:"requests_per_second"`
+	Burst             types.Int64   `tfsdk:"burst"`
+}
+
+// limiter returns the token bucket of the rate limit, allowing one request
+// at once when the burst is not set.
+func (model rateLimitModel) limiter() *rate.Limiter {
+	burst := 1
+	if !model.Burst.IsNull() && !model.Burst.IsUnknown() {
+		burst = int(model.Burst.ValueInt64())
+	}
+
+	return rate.NewLimiter(rate.Limit(model.RequestsPerSecond.ValueFloat64()), burst)
+}
+
+// rateLimitedTransport waits for the limiter before each round trip, so that
+// the retries of a request are spread according to the rate limit.
+type rateLimitedTransport struct {
+	transport http.RoundTripper
+	limiter   *rate.Limiter
+}
+
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+
+	return t.transport.RoundTrip(req)
+}
@@ -0,0 +1,104 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+const (
+	refreshPolicyNever    = "never"
+	refreshPolicyAlways   = "always"
+	refreshPolicyOnExpiry = "on_expiry"
+)
+
+// responseExpiryKey is the private state key holding the time the last
+// response expires at.
+const responseExpiryKey = "response_expiry"
+
+// responseExpiry returns the time the response expires at, from the max-age
+// directive of its Cache-Control header or from its Expires header. Responses
+// without freshness information, or that must not be cached, are already
+// expired.
+func responseExpiry(header http.Header, now time.Time) time.Time {
+	var age time.Duration
+	if seconds, err := strconv.Atoi(header.Get("Age")); err == nil && seconds > 0 {
+		age = time.Duration(seconds) * time.Second
+	}
+
+	for _, directive := range strings.Split(strings.Join(header.Values("Cache-Control"), ","), ",") {
+		directive = strings.ToLower(strings.TrimSpace(directive))
+
+		switch {
+		case directive == "no-store" || directive == "no-cache":
+			return now
+		case strings.HasPrefix(directive, "max-age="):
+			seconds, err := strconv.Atoi(strings.Trim(strings.TrimPrefix(directive, "max-age="), `"`))
+			if err != nil {
+				return now
+			}
+			return now.Add(time.Duration(seconds)*time.Second - age)
+		}
+	}
+
+	if value := header.Get("Expires"); value != "" {
+		expires, err := http.ParseTime(value)
+		if err != nil {
+			return now
+		}
+
+		// The lifetime is relative to the clock of the server.
+		if date, err := http.ParseTime(header.Get("Date")); err == nil {
+			return now.Add(expires.Sub(date) - age)
+		}
+		return expires
+	}
+
+	return now
+}
+
+func getResponseExpiry(ctx context.Context, private privateState) (time.Time, diag.Diagnostics) {
+	value, diags := private.GetKey(ctx, responseExpiryKey)
+	if diags.HasError() || len(value) == 0 {
+		return time.Time{}, diags
+	}
+
+	var expiry time.Time
+	if err := expiry.UnmarshalJSON(value); err != nil {
+		diags.AddError(
+			"Error reading private state",
+			fmt.Sprintf("Error decoding response expiry: %s", err),
+		)
+		return time.Time{}, diags
+	}
+
+	return expiry, diags
+}
+
+// setResponseExpiry stores the expiry in the private state, or removes it when
+// zero.
+func setResponseExpiry(ctx context.Context, private privateState, expiry time.Time) diag.Diagnostics {
+	if expiry.IsZero() {
+		return private.SetKey(ctx, responseExpiryKey, nil)
+	}
+
+	value, err := expiry.UTC().MarshalJSON()
+	if err != nil {
+		var diags diag.Diagnostics
+		diags.AddError(
+			"Error writing private state",
+			fmt.Sprintf("Error encoding response expiry: %s", err),
+		)
+		return diags
+	}
+
+	return private.SetKey(ctx, responseExpiryKey, value)
+}
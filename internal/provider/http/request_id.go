@@ -0,0 +1,52 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package http
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// requestIDTransport sets the request ID header of every attempt to a new
+// UUID, so that each attempt can be found in the logs of the server. The
+// value set in `request_headers` is sent as is instead.
+type requestIDTransport struct {
+	transport http.RoundTripper
+	header    string
+
+	mu   sync.Mutex
+	last string
+}
+
+func (t *requestIDTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	id := req.Header.Get(t.header)
+	if id == "" {
+		id = uuid.NewString()
+
+		// The request is reused by the retries, so it is not modified.
+		req = req.Clone(req.Context())
+		req.Header.Set(t.header, id)
+	}
+
+	t.mu.Lock()
+	t.last = id
+	t.mu.Unlock()
+
+	return t.transport.RoundTrip(req)
+}
+
+// lastID returns the request ID of the last attempt, empty when no attempt
+// was made.
+func (t *requestIDTransport) lastID() string {
+	if t == nil {
+		return ""
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.last
+}
@@ -0,0 +1,276 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package http
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const defaultGraphQLTimeout = 10000
+
+var _ resource.Resource = (*graphqlResource)(nil)
+var _ resource.ResourceWithModifyPlan = (*graphqlResource)(nil)
+
+func NewGraphQLResource() resource.Resource {
+	return &graphqlResource{}
+}
+
+type graphqlResource struct{}
+type graphqlResourceModel struct {
+	ID             types.String  `tfsdk:"id"`
+	Endpoint       types.String  `tfsdk:"endpoint"`
+	Query          types.String  `tfsdk:"query"`
+	Variables      types.Dynamic `tfsdk:"variables"`
+	OperationName  types.String  `tfsdk:"operation_name"`
+	Headers        types.Map     `tfsdk:"headers"`
+	AllowErrors    types.Bool    `tfsdk:"allow_errors"`
+	RequestTimeout types.Int64   `tfsdk:"request_timeout_ms"`
+	Data           types.Dynamic `tfsdk:"data"`
+	Errors         types.Dynamic `tfsdk:"errors"`
+}
+
+func (r *graphqlResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_graphql"
+}
+
+func (r *graphqlResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: `
+The ` + "`graphql`" + ` resource sends a GraphQL query or mutation to an endpoint, as described in
+[GraphQL over HTTP](https://graphql.org/learn/serving-over-http/#post-request), when it is created and
+each time its arguments change. The decoded ` + "`data`" + ` of the response is exported.
+
+The operation fails when the response holds ` + "`errors`" + `, unless ` + "`allow_errors`" + ` is set.
+Refreshing the resource does not send the query again, and destroying it only removes it from the state.
+`,
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The time the query was first sent, in RFC 3339 format.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"endpoint": schema.StringAttribute{
+				Description: "The URL of the GraphQL endpoint.",
+				Required:    true,
+			},
+
+			"query": schema.StringAttribute{
+				Description: "The GraphQL query or mutation.",
+				Required:    true,
+			},
+
+			"variables": schema.DynamicAttribute{
+				Description: "The variables of the query, e.g. `{ id = \"42\" }`.",
+				Optional:    true,
+			},
+
+			"operation_name": schema.StringAttribute{
+				Description: "The name of the operation to run, when the query contains several operations.",
+				Optional:    true,
+			},
+
+			"headers": schema.MapAttribute{
+				Description: "A map of request header field names and values, e.g. to authenticate the request.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Sensitive:   true,
+			},
+
+			"allow_errors": schema.BoolAttribute{
+				Description: "Whether a response holding `errors` is accepted, with a warning, e.g. when `data` is partial. " +
+					"Defaults to `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+
+			"request_timeout_ms": schema.Int64Attribute{
+				Description: fmt.Sprintf("The time allowed for the request in milliseconds. Defaults to `%d`.", defaultGraphQLTimeout),
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(defaultGraphQLTimeout),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+
+			"data": schema.DynamicAttribute{
+				Description: "The `data` member of the response, decoded.",
+				Computed:    true,
+			},
+
+			"errors": schema.DynamicAttribute{
+				Description: "The `errors` member of the response, decoded, `null` when the response holds none.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *graphqlResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+}
+
+func (r *graphqlResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	deferUnknownConfig(req, resp)
+}
+
+func (r *graphqlResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var model graphqlResourceModel
+	diags := req.Plan.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	model.send(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	model.ID = types.StringValue(time.Now().UTC().Format(time.RFC3339))
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *graphqlResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// The query is only sent when the resource is created or updated,
+	// resp.State already holds the prior state.
+}
+
+func (r *graphqlResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var model graphqlResourceModel
+	diags := req.Plan.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	model.send(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *graphqlResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// The query has no counterpart undoing it, the resource is only removed
+	// from the state.
+}
+
+// send sends the query and sets the data and errors of the response.
+func (model *graphqlResourceModel) send(ctx context.Context, diagnostics *diag.Diagnostics) {
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(model.RequestTimeout.ValueInt64())*time.Millisecond)
+	defer cancel()
+
+	query := graphqlModel{
+		Query:         model.Query,
+		Variables:     model.Variables,
+		OperationName: model.OperationName,
+	}
+	body, err := query.requestBody()
+	if err != nil {
+		diagnostics.AddAttributeError(
+			path.Root("variables"),
+			"Error encoding GraphQL request",
+			fmt.Sprintf("Error encoding GraphQL request: %s", err),
+		)
+		return
+	}
+
+	headers := make(map[string]string)
+	if !model.Headers.IsNull() {
+		diags := model.Headers.ElementsAs(ctx, &headers, false)
+		diagnostics.Append(diags...)
+		if diagnostics.HasError() {
+			return
+		}
+	}
+
+	data, err := postGraphQL(ctx, model.Endpoint.ValueString(), body, headers)
+	if err != nil {
+		diagnostics.AddError(
+			"Error making request",
+			fmt.Sprintf("Error making request: %s", err),
+		)
+		return
+	}
+
+	responseData, responseErrors, err := decodeGraphQLResponse(data)
+	if err != nil {
+		diagnostics.AddError(
+			"Error decoding GraphQL response",
+			fmt.Sprintf("Error decoding GraphQL response: %s", err),
+		)
+		return
+	}
+
+	if messages := graphqlErrorMessages(data); len(messages) > 0 {
+		detail := "The GraphQL API returned errors:\n\n  - " + strings.Join(messages, "\n  - ")
+		if !model.AllowErrors.ValueBool() {
+			diagnostics.AddError("GraphQL response contains errors", detail)
+			return
+		}
+		diagnostics.AddWarning("GraphQL response contains errors", detail+"\n\nThe errors are exported in errors.")
+	}
+
+	model.Data = types.DynamicValue(responseData)
+	model.Errors = types.DynamicValue(responseErrors)
+}
+
+// postGraphQL sends the GraphQL request to the endpoint and returns the body
+// of its response, failing on the unsuccessful statuses.
+func postGraphQL(ctx context.Context, endpoint string, body []byte, headers map[string]string) ([]byte, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	// Headers set in `headers` take precedence.
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Accept", "application/json")
+	for name, value := range headers {
+		request.Header.Set(name, value)
+	}
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return nil, fmt.Errorf("unexpected HTTP status %s\n\n%s", response.Status, newResponseExcerpt(response, defaultErrorExcerptBytes))
+	}
+
+	return io.ReadAll(response.Body)
+}
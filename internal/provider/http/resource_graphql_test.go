@@ -0,0 +1,147 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package http_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+// newProjectsServer returns a GraphQL endpoint creating projects, which
+// answers with an error when the name is empty.
+func newProjectsServer(t *testing.T) (*httptest.Server, func() int) {
+	var mu sync.Mutex
+	var requests int
+
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests++
+		mu.Unlock()
+
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"errors":[{"message":"unauthenticated"}]}`))
+			return
+		}
+
+		var request struct {
+			Query     string `json:"query"`
+			Variables struct {
+				Name string `json:"name"`
+			} `json:"variables"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request.Query == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if request.Variables.Name == "" {
+			_, _ = w.Write([]byte(`{"data":{"createProject":null},"errors":[{"message":"the name is required","path":["createProject"]}]}`))
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"data":{"createProject":{"id":"p-1","name":%q}}}`, request.Variables.Name)
+	}))
+	t.Cleanup(svr.Close)
+
+	return svr, func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return requests
+	}
+}
+
+func TestGraphQLResource(t *testing.T) {
+	svr, requests := newProjectsServer(t)
+
+	config := func(name string) string {
+		return fmt.Sprintf(`
+			resource "utilities_graphql" "test" {
+				endpoint  = "%s"
+				query     = "mutation($name: String!) { createProject(name: $name) { id name } }"
+				variables = { name = %q }
+				headers   = { Authorization = "Bearer token" }
+			}`, svr.URL, name)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: config("alpha"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utilities_graphql.test", "data.createProject.id", "p-1"),
+					resource.TestCheckResourceAttr("utilities_graphql.test", "data.createProject.name", "alpha"),
+					resource.TestCheckNoResourceAttr("utilities_graphql.test", "errors"),
+					resource.TestCheckResourceAttrSet("utilities_graphql.test", "id"),
+				),
+			},
+			// Refreshing does not send the query again.
+			{
+				Config: config("alpha"),
+				Check: func(_ *terraform.State) error {
+					if n := requests(); n != 1 {
+						return fmt.Errorf("expected 1 request, got %d", n)
+					}
+					return nil
+				},
+			},
+			{
+				Config: config("beta"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utilities_graphql.test", "data.createProject.name", "beta"),
+				),
+			},
+		},
+	})
+}
+
+func TestGraphQLResource_Errors(t *testing.T) {
+	svr, _ := newProjectsServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "utilities_graphql" "test" {
+						endpoint  = "%s"
+						query     = "mutation($name: String!) { createProject(name: $name) { id } }"
+						variables = { name = "" }
+						headers   = { Authorization = "Bearer token" }
+					}`, svr.URL),
+				ExpectError: regexp.MustCompile(`the name is required \(at createProject\)`),
+			},
+			{
+				Config: fmt.Sprintf(`
+					resource "utilities_graphql" "test" {
+						endpoint     = "%s"
+						query        = "mutation($name: String!) { createProject(name: $name) { id } }"
+						variables    = { name = "" }
+						headers      = { Authorization = "Bearer token" }
+						allow_errors = true
+					}`, svr.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utilities_graphql.test", "errors.0.message", "the name is required"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+					resource "utilities_graphql" "test" {
+						endpoint = "%s"
+						query    = "{ projects { id } }"
+					}`, svr.URL),
+				ExpectError: regexp.MustCompile(`(?s)unexpected HTTP status 401 Unauthorized.*unauthenticated`),
+			},
+		},
+	})
+}
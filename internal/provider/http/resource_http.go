@@ -5,32 +5,71 @@ package http
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"time"
+
+	"terraform-provider-utilities/internal/provider/providerdata"
 
 	"github.com/hashicorp/go-retryablehttp"
+	"github.com/hashicorp/terraform-plugin-framework-validators/boolvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/dynamicvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 var _ resource.Resource = (*httpResource)(nil)
 var _ resource.ResourceWithImportState = &httpResource{}
+var _ resource.ResourceWithModifyPlan = &httpResource{}
 
 func NewHttpResource() resource.Resource {
 	return &httpResource{}
 }
 
-type httpResource struct{}
+type httpResource struct {
+	providerData *providerdata.ProviderData
+}
 type httpResourceModel struct {
 	modelV0
 
-	Keepers types.Map `tfsdk:"keepers"`
+	RequestBodyWO        types.String `tfsdk:"request_body_wo"`
+	RequestBodyWOVersion types.Int64  `tfsdk:"request_body_wo_version"`
+	Keepers              types.Map    `tfsdk:"keepers"`
+	RefreshTriggers      types.Map    `tfsdk:"refresh_triggers"`
+	RefreshPolicy        types.String `tfsdk:"refresh_policy"`
+	ObjectID             types.String `tfsdk:"object_id"`
+	Create               types.Object `tfsdk:"create"`
+	Read                 types.Object `tfsdk:"read"`
+	Update               types.Object `tfsdk:"update"`
+	Destroy              types.Object `tfsdk:"destroy"`
+}
+
+// read makes the request with the write-only request body, when set.
+func (model *httpResourceModel) read(ctx context.Context, diagnostics *diag.Diagnostics) {
+	if model.RequestBodyWO.IsNull() {
+		model.modelV0.read(ctx, diagnostics)
+		return
+	}
+
+	requestBody := model.RequestBody
+	model.RequestBody = model.RequestBodyWO
+	model.redactRequestBody = true
+	model.modelV0.read(ctx, diagnostics)
+	model.RequestBody = requestBody
+	model.redactRequestBody = false
 }
 
 func (d *httpResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -59,6 +98,18 @@ By default, there are no retries. Configuring the retry block will result in
 retries if an error is returned by the client (e.g., connection errors) or if 
 a 5xx-range (except 501) status code is received. For further details see 
 [go-retryablehttp](https://pkg.go.dev/github.com/hashicorp/go-retryablehttp).
+
+When the response to a ` + "`GET`" + ` or ` + "`HEAD`" + ` request has an ` + "`ETag`" + ` or ` + "`Last-Modified`" + `
+header, it is refreshed with a conditional request sending them back in the ` + "`If-None-Match`" + ` and
+` + "`If-Modified-Since`" + ` headers. The previous response is kept when the server replies with
+` + "`304 Not Modified`" + `.
+
+The ` + "`create`" + `, ` + "`read`" + `, ` + "`update`" + ` and ` + "`destroy`" + ` blocks map the lifecycle of the resource to
+the requests managing an object of a REST API. Without them, the same request is sent on create and update,
+and nothing is sent on destroy. When the ` + "`read`" + ` block is set, its request refreshes the response on every
+plan and the resource is removed from the state when the server replies with ` + "`404 Not Found`" + ` or
+` + "`410 Gone`" + `. The ` + "`object_id`" + ` extracted from the response to the create request replaces ` + "`{id}`" + ` in
+the URL and the request body of the other operations.
 `,
 
 		Attributes: map[string]schema.Attribute{
@@ -72,6 +123,12 @@ a 5xx-range (except 501) status code is received. For further details see
 				Required:    true,
 			},
 
+			"enabled": schema.BoolAttribute{
+				Description: "Whether the request is made. When `false`, the request is skipped and the computed " +
+					"attributes are null. Defaults to `true`.",
+				Optional: true,
+			},
+
 			"method": schema.StringAttribute{
 				Description: "The HTTP Method for the request. " +
 					"Allowed methods are a subset of methods defined in [RFC7231](https://datatracker.ietf.org/doc/html/rfc7231#section-4.3) namely, " +
@@ -92,19 +149,196 @@ a 5xx-range (except 501) status code is received. For further details see
 				Optional:    true,
 			},
 
+			"sensitive_request_headers": schema.MapAttribute{
+				Description: "A map of request header field names and values merged with `request_headers`, taking precedence, " +
+					"for secrets such as API keys. The values are redacted from the logs, the diagnostics, `curl_command` and " +
+					"the HAR file.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Sensitive:   true,
+			},
+
+			"request_id_header": schema.StringAttribute{
+				Description: "The name of a request header set to a new UUID for every attempt, e.g. `X-Request-Id`, so that " +
+					"the attempts can be correlated with the logs of the server. The value set in `request_headers` is sent " +
+					"instead when the header is set there. Defaults to the `request_id_header` of the provider, no header being set when it is not set either.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"share_response": schema.BoolAttribute{
+				Description: "Whether to share the response with the other `utilities_http` resources and data sources with " +
+					"`share_response` set making an identical request during the run, so that a single request is made. Requests " +
+					"are identical when their method, URL, headers, body, credentials, TLS, proxy and network settings are. The " +
+					"shared response bodies are held in memory until Terraform exits, up to `max_response_body_bytes`, and can't be " +
+					"streamed with `forward_to`. Defaults to `false`.",
+				Optional: true,
+				Validators: []validator.Bool{
+					boolvalidator.ConflictsWith(path.MatchRoot("forward_to")),
+				},
+			},
+
+			"auth": schema.StringAttribute{
+				Description: "The identity of the environment the provider runs in the request is authenticated with, replacing the " +
+					"`Authorization` header of `request_headers`, one of `aws_iam`, `gcp_id_token` or `azure_msi`. " +
+					"With `aws_iam` the request is signed with Signature Version 4, the credentials and region being loaded as the AWS CLI does. " +
+					"With `gcp_id_token` an ID token of the default service account is obtained from the GCP metadata server. " +
+					"With `azure_msi` an access token of the managed identity is obtained from the workload identity federated token, " +
+					"the App Service identity endpoint or the Instance Metadata Service. " +
+					"Only the requests to the host of `url` are authenticated, not the redirects to other hosts.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(authAWSIAM, authGCPIDToken, authAzureMSI),
+				},
+			},
+
+			"auth_audience": schema.StringAttribute{
+				Description: "The service the request is signed for with `aws_iam`, defaults to `execute-api`; " +
+					"the audience of the ID token with `gcp_id_token` or the resource of the access token with `azure_msi`, " +
+					"defaults to the origin of `url`.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("auth")),
+				},
+			},
+
 			"request_body": schema.StringAttribute{
 				Description: "The request body as a string.",
 				Optional:    true,
 			},
 
+			"request_body_wo": schema.StringAttribute{
+				Description: "The request body as a string, never stored in the plan or the state. " +
+					"Requires Terraform 1.11 or later, change `request_body_wo_version` to send the request again.",
+				Optional:  true,
+				Sensitive: true,
+				WriteOnly: true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(
+						path.MatchRoot("request_body"),
+						path.MatchRoot("request_body_file"),
+						path.MatchRoot("form_data"),
+						path.MatchRoot("graphql"),
+						path.MatchRoot("soap"),
+					),
+				},
+			},
+
+			"request_body_wo_version": schema.Int64Attribute{
+				Description: "The version of `request_body_wo`, changing it sends the request again with the new body.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("request_body_wo")),
+				},
+			},
+
+			"request_body_json": schema.DynamicAttribute{
+				Description: "The request body as a value of any type, e.g. `{ name = \"example\" }`, encoded in JSON with " +
+					"the object keys sorted. The `Content-Type` request header is set to `application/json` unless set in `request_headers`.",
+				Optional: true,
+				Validators: []validator.Dynamic{
+					dynamicvalidator.ConflictsWith(
+						path.MatchRoot("request_body"),
+						path.MatchRoot("request_body_wo"),
+						path.MatchRoot("request_body_file"),
+						path.MatchRoot("form_data"),
+						path.MatchRoot("graphql"),
+						path.MatchRoot("soap"),
+					),
+				},
+			},
+
+			"request_body_file": schema.StringAttribute{
+				Description: "The path to a file streamed as the request body, instead of keeping the payload " +
+					"in the configuration and the state.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("request_body")),
+				},
+			},
+
+			"form_data": schema.MapAttribute{
+				Description: "A map of form field names and values, sent url-encoded as the request body. " +
+					"The `Content-Type` request header is set to `application/x-www-form-urlencoded` unless set in `request_headers`.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.Map{
+					mapvalidator.ConflictsWith(
+						path.MatchRoot("request_body"),
+						path.MatchRoot("request_body_file"),
+					),
+				},
+			},
+
+			"accept_encoding": schema.StringAttribute{
+				Description: "The value of the `Accept-Encoding` request header, e.g. `gzip, deflate, br`. " +
+					"Responses compressed with `gzip`, `deflate` or `br` are decompressed before populating `response_body` " +
+					"and `response_body_base64`, whether the encoding was requested or not.",
+				Optional: true,
+			},
+
 			"request_timeout_ms": schema.Int64Attribute{
-				Description: "The request timeout in milliseconds.",
+				Description: "The request timeout in milliseconds, applied to each attempt. Prefer `attempt_timeout_ms`.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+
+			"attempt_timeout_ms": schema.Int64Attribute{
+				Description: "The timeout of each attempt of the request in milliseconds, the reading of the response body included.",
 				Optional:    true,
 				Validators: []validator.Int64{
 					int64validator.AtLeast(1),
+					int64validator.ConflictsWith(path.MatchRoot("request_timeout_ms")),
 				},
 			},
 
+			"total_timeout_ms": schema.Int64Attribute{
+				Description: "The timeout of the request in milliseconds, all the attempts, the delays between retries and " +
+					"the reading of the response body included.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+
+			"max_response_body_bytes": schema.Int64Attribute{
+				Description: "The maximum size in bytes of the response body, before and after decompression. " +
+					"The request fails when the body is larger, instead of reading it into memory and the state.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+					int64validator.ConflictsWith(path.MatchRoot("forward_to")),
+				},
+			},
+
+			"error_excerpt_bytes": schema.Int64Attribute{
+				Description: "The maximum number of bytes of the response body added to the error, along with the status " +
+					"and the `Content-Type`, `Retry-After`, `WWW-Authenticate`, `X-Request-Id` and `X-Correlation-Id` " +
+					fmt.Sprintf("headers of the response, when the request gives up on an unsuccessful response. Defaults to `%d`, ", defaultErrorExcerptBytes) +
+					"`0` leaving the body out.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+
+			"debug": schema.BoolAttribute{
+				Description: "Records the DNS, connection and TLS events and the retries of the request, " +
+					"logged at the `DEBUG` level and added to the error when the request fails. Defaults to `false`.",
+				Optional: true,
+			},
+
+			"curl_command": schema.StringAttribute{
+				Description: "The curl command line sending the request, to reproduce it outside of Terraform. " +
+					"The password of the URL, the write-only request body and the values of the headers, query parameters and " +
+					"form fields whose name looks sensitive, e.g. `Authorization` or `api_key`, are replaced by `REDACTED`.",
+				Computed: true,
+			},
+
 			"response_body": schema.StringAttribute{
 				Description: "The response body returned as a string.",
 				Computed:    true,
@@ -122,6 +356,119 @@ a 5xx-range (except 501) status code is received. For further details see
 				Computed:    true,
 			},
 
+			"compress_state_body": schema.BoolAttribute{
+				Description: "Whether the response body is stored in the state compressed, in `response_body_gzip_base64`, " +
+					"instead of in `response_body`, `body`, `response_body_base64` and `response_body_json`, which are `null`, " +
+					"to shrink the state of large text responses. Defaults to `false`.",
+				Optional: true,
+			},
+
+			"discard_response_body": schema.BoolAttribute{
+				Description: "Whether the response body is left out of the state: `response_body`, `body`, `response_body_base64` " +
+					"and `response_body_json` are `null`, the status code, the response headers and the `content_summary`, " +
+					"holding the SHA-256 hash of the body, being recorded. The assertions and queries are still evaluated " +
+					"against the body. Defaults to `false`.",
+				Optional: true,
+				Validators: []validator.Bool{
+					boolvalidator.ConflictsWith(path.MatchRoot("compress_state_body")),
+				},
+			},
+
+			"response_body_gzip_base64": schema.StringAttribute{
+				Description: "The response body compressed with gzip and encoded as base64 when `compress_state_body` is `true`, " +
+					"`null` otherwise. It is decompressed with the `gunzip_base64` function.",
+				Computed: true,
+			},
+
+			"content_summary": schema.ObjectAttribute{
+				Description: "A summary of the response body, readable in the plan output when the body is large: its `size` in bytes, " +
+					"its `sha256` hash in hexadecimal, the `content_type` of the response and a `preview` of its first 200 characters, " +
+					"`null` when the body is not UTF-8. It is null when the body is streamed to `forward_to`.",
+				AttributeTypes: contentSummaryAttrTypes,
+				Computed:       true,
+			},
+
+			"response_body_json": schema.DynamicAttribute{
+				Description: "The response body decoded as JSON when the response `Content-Type` is `application/json` " +
+					"or uses the `+json` structured syntax suffix, `null` otherwise.",
+				Computed: true,
+			},
+
+			"response_queries": schema.MapAttribute{
+				Description: "A map of names to [JMESPath](https://jmespath.org/) expressions evaluated against the response body " +
+					"decoded as JSON. The results are exported in `query_results`.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+
+			"query_results": schema.DynamicAttribute{
+				Description: "An object holding the result of each of the `response_queries`, keyed by name.",
+				Computed:    true,
+			},
+
+			"graphql_data": schema.DynamicAttribute{
+				Description: "The `data` member of the GraphQL response, when the `graphql` block is set.",
+				Computed:    true,
+			},
+
+			"graphql_errors": schema.DynamicAttribute{
+				Description: "The `errors` member of the GraphQL response, when the `graphql` block is set.",
+				Computed:    true,
+			},
+
+			"soap_body": schema.StringAttribute{
+				Description: "The content of the `Body` element of the SOAP response envelope, when the `soap` block is set.",
+				Computed:    true,
+			},
+
+			"soap_fault": schema.ObjectAttribute{
+				Description: "The `Fault` of the SOAP response, when the `soap` block is set and the response holds one: its `code`, " +
+					"e.g. `soap:Client`, the human readable `reason`, the `actor` that caused it and the content of its `detail` " +
+					"element, if given. A fault is usually sent with an unsuccessful status, which fails the request unless set in " +
+					"`success_status_codes`.",
+				AttributeTypes: soapFaultAttrTypes,
+				Computed:       true,
+			},
+
+			"response_body_regex": schema.StringAttribute{
+				Description: "A regular expression the response body must match, " +
+					"otherwise an error is raised regardless of the status code. " +
+					"The syntax is described in the [RE2 documentation](https://github.com/google/re2/wiki/Syntax).",
+				Optional: true,
+			},
+
+			"expected_response_body": schema.StringAttribute{
+				Description: "The exact response body expected, " +
+					"otherwise an error is raised regardless of the status code.",
+				Optional: true,
+			},
+
+			"expected_content_type": schema.StringAttribute{
+				Description: "The media type expected in the `Content-Type` of the response, its parameters being ignored, " +
+					"e.g. `application/json`, or `application/*` to match any subtype, otherwise an error is raised regardless of the status code.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(mediaRangeRegexp, "must be a media type, e.g. application/json or application/*"),
+				},
+			},
+
+			"validate_cel": schema.StringAttribute{
+				Description: "A [CEL](https://cel.dev) expression validating the response, otherwise an error is raised " +
+					"with the result of the expression. The expression is evaluated with `status` (the status code), " +
+					"`headers` (the response headers, as in `response_headers`) and `body_json` (the response body decoded " +
+					"as JSON, `null` when the response `Content-Type` is not JSON), " +
+					"e.g. `status == 200 && body_json.state == \"ready\"`.",
+				Optional: true,
+			},
+
+			"response_schema": schema.StringAttribute{
+				Description: "A [JSON Schema](https://json-schema.org) draft-07 the response body must match, otherwise an error is raised " +
+					"listing the paths of the response body failing the validation. Either the schema document itself " +
+					"or the `http://` or `https://` URL to fetch it from. References within the schema are supported, " +
+					"`format` being ignored.",
+				Optional: true,
+			},
+
 			"ca_cert_pem": schema.StringAttribute{
 				Description: "Certificate Authority (CA) " +
 					"in [PEM (RFC 1421)](https://datatracker.ietf.org/doc/html/rfc1421) format.",
@@ -149,11 +496,146 @@ a 5xx-range (except 501) status code is received. For further details see
 				},
 			},
 
+			"ca_cert_file": schema.StringAttribute{
+				Description: "The path of a file holding the Certificate Authority (CA) " +
+					"in [PEM (RFC 1421)](https://datatracker.ietf.org/doc/html/rfc1421) format, instead of `ca_cert_pem`.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("ca_cert_pem"), path.MatchRoot("insecure")),
+				},
+			},
+
+			"ca_cert_append": schema.BoolAttribute{
+				Description: "Whether the Certificate Authority (CA) of `ca_cert_pem` or `ca_cert_file` is appended to the " +
+					"system certificate pool, instead of replacing it, so that the servers with a publicly trusted certificate, " +
+					"e.g. the targets of redirects, are verified too. Defaults to `false`.",
+				Optional: true,
+			},
+
+			"client_cert_file": schema.StringAttribute{
+				Description: "The path of a file holding the client certificate " +
+					"in [PEM (RFC 1421)](https://datatracker.ietf.org/doc/html/rfc1421) format, instead of `client_cert_pem`.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("client_cert_pem")),
+					stringvalidator.AlsoRequires(path.MatchRoot("client_key_file")),
+				},
+			},
+
+			"client_key_file": schema.StringAttribute{
+				Description: "The path of a file holding the client key " +
+					"in [PEM (RFC 1421)](https://datatracker.ietf.org/doc/html/rfc1421) format, instead of `client_key_pem`.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("client_key_pem")),
+					stringvalidator.AlsoRequires(path.MatchRoot("client_cert_file")),
+				},
+			},
+
+			"client_key_password": schema.StringAttribute{
+				Description: "The password the `client_key_pem` or `client_key_file` key is encrypted with, " +
+					"either a PKCS#8 `ENCRYPTED PRIVATE KEY` or a legacy OpenSSL key with a `DEK-Info` header.",
+				Optional:  true,
+				Sensitive: true,
+				Validators: []validator.String{
+					stringvalidator.AtLeastOneOf(path.MatchRoot("client_key_pem"), path.MatchRoot("client_key_file")),
+				},
+			},
+
+			"client_pkcs12_base64": schema.StringAttribute{
+				Description: "The base64 encoded PKCS#12 (.p12 or .pfx) bundle holding the client certificate " +
+					"and its private key, instead of `client_cert_pem` and `client_key_pem`.",
+				Optional:  true,
+				Sensitive: true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(
+						path.MatchRoot("client_cert_pem"),
+						path.MatchRoot("client_key_pem"),
+						path.MatchRoot("client_cert_file"),
+						path.MatchRoot("client_key_file"),
+					),
+				},
+			},
+
+			"client_pkcs12_password": schema.StringAttribute{
+				Description: "The password of the `client_pkcs12_base64` bundle.",
+				Optional:    true,
+				Sensitive:   true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("client_pkcs12_base64")),
+				},
+			},
+
 			"insecure": schema.BoolAttribute{
 				Description: "Disables verification of the server's certificate chain and hostname. Defaults to `false`",
 				Optional:    true,
 			},
 
+			"pinned_cert_sha256": schema.ListAttribute{
+				Description: "The SHA-256 fingerprints, in hexadecimal, of the certificates or public keys the server is trusted with. " +
+					"When set, the certificate chain and hostname of the server are not verified, its certificate or public key " +
+					"must match one of the fingerprints instead.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ValueStringsAre(
+						stringvalidator.RegexMatches(fingerprintRegexp, "must be a SHA-256 fingerprint in hexadecimal"),
+					),
+					listvalidator.ConflictsWith(path.MatchRoot("insecure"), path.MatchRoot("ca_cert_pem"), path.MatchRoot("ca_cert_file")),
+				},
+			},
+
+			"check_revocation": schema.BoolAttribute{
+				Description: "Whether to fail when the certificate of the server is revoked. The revocation status is read from " +
+					"the OCSP response stapled to the TLS handshake or, when the server staples none, requested from the OCSP " +
+					"responder of the certificate, its CRL distribution points being used when it has no responder or the " +
+					"responder fails. The request also fails when the status cannot be checked. Defaults to `false`.",
+				Optional: true,
+			},
+
+			"proxy_url": schema.StringAttribute{
+				Description: "The URL of the proxy the request is sent through, with the `http` or `https` scheme. " +
+					"It overrides the proxy configured with the `HTTP_PROXY`, `HTTPS_PROXY` and `NO_PROXY` environment variables.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(proxyURLRegexp, "must be an http or https URL"),
+				},
+			},
+
+			"unix_socket": schema.StringAttribute{
+				Description: "The path of a Unix domain socket to send the request to, e.g. `/var/run/docker.sock`. " +
+					"The host of `url` is only used in the `Host` header.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("proxy_url")),
+				},
+			},
+
+			"ip_version": schema.StringAttribute{
+				Description: "The IP version of the connection, one of `any` (the default), `4` or `6`. " +
+					"The request fails when the host, or the proxy, has no address of the version, e.g. to check that a dual-stack endpoint responds over IPv6.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(ipVersionAny, ipVersion4, ipVersion6),
+					stringvalidator.ConflictsWith(path.MatchRoot("unix_socket")),
+				},
+			},
+
+			"dns_over_https": schema.StringAttribute{
+				Description: "The DNS-over-HTTPS endpoint the host of `url`, or of the proxy, is resolved with instead of the " +
+					"local resolver, `cloudflare`, `google` or the URL of an endpoint, e.g. `https://dns.quad9.net/dns-query`. " +
+					"Only the addresses of `ip_version` are looked up.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.Any(
+						stringvalidator.OneOf(dohCloudflare, dohGoogle),
+						stringvalidator.RegexMatches(proxyURLRegexp, "must be an http or https URL"),
+					),
+					stringvalidator.ConflictsWith(path.MatchRoot("unix_socket")),
+				},
+			},
+
 			"response_headers": schema.MapAttribute{
 				Description: `A map of response header field names and values.` +
 					` Duplicate headers are concatenated according to [RFC2616](https://www.w3.org/Protocols/rfc2616/rfc2616-sec4.html#sec4.2).`,
@@ -161,17 +643,92 @@ a 5xx-range (except 501) status code is received. For further details see
 				Computed:    true,
 			},
 
+			"response_headers_all": schema.MapAttribute{
+				Description: "A map of response header field names and the list of their values, " +
+					"the values of duplicate headers being kept apart instead of concatenated as in `response_headers`.",
+				ElementType: types.ListType{ElemType: types.StringType},
+				Computed:    true,
+			},
+
+			"tls_peer_certificates": schema.ListAttribute{
+				Description: "The certificate chain presented by the server, starting with its certificate. It is null when TLS is not used. " +
+					"Each certificate has a `subject`, an `issuer`, `sans` (its subject alternative names: DNS names, IP addresses, email addresses and URIs), " +
+					"`not_before` and `not_after` (its validity dates, in [RFC 3339](https://datatracker.ietf.org/doc/html/rfc3339) format) " +
+					"and `sha256` (its SHA-256 fingerprint, in hexadecimal).",
+				ElementType: types.ObjectType{AttrTypes: tlsCertificateAttrTypes},
+				Computed:    true,
+			},
+
 			"status_code": schema.Int64Attribute{
 				Description: `The HTTP response status code.`,
 				Computed:    true,
 			},
 
+			"request_id": schema.StringAttribute{
+				Description: "The request ID of the last attempt, null when no request ID header is set.",
+				Computed:    true,
+			},
+
+			"is_success": schema.BoolAttribute{
+				Description: "Whether the status code is one of the `success_status_codes`, or in the 2xx range when they are not set.",
+				Computed:    true,
+			},
+
+			"status_class": schema.StringAttribute{
+				Description: "The class of the status code: `1xx`, `2xx`, `3xx`, `4xx` or `5xx`.",
+				Computed:    true,
+			},
+
+			"duration_ms": schema.Int64Attribute{
+				Description: "The duration of the request in milliseconds, from the first attempt to the end of the response body, retries included.",
+				Computed:    true,
+			},
+
+			"dns_ms": schema.Int64Attribute{
+				Description: "The duration of the DNS lookup of the last attempt in milliseconds, `0` when the host was not looked up.",
+				Computed:    true,
+			},
+
+			"connect_ms": schema.Int64Attribute{
+				Description: "The duration of the TCP connection of the last attempt in milliseconds, `0` when a connection was reused.",
+				Computed:    true,
+			},
+
+			"tls_ms": schema.Int64Attribute{
+				Description: "The duration of the TLS handshake of the last attempt in milliseconds, `0` when TLS is not used or a connection was reused.",
+				Computed:    true,
+			},
+
+			"first_byte_ms": schema.Int64Attribute{
+				Description: "The time to the first response byte of the last attempt in milliseconds, connection included.",
+				Computed:    true,
+			},
+
 			"success_status_codes": schema.ListAttribute{
 				Description: "The list of status codes that are considered successful.",
 				Optional:    true,
 				ElementType: types.Int64Type,
 			},
 
+			"success_body_contains": schema.ListAttribute{
+				Description: "The substrings of which at least one is expected in the body of a successful response, " +
+					"otherwise the attempt fails and is retried according to the `retry` block, e.g. until a health check reports a ready state.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ConflictsWith(path.MatchRoot("forward_to")),
+				},
+			},
+
+			"retry_status_codes": schema.ListAttribute{
+				Description: "The list of status codes that trigger a retry, in addition to connection errors and 5xx-range " +
+					"(except 501) status codes. Ignored without the `retry` block, as the requests are not retried. A status " +
+					"code can't be both in `success_status_codes` and `retry_status_codes`.",
+				Optional:    true,
+				ElementType: types.Int64Type,
+			},
+
 			"keepers": schema.MapAttribute{
 				Description: "Arbitrary map of values that, when changed, will trigger recreation of " +
 					"resource.",
@@ -181,9 +738,237 @@ a 5xx-range (except 501) status code is received. For further details see
 					mapplanmodifier.RequiresReplaceIfConfigured(),
 				},
 			},
+
+			"refresh_triggers": schema.MapAttribute{
+				Description: "Arbitrary map of values that, when changed, will send the request again in place, " +
+					"without recreating the resource unlike `keepers`.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+
+			"refresh_policy": schema.StringAttribute{
+				Description: "Whether the request is made again on refresh, surfacing the changes of the response as drift: " +
+					"`never`, `always`, or `on_expiry` when the previous response has expired according to its `Cache-Control` " +
+					"`max-age` directive or its `Expires` header, responses without them being expired. Conditional requests are " +
+					"sent when the previous response has an `ETag` or `Last-Modified` header. By default, only the responses " +
+					"with these headers are refreshed, with a conditional request. The responses of the requests sent with " +
+					"`request_body_wo` are never refreshed, as the write-only body is not kept.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(refreshPolicyNever, refreshPolicyAlways, refreshPolicyOnExpiry),
+				},
+			},
+
+			"object_id": schema.StringAttribute{
+				Description: "The identifier of the object, from the `id_attribute` of the response to the create request " +
+					"or the last segment of its `Location` header. It is null when the `create` block is not set.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 		},
 
 		Blocks: map[string]schema.Block{
+			"create": lifecycleBlock("create", map[string]schema.Attribute{
+				"id_attribute": schema.StringAttribute{
+					Description: "The attribute of the JSON response body holding the `object_id`. Defaults to `id`.",
+					Optional:    true,
+				},
+			}),
+			"read":    lifecycleBlock("read", nil),
+			"update":  lifecycleBlock("update", nil),
+			"destroy": lifecycleBlock("destroy", nil),
+			"graphql": schema.SingleNestedBlock{
+				Description: "GraphQL request configuration. Configuring this block sends the query, its variables and " +
+					"operation name as a JSON document, with the `POST` method unless `method` is set. The decoded response " +
+					"is exported in `graphql_data` and `graphql_errors`.",
+				Attributes: map[string]schema.Attribute{
+					"query": schema.StringAttribute{
+						Description: "The GraphQL query or mutation.",
+						Required:    true,
+					},
+					"variables": schema.DynamicAttribute{
+						Description: "The variables of the query, e.g. `{ id = \"42\" }`.",
+						Optional:    true,
+					},
+					"operation_name": schema.StringAttribute{
+						Description: "The name of the operation to run, when the query contains several operations.",
+						Optional:    true,
+					},
+				},
+				Validators: []validator.Object{
+					objectvalidator.ConflictsWith(
+						path.MatchRoot("request_body"),
+						path.MatchRoot("request_body_file"),
+						path.MatchRoot("form_data"),
+					),
+				},
+			},
+			"soap": schema.SingleNestedBlock{
+				Description: "SOAP request configuration. Configuring this block wraps the body in a SOAP envelope and sets the " +
+					"`Content-Type` and action headers of the SOAP version, with the `POST` method unless `method` is set. The content " +
+					"of the response body is exported in `soap_body` and its fault in `soap_fault`.",
+				Attributes: map[string]schema.Attribute{
+					"action": schema.StringAttribute{
+						Description: "The SOAP action, sent in the `SOAPAction` header for SOAP 1.1 and as the `action` parameter " +
+							"of the `Content-Type` for SOAP 1.2.",
+						Optional: true,
+					},
+					"envelope_body": schema.StringAttribute{
+						Description: "The XML content of the `Body` element of the envelope, e.g. `<GetUser xmlns=\"urn:users\"><Id>42</Id></GetUser>`.",
+						Required:    true,
+					},
+					"envelope_header": schema.StringAttribute{
+						Description: "The XML content of the `Header` element of the envelope, e.g. a WS-Security header. " +
+							"The envelope has no `Header` element when not set.",
+						Optional: true,
+					},
+					"version": schema.StringAttribute{
+						Description: "The SOAP version, either `1.1` or `1.2`. Defaults to `1.1`.",
+						Optional:    true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(soap11, soap12),
+						},
+					},
+				},
+				Validators: []validator.Object{
+					objectvalidator.ConflictsWith(
+						path.MatchRoot("request_body"),
+						path.MatchRoot("request_body_wo"),
+						path.MatchRoot("request_body_file"),
+						path.MatchRoot("form_data"),
+						path.MatchRoot("graphql"),
+					),
+				},
+			},
+			"openapi": schema.SingleNestedBlock{
+				Description: "OpenAPI validation configuration. Configuring this block validates the method, the path and query " +
+					"parameters, the headers and the JSON body of the request against an operation of an " +
+					"[OpenAPI 3](https://spec.openapis.org/oas/v3.1.0) document before the request is made, " +
+					"the failed parts of the request being listed in the error. The request is validated when planned, unless the body is read from `request_body_file`, and again when made.",
+				Attributes: map[string]schema.Attribute{
+					"spec": schema.StringAttribute{
+						Description: "The OpenAPI document, in JSON or YAML format, or the `http://` or `https://` URL to fetch it from, " +
+							"with the TLS and proxy settings of the request, and its headers when served by the same origin.",
+						Required: true,
+					},
+					"operation_id": schema.StringAttribute{
+						Description: "The `operationId` of the operation the request is validated against, e.g. `createPet`.",
+						Required:    true,
+					},
+				},
+				Validators: []validator.Object{
+					objectvalidator.ConflictsWith(
+						path.MatchRoot("graphql"),
+						path.MatchRoot("soap"),
+					),
+				},
+			},
+			"hmac_signature": schema.SingleNestedBlock{
+				Description: "HMAC signature configuration. Configuring this block signs the request with a shared secret, " +
+					"as GitHub, Slack or Stripe sign their webhooks, the signature being set in a request header. " +
+					"The signature is computed once, the retries sending the same one.",
+				Attributes: map[string]schema.Attribute{
+					"secret": schema.StringAttribute{
+						Description: "The shared secret the signature is computed with.",
+						Required:    true,
+						Sensitive:   true,
+					},
+					"algorithm": schema.StringAttribute{
+						Description: "The hash function of the HMAC, one of `sha1`, `sha256` or `sha512`. Defaults to `sha256`.",
+						Optional:    true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(hmacSHA1, hmacSHA256, hmacSHA512),
+						},
+					},
+					"header": schema.StringAttribute{
+						Description: "The name of the request header set to the signature, e.g. `X-Hub-Signature-256`.",
+						Required:    true,
+					},
+					"string_to_sign_template": schema.StringAttribute{
+						Description: "The string the signature is computed over, `{body}`, `{timestamp}`, `{method}` and `{path}` being " +
+							"replaced by the request body, the current Unix time in seconds, the method and the path and query of the URL, " +
+							"e.g. `v0:{timestamp}:{body}` for Slack or `{timestamp}.{body}` for Stripe. Defaults to `{body}`.",
+						Optional: true,
+					},
+					"header_value_template": schema.StringAttribute{
+						Description: "The value of the header, `{signature}` and `{timestamp}` being replaced by the signature encoded " +
+							"in hexadecimal and the Unix time, e.g. `sha256={signature}` for GitHub, `v0={signature}` for Slack or " +
+							"`t={timestamp},v1={signature}` for Stripe. Defaults to `{signature}`.",
+						Optional: true,
+					},
+					"timestamp_header": schema.StringAttribute{
+						Description: "The name of a request header set to the Unix time the signature covers, " +
+							"e.g. `X-Slack-Request-Timestamp`.",
+						Optional: true,
+					},
+				},
+			},
+			"forward_to": schema.SingleNestedBlock{
+				Description: "Forwarding configuration. Configuring this block streams the response body, as received, to another URL " +
+					"instead of exporting it: `response_body`, `response_body_base64` and `body` are null. Only successful (2xx) responses are forwarded.",
+				Attributes: map[string]schema.Attribute{
+					"url": schema.StringAttribute{
+						Description: "The URL the response body is sent to.",
+						Required:    true,
+					},
+					"method": schema.StringAttribute{
+						Description: "The HTTP method of the forward request, either `PUT` or `POST`. Defaults to `PUT`.",
+						Optional:    true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(http.MethodPut, http.MethodPost),
+						},
+					},
+					"request_headers": schema.MapAttribute{
+						Description: "A map of request header field names and values of the forward request. " +
+							"The `Content-Type` and `Content-Encoding` of the response are forwarded unless set here.",
+						ElementType: types.StringType,
+						Optional:    true,
+						Sensitive:   true,
+					},
+					"max_bytes": schema.Int64Attribute{
+						Description: "The maximum size of the forwarded body in bytes. Larger responses fail the request.",
+						Optional:    true,
+						Validators: []validator.Int64{
+							int64validator.AtLeast(1),
+						},
+					},
+					"status_code": schema.Int64Attribute{
+						Description: "The HTTP response status code of the forward request.",
+						Computed:    true,
+					},
+				},
+				Validators: []validator.Object{
+					objectvalidator.ConflictsWith(
+						path.MatchRoot("graphql"),
+						path.MatchRoot("soap"),
+						path.MatchRoot("response_queries"),
+						path.MatchRoot("response_body_regex"),
+						path.MatchRoot("expected_response_body"),
+					),
+				},
+			},
+			"rate_limit": schema.SingleNestedBlock{
+				Description: "Rate limit configuration. Configuring this block spaces the attempts of the request, retries included, " +
+					"according to a token bucket, so that retries do not exceed the rate limits of the upstream API.",
+				Attributes: map[string]schema.Attribute{
+					"requests_per_second": schema.Float64Attribute{
+						Description: "The number of attempts allowed per second, at least `0.001`. For example, `0.5` allows one attempt every two seconds.",
+						Required:    true,
+						Validators: []validator.Float64{
+							float64validator.AtLeast(0.001),
+						},
+					},
+					"burst": schema.Int64Attribute{
+						Description: "The number of attempts allowed at once before the rate applies. Defaults to `1`.",
+						Optional:    true,
+						Validators: []validator.Int64{
+							int64validator.AtLeast(1),
+						},
+					},
+				},
+			},
 			"retry": schema.SingleNestedBlock{
 				Description: "Retry request configuration. By default there are no retries. Configuring this block will result in " +
 					"retries if an error is returned by the client (e.g., connection errors) or if a 5xx-range (except 501) status code is received. " +
@@ -211,6 +996,40 @@ a 5xx-range (except 501) status code is received. For further details see
 							int64validator.AtLeastSumOf(path.MatchRelative().AtParent().AtName("min_delay_ms")),
 						},
 					},
+					"backoff": schema.StringAttribute{
+						Description: "The strategy used to compute the delay between retry requests, one of `constant`, `linear` or `exponential`. " +
+							"The delay grows from `min_delay_ms` and is capped by `max_delay_ms`. Defaults to `exponential`.",
+						Optional: true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(backoffConstant, backoffLinear, backoffExponential),
+						},
+					},
+					"jitter": schema.BoolAttribute{
+						Description: "Randomize the delay between retry requests between `min_delay_ms` and the delay computed by the `backoff` strategy, " +
+							"to avoid many clients retrying in lockstep. Defaults to `false`",
+						Optional: true,
+					},
+
+					"respect_retry_after": schema.BoolAttribute{
+						Description: "Whether the delay requested by the `Retry-After` header of a 429 or 503 response is waited " +
+							"before the next retry request instead of the one of the `backoff` strategy, capped by `max_delay_ms`. Defaults to `true`.",
+						Optional: true,
+					},
+
+					"retry_on": schema.ListAttribute{
+						Description: "The classes of the errors retried, among `dns` (the host name cannot be resolved), `connect` " +
+							"(the connection is refused or reset, or fails otherwise), `tls` (the TLS handshake fails), `timeout` " +
+							"(the attempt times out) and `http_status` (the response status code is retried, or the response " +
+							"body fails `success_body_contains`), e.g. `[\"connect\", " +
+							"\"http_status\"]` to keep retrying while a service starts but fail fast on certificate errors. The other " +
+							"errors fail the request right away. " +
+							"Defaults to all the classes.",
+						ElementType: types.StringType,
+						Optional:    true,
+						Validators: []validator.List{
+							listvalidator.ValueStringsAre(stringvalidator.OneOf(retryOnClasses...)),
+						},
+					},
 				},
 			},
 		},
@@ -222,9 +1041,101 @@ func (d *httpResource) Configure(ctx context.Context, req resource.ConfigureRequ
 	if req.ProviderData == nil {
 		return
 	}
+
+	data, ok := req.ProviderData.(*providerdata.ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerdata.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.providerData = data
 }
 
 func (d *httpResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var refreshPolicy types.String
+	diags := req.State.GetAttribute(ctx, path.Root("refresh_policy"), &refreshPolicy)
+	resp.Diagnostics.Append(diags...)
+
+	var readRequest types.Object
+	diags = req.State.GetAttribute(ctx, path.Root("read"), &readRequest)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	switch refreshPolicy.ValueString() {
+	case refreshPolicyNever:
+		return
+	case refreshPolicyOnExpiry:
+		expiry, diags := getResponseExpiry(ctx, req.Private)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() || time.Now().Before(expiry) {
+			return
+		}
+	}
+
+	if !readRequest.IsNull() {
+		d.readObject(ctx, req, resp)
+		return
+	}
+
+	validators, diags := getCacheValidators(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+
+	// Without refresh policy, responses without cache validators are not
+	// refreshed, resp.State already holds the prior state.
+	if resp.Diagnostics.HasError() || (validators == nil && refreshPolicy.IsNull()) {
+		return
+	}
+
+	var model httpResourceModel
+	diags = req.State.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || (refreshPolicy.IsNull() && validators.URL != model.URL.ValueString()) {
+		return
+	}
+
+	// The write-only request body is not kept in the state, the request
+	// cannot be made again without it.
+	if !model.RequestBodyWOVersion.IsNull() {
+		return
+	}
+
+	// The response is refreshed with a conditional request when it has cache
+	// validators, keeping the previous response when it has not changed.
+	model.validators = validators
+
+	var refreshDiags diag.Diagnostics
+	model.applyProviderData(d.providerData)
+	model.typeName = "utilities_http"
+	model.read(ctx, &refreshDiags)
+	if refreshDiags.HasError() {
+		for _, err := range refreshDiags.Errors() {
+			resp.Diagnostics.AddWarning(
+				"Error refreshing response",
+				fmt.Sprintf("The response could not be refreshed, the previous one is kept.\n\n%s", err.Detail()),
+			)
+		}
+		return
+	}
+	resp.Diagnostics.Append(refreshDiags...)
+
+	diags = setCacheValidators(ctx, resp.Private, model.validators)
+	resp.Diagnostics.Append(diags...)
+
+	diags = setResponseExpiry(ctx, resp.Private, model.expiry)
+	resp.Diagnostics.Append(diags...)
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+// readObject refreshes the response with the request of the read block,
+// removing the resource when the object no longer exists.
+func (d *httpResource) readObject(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	var model httpResourceModel
 	diags := req.State.Get(ctx, &model)
 	resp.Diagnostics.Append(diags...)
@@ -232,10 +1143,76 @@ func (d *httpResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
+	request := lifecycleRequest(ctx, model.Read, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var refreshDiags diag.Diagnostics
+	model.applyProviderData(d.providerData)
+	model.typeName = "utilities_http"
+	successStatusCodes := model.acceptObjectGone()
+	model.send(ctx, request, &refreshDiags)
+	model.SuccessStatusCodes = successStatusCodes
+	if refreshDiags.HasError() {
+		for _, err := range refreshDiags.Errors() {
+			resp.Diagnostics.AddWarning(
+				"Error refreshing response",
+				fmt.Sprintf("The response could not be refreshed, the previous one is kept.\n\n%s", err.Detail()),
+			)
+		}
+		return
+	}
+	resp.Diagnostics.Append(refreshDiags...)
+
+	if model.isObjectGone() {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	diags = setResponseExpiry(ctx, resp.Private, model.expiry)
+	resp.Diagnostics.Append(diags...)
+
 	diags = resp.State.Set(ctx, model)
 	resp.Diagnostics.Append(diags...)
 }
 
+func (r *httpResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	deferUnknownConfig(req, resp)
+
+	// The request is validated against the OpenAPI document once it is
+	// known, so that malformed requests fail the plan.
+	if req.Plan.Raw.IsNull() || !req.Config.Raw.IsFullyKnown() {
+		return
+	}
+
+	var model httpResourceModel
+	diags := req.Config.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || model.OpenAPI.IsNull() || (!model.Enabled.IsNull() && !model.Enabled.ValueBool()) {
+		return
+	}
+
+	if !model.RequestBodyWO.IsNull() {
+		model.RequestBody = model.RequestBodyWO
+	}
+
+	request := model.plannedRequest(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The document is fetched as the request is made, e.g. through the proxy
+	// and with the CA certificate of the resource.
+	transport := model.transport(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := &http.Client{Transport: transport, Timeout: model.attemptTimeout()}
+	model.checkOpenAPI(ctx, client, request, &resp.Diagnostics)
+}
+
 func (r *httpResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var model httpResourceModel
 	diags := req.Config.Get(ctx, &model)
@@ -244,7 +1221,25 @@ func (r *httpResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
-	model.read(ctx, &resp.Diagnostics)
+	request := model.createRequest(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	model.applyProviderData(r.providerData)
+	model.typeName = "utilities_http"
+	if request != nil {
+		model.send(ctx, &request.lifecycleRequestModel, &resp.Diagnostics)
+	} else {
+		model.read(ctx, &resp.Diagnostics)
+	}
+	model.setObjectID(request)
+
+	diags = setCacheValidators(ctx, resp.Private, model.validators)
+	resp.Diagnostics.Append(diags...)
+
+	diags = setResponseExpiry(ctx, resp.Private, model.expiry)
+	resp.Diagnostics.Append(diags...)
 
 	diags = resp.State.Set(ctx, model)
 	resp.Diagnostics.Append(diags...)
@@ -252,13 +1247,37 @@ func (r *httpResource) Create(ctx context.Context, req resource.CreateRequest, r
 
 func (r *httpResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	var model httpResourceModel
-	diags := req.State.Get(ctx, &model)
+	diags := req.Plan.Get(ctx, &model)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	model.read(ctx, &resp.Diagnostics)
+	// Write-only values are only available in the configuration.
+	diags = req.Config.GetAttribute(ctx, path.Root("request_body_wo"), &model.RequestBodyWO)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	request := lifecycleRequest(ctx, model.Update, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The configuration changed, the request is not conditional.
+	model.applyProviderData(r.providerData)
+	model.typeName = "utilities_http"
+	model.send(ctx, request, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = setCacheValidators(ctx, resp.Private, model.validators)
+	resp.Diagnostics.Append(diags...)
+
+	diags = setResponseExpiry(ctx, resp.Private, model.expiry)
+	resp.Diagnostics.Append(diags...)
 
 	diags = resp.State.Set(ctx, model)
 	resp.Diagnostics.Append(diags...)
@@ -270,6 +1289,19 @@ func (r *httpResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	// Without the destroy block, the resource is only removed from the state.
+	request := lifecycleRequest(ctx, data.Destroy, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() || request == nil {
+		return
+	}
+
+	// The object already deleted, e.g. out of band, is not an error.
+	data.applyProviderData(r.providerData)
+	data.typeName = "utilities_http"
+	successStatusCodes := data.acceptObjectGone()
+	data.send(ctx, request, &resp.Diagnostics)
+	data.SuccessStatusCodes = successStatusCodes
 }
 
 func (r *httpResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
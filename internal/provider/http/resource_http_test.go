@@ -0,0 +1,643 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package http_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+
+	utilitieshttp "terraform-provider-utilities/internal/provider/http"
+	"terraform-provider-utilities/internal/testserver"
+)
+
+func TestResource_ConditionalRequests(t *testing.T) {
+	var version, notModified atomic.Int64
+	version.Store(1)
+
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		etag := fmt.Sprintf(`"v%d"`, version.Load())
+		w.Header().Set("ETag", etag)
+
+		if r.Header.Get("If-None-Match") == etag {
+			notModified.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(http.StatusOK)
+		_, _ = fmt.Fprintf(w, "v%d", version.Load())
+	}))
+	defer svr.Close()
+
+	config := fmt.Sprintf(`
+		resource "utilities_http" "test" {
+			url = %q
+		}`, svr.URL)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utilities_http.test", "response_body", "v1"),
+					resource.TestCheckResourceAttr("utilities_http.test", "response_headers.Etag", `"v1"`),
+				),
+			},
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utilities_http.test", "response_body", "v1"),
+					resource.TestCheckResourceAttr("utilities_http.test", "status_code", "200"),
+					func(*terraform.State) error {
+						if notModified.Load() == 0 {
+							return fmt.Errorf("expected the response to be refreshed with a conditional request")
+						}
+						return nil
+					},
+				),
+			},
+			{
+				PreConfig: func() {
+					version.Store(2)
+				},
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utilities_http.test", "response_body", "v2"),
+					resource.TestCheckResourceAttr("utilities_http.test", "response_headers.Etag", `"v2"`),
+				),
+			},
+		},
+	})
+}
+
+func TestResource_WriteOnlyRequestBody(t *testing.T) {
+	var body atomic.Value
+
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		body.Store(string(data))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer svr.Close()
+
+	config := func(requestBody string, version int) string {
+		return fmt.Sprintf(`
+			resource "utilities_http" "test" {
+				url                     = %q
+				method                  = "POST"
+				request_body_wo         = %q
+				request_body_wo_version = %d
+			}`, svr.URL, requestBody, version)
+	}
+
+	checkBody := func(expected string) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			if actual, _ := body.Load().(string); actual != expected {
+				return fmt.Errorf("expected the request body %q, got %q", expected, actual)
+			}
+			return nil
+		}
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config("secret", 1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("utilities_http.test", "request_body_wo"),
+					resource.TestCheckResourceAttr("utilities_http.test", "request_body_wo_version", "1"),
+					checkBody("secret"),
+				),
+			},
+			{
+				Config: config("rotated", 2),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("utilities_http.test", "request_body_wo"),
+					resource.TestCheckResourceAttr("utilities_http.test", "request_body_wo_version", "2"),
+					checkBody("rotated"),
+				),
+			},
+		},
+	})
+}
+
+func TestResource_WriteOnlyRequestBodyRefresh(t *testing.T) {
+	var mu sync.Mutex
+	var bodies []string
+
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		bodies = append(bodies, string(data))
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer svr.Close()
+
+	config := fmt.Sprintf(`
+		resource "utilities_http" "test" {
+			url                     = %q
+			method                  = "POST"
+			refresh_policy          = "always"
+			request_body_wo         = "secret"
+			request_body_wo_version = 1
+		}`, svr.URL)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+			},
+			{
+				// The request is not sent again without its body on refresh.
+				RefreshState: true,
+				Check: func(*terraform.State) error {
+					mu.Lock()
+					defer mu.Unlock()
+
+					if len(bodies) != 1 || bodies[0] != "secret" {
+						return fmt.Errorf("expected a single request with the write-only body, got %q", bodies)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestResource_WriteOnlyRequestBodyConflicts(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "utilities_http" "test" {
+						url             = "https://example.com"
+						method          = "POST"
+						request_body_wo = "secret"
+						form_data       = { name = "value" }
+					}`,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+			{
+				Config: `
+					resource "utilities_http" "test" {
+						url             = "https://example.com"
+						request_body_wo = "secret"
+
+						graphql {
+							query = "{ viewer { login } }"
+						}
+					}`,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+		},
+	})
+}
+
+func TestResource_OpenAPIPlan(t *testing.T) {
+	svr := testserver.New(t, testserver.Config{
+		TLS: true,
+		Routes: map[string]testserver.Route{
+			"POST /pets": {Body: "{}"},
+			"GET /openapi.yaml": {
+				Handler: func(w http.ResponseWriter, r *http.Request) {
+					// The document is private, fetched with the headers of the request.
+					if r.Header.Get("X-Api-Key") != "secret" {
+						w.WriteHeader(http.StatusUnauthorized)
+						return
+					}
+					_, _ = w.Write([]byte(`
+openapi: 3.0.0
+paths:
+  /pets:
+    post:
+      operationId: createPet
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [name]
+`))
+				},
+			},
+		},
+	})
+
+	config := func(body string) string {
+		return fmt.Sprintf(`
+			resource "utilities_http" "test" {
+				url               = "%s/pets"
+				method            = "POST"
+				ca_cert_pem       = file(%q)
+				request_headers   = { X-Api-Key = "secret" }
+				request_body_json = %s
+
+				openapi {
+					spec         = "%s/openapi.yaml"
+					operation_id = "createPet"
+				}
+			}`, svr.URL, svr.Certificate.CertFile, body, svr.URL)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: config(`{ name = "Rex" }`),
+				Check:  resource.TestCheckResourceAttr("utilities_http.test", "status_code", "200"),
+			},
+			{
+				Config:      config(`{ age = 3 }`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`body: missing required property "name"`),
+			},
+		},
+	})
+}
+
+func BenchmarkResource_Read(b *testing.B) {
+	ctx := context.Background()
+	r := utilitieshttp.NewHttpResource()
+
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	for name, value := range map[string]string{
+		"id":            "https://example.com",
+		"url":           "https://example.com",
+		"response_body": "{}",
+	} {
+		if diags := state.SetAttribute(ctx, path.Root(name), value); diags.HasError() {
+			b.Fatalf("error setting state: %v", diags)
+		}
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		resp := fwresource.ReadResponse{State: state}
+		r.Read(ctx, fwresource.ReadRequest{State: state}, &resp)
+		if resp.Diagnostics.HasError() {
+			b.Fatalf("error reading: %v", resp.Diagnostics)
+		}
+	}
+}
+
+// objectValue returns the object of the schema type with the given attribute
+// values, the others being null.
+func objectValue(t *testing.T, schemaType tftypes.Type, values map[string]tftypes.Value) tftypes.Value {
+	objectType, ok := schemaType.(tftypes.Object)
+	if !ok {
+		t.Fatalf("expected an object type, got %s", schemaType)
+	}
+
+	attributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attributeType := range objectType.AttributeTypes {
+		value, ok := values[name]
+		if !ok {
+			value = tftypes.NewValue(attributeType, nil)
+		}
+		attributes[name] = value
+	}
+
+	return tftypes.NewValue(objectType, attributes)
+}
+
+func TestResource_DeferredConfigUnknown(t *testing.T) {
+	ctx := context.Background()
+	r, ok := utilitieshttp.NewHttpResource().(fwresource.ResourceWithModifyPlan)
+	if !ok {
+		t.Fatalf("expected the resource to modify plans")
+	}
+
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+	schemaType := schemaResp.Schema.Type().TerraformType(ctx)
+
+	for name, tc := range map[string]struct {
+		url             tftypes.Value
+		deferralAllowed bool
+		expectDeferred  bool
+	}{
+		"unknown":             {tftypes.NewValue(tftypes.String, tftypes.UnknownValue), true, true},
+		"unknown-no-deferral": {tftypes.NewValue(tftypes.String, tftypes.UnknownValue), false, false},
+		"known":               {tftypes.NewValue(tftypes.String, "https://example.com"), true, false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			value := objectValue(t, schemaType, map[string]tftypes.Value{"url": tc.url})
+
+			req := fwresource.ModifyPlanRequest{
+				Config:             tfsdk.Config{Schema: schemaResp.Schema, Raw: value},
+				Plan:               tfsdk.Plan{Schema: schemaResp.Schema, Raw: value},
+				State:              tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaType, nil)},
+				ClientCapabilities: fwresource.ModifyPlanClientCapabilities{DeferralAllowed: tc.deferralAllowed},
+			}
+			resp := fwresource.ModifyPlanResponse{Plan: req.Plan}
+			r.ModifyPlan(ctx, req, &resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+			if deferred := resp.Deferred != nil; deferred != tc.expectDeferred {
+				t.Errorf("expected deferred to be %t, got %t", tc.expectDeferred, deferred)
+			}
+			if resp.Deferred != nil && resp.Deferred.Reason != fwresource.DeferredReasonResourceConfigUnknown {
+				t.Errorf("unexpected deferred reason %s", resp.Deferred.Reason)
+			}
+		})
+	}
+}
+
+func TestResource_Lifecycle(t *testing.T) {
+	var mu sync.Mutex
+	items := map[string]string{}
+
+	svr := testserver.New(t, testserver.Config{
+		Routes: map[string]testserver.Route{
+			"POST /items": {Handler: func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				mu.Lock()
+				items["42"] = string(body)
+				mu.Unlock()
+				w.Header().Set("Location", "/items/42")
+				w.WriteHeader(http.StatusCreated)
+			}},
+			"GET /items/{id}": {Handler: func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				item, ok := items[r.PathValue("id")]
+				mu.Unlock()
+				if !ok {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				_, _ = w.Write([]byte(item))
+			}},
+			"PUT /items/{id}": {Handler: func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				mu.Lock()
+				items[r.PathValue("id")] = string(body)
+				mu.Unlock()
+				_, _ = w.Write(body)
+			}},
+			"DELETE /items/{id}": {Handler: func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				delete(items, r.PathValue("id"))
+				mu.Unlock()
+				w.WriteHeader(http.StatusNoContent)
+			}},
+		},
+	})
+
+	config := func(name string) string {
+		return fmt.Sprintf(`
+						resource "utilities_http" "http_test" {
+							url = "%[1]s/items"
+
+							create {
+								method       = "POST"
+								request_body = "{\"name\": \"%[2]s\"}"
+							}
+
+							read {
+								method = "GET"
+								url    = "%[1]s/items/{id}"
+							}
+
+							update {
+								method       = "PUT"
+								url          = "%[1]s/items/{id}"
+								request_body = "{\"id\": \"{id}\", \"name\": \"%[2]s\"}"
+							}
+
+							destroy {
+								method = "DELETE"
+								url    = "%[1]s/items/{id}"
+							}
+						}`, svr.URL, name)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		CheckDestroy: func(*terraform.State) error {
+			if requests := svr.Requests("DELETE /items/{id}"); requests != 1 {
+				return fmt.Errorf("expected 1 delete request, got %d", requests)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if len(items) != 0 {
+				return fmt.Errorf("expected the item to be deleted, got %v", items)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config("foo"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("utilities_http.http_test", "object_id", "42"),
+					resource.TestCheckResourceAttr("utilities_http.http_test", "status_code", "201"),
+				),
+			},
+			{
+				Config: config("bar"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("utilities_http.http_test", "object_id", "42"),
+					resource.TestCheckResourceAttr("utilities_http.http_test", "response_body", `{"id": "42", "name": "bar"}`),
+					func(*terraform.State) error {
+						if requests := svr.Requests("PUT /items/{id}"); requests != 1 {
+							return fmt.Errorf("expected 1 update request, got %d", requests)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				// The object deleted out of band is created again.
+				PreConfig: func() {
+					mu.Lock()
+					delete(items, "42")
+					mu.Unlock()
+				},
+				Config: config("bar"),
+				Check: func(*terraform.State) error {
+					if requests := svr.Requests("POST /items"); requests != 2 {
+						return fmt.Errorf("expected 2 create requests, got %d", requests)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestResource_LifecycleDestroyGone(t *testing.T) {
+	svr := testserver.New(t, testserver.Config{
+		Routes: map[string]testserver.Route{
+			"POST /items": {
+				Status:  http.StatusCreated,
+				Headers: map[string]string{"Location": "/items/42"},
+			},
+			"GET /items/{id}":    {Body: `{"id": "42"}`},
+			"DELETE /items/{id}": {Status: http.StatusNotFound},
+		},
+	})
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		CheckDestroy: func(*terraform.State) error {
+			if requests := svr.Requests("DELETE /items/{id}"); requests != 1 {
+				return fmt.Errorf("expected 1 delete request, got %d", requests)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				// The object deleted out of band does not fail the destroy.
+				Config: fmt.Sprintf(`
+						resource "utilities_http" "http_test" {
+							url                  = "%[1]s/items"
+							success_status_codes = [200, 201, 204]
+
+							create {
+								method = "POST"
+							}
+
+							read {
+								url = "%[1]s/items/{id}"
+							}
+
+							destroy {
+								method = "DELETE"
+								url    = "%[1]s/items/{id}"
+							}
+						}`, svr.URL),
+				Check: resource.TestCheckResourceAttr("utilities_http.http_test", "object_id", "42"),
+			},
+		},
+	})
+}
+
+func TestResource_RefreshPolicy(t *testing.T) {
+	for policy, expectRefresh := range map[string]bool{
+		"always":    true,
+		"on_expiry": true,
+		"never":     false,
+	} {
+		t.Run(policy, func(t *testing.T) {
+			var version atomic.Int64
+			version.Store(1)
+
+			svr := testserver.New(t, testserver.Config{
+				Routes: map[string]testserver.Route{
+					"GET /": {Handler: func(w http.ResponseWriter, r *http.Request) {
+						w.Header().Set("Cache-Control", "max-age=0")
+						_, _ = fmt.Fprintf(w, "%d.0.0", version.Load())
+					}},
+				},
+			})
+
+			expected := "1.0.0"
+			if expectRefresh {
+				expected = "2.0.0"
+			}
+
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: protoV6ProviderFactories(),
+				Steps: []resource.TestStep{
+					{
+						Config: fmt.Sprintf(`
+							resource "utilities_http" "http_test" {
+								url            = %q
+								refresh_policy = %q
+							}`, svr.URL, policy),
+						Check: resource.TestCheckResourceAttr("utilities_http.http_test", "response_body", "1.0.0"),
+					},
+					{
+						PreConfig:    func() { version.Store(2) },
+						RefreshState: true,
+						Check:        resource.TestCheckResourceAttr("utilities_http.http_test", "response_body", expected),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestResource_RefreshTriggers(t *testing.T) {
+	svr := testserver.New(t, testserver.Config{
+		Routes: map[string]testserver.Route{
+			"GET /": {Body: "1.0.0"},
+		},
+	})
+
+	config := func(trigger string) string {
+		return fmt.Sprintf(`
+						resource "utilities_http" "http_test" {
+							url = %q
+							refresh_triggers = {
+								deployment = %q
+							}
+						}`, svr.URL, trigger)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: config("1"),
+				Check:  resource.TestCheckResourceAttr("utilities_http.http_test", "response_body", "1.0.0"),
+			},
+			{
+				Config: config("2"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("utilities_http.http_test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: func(*terraform.State) error {
+					if requests := svr.Requests("GET /"); requests != 2 {
+						return fmt.Errorf("expected 2 requests, got %d", requests)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,657 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	defaultOpenAPITimeout     = 10000
+	defaultOpenAPIIDAttribute = "id"
+)
+
+var _ resource.Resource = (*openAPIObjectResource)(nil)
+var _ resource.ResourceWithModifyPlan = (*openAPIObjectResource)(nil)
+
+func NewOpenAPIObjectResource() resource.Resource {
+	return &openAPIObjectResource{}
+}
+
+type openAPIObjectResource struct{}
+type openAPIObjectResourceModel struct {
+	ID             types.String  `tfsdk:"id"`
+	SpecURL        types.String  `tfsdk:"spec_url"`
+	Path           types.String  `tfsdk:"path"`
+	ItemPath       types.String  `tfsdk:"item_path"`
+	Schema         types.String  `tfsdk:"schema"`
+	BaseURL        types.String  `tfsdk:"base_url"`
+	IDAttribute    types.String  `tfsdk:"id_attribute"`
+	Body           types.Dynamic `tfsdk:"body"`
+	RequestHeaders types.Map     `tfsdk:"request_headers"`
+	RequestTimeout types.Int64   `tfsdk:"request_timeout_ms"`
+	Response       types.Dynamic `tfsdk:"response"`
+}
+
+func (r *openAPIObjectResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_openapi_object"
+}
+
+func (r *openAPIObjectResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: `
+The ` + "`openapi_object`" + ` resource manages an object of a REST API described by an
+[OpenAPI 3](https://spec.openapis.org/oas/v3.1.0) document, in JSON or YAML format.
+
+The operations are mapped from the paths of the document:
+
+- the object is created with ` + "`POST`" + ` on ` + "`path`" + `, e.g. ` + "`/pets`" + `, and identified by the
+  ` + "`id_attribute`" + ` of the response.
+- it is read with ` + "`GET`" + `, updated with ` + "`PUT`" + `, or ` + "`PATCH`" + ` when the document has no ` + "`PUT`" + ` operation,
+  and deleted with ` + "`DELETE`" + ` on ` + "`item_path`" + `, e.g. ` + "`/pets/{petId}`" + `.
+
+The attributes of ` + "`body`" + ` are compared with the object on refresh, and updated when they have drifted.
+Attributes missing from the responses, and the ` + "`writeOnly`" + ` properties of ` + "`schema`" + `, are not compared.
+`,
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The identifier of the object.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"spec_url": schema.StringAttribute{
+				Description: "The URL of the OpenAPI document.",
+				Required:    true,
+			},
+
+			"path": schema.StringAttribute{
+				Description: "The path of the collection the object belongs to in the OpenAPI document, e.g. `/pets`.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
+			"item_path": schema.StringAttribute{
+				Description: "The path of the object in the OpenAPI document, e.g. `/pets/{petId}`. " +
+					"Defaults to the only path made of `path` followed by a path parameter.",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
+			"schema": schema.StringAttribute{
+				Description: "The name of the schema of the object in the components of the OpenAPI document. " +
+					"Its `readOnly` properties may not be set in `body`, and its `writeOnly` properties are not compared on refresh.",
+				Optional: true,
+			},
+
+			"base_url": schema.StringAttribute{
+				Description: "The URL the paths are relative to. Defaults to the URL of the first server of the OpenAPI document.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
+			"id_attribute": schema.StringAttribute{
+				Description: fmt.Sprintf("The attribute of the creation response holding the identifier of the object. "+
+					"When the response has none, the last segment of its `Location` header is used. Defaults to `%s`.", defaultOpenAPIIDAttribute),
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
+			"body": schema.DynamicAttribute{
+				Description: "The object, sent as JSON.",
+				Required:    true,
+			},
+
+			"request_headers": schema.MapAttribute{
+				Description: "A map of request header field names and values, sent with every request, e.g. to authenticate them.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Sensitive:   true,
+			},
+
+			"request_timeout_ms": schema.Int64Attribute{
+				Description: fmt.Sprintf("The time allowed for each operation in milliseconds. Defaults to `%d`.", defaultOpenAPITimeout),
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(defaultOpenAPITimeout),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+
+			"response": schema.DynamicAttribute{
+				Description: "The object as last returned by the API.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *openAPIObjectResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+}
+
+func (r *openAPIObjectResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var model openAPIObjectResourceModel
+	diags := req.State.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	found := model.read(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *openAPIObjectResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	deferUnknownConfig(req, resp)
+}
+
+func (r *openAPIObjectResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var model openAPIObjectResourceModel
+	diags := req.Plan.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	model.create(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *openAPIObjectResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var model openAPIObjectResourceModel
+	diags := req.Plan.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	model.update(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *openAPIObjectResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var model openAPIObjectResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	model.delete(ctx, &resp.Diagnostics)
+}
+
+// openAPIObject sends the requests of the operations of an object.
+type openAPIObject struct {
+	spec           *openAPISpec
+	headers        map[string]string
+	baseURL        string
+	collectionPath string
+	itemPath       string
+	schema         *openAPISchema
+}
+
+// open retrieves the OpenAPI document and resolves the paths of the object.
+func (model *openAPIObjectResourceModel) open(ctx context.Context, diagnostics *diag.Diagnostics) *openAPIObject {
+	object := &openAPIObject{collectionPath: model.Path.ValueString()}
+
+	diags := model.RequestHeaders.ElementsAs(ctx, &object.headers, false)
+	diagnostics.Append(diags...)
+	if diagnostics.HasError() {
+		return nil
+	}
+
+	status, data, _, err := object.do(ctx, http.MethodGet, model.SpecURL.ValueString(), nil)
+	if err == nil && status != http.StatusOK {
+		err = fmt.Errorf("unexpected HTTP status %d", status)
+	}
+	if err != nil {
+		diagnostics.AddError(
+			"Error retrieving OpenAPI document",
+			fmt.Sprintf("Error retrieving OpenAPI document: %s", err),
+		)
+		return nil
+	}
+
+	object.spec, err = parseOpenAPISpec(data)
+	if err != nil {
+		diagnostics.AddError(
+			"Invalid OpenAPI document",
+			fmt.Sprintf("Error parsing OpenAPI document: %s", err),
+		)
+		return nil
+	}
+
+	object.itemPath = model.ItemPath.ValueString()
+	if model.ItemPath.IsNull() {
+		object.itemPath, err = object.spec.itemPath(object.collectionPath)
+	}
+	if err == nil {
+		object.baseURL = model.BaseURL.ValueString()
+		if model.BaseURL.IsNull() {
+			object.baseURL, err = object.spec.baseURL(model.SpecURL.ValueString())
+		}
+	}
+	if err == nil && !model.Schema.IsNull() {
+		objectSchema, ok := object.spec.Components.Schemas[model.Schema.ValueString()]
+		if !ok {
+			err = fmt.Errorf("the document has no %q schema", model.Schema.ValueString())
+		}
+		object.schema = &objectSchema
+	}
+	if err != nil {
+		diagnostics.AddError(
+			"Invalid OpenAPI document",
+			fmt.Sprintf("Error mapping the operations of the object: %s", err),
+		)
+		return nil
+	}
+
+	return object
+}
+
+// operation returns the URL of the operation, or an error when the document
+// does not define it.
+func (object *openAPIObject) operation(method, operationPath, id string) (string, error) {
+	if !object.spec.hasOperation(operationPath, method) {
+		return "", fmt.Errorf("the OpenAPI document has no %s operation on %s", method, operationPath)
+	}
+
+	return object.baseURL + expandItemPath(operationPath, id), nil
+}
+
+func (object *openAPIObject) do(ctx context.Context, method, url string, body []byte) (int, []byte, http.Header, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, url, reader)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+
+	request.Header.Set("Accept", "application/json")
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+
+	for name, value := range object.headers {
+		request.Header.Set(name, value)
+	}
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	defer response.Body.Close()
+
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+
+	return response.StatusCode, data, response.Header, nil
+}
+
+// send runs an operation with the body of the model, and returns the response
+// body.
+func (model *openAPIObjectResourceModel) send(ctx context.Context, object *openAPIObject, method, operationPath string) ([]byte, http.Header, error) {
+	operationURL, err := object.operation(method, operationPath, model.ID.ValueString())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	body, err := model.encodeBody(object)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	status, data, header, err := object.do(ctx, method, operationURL, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if status < 200 || status > 299 {
+		return nil, nil, fmt.Errorf("unexpected HTTP status %d: %s", status, data)
+	}
+
+	return data, header, nil
+}
+
+// encodeBody encodes the body of the model, rejecting the readOnly
+// properties of the schema.
+func (model *openAPIObjectResourceModel) encodeBody(object *openAPIObject) ([]byte, error) {
+	body, err := valueToJSON(model.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	properties, ok := body.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("the body must be an object")
+	}
+
+	if object.schema != nil {
+		for name := range properties {
+			if object.schema.Properties[name].ReadOnly {
+				return nil, fmt.Errorf("the %q property is read-only", name)
+			}
+		}
+	}
+
+	return json.Marshal(body)
+}
+
+func (model *openAPIObjectResourceModel) timeout() time.Duration {
+	return time.Duration(model.RequestTimeout.ValueInt64()) * time.Millisecond
+}
+
+func (model *openAPIObjectResourceModel) create(ctx context.Context, diagnostics *diag.Diagnostics) {
+	ctx, cancel := context.WithTimeout(ctx, model.timeout())
+	defer cancel()
+
+	object := model.open(ctx, diagnostics)
+	if diagnostics.HasError() {
+		return
+	}
+
+	data, header, err := model.send(ctx, object, http.MethodPost, object.collectionPath)
+	if err != nil {
+		diagnostics.AddError(
+			"Error creating object",
+			fmt.Sprintf("Error creating object: %s", err),
+		)
+		return
+	}
+
+	idAttribute := defaultOpenAPIIDAttribute
+	if !model.IDAttribute.IsNull() {
+		idAttribute = model.IDAttribute.ValueString()
+	}
+
+	id, err := extractID(data, header, idAttribute)
+	if err != nil {
+		diagnostics.AddError(
+			"Error creating object",
+			fmt.Sprintf("Error extracting the identifier of the object: %s", err),
+		)
+		return
+	}
+
+	model.ID = types.StringValue(id)
+	model.Response = decodeResponse(data)
+}
+
+// read refreshes the object and the drifted attributes of the body, and
+// reports whether the object still exists.
+func (model *openAPIObjectResourceModel) read(ctx context.Context, diagnostics *diag.Diagnostics) bool {
+	ctx, cancel := context.WithTimeout(ctx, model.timeout())
+	defer cancel()
+
+	object := model.open(ctx, diagnostics)
+	if diagnostics.HasError() {
+		return false
+	}
+
+	operationURL, err := object.operation(http.MethodGet, object.itemPath, model.ID.ValueString())
+	if err != nil {
+		diagnostics.AddError(
+			"Error reading object",
+			fmt.Sprintf("Error reading object: %s", err),
+		)
+		return false
+	}
+
+	status, data, _, err := object.do(ctx, http.MethodGet, operationURL, nil)
+	if err == nil && status != http.StatusOK && status != http.StatusNotFound && status != http.StatusGone {
+		err = fmt.Errorf("unexpected HTTP status %d: %s", status, data)
+	}
+	if err != nil {
+		diagnostics.AddError(
+			"Error reading object",
+			fmt.Sprintf("Error reading object: %s", err),
+		)
+		return false
+	}
+
+	if status == http.StatusNotFound || status == http.StatusGone {
+		return false
+	}
+
+	body, err := refreshBody(model.Body, data, object.schema)
+	if err != nil {
+		diagnostics.AddError(
+			"Error reading object",
+			fmt.Sprintf("Error comparing object: %s", err),
+		)
+		return false
+	}
+
+	model.Body = body
+	model.Response = decodeResponse(data)
+	return true
+}
+
+func (model *openAPIObjectResourceModel) update(ctx context.Context, diagnostics *diag.Diagnostics) {
+	ctx, cancel := context.WithTimeout(ctx, model.timeout())
+	defer cancel()
+
+	object := model.open(ctx, diagnostics)
+	if diagnostics.HasError() {
+		return
+	}
+
+	method := http.MethodPut
+	if !object.spec.hasOperation(object.itemPath, method) {
+		method = http.MethodPatch
+	}
+
+	data, _, err := model.send(ctx, object, method, object.itemPath)
+	if err != nil {
+		diagnostics.AddError(
+			"Error updating object",
+			fmt.Sprintf("Error updating object: %s", err),
+		)
+		return
+	}
+
+	model.Response = decodeResponse(data)
+}
+
+func (model *openAPIObjectResourceModel) delete(ctx context.Context, diagnostics *diag.Diagnostics) {
+	ctx, cancel := context.WithTimeout(ctx, model.timeout())
+	defer cancel()
+
+	object := model.open(ctx, diagnostics)
+	if diagnostics.HasError() {
+		return
+	}
+
+	operationURL, err := object.operation(http.MethodDelete, object.itemPath, model.ID.ValueString())
+	if err == nil {
+		var status int
+		var data []byte
+		status, data, _, err = object.do(ctx, http.MethodDelete, operationURL, nil)
+		if err == nil && (status < 200 || status > 299) && status != http.StatusNotFound && status != http.StatusGone {
+			err = fmt.Errorf("unexpected HTTP status %d: %s", status, data)
+		}
+	}
+	if err != nil {
+		diagnostics.AddError(
+			"Error deleting object",
+			fmt.Sprintf("Error deleting object: %s", err),
+		)
+	}
+}
+
+// extractID returns the identifier of a created object from its attribute in
+// the response body, or from the Location header.
+func extractID(data []byte, header http.Header, idAttribute string) (string, error) {
+	var body map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&body); err == nil {
+		switch id := body[idAttribute].(type) {
+		case string:
+			return id, nil
+		case json.Number:
+			return id.String(), nil
+		}
+	}
+
+	if location := header.Get("Location"); location != "" {
+		return path.Base(strings.TrimSuffix(location, "/")), nil
+	}
+
+	return "", fmt.Errorf("the response has no %q attribute nor Location header", idAttribute)
+}
+
+// decodeResponse returns the response body as a Terraform value, or null
+// when it is not JSON.
+func decodeResponse(data []byte) types.Dynamic {
+	value, err := decodeJSON(data)
+	if err != nil {
+		return types.DynamicNull()
+	}
+
+	return types.DynamicValue(value)
+}
+
+// refreshBody replaces the attributes of the body which differ from the
+// object returned by the API.
+func refreshBody(body types.Dynamic, data []byte, objectSchema *openAPISchema) (types.Dynamic, error) {
+	object, ok := body.UnderlyingValue().(types.Object)
+	if !ok {
+		return body, nil
+	}
+
+	var remote map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&remote); err != nil {
+		return body, fmt.Errorf("the response is not a JSON object: %w", err)
+	}
+
+	attributes := object.Attributes()
+	attributeTypes := object.AttributeTypes(context.Background())
+
+	names := make([]string, 0, len(attributes))
+	for name := range attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	drifted := false
+	for _, name := range names {
+		remoteValue, ok := remote[name]
+		if !ok || (objectSchema != nil && objectSchema.Properties[name].WriteOnly) {
+			continue
+		}
+
+		localValue, err := valueToJSON(attributes[name])
+		if err != nil {
+			return body, err
+		}
+
+		equal, err := jsonEqual(localValue, remoteValue)
+		if err != nil {
+			return body, err
+		}
+		if equal {
+			continue
+		}
+
+		value, err := jsonToValue(remoteValue)
+		if err != nil {
+			return body, err
+		}
+
+		attributes[name] = value
+		attributeTypes[name] = value.Type(context.Background())
+		drifted = true
+	}
+
+	if !drifted {
+		return body, nil
+	}
+
+	refreshed, diags := types.ObjectValue(attributeTypes, attributes)
+	if diags.HasError() {
+		return body, diagsError(diags)
+	}
+
+	return types.DynamicValue(refreshed), nil
+}
+
+// jsonEqual reports whether two decoded JSON values are equal, regardless of
+// the formatting of their numbers.
+func jsonEqual(a, b interface{}) (bool, error) {
+	var values [2]interface{}
+	for i, value := range []interface{}{a, b} {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return false, err
+		}
+		if err := json.Unmarshal(data, &values[i]); err != nil {
+			return false, err
+		}
+	}
+
+	return reflect.DeepEqual(values[0], values[1]), nil
+}
@@ -0,0 +1,238 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package http_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+const testPetsSpec = `
+openapi: 3.0.3
+info:
+  title: Pets
+  version: 1.0.0
+servers:
+  - url: /api
+paths:
+  /pets:
+    post:
+      responses:
+        "201":
+          description: Created
+  /pets/{petId}:
+    get:
+      responses:
+        "200":
+          description: OK
+    put:
+      responses:
+        "200":
+          description: OK
+    delete:
+      responses:
+        "204":
+          description: Deleted
+components:
+  schemas:
+    Pet:
+      properties:
+        id:
+          type: integer
+          readOnly: true
+        name:
+          type: string
+        tag:
+          type: string
+        secret:
+          type: string
+          writeOnly: true
+`
+
+// petsServer is an in-memory implementation of the pets API of testPetsSpec.
+type petsServer struct {
+	mu     sync.Mutex
+	nextID int
+	pets   map[string]map[string]interface{}
+}
+
+func newPetsServer(t *testing.T) (*petsServer, *httptest.Server) {
+	pets := &petsServer{nextID: 1, pets: make(map[string]map[string]interface{})}
+
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pets.mu.Lock()
+		defer pets.mu.Unlock()
+
+		if r.URL.Path == "/openapi.yaml" {
+			w.Header().Set("Content-Type", "application/yaml")
+			_, _ = w.Write([]byte(testPetsSpec))
+			return
+		}
+
+		id, isItem := strings.CutPrefix(r.URL.Path, "/api/pets/")
+		if !isItem && r.URL.Path != "/api/pets" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		var body map[string]interface{}
+		if r.Method == http.MethodPost || r.Method == http.MethodPut {
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			// The secret is write-only.
+			delete(body, "secret")
+		}
+
+		switch {
+		case !isItem && r.Method == http.MethodPost:
+			id = strconv.Itoa(pets.nextID)
+			pets.nextID++
+			body["id"] = json.Number(id)
+			pets.pets[id] = body
+			w.WriteHeader(http.StatusCreated)
+		case isItem && pets.pets[id] == nil:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		case isItem && r.Method == http.MethodGet:
+			w.WriteHeader(http.StatusOK)
+		case isItem && r.Method == http.MethodPut:
+			body["id"] = pets.pets[id]["id"]
+			pets.pets[id] = body
+			w.WriteHeader(http.StatusOK)
+		case isItem && r.Method == http.MethodDelete:
+			delete(pets.pets, id)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(pets.pets[id])
+	}))
+	t.Cleanup(svr.Close)
+
+	return pets, svr
+}
+
+func TestOpenAPIObjectResource(t *testing.T) {
+	pets, svr := newPetsServer(t)
+
+	config := func(name string) string {
+		return fmt.Sprintf(`
+			resource "utilities_openapi_object" "test" {
+				spec_url = "%s/openapi.yaml"
+				path     = "/pets"
+				schema   = "Pet"
+				body = {
+					name   = %q
+					tag    = "dog"
+					secret = "bone"
+				}
+			}`, svr.URL, name)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: config("rex"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utilities_openapi_object.test", "id", "1"),
+					resource.TestCheckResourceAttr("utilities_openapi_object.test", "response.name", "rex"),
+					resource.TestCheckResourceAttr("utilities_openapi_object.test", "response.id", "1"),
+				),
+			},
+			{
+				Config: config("max"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utilities_openapi_object.test", "id", "1"),
+					resource.TestCheckResourceAttr("utilities_openapi_object.test", "response.name", "max"),
+				),
+			},
+			{
+				PreConfig: func() {
+					pets.mu.Lock()
+					defer pets.mu.Unlock()
+					pets.pets["1"]["tag"] = "cat"
+				},
+				Config:             config("max"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config("max"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utilities_openapi_object.test", "response.tag", "dog"),
+				),
+			},
+		},
+		CheckDestroy: func(*terraform.State) error {
+			pets.mu.Lock()
+			defer pets.mu.Unlock()
+
+			if len(pets.pets) != 0 {
+				return fmt.Errorf("expected the pets to be deleted, got %v", pets.pets)
+			}
+			return nil
+		},
+	})
+}
+
+func TestOpenAPIObjectResource_ReadOnlyProperty(t *testing.T) {
+	_, svr := newPetsServer(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "utilities_openapi_object" "test" {
+						spec_url = "%s/openapi.yaml"
+						path     = "/pets"
+						schema   = "Pet"
+						body = {
+							id   = 42
+							name = "rex"
+						}
+					}`, svr.URL),
+				ExpectError: regexp.MustCompile(`the "id" property is read-only`),
+			},
+		},
+	})
+}
+
+func TestOpenAPIObjectResource_MissingOperation(t *testing.T) {
+	_, svr := newPetsServer(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "utilities_openapi_object" "test" {
+						spec_url  = "%s/openapi.yaml"
+						path      = "/pets/{petId}"
+						item_path = "/pets/{petId}"
+						body = {
+							name = "rex"
+						}
+					}`, svr.URL),
+				ExpectError: regexp.MustCompile(`the OpenAPI document has no POST operation on /pets/\{petId\}`),
+			},
+		},
+	})
+}
@@ -0,0 +1,358 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+const (
+	defaultWebhookTimeout     = 10000
+	defaultWebhookRetryDelay  = 1000
+	defaultWebhookContentType = "application/json"
+
+	// maxWebhookRetryDelay caps the exponential backoff between retries.
+	maxWebhookRetryDelay = 30 * time.Second
+
+	webhookEventCreate  = "create"
+	webhookEventDestroy = "destroy"
+)
+
+var _ resource.Resource = (*webhookResource)(nil)
+var _ resource.ResourceWithModifyPlan = (*webhookResource)(nil)
+
+func NewWebhookResource() resource.Resource {
+	return &webhookResource{}
+}
+
+type webhookResource struct{}
+type webhookResourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	URL            types.String `tfsdk:"url"`
+	Payload        types.String `tfsdk:"payload"`
+	DestroyPayload types.String `tfsdk:"destroy_payload"`
+	ContentType    types.String `tfsdk:"content_type"`
+	RequestHeaders types.Map    `tfsdk:"request_headers"`
+	RetryAttempts  types.Int64  `tfsdk:"retry_attempts"`
+	RetryDelay     types.Int64  `tfsdk:"retry_delay_ms"`
+	RequestTimeout types.Int64  `tfsdk:"request_timeout_ms"`
+	HMACSignature  types.Object `tfsdk:"hmac_signature"`
+	Keepers        types.Map    `tfsdk:"keepers"`
+	StatusCode     types.Int64  `tfsdk:"status_code"`
+}
+
+func (r *webhookResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_webhook"
+}
+
+func (r *webhookResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: `
+The ` + "`webhook`" + ` resource posts a payload to a URL when it is created and, optionally, another
+payload when it is destroyed, e.g. to notify a Slack or Teams channel or to open a PagerDuty event
+tied to the lifecycle of the infrastructure.
+
+In both payloads, ` + "`{event}`" + ` is replaced by ` + "`create`" + ` or ` + "`destroy`" + ` and
+` + "`{timestamp}`" + ` by the current time in RFC 3339 format. Changing the arguments of the resource
+does not send any payload, use ` + "`keepers`" + ` to send the payloads again.
+`,
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The time the payload was sent, in RFC 3339 format.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"url": schema.StringAttribute{
+				Description: "The URL the payloads are posted to.",
+				Required:    true,
+			},
+
+			"payload": schema.StringAttribute{
+				Description: "The payload posted when the resource is created.",
+				Required:    true,
+			},
+
+			"destroy_payload": schema.StringAttribute{
+				Description: "The payload posted when the resource is destroyed. Nothing is sent on destroy when not set.",
+				Optional:    true,
+			},
+
+			"content_type": schema.StringAttribute{
+				Description: fmt.Sprintf("The `Content-Type` of the payloads. Defaults to `%s`.", defaultWebhookContentType),
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(defaultWebhookContentType),
+			},
+
+			"request_headers": schema.MapAttribute{
+				Description: "A map of request header field names and values, e.g. to authenticate the request.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Sensitive:   true,
+			},
+
+			"retry_attempts": schema.Int64Attribute{
+				Description: "The number of times a request is retried after a connection error, a 429 or a 5xx-range (except 501) " +
+					"status code. Defaults to `0`.",
+				Optional: true,
+				Computed: true,
+				Default:  int64default.StaticInt64(0),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+
+			"retry_delay_ms": schema.Int64Attribute{
+				Description: fmt.Sprintf("The delay before the first retry in milliseconds, doubled for each following one "+
+					"unless the `Retry-After` response header requests another. Defaults to `%d`.", defaultWebhookRetryDelay),
+				Optional: true,
+				Computed: true,
+				Default:  int64default.StaticInt64(defaultWebhookRetryDelay),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+
+			"request_timeout_ms": schema.Int64Attribute{
+				Description: fmt.Sprintf("The time allowed for each payload to be sent, retries included, in milliseconds. Defaults to `%d`.", defaultWebhookTimeout),
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(defaultWebhookTimeout),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+
+			"keepers": schema.MapAttribute{
+				Description: "Arbitrary map of values that, when changed, will trigger recreation of " +
+					"resource.",
+				ElementType: types.StringType,
+				Optional:    true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplaceIfConfigured(),
+				},
+			},
+
+			"status_code": schema.Int64Attribute{
+				Description: "The HTTP response status code of the payload sent on create.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"hmac_signature": schema.SingleNestedBlock{
+				Description: "HMAC signature configuration. Configuring this block signs the payloads with a shared secret, " +
+					"the signature being set in a request header. See the `hmac_signature` block of the `http` resource.",
+				Attributes: map[string]schema.Attribute{
+					"secret": schema.StringAttribute{
+						Description: "The shared secret the signature is computed with.",
+						Required:    true,
+						Sensitive:   true,
+					},
+					"algorithm": schema.StringAttribute{
+						Description: "The hash function of the HMAC, one of `sha1`, `sha256` or `sha512`. Defaults to `sha256`.",
+						Optional:    true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(hmacSHA1, hmacSHA256, hmacSHA512),
+						},
+					},
+					"header": schema.StringAttribute{
+						Description: "The name of the request header set to the signature, e.g. `X-Hub-Signature-256`.",
+						Required:    true,
+					},
+					"string_to_sign_template": schema.StringAttribute{
+						Description: "The string the signature is computed over, `{body}`, `{timestamp}`, `{method}` and `{path}` being " +
+							"replaced by the payload, the current Unix time in seconds, the method and the path and query of the URL. " +
+							"Defaults to `{body}`.",
+						Optional: true,
+					},
+					"header_value_template": schema.StringAttribute{
+						Description: "The value of the header, `{signature}` and `{timestamp}` being replaced by the signature encoded " +
+							"in hexadecimal and the Unix time, e.g. `sha256={signature}`. Defaults to `{signature}`.",
+						Optional: true,
+					},
+					"timestamp_header": schema.StringAttribute{
+						Description: "The name of a request header set to the Unix time the signature covers.",
+						Optional:    true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *webhookResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+}
+
+func (r *webhookResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	deferUnknownConfig(req, resp)
+}
+
+func (r *webhookResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var model webhookResourceModel
+	diags := req.Plan.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	statusCode := model.send(ctx, webhookEventCreate, model.Payload.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	model.ID = types.StringValue(time.Now().UTC().Format(time.RFC3339))
+	model.StatusCode = types.Int64Value(int64(statusCode))
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *webhookResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// The payload is only sent when the resource is created,
+	// resp.State already holds the prior state.
+}
+
+func (r *webhookResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var model webhookResourceModel
+	diags := req.Plan.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The arguments are stored for the destroy payload, nothing is sent.
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *webhookResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var model webhookResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if model.DestroyPayload.IsNull() {
+		return
+	}
+
+	model.send(ctx, webhookEventDestroy, model.DestroyPayload.ValueString(), &resp.Diagnostics)
+}
+
+// send posts the payload, its placeholders replaced, and returns the status
+// code of the response, failing on the unsuccessful statuses.
+func (model *webhookResourceModel) send(ctx context.Context, event string, payload string, diagnostics *diag.Diagnostics) int {
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(model.RequestTimeout.ValueInt64())*time.Millisecond)
+	defer cancel()
+
+	payload = strings.NewReplacer(
+		"{event}", event,
+		"{timestamp}", time.Now().UTC().Format(time.RFC3339),
+	).Replace(payload)
+
+	request, err := retryablehttp.NewRequestWithContext(ctx, http.MethodPost, model.URL.ValueString(), []byte(payload))
+	if err != nil {
+		diagnostics.AddError(
+			"Error creating request",
+			fmt.Sprintf("Error creating request: %s", err),
+		)
+		return 0
+	}
+
+	// Headers set in `request_headers` take precedence.
+	request.Header.Set("Content-Type", model.ContentType.ValueString())
+
+	headers := make(map[string]string)
+	if !model.RequestHeaders.IsNull() {
+		diags := model.RequestHeaders.ElementsAs(ctx, &headers, false)
+		diagnostics.Append(diags...)
+		if diagnostics.HasError() {
+			return 0
+		}
+	}
+	for name, value := range headers {
+		request.Header.Set(name, value)
+	}
+
+	if !model.HMACSignature.IsNull() {
+		var signature hmacSignatureModel
+		diags := model.HMACSignature.As(ctx, &signature, basetypes.ObjectAsOptions{})
+		diagnostics.Append(diags...)
+		if diagnostics.HasError() {
+			return 0
+		}
+
+		if err := signature.sign(request, time.Now()); err != nil {
+			diagnostics.AddAttributeError(
+				path.Root("hmac_signature"),
+				"Error signing request",
+				fmt.Sprintf("Error signing request: %s", err),
+			)
+			return 0
+		}
+	}
+
+	retryClient := retryablehttp.NewClient()
+	retryClient.Logger = levelledLogger{ctx}
+	retryClient.RetryMax = int(model.RetryAttempts.ValueInt64())
+	retryClient.RetryWaitMin = time.Duration(model.RetryDelay.ValueInt64()) * time.Millisecond
+	retryClient.RetryWaitMax = max(retryClient.RetryWaitMin, maxWebhookRetryDelay)
+	retryClient.Backoff = withRetryAfter(makeBackoff(backoffExponential, false))
+	// The last response is kept to report why the payload was not accepted.
+	retryClient.ErrorHandler = retryablehttp.PassthroughErrorHandler
+
+	response, err := retryClient.Do(request)
+	if err != nil {
+		diagnostics.AddError(
+			"Error sending webhook",
+			fmt.Sprintf("Error sending the %s payload: %s", event, err),
+		)
+		return 0
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		diagnostics.AddError(
+			"Error sending webhook",
+			fmt.Sprintf("The %s payload was not accepted, unexpected HTTP status %s\n\n%s",
+				event, response.Status, newResponseExcerpt(response, defaultErrorExcerptBytes)),
+		)
+		return 0
+	}
+
+	return response.StatusCode
+}
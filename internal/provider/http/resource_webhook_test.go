@@ -0,0 +1,121 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package http_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+// newWebhookServer returns a webhook endpoint rejecting the first request and
+// the unsigned ones, and the payloads it accepted.
+func newWebhookServer(t *testing.T) (*httptest.Server, func() []string) {
+	var mu sync.Mutex
+	var requests int
+	var payloads []string
+
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		requests++
+		if requests == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		mac := hmac.New(sha256.New, []byte("secret"))
+		mac.Write(body)
+		if r.Method != http.MethodPost || r.Header.Get("X-Signature") != "sha256="+hex.EncodeToString(mac.Sum(nil)) {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte("invalid signature"))
+			return
+		}
+
+		payloads = append(payloads, string(body))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(svr.Close)
+
+	return svr, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return slices.Clone(payloads)
+	}
+}
+
+func TestWebhookResource(t *testing.T) {
+	svr, payloads := newWebhookServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		CheckDestroy: func(_ *terraform.State) error {
+			if p := payloads(); len(p) != 2 || p[1] != `{"text":"destroy"}` {
+				return fmt.Errorf("expected the destroy payload to be sent, got %q", p)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "utilities_webhook" "test" {
+						url             = "%s"
+						payload         = jsonencode({ text = "{event}" })
+						destroy_payload = jsonencode({ text = "{event}" })
+						retry_attempts  = 1
+						retry_delay_ms  = 10
+
+						hmac_signature {
+							secret                = "secret"
+							header                = "X-Signature"
+							header_value_template = "sha256={signature}"
+						}
+					}`, svr.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utilities_webhook.test", "status_code", "204"),
+					resource.TestCheckResourceAttrSet("utilities_webhook.test", "id"),
+					func(_ *terraform.State) error {
+						if p := payloads(); len(p) != 1 || p[0] != `{"text":"create"}` {
+							return fmt.Errorf("expected the create payload to be sent, got %q", p)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestWebhookResource_Rejected(t *testing.T) {
+	svr, _ := newWebhookServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "utilities_webhook" "test" {
+						url            = "%s"
+						payload        = "{}"
+						retry_attempts = 1
+						retry_delay_ms = 10
+					}`, svr.URL),
+				ExpectError: regexp.MustCompile(`(?s)unexpected HTTP status 401 Unauthorized.*invalid signature`),
+			},
+		},
+	})
+}
@@ -0,0 +1,81 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package http
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+)
+
+// responseCacheKey returns the key identifying the request in the response
+// cache: its method, URL, headers and body, and the settings it is made with,
+// so that requests made as different identities or through different
+// transports do not share their responses.
+func responseCacheKey(request *retryablehttp.Request, settings []string) (string, error) {
+	body, err := request.BodyBytes()
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s %s\n", request.Method, request.URL)
+
+	names := make([]string, 0, len(request.Header))
+	for name := range request.Header {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		fmt.Fprintf(hash, "%s: %q\n", name, request.Header.Values(name))
+	}
+
+	for _, setting := range settings {
+		fmt.Fprintf(hash, "%q\n", setting)
+	}
+
+	fmt.Fprintf(hash, "\n%s", body)
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// responseCacheSettings returns the settings of the model changing the
+// identity the request is made as, how it reaches the server or how much of
+// the response is read, as `name=value` pairs.
+func (model *modelV0) responseCacheSettings() []string {
+	values := map[string]attr.Value{
+		"auth":                    model.Auth,
+		"auth_audience":           model.AuthAudience,
+		"ca_cert_pem":             model.CaCertificate,
+		"ca_cert_file":            model.CaCertFile,
+		"ca_cert_append":          model.CaCertAppend,
+		"client_cert_pem":         model.ClientCert,
+		"client_key_pem":          model.ClientKey,
+		"client_cert_file":        model.ClientCertFile,
+		"client_key_file":         model.ClientKeyFile,
+		"client_key_password":     model.ClientKeyPassword,
+		"client_pkcs12_base64":    model.ClientPKCS12,
+		"client_pkcs12_password":  model.ClientPKCS12Password,
+		"insecure":                model.Insecure,
+		"pinned_cert_sha256":      model.PinnedCertSHA256,
+		"check_revocation":        model.CheckRevocation,
+		"proxy_url":               model.ProxyURL,
+		"unix_socket":             model.UnixSocket,
+		"ip_version":              model.IPVersion,
+		"dns_over_https":          model.DNSOverHTTPS,
+		"max_response_body_bytes": model.MaxResponseBodyBytes,
+	}
+
+	settings := make([]string, 0, len(values))
+	for name, value := range values {
+		settings = append(settings, name+"="+value.String())
+	}
+	slices.Sort(settings)
+
+	return settings
+}
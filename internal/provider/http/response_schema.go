@@ -0,0 +1,114 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package http
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// responseSchemaMaxErrors is the number of failed paths listed in the error.
+const responseSchemaMaxErrors = 20
+
+// checkResponseSchema validates the response body against the JSON Schema of
+// `response_schema`, given inline or as the URL to fetch it from with the
+// client.
+func (model *modelV0) checkResponseSchema(ctx context.Context, client *http.Client, body []byte, diagnostics *diag.Diagnostics) {
+	if model.ResponseSchema.IsNull() {
+		return
+	}
+
+	source := model.ResponseSchema.ValueString()
+
+	data := []byte(source)
+	if isSchemaURL(source) {
+		var err error
+		data, err = fetchSchema(ctx, client, source, nil)
+		if err != nil {
+			diagnostics.AddAttributeError(
+				path.Root("response_schema"),
+				"Error fetching JSON Schema",
+				fmt.Sprintf("The JSON Schema could not be fetched from %s: %s", source, err),
+			)
+			return
+		}
+	}
+
+	schema, err := newJSONSchema(data)
+	if err != nil {
+		diagnostics.AddAttributeError(
+			path.Root("response_schema"),
+			"Invalid JSON Schema",
+			fmt.Sprintf("The JSON Schema could not be decoded: %s", err),
+		)
+		return
+	}
+
+	errs, err := schema.validate(body)
+	if err != nil {
+		diagnostics.AddAttributeError(
+			path.Root("response_schema"),
+			"Unexpected response body",
+			fmt.Sprintf("The response body is not valid JSON: %s", err),
+		)
+		return
+	}
+
+	if len(errs) > 0 {
+		diagnostics.AddAttributeError(
+			path.Root("response_schema"),
+			"Unexpected response body",
+			fmt.Sprintf("The response body does not match the JSON Schema:\n%s", formatSchemaErrors(errs)),
+		)
+	}
+}
+
+// formatSchemaErrors lists the validation errors, one per line.
+func formatSchemaErrors(errs []jsonSchemaError) string {
+	lines := make([]string, 0, min(len(errs), responseSchemaMaxErrors+1))
+	for i, err := range errs {
+		if i == responseSchemaMaxErrors {
+			lines = append(lines, fmt.Sprintf("  - and %d more", len(errs)-i))
+			break
+		}
+		lines = append(lines, fmt.Sprintf("  - %s", err))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func isSchemaURL(source string) bool {
+	return strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://")
+}
+
+// fetchSchema returns the document at the URL, fetched with the headers when
+// set.
+func fetchSchema(ctx context.Context, client *http.Client, url string, header http.Header) ([]byte, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if header != nil {
+		request.Header = header.Clone()
+		request.Header.Del("Content-Type")
+	}
+	request.Header.Set("Accept", "application/schema+json, application/json")
+
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d", response.StatusCode)
+	}
+
+	return io.ReadAll(io.LimitReader(response.Body, 10<<20))
+}
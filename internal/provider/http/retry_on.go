@@ -0,0 +1,101 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package http
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+// The classes of the errors of `retry_on`.
+const (
+	retryOnDNS        = "dns"
+	retryOnConnect    = "connect"
+	retryOnTLS        = "tls"
+	retryOnTimeout    = "timeout"
+	retryOnHTTPStatus = "http_status"
+)
+
+var retryOnClasses = []string{retryOnDNS, retryOnConnect, retryOnTLS, retryOnTimeout, retryOnHTTPStatus}
+
+// retryOn is the set of the classes of the errors retried, nil retrying them
+// all.
+type retryOn []string
+
+func (classes retryOn) allows(class string) bool {
+	return classes == nil || slices.Contains(classes, class)
+}
+
+// withRetryOn fails the requests instead of retrying them when the class of
+// their error is not retried.
+func withRetryOn(checkRetry retryablehttp.CheckRetry, classes retryOn) retryablehttp.CheckRetry {
+	if classes == nil {
+		return checkRetry
+	}
+
+	return func(ctx context.Context, resp *http.Response, err error) (bool, error) {
+		retry, checkErr := checkRetry(ctx, resp, err)
+		if !retry {
+			return retry, checkErr
+		}
+
+		if err != nil {
+			if classes.allows(errorClass(err)) {
+				return retry, checkErr
+			}
+			return false, err
+		}
+
+		if classes.allows(retryOnHTTPStatus) {
+			return retry, checkErr
+		}
+		if checkErr == nil {
+			checkErr = fmt.Errorf("unexpected HTTP status %s", resp.Status)
+		}
+		return false, checkErr
+	}
+}
+
+// errorClass returns the class of the error of a request: the response failed
+// an assertion, the name could not be resolved, the TLS handshake failed, the
+// request timed out, or else the connection failed.
+func errorClass(err error) string {
+	var assertionErr *bodyAssertionError
+	var dnsErr *net.DNSError
+	var certErr *tls.CertificateVerificationError
+	var recordErr tls.RecordHeaderError
+	var unknownAuthorityErr x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var certInvalidErr x509.CertificateInvalidError
+	var pinErr pinMismatchError
+	var netErr net.Error
+
+	switch {
+	// The response was received, and rejected as an unexpected status is.
+	case errors.As(err, &assertionErr):
+		return retryOnHTTPStatus
+	case errors.As(err, &dnsErr):
+		return retryOnDNS
+	case errors.As(err, &certErr), errors.As(err, &recordErr), errors.As(err, &unknownAuthorityErr),
+		errors.As(err, &hostnameErr), errors.As(err, &certInvalidErr), errors.As(err, &pinErr):
+		return retryOnTLS
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+		return retryOnTimeout
+	// The alerts of the server are not exported, e.g. `remote error: tls:
+	// handshake failure`.
+	case strings.Contains(err.Error(), "tls: "):
+		return retryOnTLS
+	default:
+		return retryOnConnect
+	}
+}
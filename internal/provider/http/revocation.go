@@ -0,0 +1,104 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package http
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"terraform-provider-utilities/internal/provider/certificate"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// revocationTimeout bounds the requests to the OCSP responders and CRL
+// distribution points.
+const revocationTimeout = 30 * time.Second
+
+// checkRevocation fails when the certificate of the server is revoked,
+// according to the OCSP response stapled to the TLS handshake or, without
+// one, to its OCSP responder or CRL distribution points.
+func (model *modelV0) checkRevocation(ctx context.Context, state *tls.ConnectionState, diagnostics *diag.Diagnostics) {
+	if !model.CheckRevocation.ValueBool() {
+		return
+	}
+
+	if state == nil || len(state.PeerCertificates) == 0 {
+		diagnostics.AddAttributeError(
+			path.Root("check_revocation"),
+			"Error checking certificate revocation",
+			"The response was not received over TLS, the revocation of the server certificate cannot be checked.",
+		)
+		return
+	}
+
+	cert := state.PeerCertificates[0]
+	issuer, err := issuerOf(state)
+	if err != nil {
+		diagnostics.AddAttributeError(
+			path.Root("check_revocation"),
+			"Error checking certificate revocation",
+			fmt.Sprintf("Error checking the revocation of the server certificate: %s", err),
+		)
+		return
+	}
+
+	var revocation *certificate.Revocation
+	if len(state.OCSPResponse) > 0 {
+		revocation, err = certificate.CheckOCSPResponse(state.OCSPResponse, cert, issuer)
+	} else {
+		revocation, err = certificate.CheckRevocation(ctx, &http.Client{Timeout: revocationTimeout}, cert, issuer, certificate.MethodAuto)
+	}
+	if err != nil {
+		diagnostics.AddAttributeError(
+			path.Root("check_revocation"),
+			"Error checking certificate revocation",
+			fmt.Sprintf("Error checking the revocation of the server certificate: %s", err),
+		)
+		return
+	}
+
+	switch revocation.Status {
+	case certificate.StatusRevoked:
+		diagnostics.AddAttributeError(
+			path.Root("check_revocation"),
+			"Server certificate revoked",
+			fmt.Sprintf("The server certificate %s was revoked at %s (reason: %s), according to its %s.",
+				cert.Subject, revocation.RevokedAt.UTC().Format(time.RFC3339), revocation.Reason, strings.ToUpper(revocation.Method)),
+		)
+	case certificate.StatusUnknown:
+		diagnostics.AddAttributeWarning(
+			path.Root("check_revocation"),
+			"Unknown server certificate revocation status",
+			fmt.Sprintf("The revocation status of the server certificate %s is unknown to its OCSP responder.", cert.Subject),
+		)
+	}
+}
+
+// issuerOf returns the certificate which issued the certificate of the
+// server: the next one of the verified chain or of the presented chain, or
+// the certificate itself when it is self-signed.
+func issuerOf(state *tls.ConnectionState) (*x509.Certificate, error) {
+	if len(state.VerifiedChains) > 0 && len(state.VerifiedChains[0]) > 1 {
+		return state.VerifiedChains[0][1], nil
+	}
+
+	if len(state.PeerCertificates) > 1 {
+		return state.PeerCertificates[1], nil
+	}
+
+	cert := state.PeerCertificates[0]
+	if bytes.Equal(cert.RawIssuer, cert.RawSubject) {
+		return cert, nil
+	}
+
+	return nil, fmt.Errorf("the server did not present the issuer of its certificate")
+}
@@ -0,0 +1,67 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package http
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// isSensitiveHeader reports whether the value of the header is redacted: its
+// name looks like a secret or it is set in `sensitive_request_headers`.
+func isSensitiveHeader(name string, sensitiveHeaders []string) bool {
+	return sensitiveNameRegexp.MatchString(name) || slices.Contains(sensitiveHeaders, http.CanonicalHeaderKey(name))
+}
+
+// sensitiveHeaderNames returns the canonical names of the headers of
+// `sensitive_request_headers`, and their values to mask in the logs.
+func sensitiveHeaderNames(headers map[string]string) (names []string, values []string) {
+	for name, value := range headers {
+		names = append(names, http.CanonicalHeaderKey(name))
+		// An empty value would mask everything.
+		if value != "" {
+			values = append(values, value)
+		}
+	}
+	slices.Sort(names)
+
+	return names, values
+}
+
+// redactDiagnostics replaces the values of the sensitive headers in the
+// diagnostics added from the index from, e.g. echoed in a response body.
+func redactDiagnostics(diagnostics *diag.Diagnostics, from int, values []string) {
+	if len(values) == 0 {
+		return
+	}
+
+	replacements := make([]string, 0, 2*len(values))
+	for _, value := range values {
+		replacements = append(replacements, value, redacted)
+	}
+	replacer := strings.NewReplacer(replacements...)
+
+	for i := from; i < len(*diagnostics); i++ {
+		d := (*diagnostics)[i]
+		summary, detail := replacer.Replace(d.Summary()), replacer.Replace(d.Detail())
+		if summary == d.Summary() && detail == d.Detail() {
+			continue
+		}
+
+		withPath, ok := d.(diag.DiagnosticWithPath)
+		switch {
+		case ok && d.Severity() == diag.SeverityError:
+			(*diagnostics)[i] = diag.NewAttributeErrorDiagnostic(withPath.Path(), summary, detail)
+		case ok:
+			(*diagnostics)[i] = diag.NewAttributeWarningDiagnostic(withPath.Path(), summary, detail)
+		case d.Severity() == diag.SeverityError:
+			(*diagnostics)[i] = diag.NewErrorDiagnostic(summary, detail)
+		default:
+			(*diagnostics)[i] = diag.NewWarningDiagnostic(summary, detail)
+		}
+	}
+}
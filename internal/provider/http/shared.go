@@ -1,6 +1,7 @@
 package http
 
 import (
+	"cmp"
 	"context"
 	"crypto/tls"
 	"crypto/x509"
@@ -8,14 +9,25 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math"
+	"math/rand"
+	"net"
 	"net/http"
+	"net/http/httptrace"
 	"net/url"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 	"unicode/utf8"
 
+	"terraform-provider-utilities/internal/provider/providerdata"
+
 	"github.com/hashicorp/go-retryablehttp"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
@@ -24,31 +36,190 @@ import (
 )
 
 type modelV0 struct {
-	ID                 types.String `tfsdk:"id"`
-	URL                types.String `tfsdk:"url"`
-	Method             types.String `tfsdk:"method"`
-	RequestHeaders     types.Map    `tfsdk:"request_headers"`
-	RequestBody        types.String `tfsdk:"request_body"`
-	RequestTimeout     types.Int64  `tfsdk:"request_timeout_ms"`
-	Retry              types.Object `tfsdk:"retry"`
-	ResponseHeaders    types.Map    `tfsdk:"response_headers"`
-	CaCertificate      types.String `tfsdk:"ca_cert_pem"`
-	ClientCert         types.String `tfsdk:"client_cert_pem"`
-	ClientKey          types.String `tfsdk:"client_key_pem"`
-	Insecure           types.Bool   `tfsdk:"insecure"`
-	ResponseBody       types.String `tfsdk:"response_body"`
-	Body               types.String `tfsdk:"body"`
-	ResponseBodyBase64 types.String `tfsdk:"response_body_base64"`
-	StatusCode         types.Int64  `tfsdk:"status_code"`
-	SuccessStatusCodes types.List   `tfsdk:"success_status_codes"`
+	ID                   types.String  `tfsdk:"id"`
+	URL                  types.String  `tfsdk:"url"`
+	Method               types.String  `tfsdk:"method"`
+	RequestHeaders       types.Map     `tfsdk:"request_headers"`
+	SensitiveHeaders     types.Map     `tfsdk:"sensitive_request_headers"`
+	RequestIDHeader      types.String  `tfsdk:"request_id_header"`
+	ShareResponse        types.Bool    `tfsdk:"share_response"`
+	Auth                 types.String  `tfsdk:"auth"`
+	AuthAudience         types.String  `tfsdk:"auth_audience"`
+	RequestBody          types.String  `tfsdk:"request_body"`
+	RequestBodyJSON      types.Dynamic `tfsdk:"request_body_json"`
+	RequestBodyFile      types.String  `tfsdk:"request_body_file"`
+	FormData             types.Map     `tfsdk:"form_data"`
+	AcceptEncoding       types.String  `tfsdk:"accept_encoding"`
+	RequestTimeout       types.Int64   `tfsdk:"request_timeout_ms"`
+	AttemptTimeout       types.Int64   `tfsdk:"attempt_timeout_ms"`
+	TotalTimeout         types.Int64   `tfsdk:"total_timeout_ms"`
+	MaxResponseBodyBytes types.Int64   `tfsdk:"max_response_body_bytes"`
+	ErrorExcerptBytes    types.Int64   `tfsdk:"error_excerpt_bytes"`
+	Debug                types.Bool    `tfsdk:"debug"`
+	CurlCommand          types.String  `tfsdk:"curl_command"`
+	Enabled              types.Bool    `tfsdk:"enabled"`
+	Retry                types.Object  `tfsdk:"retry"`
+	RateLimit            types.Object  `tfsdk:"rate_limit"`
+	GraphQL              types.Object  `tfsdk:"graphql"`
+	SOAP                 types.Object  `tfsdk:"soap"`
+	OpenAPI              types.Object  `tfsdk:"openapi"`
+	HMACSignature        types.Object  `tfsdk:"hmac_signature"`
+	ForwardTo            types.Object  `tfsdk:"forward_to"`
+	GraphQLData          types.Dynamic `tfsdk:"graphql_data"`
+	GraphQLErrors        types.Dynamic `tfsdk:"graphql_errors"`
+	SOAPBody             types.String  `tfsdk:"soap_body"`
+	SOAPFault            types.Object  `tfsdk:"soap_fault"`
+	ResponseHeaders      types.Map     `tfsdk:"response_headers"`
+	ResponseHeadersAll   types.Map     `tfsdk:"response_headers_all"`
+	TLSPeerCertificates  types.List    `tfsdk:"tls_peer_certificates"`
+	CaCertificate        types.String  `tfsdk:"ca_cert_pem"`
+	CaCertFile           types.String  `tfsdk:"ca_cert_file"`
+	CaCertAppend         types.Bool    `tfsdk:"ca_cert_append"`
+	ClientCert           types.String  `tfsdk:"client_cert_pem"`
+	ClientKey            types.String  `tfsdk:"client_key_pem"`
+	ClientCertFile       types.String  `tfsdk:"client_cert_file"`
+	ClientKeyFile        types.String  `tfsdk:"client_key_file"`
+	ClientKeyPassword    types.String  `tfsdk:"client_key_password"`
+	ClientPKCS12         types.String  `tfsdk:"client_pkcs12_base64"`
+	ClientPKCS12Password types.String  `tfsdk:"client_pkcs12_password"`
+	Insecure             types.Bool    `tfsdk:"insecure"`
+	PinnedCertSHA256     types.List    `tfsdk:"pinned_cert_sha256"`
+	CheckRevocation      types.Bool    `tfsdk:"check_revocation"`
+	ProxyURL             types.String  `tfsdk:"proxy_url"`
+	UnixSocket           types.String  `tfsdk:"unix_socket"`
+	IPVersion            types.String  `tfsdk:"ip_version"`
+	DNSOverHTTPS         types.String  `tfsdk:"dns_over_https"`
+	ResponseBody         types.String  `tfsdk:"response_body"`
+	Body                 types.String  `tfsdk:"body"`
+	ResponseBodyBase64   types.String  `tfsdk:"response_body_base64"`
+	ResponseBodyGzip     types.String  `tfsdk:"response_body_gzip_base64"`
+	CompressStateBody    types.Bool    `tfsdk:"compress_state_body"`
+	DiscardResponseBody  types.Bool    `tfsdk:"discard_response_body"`
+	ContentSummary       types.Object  `tfsdk:"content_summary"`
+	ResponseBodyJSON     types.Dynamic `tfsdk:"response_body_json"`
+	ResponseQueries      types.Map     `tfsdk:"response_queries"`
+	QueryResults         types.Dynamic `tfsdk:"query_results"`
+	ResponseBodyRegex    types.String  `tfsdk:"response_body_regex"`
+	ExpectedResponseBody types.String  `tfsdk:"expected_response_body"`
+	ExpectedContentType  types.String  `tfsdk:"expected_content_type"`
+	ValidateCEL          types.String  `tfsdk:"validate_cel"`
+	ResponseSchema       types.String  `tfsdk:"response_schema"`
+	StatusCode           types.Int64   `tfsdk:"status_code"`
+	RequestID            types.String  `tfsdk:"request_id"`
+	IsSuccess            types.Bool    `tfsdk:"is_success"`
+	StatusClass          types.String  `tfsdk:"status_class"`
+	Duration             types.Int64   `tfsdk:"duration_ms"`
+	DNSDuration          types.Int64   `tfsdk:"dns_ms"`
+	ConnectDuration      types.Int64   `tfsdk:"connect_ms"`
+	TLSDuration          types.Int64   `tfsdk:"tls_ms"`
+	FirstByteDuration    types.Int64   `tfsdk:"first_byte_ms"`
+	SuccessStatusCodes   types.List    `tfsdk:"success_status_codes"`
+	SuccessBodyContains  types.List    `tfsdk:"success_body_contains"`
+	RetryStatusCodes     types.List    `tfsdk:"retry_status_codes"`
+
+	// validators holds the cache validators of the previous response, sent as
+	// conditional request headers, and is replaced by the ones of the new
+	// response.
+	validators *cacheValidators
+
+	// expiry is the time the response expires at, used by the on_expiry
+	// refresh policy.
+	expiry time.Time
+
+	// circuitBreaker fails fast the requests to the hosts that are down.
+	circuitBreaker *providerdata.CircuitBreaker
+
+	// retryBudget limits the retries of the requests to each host.
+	retryBudget *providerdata.RetryBudget
+
+	// semaphore limits the requests in flight at once.
+	semaphore *providerdata.Semaphore
+
+	// metrics records the requests made, under the name of the resource or
+	// data source type.
+	metrics  *providerdata.Metrics
+	typeName string
+
+	// har records the round trips of the requests in the HAR file.
+	har *providerdata.HAR
+
+	// requestIDHeader is the request ID header of the provider, used unless
+	// `request_id_header` is set.
+	requestIDHeader string
+
+	// responseCache shares the responses of the identical requests with
+	// `share_response` set.
+	responseCache *providerdata.ResponseCache
+
+	// sensitiveHeaders are the canonical names of the headers of
+	// `sensitive_request_headers`, redacted as the sensitive headers are.
+	sensitiveHeaders []string
+
+	// redactRequestBody hides the request body from the curl command, it is
+	// write-only.
+	redactRequestBody bool
+}
+
+// applyProviderData shares the state of the provider with the request, nil
+// when the provider is not configured.
+func (model *modelV0) applyProviderData(data *providerdata.ProviderData) {
+	if data == nil {
+		return
+	}
+
+	model.circuitBreaker = data.CircuitBreaker
+	model.semaphore = data.Semaphore
+	model.retryBudget = data.RetryBudget
+	model.metrics = data.Metrics
+	model.har = data.HAR
+	model.requestIDHeader = data.RequestIDHeader
+	model.responseCache = data.ResponseCache
 }
 
 type retryModel struct {
-	Attempts types.Int64 `tfsdk:"attempts"`
-	MinDelay types.Int64 `tfsdk:"min_delay_ms"`
-	MaxDelay types.Int64 `tfsdk:"max_delay_ms"`
+	Attempts types.Int64  `tfsdk:"attempts"`
+	MinDelay types.Int64  `tfsdk:"min_delay_ms"`
+	MaxDelay types.Int64  `tfsdk:"max_delay_ms"`
+	Backoff  types.String `tfsdk:"backoff"`
+	Jitter   types.Bool   `tfsdk:"jitter"`
+
+	RespectRetryAfter types.Bool `tfsdk:"respect_retry_after"`
+	RetryOn           types.List `tfsdk:"retry_on"`
+}
+
+// retryOn returns the classes of the errors retried, nil when `retry_on` is
+// not set.
+func (retry *retryModel) retryOn(ctx context.Context, diagnostics *diag.Diagnostics) retryOn {
+	if retry.RetryOn.IsNull() || retry.RetryOn.IsUnknown() {
+		return nil
+	}
+
+	classes := retryOn{}
+	diags := retry.RetryOn.ElementsAs(ctx, &classes, false)
+	diagnostics.Append(diags...)
+
+	return classes
 }
 
+// proxyURLRegexp matches the supported proxy URL schemes.
+var proxyURLRegexp = regexp.MustCompile(`^https?://`)
+
+// mediaRangeRegexp matches a media type, its type or subtype being `*` to
+// match any.
+var mediaRangeRegexp = regexp.MustCompile(`^[^/\s]+/[^/\s]+$`)
+
+const (
+	backoffConstant    = "constant"
+	backoffLinear      = "linear"
+	backoffExponential = "exponential"
+)
+
+const (
+	ipVersionAny = "any"
+	ipVersion4   = "4"
+	ipVersion6   = "6"
+)
+
 var _ retryablehttp.LeveledLogger = levelledLogger{}
 
 // levelledLogger is used to log messages from retryablehttp.Client to tflog.
@@ -82,7 +253,74 @@ func (l levelledLogger) additionalFields(keysAndValues []interface{}) map[string
 	return additionalFields
 }
 
-func makeCustomRetryPolicy(successStatusCodes []int) retryablehttp.CheckRetry {
+// makeBackoff returns a retryablehttp.Backoff computing the delay before the
+// given attempt from minDelay with the strategy, capped by maxDelay. When
+// jitter is set the delay is instead picked uniformly between minDelay and
+// that delay, so that the clients failing at once do not retry in lockstep.
+func makeBackoff(strategy string, jitter bool) retryablehttp.Backoff {
+	return func(minDelay, maxDelay time.Duration, attemptNum int, resp *http.Response) time.Duration {
+		var sleep float64
+		switch strategy {
+		case backoffConstant:
+			sleep = float64(minDelay)
+		case backoffLinear:
+			sleep = float64(minDelay) * float64(attemptNum+1)
+		default:
+			sleep = float64(minDelay) * math.Pow(2, float64(attemptNum))
+		}
+
+		delay := time.Duration(sleep)
+		if sleep > float64(maxDelay) {
+			delay = maxDelay
+		}
+
+		if jitter && delay > minDelay {
+			delay = minDelay + time.Duration(rand.Int63n(int64(delay-minDelay)+1))
+		}
+
+		return delay
+	}
+}
+
+// withRetryAfter wraps the backoff so that the delay requested by the
+// Retry-After header of a 429 or 503 response is waited instead, capped by max.
+func withRetryAfter(backoff retryablehttp.Backoff) retryablehttp.Backoff {
+	return func(minDelay, maxDelay time.Duration, attemptNum int, resp *http.Response) time.Duration {
+		if delay, ok := retryAfter(resp); ok {
+			return min(delay, maxDelay)
+		}
+
+		return backoff(minDelay, maxDelay, attemptNum, resp)
+	}
+}
+
+// retryAfter returns the delay requested by the Retry-After header of a 429
+// or 503 response, given in seconds or as an HTTP date.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil || (resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode != http.StatusServiceUnavailable) {
+		return 0, false
+	}
+
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+
+	return 0, false
+}
+
+func makeCustomRetryPolicy(successStatusCodes, retryStatusCodes []int) retryablehttp.CheckRetry {
 	return func(ctx context.Context, resp *http.Response, err error) (bool, error) {
 		if ctx.Err() != nil {
 			return false, ctx.Err()
@@ -98,6 +336,12 @@ func makeCustomRetryPolicy(successStatusCodes []int) retryablehttp.CheckRetry {
 			return true, nil
 		}
 
+		for _, code := range retryStatusCodes {
+			if resp.StatusCode == code {
+				return true, nil
+			}
+		}
+
 		if len(successStatusCodes) == 0 {
 			return shouldRetry, err2
 		}
@@ -112,6 +356,21 @@ func makeCustomRetryPolicy(successStatusCodes []int) retryablehttp.CheckRetry {
 	}
 }
 
+// isSuccessStatus reports whether the status code is one of the success
+// status codes, or in the 2xx range when they are not set.
+func isSuccessStatus(statusCode int, successStatusCodes []int) bool {
+	if len(successStatusCodes) == 0 {
+		return statusCode >= 200 && statusCode < 300
+	}
+
+	return slices.Contains(successStatusCodes, statusCode)
+}
+
+// statusClass returns the class of the status code, e.g. `2xx`.
+func statusClass(statusCode int) string {
+	return fmt.Sprintf("%dxx", statusCode/100)
+}
+
 type Diags struct {
 	Diagnostics diag.Diagnostics
 }
@@ -121,67 +380,71 @@ func (model *modelV0) read(ctx context.Context, diagnostics *diag.Diagnostics) {
 	method := model.Method.ValueString()
 	requestHeaders := model.RequestHeaders
 
-	if method == "" {
-		method = "GET"
-	}
-
-	caCertificate := model.CaCertificate
-
-	tr, ok := http.DefaultTransport.(*http.Transport)
-	if !ok {
-		diagnostics.AddError(
-			"Error configuring http transport",
-			"Error http: Can't configure http transport.",
-		)
-		return
+	var graphql *graphqlModel
+	if !model.GraphQL.IsNull() && !model.GraphQL.IsUnknown() {
+		graphql = &graphqlModel{}
+		diags := model.GraphQL.As(ctx, graphql, basetypes.ObjectAsOptions{})
+		diagnostics.Append(diags...)
+		if diagnostics.HasError() {
+			return
+		}
 	}
 
-	// Prevent issues with multiple data source configurations modifying the shared transport.
-	clonedTr := tr.Clone()
-
-	// Prevent issues with tests caching the proxy configuration.
-	clonedTr.Proxy = func(req *http.Request) (*url.URL, error) {
-		return httpproxy.FromEnvironment().ProxyFunc()(req.URL)
+	var soap *soapModel
+	if !model.SOAP.IsNull() && !model.SOAP.IsUnknown() {
+		soap = &soapModel{}
+		diags := model.SOAP.As(ctx, soap, basetypes.ObjectAsOptions{})
+		diagnostics.Append(diags...)
+		if diagnostics.HasError() {
+			return
+		}
 	}
 
-	if clonedTr.TLSClientConfig == nil {
-		clonedTr.TLSClientConfig = &tls.Config{}
+	var forward *forwardModel
+	if !model.ForwardTo.IsNull() && !model.ForwardTo.IsUnknown() {
+		forward = &forwardModel{}
+		diags := model.ForwardTo.As(ctx, forward, basetypes.ObjectAsOptions{})
+		diagnostics.Append(diags...)
+		if diagnostics.HasError() {
+			return
+		}
 	}
 
-	if !model.Insecure.IsNull() {
-		if clonedTr.TLSClientConfig == nil {
-			clonedTr.TLSClientConfig = &tls.Config{}
-		}
-		clonedTr.TLSClientConfig.InsecureSkipVerify = model.Insecure.ValueBool()
+	if !model.Enabled.IsNull() && !model.Enabled.ValueBool() {
+		model.disable(ctx, forward, diagnostics)
+		return
 	}
 
-	// Use `ca_cert_pem` cert pool
-	if !caCertificate.IsNull() {
-		caCertPool := x509.NewCertPool()
-		if ok := caCertPool.AppendCertsFromPEM([]byte(caCertificate.ValueString())); !ok {
-			diagnostics.AddError(
-				"Error configuring TLS client",
-				"Error tls: Can't add the CA certificate to certificate pool. Only PEM encoded certificates are supported.",
-			)
+	var sensitiveHeaders map[string]string
+	if !model.SensitiveHeaders.IsNull() {
+		diags := model.SensitiveHeaders.ElementsAs(ctx, &sensitiveHeaders, false)
+		diagnostics.Append(diags...)
+		if diagnostics.HasError() {
 			return
 		}
 
-		if clonedTr.TLSClientConfig == nil {
-			clonedTr.TLSClientConfig = &tls.Config{}
-		}
-		clonedTr.TLSClientConfig.RootCAs = caCertPool
+		// The values are masked in the logs and redacted from the diagnostics.
+		var values []string
+		model.sensitiveHeaders, values = sensitiveHeaderNames(sensitiveHeaders)
+		ctx = tflog.MaskMessageStrings(ctx, values...)
+		ctx = tflog.MaskAllFieldValuesStrings(ctx, values...)
+
+		from := len(*diagnostics)
+		defer func() {
+			redactDiagnostics(diagnostics, from, values)
+		}()
 	}
 
-	if !model.ClientCert.IsNull() && !model.ClientKey.IsNull() {
-		cert, err := tls.X509KeyPair([]byte(model.ClientCert.ValueString()), []byte(model.ClientKey.ValueString()))
-		if err != nil {
-			diagnostics.AddError(
-				"error creating x509 key pair",
-				fmt.Sprintf("error creating x509 key pair from provided pem blocks\n\nError: %s", err),
-			)
-			return
+	if method == "" {
+		method = "GET"
+		if graphql != nil || soap != nil {
+			method = "POST"
 		}
-		clonedTr.TLSClientConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	clonedTr := model.transport(ctx, diagnostics)
+	if diagnostics.HasError() {
+		return
 	}
 
 	var retry retryModel
@@ -197,13 +460,70 @@ func (model *modelV0) read(ctx context.Context, diagnostics *diag.Diagnostics) {
 	retryClient := retryablehttp.NewClient()
 	retryClient.HTTPClient.Transport = clonedTr
 
-	var timeout time.Duration
+	// The round trips are recorded as sent, authenticated and after waiting
+	// for the rate limit.
+	var recorder *harTransport
+	if model.har != nil {
+		recorder = newHARTransport(clonedTr, model.redactRequestBody)
+		recorder.sensitiveHeaders = model.sensitiveHeaders
+		retryClient.HTTPClient.Transport = recorder
+	}
 
-	if model.RequestTimeout.ValueInt64() > 0 {
-		timeout = time.Duration(model.RequestTimeout.ValueInt64()) * time.Millisecond
+	if !model.RateLimit.IsNull() && !model.RateLimit.IsUnknown() {
+		var rateLimit rateLimitModel
+		diags := model.RateLimit.As(ctx, &rateLimit, basetypes.ObjectAsOptions{})
+		diagnostics.Append(diags...)
+		if diagnostics.HasError() {
+			return
+		}
+
+		retryClient.HTTPClient.Transport = &rateLimitedTransport{
+			transport: retryClient.HTTPClient.Transport,
+			limiter:   rateLimit.limiter(),
+		}
+	}
+
+	if !model.Auth.IsNull() {
+		transport, err := newIdentityTransport(retryClient.HTTPClient.Transport, model.Auth.ValueString(), model.AuthAudience.ValueString(), requestURL)
+		if err != nil {
+			diagnostics.AddAttributeError(
+				path.Root("url"),
+				"Invalid URL",
+				fmt.Sprintf("Error parsing the URL: %s", err),
+			)
+			return
+		}
+		retryClient.HTTPClient.Transport = transport
+	}
+
+	// The request ID is set around the other transports, so that the HAR file
+	// records it.
+	var requestID *requestIDTransport
+	if header := cmp.Or(model.RequestIDHeader.ValueString(), model.requestIDHeader); header != "" {
+		requestID = &requestIDTransport{
+			transport: retryClient.HTTPClient.Transport,
+			header:    header,
+		}
+		retryClient.HTTPClient.Transport = requestID
+	}
+
+	timeout := model.attemptTimeout()
+	if timeout > 0 {
 		retryClient.HTTPClient.Timeout = timeout
 	}
 
+	// The total timeout bounds the attempts, the delays between them and the
+	// reading of the response body.
+	var totalTimeout time.Duration
+
+	if model.TotalTimeout.ValueInt64() > 0 {
+		totalTimeout = time.Duration(model.TotalTimeout.ValueInt64()) * time.Millisecond
+
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, totalTimeout)
+		defer cancel()
+	}
+
 	retryClient.Logger = levelledLogger{ctx}
 	retryClient.RetryMax = int(retry.Attempts.ValueInt64())
 
@@ -213,6 +533,29 @@ func (model *modelV0) read(ctx context.Context, diagnostics *diag.Diagnostics) {
 		diagnostics.Append(diags...)
 	}
 
+	var retryStatusCodes []int
+	if !model.RetryStatusCodes.IsNull() && !model.RetryStatusCodes.IsUnknown() {
+		diags := model.RetryStatusCodes.ElementsAs(ctx, &retryStatusCodes, false)
+		diagnostics.Append(diags...)
+	}
+
+	for _, code := range retryStatusCodes {
+		if slices.Contains(successStatusCodes, code) {
+			diagnostics.AddAttributeError(
+				path.Root("retry_status_codes"),
+				"Invalid retry status codes",
+				fmt.Sprintf("The status code %d can't be both in `success_status_codes` and `retry_status_codes`.", code),
+			)
+			return
+		}
+	}
+
+	// Without the `retry` block the requests are not retried, the responses
+	// with these status codes are handled as any other.
+	if model.Retry.IsNull() {
+		retryStatusCodes = nil
+	}
+
 	if !retry.MinDelay.IsNull() && !retry.MinDelay.IsUnknown() && retry.MinDelay.ValueInt64() >= 0 {
 		retryClient.RetryWaitMin = time.Duration(retry.MinDelay.ValueInt64()) * time.Millisecond
 	}
@@ -221,7 +564,39 @@ func (model *modelV0) read(ctx context.Context, diagnostics *diag.Diagnostics) {
 		retryClient.RetryWaitMax = time.Duration(retry.MaxDelay.ValueInt64()) * time.Millisecond
 	}
 
-	retryClient.CheckRetry = makeCustomRetryPolicy(successStatusCodes)
+	conditional := model.validators != nil && model.validators.URL == requestURL && isConditionalMethod(method)
+	if conditional && len(successStatusCodes) > 0 {
+		successStatusCodes = append(successStatusCodes, http.StatusNotModified)
+	}
+
+	retryClient.Backoff = makeBackoff(retry.Backoff.ValueString(), retry.Jitter.ValueBool())
+	if retry.RespectRetryAfter.IsNull() || retry.RespectRetryAfter.ValueBool() {
+		retryClient.Backoff = withRetryAfter(retryClient.Backoff)
+	}
+
+	retryClient.CheckRetry = withRetryOn(makeCustomRetryPolicy(successStatusCodes, retryStatusCodes), retry.retryOn(ctx, diagnostics))
+	if diagnostics.HasError() {
+		return
+	}
+
+	// The last unsuccessful response is reported when the request gives up.
+	excerptBytes := int64(defaultErrorExcerptBytes)
+	if !model.ErrorExcerptBytes.IsNull() {
+		excerptBytes = model.ErrorExcerptBytes.ValueInt64()
+	}
+
+	var excerpt *responseExcerpt
+	customRetryPolicy := retryClient.CheckRetry
+	retryClient.CheckRetry = func(ctx context.Context, resp *http.Response, err error) (bool, error) {
+		retry, checkErr := customRetryPolicy(ctx, resp, err)
+
+		excerpt = nil
+		if resp != nil && (err != nil || checkErr != nil) {
+			excerpt = newResponseExcerpt(resp, excerptBytes)
+		}
+
+		return retry, checkErr
+	}
 	request, err := retryablehttp.NewRequestWithContext(ctx, method, requestURL, nil)
 
 	if err != nil {
@@ -232,6 +607,17 @@ func (model *modelV0) read(ctx context.Context, diagnostics *diag.Diagnostics) {
 		return
 	}
 
+	if !model.SuccessBodyContains.IsNull() {
+		var substrings []string
+		diags := model.SuccessBodyContains.ElementsAs(ctx, &substrings, false)
+		diagnostics.Append(diags...)
+		if diagnostics.HasError() {
+			return
+		}
+
+		request.SetResponseHandler(model.bodyContainsHandler(substrings, successStatusCodes))
+	}
+
 	if !model.RequestBody.IsNull() {
 		err = request.SetBody(strings.NewReader(model.RequestBody.ValueString()))
 
@@ -245,71 +631,455 @@ func (model *modelV0) read(ctx context.Context, diagnostics *diag.Diagnostics) {
 		}
 	}
 
-	for name, value := range requestHeaders.Elements() {
-		var header string
-		diags := tfsdk.ValueAs(ctx, value, &header)
-		diagnostics.Append(diags...)
-		if diagnostics.HasError() {
+	if !model.RequestBodyJSON.IsNull() {
+		body, err := encodeJSON(model.RequestBodyJSON)
+		if err != nil {
+			diagnostics.AddAttributeError(
+				path.Root("request_body_json"),
+				"Error encoding request body",
+				fmt.Sprintf("Error encoding request body: %s", err),
+			)
 			return
 		}
 
-		request.Header.Set(name, header)
-		if strings.ToLower(name) == "host" {
-			request.Host = header
+		err = request.SetBody(body)
+		if err != nil {
+			diagnostics.AddError(
+				"Error Setting Request Body",
+				"An unexpected error occurred while setting the request body: "+err.Error(),
+			)
+
+			return
 		}
-	}
 
-	response, err := retryClient.Do(request)
-	if err != nil {
-		target := &url.Error{}
-		if errors.As(err, &target) {
-			if target.Timeout() {
-				detail := fmt.Sprintf("timeout error: %s", err)
+		// Headers set in `request_headers` take precedence.
+		request.Header.Set("Content-Type", "application/json")
+	}
 
-				if timeout > 0 {
-					detail = fmt.Sprintf("request exceeded the specified timeout: %s, err: %s", timeout.String(), err)
-				}
+	if !model.RequestBodyFile.IsNull() {
+		filename := model.RequestBodyFile.ValueString()
+		info, err := os.Stat(filename)
+		if err != nil {
+			diagnostics.AddAttributeError(
+				path.Root("request_body_file"),
+				"Error reading request body file",
+				fmt.Sprintf("Error reading request body file: %s", err),
+			)
+			return
+		}
 
-				diagnostics.AddError(
-					"Error making request",
-					detail,
-				)
-				return
-			}
+		// The file is opened again for every attempt, so that retries send the
+		// whole body without buffering it in memory.
+		err = request.SetBody(func() (io.Reader, error) {
+			return os.Open(filename)
+		})
+		if err != nil {
+			diagnostics.AddAttributeError(
+				path.Root("request_body_file"),
+				"Error reading request body file",
+				fmt.Sprintf("Error reading request body file: %s", err),
+			)
+			return
 		}
 
-		diagnostics.AddError(
-			"Error making request",
-			fmt.Sprintf("Error making request: %s", err),
-		)
-		return
+		request.ContentLength = info.Size()
 	}
 
-	defer response.Body.Close()
+	var formData map[string]string
+	if !model.FormData.IsNull() {
+		diags := model.FormData.ElementsAs(ctx, &formData, false)
+		diagnostics.Append(diags...)
+		if diagnostics.HasError() {
+			return
+		}
 
-	bytes, err := io.ReadAll(response.Body)
-	if err != nil {
-		diagnostics.AddError(
-			"Error reading response body",
-			fmt.Sprintf("Error reading response body: %s", err),
-		)
-		return
-	}
+		form := url.Values{}
+		for name, value := range formData {
+			form.Set(name, value)
+		}
 
-	if !utf8.Valid(bytes) {
-		diagnostics.AddWarning(
-			"Response body is not recognized as UTF-8",
-			"Terraform may not properly handle the response_body if the contents are binary.",
-		)
-	}
+		err = request.SetBody([]byte(form.Encode()))
+		if err != nil {
+			diagnostics.AddError(
+				"Error Setting Request Body",
+				"An unexpected error occurred while setting the request body: "+err.Error(),
+			)
 
-	responseBody := string(bytes)
-	responseBodyBase64Std := base64.StdEncoding.EncodeToString(bytes)
+			return
+		}
 
-	responseHeaders := make(map[string]string)
-	for k, v := range response.Header {
-		// Concatenate according to RFC9110 https://www.rfc-editor.org/rfc/rfc9110.html#section-5.2
-		responseHeaders[k] = strings.Join(v, ", ")
+		// Headers set in `request_headers` take precedence.
+		request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+
+	if graphql != nil {
+		body, err := graphql.requestBody()
+		if err != nil {
+			diagnostics.AddAttributeError(
+				path.Root("graphql").AtName("variables"),
+				"Error encoding GraphQL request",
+				fmt.Sprintf("Error encoding GraphQL request: %s", err),
+			)
+			return
+		}
+
+		err = request.SetBody(body)
+		if err != nil {
+			diagnostics.AddError(
+				"Error Setting Request Body",
+				"An unexpected error occurred while setting the request body: "+err.Error(),
+			)
+
+			return
+		}
+
+		// Headers set in `request_headers` take precedence.
+		request.Header.Set("Content-Type", "application/json")
+		request.Header.Set("Accept", "application/json")
+	}
+
+	if soap != nil {
+		err = request.SetBody(soap.envelope())
+		if err != nil {
+			diagnostics.AddError(
+				"Error Setting Request Body",
+				"An unexpected error occurred while setting the request body: "+err.Error(),
+			)
+
+			return
+		}
+
+		// Headers set in `request_headers` take precedence.
+		soap.setHeaders(request.Header)
+	}
+
+	// Headers set in `request_headers` take precedence.
+	if conditional {
+		model.validators.setHeaders(request.Header)
+	}
+
+	if !model.AcceptEncoding.IsNull() {
+		request.Header.Set("Accept-Encoding", model.AcceptEncoding.ValueString())
+	}
+
+	for name, value := range requestHeaders.Elements() {
+		var header string
+		diags := tfsdk.ValueAs(ctx, value, &header)
+		diagnostics.Append(diags...)
+		if diagnostics.HasError() {
+			return
+		}
+
+		request.Header.Set(name, header)
+		if strings.ToLower(name) == "host" {
+			request.Host = header
+		}
+	}
+
+	for name, header := range sensitiveHeaders {
+		request.Header.Set(name, header)
+	}
+
+	if !model.HMACSignature.IsNull() && !model.HMACSignature.IsUnknown() {
+		var signature hmacSignatureModel
+		diags := model.HMACSignature.As(ctx, &signature, basetypes.ObjectAsOptions{})
+		diagnostics.Append(diags...)
+		if diagnostics.HasError() {
+			return
+		}
+
+		if err := signature.sign(request, time.Now()); err != nil {
+			diagnostics.AddAttributeError(
+				path.Root("hmac_signature"),
+				"Error signing request",
+				fmt.Sprintf("Error signing request: %s", err),
+			)
+			return
+		}
+	}
+
+	if !model.OpenAPI.IsNull() {
+		body, err := request.BodyBytes()
+		if err != nil {
+			diagnostics.AddError(
+				"Error reading request body",
+				fmt.Sprintf("Error reading request body: %s", err),
+			)
+			return
+		}
+
+		openAPIClient := &http.Client{Transport: clonedTr, Timeout: retryClient.HTTPClient.Timeout}
+		model.checkOpenAPI(ctx, openAPIClient, &openAPIRequest{
+			method: request.Method,
+			url:    request.URL,
+			header: request.Header,
+			body:   body,
+		}, diagnostics)
+		if diagnostics.HasError() {
+			return
+		}
+	}
+
+	curlCommand := model.curlCommand(request, formData)
+
+	if err := model.circuitBreaker.Allow(request.URL.Host); err != nil {
+		diagnostics.AddError(
+			"Error making request",
+			fmt.Sprintf("Error making request: %s", err),
+		)
+		return
+	}
+
+	if err := model.semaphore.Acquire(ctx); err != nil {
+		diagnostics.AddError(
+			"Error making request",
+			fmt.Sprintf("Error waiting for a concurrent request to complete: %s", err),
+		)
+		return
+	}
+	defer model.semaphore.Release()
+
+	// The retries are spent from the budget of the host before the delay, so
+	// that the request gives up right away once the budget is exhausted.
+	var budgetErr error
+	backoff := retryClient.Backoff
+	retryClient.Backoff = func(minDelay, maxDelay time.Duration, attemptNum int, resp *http.Response) time.Duration {
+		if budgetErr = model.retryBudget.Spend(request.URL.Host); budgetErr != nil {
+			return 0
+		}
+		return backoff(minDelay, maxDelay, attemptNum, resp)
+	}
+	retryClient.PrepareRetry = func(*http.Request) error {
+		return budgetErr
+	}
+
+	timings := newRequestTimings()
+	request = request.WithContext(httptrace.WithClientTrace(request.Context(), timings.clientTrace()))
+
+	var trace *requestTrace
+	if model.Debug.ValueBool() {
+		trace = newRequestTrace(ctx)
+		trace.record("request: %s", curlCommand)
+		request = request.WithContext(httptrace.WithClientTrace(request.Context(), trace.clientTrace()))
+
+		checkRetry := retryClient.CheckRetry
+		retryClient.CheckRetry = func(ctx context.Context, resp *http.Response, err error) (bool, error) {
+			if err != nil {
+				trace.record("attempt failed: %s", err)
+			} else {
+				trace.record("response status %s", resp.Status)
+			}
+			return checkRetry(ctx, resp, err)
+		}
+
+		// The trace is appended to the errors of the request.
+		from := len(*diagnostics)
+		defer func() {
+			trace.appendTo(diagnostics, from)
+		}()
+	}
+
+	var attempts int
+	retryClient.RequestLogHook = func(_ retryablehttp.Logger, request *http.Request, attempt int) {
+		attempts = attempt + 1
+		if trace != nil {
+			trace.record("attempt %d: %s %s", attempts, request.Method, request.URL)
+		}
+	}
+
+	// The round trips are recorded once the response body is closed.
+	defer recorder.record(ctx, model.har)
+
+	var received int64
+	cacheHit := false
+	defer func() {
+		model.metrics.Record(ctx, model.typeName, providerdata.Request{
+			Attempts: attempts,
+			Bytes:    received,
+			CacheHit: cacheHit,
+			Failed:   diagnostics.HasError(),
+		})
+	}()
+
+	var response *http.Response
+	var shared bool
+	if model.ShareResponse.ValueBool() {
+		key, keyErr := responseCacheKey(request, model.responseCacheSettings())
+		if keyErr != nil {
+			diagnostics.AddError(
+				"Error reading request body",
+				fmt.Sprintf("Error reading request body: %s", keyErr),
+			)
+			return
+		}
+
+		// The body is read in memory, at most up to the limit of the request.
+		response, shared, err = model.responseCache.Do(ctx, key, model.MaxResponseBodyBytes.ValueInt64(), func() (*http.Response, error) {
+			return retryClient.Do(request)
+		})
+		cacheHit = shared
+	} else {
+		response, err = retryClient.Do(request)
+	}
+	if !shared {
+		model.circuitBreaker.Record(request.URL.Host, err == nil && response.StatusCode < http.StatusInternalServerError)
+	}
+	if err != nil {
+		// The request ID of the last attempt correlates the failure with the
+		// logs of the server.
+		var requestIDDetail string
+		if id := requestID.lastID(); id != "" {
+			requestIDDetail = fmt.Sprintf("\n\nRequest ID: %s", id)
+		}
+
+		if totalTimeout > 0 && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			diagnostics.AddError(
+				"Error making request",
+				fmt.Sprintf("request exceeded the specified total timeout: %s, err: %s", totalTimeout.String(), err)+requestIDDetail,
+			)
+			return
+		}
+
+		target := &url.Error{}
+		if errors.As(err, &target) {
+			if target.Timeout() {
+				detail := fmt.Sprintf("timeout error: %s", err)
+
+				if timeout > 0 {
+					detail = fmt.Sprintf("request exceeded the specified timeout: %s, err: %s", timeout.String(), err)
+				}
+
+				diagnostics.AddError(
+					"Error making request",
+					detail+requestIDDetail,
+				)
+				return
+			}
+		}
+
+		detail := fmt.Sprintf("Error making request: %s", err)
+		if soap != nil && excerpt != nil && !excerpt.truncated {
+			if _, fault, err := decodeSOAPResponse(excerpt.body); err == nil && fault != nil {
+				detail += fmt.Sprintf("\n\nSOAP fault %s", fault)
+			}
+		}
+		if excerpt != nil {
+			detail += "\n\n" + excerpt.String()
+		}
+		detail += requestIDDetail
+
+		diagnostics.AddError(
+			"Error making request",
+			detail,
+		)
+		return
+	}
+
+	defer response.Body.Close()
+
+	model.checkRevocation(ctx, response.TLS, diagnostics)
+	if diagnostics.HasError() {
+		return
+	}
+
+	model.expiry = responseExpiry(response.Header, time.Now())
+
+	// The response has not changed, keep the previous one.
+	if conditional && response.StatusCode == http.StatusNotModified {
+		cacheHit = true
+		return
+	}
+
+	response.Body = &countingReadCloser{ReadCloser: response.Body, count: &received}
+
+	var bytes []byte
+	forwardTo := types.ObjectNull(forwardAttrTypes)
+
+	if forward != nil {
+		statusCode, err := forward.send(ctx, response, timeout)
+		if err != nil {
+			diagnostics.AddAttributeError(
+				path.Root("forward_to"),
+				"Error forwarding response body",
+				fmt.Sprintf("Error forwarding response body: %s", err),
+			)
+			return
+		}
+
+		forward.StatusCode = types.Int64Value(int64(statusCode))
+
+		var diags diag.Diagnostics
+		forwardTo, diags = types.ObjectValueFrom(ctx, forwardAttrTypes, forward)
+		diagnostics.Append(diags...)
+		if diagnostics.HasError() {
+			return
+		}
+	} else {
+		var body io.Reader = response.Body
+		if !model.MaxResponseBodyBytes.IsNull() {
+			maxBytes := model.MaxResponseBodyBytes.ValueInt64()
+			if response.ContentLength > maxBytes {
+				addBodyTooLargeError(diagnostics, bodyTooLargeError{maxBytes})
+				return
+			}
+			body = &cappedReader{r: response.Body, remaining: maxBytes, maxBytes: maxBytes}
+		}
+
+		bytes, err = io.ReadAll(body)
+		if tooLarge := (bodyTooLargeError{}); errors.As(err, &tooLarge) {
+			addBodyTooLargeError(diagnostics, tooLarge)
+			return
+		}
+		if err != nil {
+			diagnostics.AddError(
+				"Error reading response body",
+				fmt.Sprintf("Error reading response body: %s", err),
+			)
+			return
+		}
+
+		// The transport only decompresses the gzip responses it requested.
+		if !response.Uncompressed {
+			bytes, err = decompressBody(bytes, response.Header.Values("Content-Encoding"))
+			if err != nil {
+				diagnostics.AddError(
+					"Error reading response body",
+					fmt.Sprintf("Error decompressing response body: %s", err),
+				)
+				return
+			}
+		}
+
+		// The limit also applies to the decompressed body.
+		if maxBytes := model.MaxResponseBodyBytes; !maxBytes.IsNull() && int64(len(bytes)) > maxBytes.ValueInt64() {
+			addBodyTooLargeError(diagnostics, bodyTooLargeError{maxBytes.ValueInt64()})
+			return
+		}
+	}
+
+	if !utf8.Valid(bytes) {
+		diagnostics.AddWarning(
+			"Response body is not recognized as UTF-8",
+			"Terraform may not properly handle the response_body if the contents are binary.",
+		)
+	}
+
+	responseBody := string(bytes)
+
+	model.checkContentType(response.Header.Get("Content-Type"), diagnostics)
+	model.checkResponseBody(responseBody, diagnostics)
+	model.checkCEL(response, bytes, diagnostics)
+	model.checkResponseSchema(ctx, &http.Client{Transport: clonedTr, Timeout: retryClient.HTTPClient.Timeout}, bytes, diagnostics)
+	if diagnostics.HasError() {
+		return
+	}
+
+	responseBodyBase64Std := base64.StdEncoding.EncodeToString(bytes)
+
+	responseHeaders := make(map[string]string)
+	for k, v := range response.Header {
+		// Concatenate according to RFC9110 https://www.rfc-editor.org/rfc/rfc9110.html#section-5.2
+		responseHeaders[k] = strings.Join(v, ", ")
 	}
 
 	respHeadersState, diags := types.MapValueFrom(ctx, types.StringType, responseHeaders)
@@ -318,10 +1088,411 @@ func (model *modelV0) read(ctx context.Context, diagnostics *diag.Diagnostics) {
 		return
 	}
 
+	// The values of the headers are kept apart, as they may contain commas.
+	respHeadersAllState, diags := types.MapValueFrom(ctx, types.ListType{ElemType: types.StringType}, map[string][]string(response.Header))
+	diagnostics.Append(diags...)
+	if diagnostics.HasError() {
+		return
+	}
+
+	peerCertificates := types.ListNull(types.ObjectType{AttrTypes: tlsCertificateAttrTypes})
+	if response.TLS != nil {
+		peerCertificates, diags = tlsCertificatesValue(response.TLS.PeerCertificates)
+		diagnostics.Append(diags...)
+		if diagnostics.HasError() {
+			return
+		}
+	}
+
+	contentSummary := types.ObjectNull(contentSummaryAttrTypes)
+	if forward == nil {
+		contentSummary, diags = contentSummaryValue(bytes, response.Header.Get("Content-Type"))
+		diagnostics.Append(diags...)
+		if diagnostics.HasError() {
+			return
+		}
+	}
+
+	responseBodyJSON := types.DynamicNull()
+	if forward == nil && isJSONContentType(response.Header.Get("Content-Type")) {
+		value, err := decodeJSON(bytes)
+		if err != nil {
+			diagnostics.AddWarning(
+				"Response body is not valid JSON",
+				fmt.Sprintf("The response Content-Type is JSON but the body could not be decoded, response_body_json will be null.\n\nError: %s", err),
+			)
+		} else {
+			responseBodyJSON = types.DynamicValue(value)
+		}
+	}
+
+	queryResults := types.DynamicNull()
+	if !model.ResponseQueries.IsNull() {
+		var queries map[string]string
+		diagnostics.Append(model.ResponseQueries.ElementsAs(ctx, &queries, false)...)
+		if diagnostics.HasError() {
+			return
+		}
+
+		value, err := evaluateQueries(bytes, queries)
+		if err != nil {
+			diagnostics.AddError(
+				"Error evaluating response queries",
+				fmt.Sprintf("Error evaluating response queries: %s", err),
+			)
+			return
+		}
+		queryResults = types.DynamicValue(value)
+	}
+
+	graphqlData, graphqlErrors := types.DynamicNull(), types.DynamicNull()
+	if graphql != nil {
+		data, errs, err := decodeGraphQLResponse(bytes)
+		if err != nil {
+			diagnostics.AddError(
+				"Error decoding GraphQL response",
+				fmt.Sprintf("Error decoding GraphQL response: %s", err),
+			)
+			return
+		}
+
+		if tuple, ok := errs.(types.Tuple); ok && len(tuple.Elements()) > 0 {
+			diagnostics.AddWarning(
+				"GraphQL response contains errors",
+				"The GraphQL API returned errors, see graphql_errors for details.",
+			)
+		}
+
+		graphqlData, graphqlErrors = types.DynamicValue(data), types.DynamicValue(errs)
+	}
+
+	soapBody, soapFault := types.StringNull(), types.ObjectNull(soapFaultAttrTypes)
+	if soap != nil {
+		content, fault, err := decodeSOAPResponse(bytes)
+		if err != nil {
+			diagnostics.AddError(
+				"Error decoding SOAP response",
+				fmt.Sprintf("Error decoding SOAP response: %s", err),
+			)
+			return
+		}
+
+		if fault != nil {
+			diagnostics.AddWarning(
+				"SOAP response contains a fault",
+				fmt.Sprintf("The SOAP service returned the fault %s, see soap_fault for details.", fault),
+			)
+		}
+
+		soapBody = types.StringValue(content)
+		soapFault, diags = soapFaultValue(fault)
+		diagnostics.Append(diags...)
+		if diagnostics.HasError() {
+			return
+		}
+	}
+
 	model.ID = types.StringValue(requestURL)
 	model.ResponseHeaders = respHeadersState
+	model.ResponseHeadersAll = respHeadersAllState
 	model.ResponseBody = types.StringValue(responseBody)
 	model.Body = types.StringValue(responseBody)
 	model.ResponseBodyBase64 = types.StringValue(responseBodyBase64Std)
+	model.ContentSummary = contentSummary
+	model.ResponseBodyJSON = responseBodyJSON
+	model.QueryResults = queryResults
+	model.GraphQLData = graphqlData
+	model.GraphQLErrors = graphqlErrors
+	model.SOAPBody = soapBody
+	model.SOAPFault = soapFault
 	model.StatusCode = types.Int64Value(int64(response.StatusCode))
+	model.RequestID = stringOrNull(requestID.lastID())
+	model.IsSuccess = types.BoolValue(isSuccessStatus(response.StatusCode, successStatusCodes))
+	model.StatusClass = types.StringValue(statusClass(response.StatusCode))
+	model.CurlCommand = types.StringValue(curlCommand)
+
+	duration, dnsDuration, connectDuration, tlsDuration, firstByteDuration := timings.milliseconds()
+	model.Duration = types.Int64Value(duration)
+	model.DNSDuration = types.Int64Value(dnsDuration)
+	model.ConnectDuration = types.Int64Value(connectDuration)
+	model.TLSDuration = types.Int64Value(tlsDuration)
+	model.FirstByteDuration = types.Int64Value(firstByteDuration)
+	model.TLSPeerCertificates = peerCertificates
+	model.ForwardTo = forwardTo
+
+	// The body was streamed to `forward_to` and is not kept.
+	if forward != nil {
+		model.ResponseBody = types.StringNull()
+		model.Body = types.StringNull()
+		model.ResponseBodyBase64 = types.StringNull()
+	}
+
+	model.discardStateBody()
+	model.compressStateBody(bytes, diagnostics)
+	if diagnostics.HasError() {
+		return
+	}
+
+	model.validators = nil
+	if isConditionalMethod(method) {
+		model.validators = newCacheValidators(requestURL, response.Header)
+	}
+}
+
+// transport returns the transport of the requests, configured with the proxy,
+// the dialer and the TLS settings of the model.
+func (model *modelV0) transport(ctx context.Context, diagnostics *diag.Diagnostics) *http.Transport {
+	tr, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		diagnostics.AddError(
+			"Error configuring http transport",
+			"Error http: Can't configure http transport.",
+		)
+		return nil
+	}
+
+	// Prevent issues with multiple data source configurations modifying the shared transport.
+	clonedTr := tr.Clone()
+
+	// Prevent issues with tests caching the proxy configuration.
+	clonedTr.Proxy = func(req *http.Request) (*url.URL, error) {
+		return httpproxy.FromEnvironment().ProxyFunc()(req.URL)
+	}
+
+	if !model.ProxyURL.IsNull() {
+		proxyURL, err := url.Parse(model.ProxyURL.ValueString())
+		if err != nil {
+			diagnostics.AddAttributeError(
+				path.Root("proxy_url"),
+				"Invalid proxy URL",
+				fmt.Sprintf("Error parsing proxy URL: %s", err),
+			)
+			return nil
+		}
+		clonedTr.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if !model.UnixSocket.IsNull() {
+		socket := model.UnixSocket.ValueString()
+		clonedTr.Proxy = nil
+		clonedTr.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, "unix", socket)
+		}
+	}
+
+	// The dialer of the default transport.
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}
+
+	ipVersion := model.IPVersion.ValueString()
+	if ipVersion == ipVersion4 || ipVersion == ipVersion6 {
+		// Restricted to the address family.
+		clonedTr.DialContext = func(ctx context.Context, _, addr string) (net.Conn, error) {
+			return dialer.DialContext(ctx, "tcp"+ipVersion, addr)
+		}
+	}
+
+	if !model.DNSOverHTTPS.IsNull() {
+		clonedTr.DialContext = newDoHDialer(model.DNSOverHTTPS.ValueString(), ipVersion, dialer).DialContext
+	}
+
+	if clonedTr.TLSClientConfig == nil {
+		clonedTr.TLSClientConfig = &tls.Config{}
+	}
+
+	if !model.Insecure.IsNull() {
+		if clonedTr.TLSClientConfig == nil {
+			clonedTr.TLSClientConfig = &tls.Config{}
+		}
+		clonedTr.TLSClientConfig.InsecureSkipVerify = model.Insecure.ValueBool()
+	}
+
+	if !model.PinnedCertSHA256.IsNull() {
+		var pins []string
+		diags := model.PinnedCertSHA256.ElementsAs(ctx, &pins, false)
+		diagnostics.Append(diags...)
+		if diagnostics.HasError() {
+			return nil
+		}
+
+		// The chain is not verified, the pins are checked instead.
+		clonedTr.TLSClientConfig.InsecureSkipVerify = true
+		clonedTr.TLSClientConfig.VerifyConnection = makePinnedCertificateVerifier(pins)
+	}
+
+	caCertPEM := readPEM(model.CaCertificate, model.CaCertFile, "ca_cert_file", diagnostics)
+	clientCertPEM := readPEM(model.ClientCert, model.ClientCertFile, "client_cert_file", diagnostics)
+	clientKeyPEM := readPEM(model.ClientKey, model.ClientKeyFile, "client_key_file", diagnostics)
+	if diagnostics.HasError() {
+		return nil
+	}
+
+	if clientKeyPEM != nil && !model.ClientKeyPassword.IsNull() {
+		var err error
+		clientKeyPEM, err = decryptPEMKey(clientKeyPEM, model.ClientKeyPassword.ValueString())
+		if err != nil {
+			diagnostics.AddAttributeError(
+				path.Root("client_key_password"),
+				"Error decrypting client key",
+				fmt.Sprintf("Error decrypting the client key: %s", err),
+			)
+			return nil
+		}
+	}
+
+	// Use `ca_cert_pem` cert pool, or the system one it is appended to.
+	if caCertPEM != nil {
+		caCertPool := x509.NewCertPool()
+		if model.CaCertAppend.ValueBool() {
+			systemCertPool, err := x509.SystemCertPool()
+			if err != nil {
+				diagnostics.AddAttributeError(
+					path.Root("ca_cert_append"),
+					"Error configuring TLS client",
+					fmt.Sprintf("Error loading the system certificate pool: %s", err),
+				)
+				return nil
+			}
+			caCertPool = systemCertPool
+		}
+
+		if ok := caCertPool.AppendCertsFromPEM(caCertPEM); !ok {
+			diagnostics.AddError(
+				"Error configuring TLS client",
+				"Error tls: Can't add the CA certificate to certificate pool. Only PEM encoded certificates are supported.",
+			)
+			return nil
+		}
+
+		if clonedTr.TLSClientConfig == nil {
+			clonedTr.TLSClientConfig = &tls.Config{}
+		}
+		clonedTr.TLSClientConfig.RootCAs = caCertPool
+	}
+
+	if clientCertPEM != nil && clientKeyPEM != nil {
+		cert, err := tls.X509KeyPair(clientCertPEM, clientKeyPEM)
+		if err != nil {
+			diagnostics.AddError(
+				"error creating x509 key pair",
+				fmt.Sprintf("error creating x509 key pair from provided pem blocks\n\nError: %s", err),
+			)
+			return nil
+		}
+		clonedTr.TLSClientConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	if !model.ClientPKCS12.IsNull() {
+		cert, err := decodePKCS12(model.ClientPKCS12.ValueString(), model.ClientPKCS12Password.ValueString())
+		if err != nil {
+			diagnostics.AddAttributeError(
+				path.Root("client_pkcs12_base64"),
+				"Error loading PKCS#12 bundle",
+				fmt.Sprintf("Error loading the client certificate from the PKCS#12 bundle: %s", err),
+			)
+			return nil
+		}
+		clonedTr.TLSClientConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return clonedTr
+}
+
+// attemptTimeout returns the timeout of each attempt, zero when not set.
+func (model *modelV0) attemptTimeout() time.Duration {
+	if model.AttemptTimeout.ValueInt64() > 0 {
+		return time.Duration(model.AttemptTimeout.ValueInt64()) * time.Millisecond
+	}
+	if model.RequestTimeout.ValueInt64() > 0 {
+		return time.Duration(model.RequestTimeout.ValueInt64()) * time.Millisecond
+	}
+	return 0
+}
+
+// disable sets the computed attributes to null, the request being skipped.
+func (model *modelV0) disable(ctx context.Context, forward *forwardModel, diagnostics *diag.Diagnostics) {
+	model.ID = types.StringNull()
+	model.ResponseHeaders = types.MapNull(types.StringType)
+	model.ResponseHeadersAll = types.MapNull(types.ListType{ElemType: types.StringType})
+	model.ResponseBody = types.StringNull()
+	model.Body = types.StringNull()
+	model.ResponseBodyBase64 = types.StringNull()
+	model.ResponseBodyGzip = types.StringNull()
+	model.ContentSummary = types.ObjectNull(contentSummaryAttrTypes)
+	model.ResponseBodyJSON = types.DynamicNull()
+	model.QueryResults = types.DynamicNull()
+	model.GraphQLData = types.DynamicNull()
+	model.GraphQLErrors = types.DynamicNull()
+	model.SOAPBody = types.StringNull()
+	model.SOAPFault = types.ObjectNull(soapFaultAttrTypes)
+	model.StatusCode = types.Int64Null()
+	model.RequestID = types.StringNull()
+	model.IsSuccess = types.BoolNull()
+	model.StatusClass = types.StringNull()
+	model.CurlCommand = types.StringNull()
+	model.Duration = types.Int64Null()
+	model.DNSDuration = types.Int64Null()
+	model.ConnectDuration = types.Int64Null()
+	model.TLSDuration = types.Int64Null()
+	model.FirstByteDuration = types.Int64Null()
+	model.TLSPeerCertificates = types.ListNull(types.ObjectType{AttrTypes: tlsCertificateAttrTypes})
+	model.validators = nil
+	model.expiry = time.Time{}
+
+	if forward != nil {
+		forward.StatusCode = types.Int64Null()
+
+		var diags diag.Diagnostics
+		model.ForwardTo, diags = types.ObjectValueFrom(ctx, forwardAttrTypes, forward)
+		diagnostics.Append(diags...)
+	}
+}
+
+// countingReadCloser counts the bytes read from the ReadCloser.
+type countingReadCloser struct {
+	io.ReadCloser
+	count *int64
+}
+
+func (c *countingReadCloser) Read(p []byte) (int, error) {
+	n, err := c.ReadCloser.Read(p)
+	*c.count += int64(n)
+	return n, err
+}
+
+// addBodyTooLargeError reports a response body exceeding
+// `max_response_body_bytes`.
+func addBodyTooLargeError(diagnostics *diag.Diagnostics, err bodyTooLargeError) {
+	diagnostics.AddAttributeError(
+		path.Root("max_response_body_bytes"),
+		"Response body too large",
+		fmt.Sprintf("The response body is not read: %s. Increase max_response_body_bytes to read it.", err),
+	)
+}
+
+// readPEM returns the inline PEM value, or the content of the file when set,
+// or nil when neither is set.
+func readPEM(value, file types.String, fileAttribute string, diagnostics *diag.Diagnostics) []byte {
+	if !value.IsNull() {
+		return []byte(value.ValueString())
+	}
+
+	if file.IsNull() {
+		return nil
+	}
+
+	data, err := os.ReadFile(file.ValueString())
+	if err != nil {
+		diagnostics.AddAttributeError(
+			path.Root(fileAttribute),
+			"Error reading PEM file",
+			fmt.Sprintf("Error reading PEM file: %s", err),
+		)
+		return nil
+	}
+
+	return data
 }
@@ -0,0 +1,160 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package http
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	soap11 = "1.1"
+	soap12 = "1.2"
+
+	soap11Namespace = "http://schemas.xmlsoap.org/soap/envelope/"
+	soap12Namespace = "http://www.w3.org/2003/05/soap-envelope"
+)
+
+// soapFaultAttrTypes are the attributes of `soap_fault`.
+var soapFaultAttrTypes = map[string]attr.Type{
+	"code":   types.StringType,
+	"reason": types.StringType,
+	"actor":  types.StringType,
+	"detail": types.StringType,
+}
+
+type soapModel struct {
+	Action         types.String `tfsdk:"action"`
+	EnvelopeBody   types.String `tfsdk:"envelope_body"`
+	EnvelopeHeader types.String `tfsdk:"envelope_header"`
+	Version        types.String `tfsdk:"version"`
+}
+
+// envelope returns the SOAP envelope wrapping the body and header elements,
+// in the namespace of the SOAP version.
+func (model *soapModel) envelope() []byte {
+	namespace := soap11Namespace
+	if model.Version.ValueString() == soap12 {
+		namespace = soap12Namespace
+	}
+
+	var b bytes.Buffer
+	b.WriteString(xml.Header)
+	fmt.Fprintf(&b, `<soap:Envelope xmlns:soap="%s">`, namespace)
+	if !model.EnvelopeHeader.IsNull() {
+		fmt.Fprintf(&b, "<soap:Header>%s</soap:Header>", model.EnvelopeHeader.ValueString())
+	}
+	fmt.Fprintf(&b, "<soap:Body>%s</soap:Body>", model.EnvelopeBody.ValueString())
+	b.WriteString("</soap:Envelope>")
+
+	return b.Bytes()
+}
+
+// setHeaders sets the Content-Type of the SOAP version and the action, in
+// the `SOAPAction` header for SOAP 1.1 and as a media type parameter for
+// SOAP 1.2.
+func (model *soapModel) setHeaders(header http.Header) {
+	action := model.Action.ValueString()
+
+	if model.Version.ValueString() == soap12 {
+		contentType := "application/soap+xml; charset=utf-8"
+		if action != "" {
+			contentType += fmt.Sprintf("; action=%q", action)
+		}
+		header.Set("Content-Type", contentType)
+		header.Set("Accept", "application/soap+xml")
+		return
+	}
+
+	header.Set("Content-Type", "text/xml; charset=utf-8")
+	header.Set("Accept", "text/xml")
+	header.Set("SOAPAction", fmt.Sprintf("%q", action))
+}
+
+// soapFault is a fault of either SOAP version, the elements of SOAP 1.1 and
+// SOAP 1.2 having different names.
+type soapFault struct {
+	FaultCode   string       `xml:"faultcode"`
+	FaultString string       `xml:"faultstring"`
+	FaultActor  string       `xml:"faultactor"`
+	FaultDetail soapInnerXML `xml:"detail"`
+
+	Code   string       `xml:"Code>Value"`
+	Reason string       `xml:"Reason>Text"`
+	Role   string       `xml:"Role"`
+	Detail soapInnerXML `xml:"Detail"`
+}
+
+type soapInnerXML struct {
+	Content string `xml:",innerxml"`
+}
+
+// String returns the code and the reason of the fault.
+func (fault *soapFault) String() string {
+	return fmt.Sprintf("%s: %s", fault.code(), fault.reason())
+}
+
+func (fault *soapFault) code() string {
+	return cmp.Or(fault.Code, fault.FaultCode)
+}
+
+func (fault *soapFault) reason() string {
+	return cmp.Or(fault.Reason, fault.FaultString)
+}
+
+// decodeSOAPResponse returns the content of the body of a SOAP envelope and
+// its fault, nil when the body holds none.
+func decodeSOAPResponse(body []byte) (string, *soapFault, error) {
+	var envelope struct {
+		XMLName xml.Name
+		Body    *struct {
+			Content string     `xml:",innerxml"`
+			Fault   *soapFault `xml:"Fault"`
+		} `xml:"Body"`
+	}
+	if err := xml.Unmarshal(body, &envelope); err != nil {
+		return "", nil, err
+	}
+
+	if envelope.XMLName.Local != "Envelope" || envelope.Body == nil {
+		return "", nil, errors.New("the response is not a SOAP envelope")
+	}
+
+	return strings.TrimSpace(envelope.Body.Content), envelope.Body.Fault, nil
+}
+
+// soapFaultValue returns the value of `soap_fault`, null when there is no
+// fault.
+func soapFaultValue(fault *soapFault) (types.Object, diag.Diagnostics) {
+	if fault == nil {
+		return types.ObjectNull(soapFaultAttrTypes), nil
+	}
+
+	actor := cmp.Or(fault.Role, fault.FaultActor)
+	detail := strings.TrimSpace(cmp.Or(fault.Detail.Content, fault.FaultDetail.Content))
+
+	return types.ObjectValue(soapFaultAttrTypes, map[string]attr.Value{
+		"code":   types.StringValue(fault.code()),
+		"reason": types.StringValue(fault.reason()),
+		"actor":  stringOrNull(actor),
+		"detail": stringOrNull(detail),
+	})
+}
+
+// stringOrNull returns the string, null when it is empty.
+func stringOrNull(value string) types.String {
+	if value == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(value)
+}
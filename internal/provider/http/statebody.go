@@ -0,0 +1,94 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package http
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"io"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// compressStateBody replaces the attributes holding the response body by its
+// gzip stream encoded as base64, the format of the gzip_base64 function, so
+// that large text responses take less room in the state.
+func (model *modelV0) compressStateBody(body []byte, diagnostics *diag.Diagnostics) {
+	model.ResponseBodyGzip = types.StringNull()
+	if !model.CompressStateBody.ValueBool() || model.ResponseBody.IsNull() {
+		return
+	}
+
+	// The header holds no name nor modification time, so that the state only
+	// changes with the body.
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write(body); err != nil {
+		addCompressStateBodyError(diagnostics, err)
+		return
+	}
+	if err := writer.Close(); err != nil {
+		addCompressStateBodyError(diagnostics, err)
+		return
+	}
+
+	model.ResponseBodyGzip = types.StringValue(base64.StdEncoding.EncodeToString(buf.Bytes()))
+	model.ResponseBody = types.StringNull()
+	model.Body = types.StringNull()
+	model.ResponseBodyBase64 = types.StringNull()
+	model.ResponseBodyJSON = types.DynamicNull()
+}
+
+// discardStateBody leaves the response body out of the state when
+// discard_response_body is set, the content summary holding its hash.
+func (model *modelV0) discardStateBody() {
+	if !model.DiscardResponseBody.ValueBool() {
+		return
+	}
+
+	model.ResponseBody = types.StringNull()
+	model.Body = types.StringNull()
+	model.ResponseBodyBase64 = types.StringNull()
+	model.ResponseBodyJSON = types.DynamicNull()
+}
+
+func addCompressStateBodyError(diagnostics *diag.Diagnostics, err error) {
+	diagnostics.AddError(
+		"Error compressing response body",
+		fmt.Sprintf("Error compressing the response body stored in the state: %s", err),
+	)
+}
+
+// responseBody returns the response body held by the state, decompressing it
+// when compress_state_body is set, and whether there is one.
+func (model *modelV0) responseBody() ([]byte, bool) {
+	if !model.ResponseBody.IsNull() {
+		return []byte(model.ResponseBody.ValueString()), true
+	}
+
+	if model.ResponseBodyGzip.IsNull() {
+		return nil, false
+	}
+
+	data, err := base64.StdEncoding.DecodeString(model.ResponseBodyGzip.ValueString())
+	if err != nil {
+		return nil, false
+	}
+
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, false
+	}
+	defer reader.Close()
+
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, false
+	}
+
+	return body, true
+}
@@ -0,0 +1,55 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package http
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// contentSummaryPreviewLength is the number of characters of the response
+// body in the preview of `content_summary`.
+const contentSummaryPreviewLength = 200
+
+// contentSummaryAttrTypes are the attributes of `content_summary`.
+var contentSummaryAttrTypes = map[string]attr.Type{
+	"size":         types.Int64Type,
+	"sha256":       types.StringType,
+	"content_type": types.StringType,
+	"preview":      types.StringType,
+}
+
+// contentSummaryValue returns the summary of the response body, the preview
+// being null when the body is not UTF-8.
+func contentSummaryValue(body []byte, contentType string) (types.Object, diag.Diagnostics) {
+	checksum := sha256.Sum256(body)
+
+	preview := types.StringNull()
+	if utf8.Valid(body) {
+		// Only the first runes are decoded, the body may be large.
+		end := 0
+		for i := 0; i < contentSummaryPreviewLength && end < len(body); i++ {
+			_, size := utf8.DecodeRune(body[end:])
+			end += size
+		}
+		preview = types.StringValue(string(body[:end]))
+	}
+
+	contentTypeValue := types.StringNull()
+	if contentType != "" {
+		contentTypeValue = types.StringValue(contentType)
+	}
+
+	return types.ObjectValue(contentSummaryAttrTypes, map[string]attr.Value{
+		"size":         types.Int64Value(int64(len(body))),
+		"sha256":       types.StringValue(hex.EncodeToString(checksum[:])),
+		"content_type": contentTypeValue,
+		"preview":      preview,
+	})
+}
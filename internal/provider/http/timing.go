@@ -0,0 +1,73 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package http
+
+import (
+	"crypto/tls"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+// requestTimings measures the phases of the last attempt of a request and
+// the duration of the whole request, retries included.
+type requestTimings struct {
+	start time.Time
+
+	mu                                             sync.Mutex
+	attemptStart, dnsStart, connectStart, tlsStart time.Time
+	dns, connect, tls, firstByte                   time.Duration
+}
+
+func newRequestTimings() *requestTimings {
+	return &requestTimings{start: time.Now()}
+}
+
+// clientTrace returns the hooks measuring the phases of an attempt, reset
+// when the attempt gets a connection.
+func (timings *requestTimings) clientTrace() *httptrace.ClientTrace {
+	since := func(start *time.Time, duration *time.Duration) {
+		timings.mu.Lock()
+		defer timings.mu.Unlock()
+		*duration = time.Since(*start)
+	}
+	now := func(start *time.Time) {
+		timings.mu.Lock()
+		defer timings.mu.Unlock()
+		*start = time.Now()
+	}
+
+	return &httptrace.ClientTrace{
+		GetConn: func(string) {
+			timings.mu.Lock()
+			defer timings.mu.Unlock()
+			timings.attemptStart = time.Now()
+			timings.dns, timings.connect, timings.tls, timings.firstByte = 0, 0, 0, 0
+		},
+		DNSStart:     func(httptrace.DNSStartInfo) { now(&timings.dnsStart) },
+		DNSDone:      func(httptrace.DNSDoneInfo) { since(&timings.dnsStart, &timings.dns) },
+		ConnectStart: func(string, string) { now(&timings.connectStart) },
+		ConnectDone:  func(string, string, error) { since(&timings.connectStart, &timings.connect) },
+		TLSHandshakeStart: func() {
+			now(&timings.tlsStart)
+		},
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			since(&timings.tlsStart, &timings.tls)
+		},
+		GotFirstResponseByte: func() {
+			since(&timings.attemptStart, &timings.firstByte)
+		},
+	}
+}
+
+// milliseconds returns the durations in milliseconds: the whole request up to
+// now, then the DNS lookup, connection, TLS handshake and first response byte
+// of the last attempt, zero when the phase did not happen.
+func (timings *requestTimings) milliseconds() (duration, dns, connect, tls, firstByte int64) {
+	timings.mu.Lock()
+	defer timings.mu.Unlock()
+
+	return time.Since(timings.start).Milliseconds(), timings.dns.Milliseconds(), timings.connect.Milliseconds(),
+		timings.tls.Milliseconds(), timings.firstByte.Milliseconds()
+}
@@ -0,0 +1,76 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package http
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// tlsCertificateAttrTypes are the attributes of the elements of
+// `tls_peer_certificates`.
+var tlsCertificateAttrTypes = map[string]attr.Type{
+	"subject":    types.StringType,
+	"issuer":     types.StringType,
+	"sans":       types.ListType{ElemType: types.StringType},
+	"not_before": types.StringType,
+	"not_after":  types.StringType,
+	"sha256":     types.StringType,
+}
+
+// tlsCertificatesValue returns the details of the certificates as a list of
+// `tls_peer_certificates` objects.
+func tlsCertificatesValue(certs []*x509.Certificate) (types.List, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	elemType := types.ObjectType{AttrTypes: tlsCertificateAttrTypes}
+
+	elements := make([]attr.Value, 0, len(certs))
+	for _, cert := range certs {
+		var sans []attr.Value
+		for _, name := range cert.DNSNames {
+			sans = append(sans, types.StringValue(name))
+		}
+		for _, ip := range cert.IPAddresses {
+			sans = append(sans, types.StringValue(ip.String()))
+		}
+		for _, email := range cert.EmailAddresses {
+			sans = append(sans, types.StringValue(email))
+		}
+		for _, uri := range cert.URIs {
+			sans = append(sans, types.StringValue(uri.String()))
+		}
+
+		sansValue, d := types.ListValue(types.StringType, sans)
+		diags.Append(d...)
+
+		fingerprint := sha256.Sum256(cert.Raw)
+
+		element, d := types.ObjectValue(tlsCertificateAttrTypes, map[string]attr.Value{
+			"subject":    types.StringValue(cert.Subject.String()),
+			"issuer":     types.StringValue(cert.Issuer.String()),
+			"sans":       sansValue,
+			"not_before": types.StringValue(cert.NotBefore.UTC().Format(time.RFC3339)),
+			"not_after":  types.StringValue(cert.NotAfter.UTC().Format(time.RFC3339)),
+			"sha256":     types.StringValue(hex.EncodeToString(fingerprint[:])),
+		})
+		diags.Append(d...)
+
+		elements = append(elements, element)
+	}
+
+	if diags.HasError() {
+		return types.ListNull(elemType), diags
+	}
+
+	list, d := types.ListValue(elemType, elements)
+	diags.Append(d...)
+
+	return list, diags
+}
@@ -0,0 +1,132 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package http
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http/httptrace"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// requestTrace records the DNS, connection and TLS events of the attempts of a
+// request, logging them as they happen.
+type requestTrace struct {
+	ctx   context.Context
+	start time.Time
+
+	mu     sync.Mutex
+	events []string
+}
+
+func newRequestTrace(ctx context.Context) *requestTrace {
+	return &requestTrace{ctx: ctx, start: time.Now()}
+}
+
+func (trace *requestTrace) record(format string, args ...interface{}) {
+	event := fmt.Sprintf("+%s %s", time.Since(trace.start).Round(time.Millisecond), fmt.Sprintf(format, args...))
+
+	trace.mu.Lock()
+	trace.events = append(trace.events, event)
+	trace.mu.Unlock()
+
+	tflog.Debug(trace.ctx, "HTTP trace: "+event)
+}
+
+// clientTrace returns the hooks recording the events of an attempt.
+func (trace *requestTrace) clientTrace() *httptrace.ClientTrace {
+	return &httptrace.ClientTrace{
+		DNSStart: func(info httptrace.DNSStartInfo) {
+			trace.record("DNS lookup of %s", info.Host)
+		},
+		DNSDone: func(info httptrace.DNSDoneInfo) {
+			if info.Err != nil {
+				trace.record("DNS lookup failed: %s", info.Err)
+				return
+			}
+			addrs := make([]string, len(info.Addrs))
+			for i, addr := range info.Addrs {
+				addrs[i] = addr.String()
+			}
+			trace.record("DNS lookup resolved %s", strings.Join(addrs, ", "))
+		},
+		ConnectStart: func(network, addr string) {
+			trace.record("connecting to %s %s", network, addr)
+		},
+		ConnectDone: func(network, addr string, err error) {
+			if err != nil {
+				trace.record("connection to %s %s failed: %s", network, addr, err)
+				return
+			}
+			trace.record("connected to %s %s", network, addr)
+		},
+		TLSHandshakeStart: func() {
+			trace.record("TLS handshake")
+		},
+		TLSHandshakeDone: func(state tls.ConnectionState, err error) {
+			if err != nil {
+				trace.record("TLS handshake failed: %s", err)
+				return
+			}
+			trace.record("TLS handshake done: %s, %s", tls.VersionName(state.Version), tls.CipherSuiteName(state.CipherSuite))
+		},
+		GotConn: func(info httptrace.GotConnInfo) {
+			trace.record("using connection to %s, reused: %t", info.Conn.RemoteAddr(), info.Reused)
+		},
+		WroteRequest: func(info httptrace.WroteRequestInfo) {
+			if info.Err != nil {
+				trace.record("writing the request failed: %s", info.Err)
+				return
+			}
+			trace.record("request written")
+		},
+		GotFirstResponseByte: func() {
+			trace.record("first response byte received")
+		},
+	}
+}
+
+func (trace *requestTrace) String() string {
+	trace.mu.Lock()
+	defer trace.mu.Unlock()
+
+	return strings.Join(trace.events, "\n")
+}
+
+// appendTo appends the trace to the details of the errors of the diagnostics
+// from the index from.
+func (trace *requestTrace) appendTo(diagnostics *diag.Diagnostics, from int) {
+	for i := from; i < len(*diagnostics); i++ {
+		d := (*diagnostics)[i]
+		if d.Severity() != diag.SeverityError {
+			continue
+		}
+
+		detail := fmt.Sprintf("%s\n\nRequest trace:\n%s", d.Detail(), trace)
+		if withPath, ok := d.(diag.DiagnosticWithPath); ok {
+			(*diagnostics)[i] = diag.NewAttributeErrorDiagnostic(withPath.Path(), d.Summary(), detail)
+		} else {
+			(*diagnostics)[i] = diag.NewErrorDiagnostic(d.Summary(), detail)
+		}
+	}
+}
+
+// untraced returns a context inheriting the cancellation of ctx but not its
+// trace, so that the requests made on behalf of a request, e.g. to resolve its
+// host, are not reported as its own.
+func untraced(ctx context.Context) (context.Context, context.CancelFunc) {
+	untracedCtx, cancel := context.WithCancel(context.Background())
+	stop := context.AfterFunc(ctx, cancel)
+
+	return untracedCtx, func() {
+		stop()
+		cancel()
+	}
+}
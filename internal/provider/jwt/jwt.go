@@ -0,0 +1,169 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+// Package jwt signs JSON Web Tokens, as defined in
+// https://datatracker.ietf.org/doc/html/rfc7519.
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+)
+
+const (
+	algorithmRS256 = "RS256"
+	algorithmRS384 = "RS384"
+	algorithmRS512 = "RS512"
+	algorithmPS256 = "PS256"
+	algorithmPS384 = "PS384"
+	algorithmPS512 = "PS512"
+	algorithmES256 = "ES256"
+	algorithmES384 = "ES384"
+	algorithmES512 = "ES512"
+	algorithmEdDSA = "EdDSA"
+)
+
+var algorithms = []string{
+	algorithmRS256, algorithmRS384, algorithmRS512,
+	algorithmPS256, algorithmPS384, algorithmPS512,
+	algorithmES256, algorithmES384, algorithmES512,
+	algorithmEdDSA,
+}
+
+// parsePrivateKey parses a PEM encoded private key in the PKCS #8, PKCS #1 or
+// SEC 1 format.
+func parsePrivateKey(data string) (crypto.Signer, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil {
+		return nil, fmt.Errorf("the key is not PEM encoded")
+	}
+
+	var key interface{}
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key of type %T", key)
+	}
+
+	return signer, nil
+}
+
+// sign returns the token of the claims in the JWS compact serialization,
+// signed with the key according to the algorithm.
+func sign(algorithm string, key crypto.Signer, keyID string, claims map[string]interface{}) (string, error) {
+	header := map[string]string{"alg": algorithm, "typ": "JWT"}
+	if keyID != "" {
+		header["kid"] = keyID
+	}
+
+	encodedHeader, err := json.Marshal(header)
+	if err != nil {
+		return "", err
+	}
+
+	encodedClaims, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(encodedHeader) + "." + base64.RawURLEncoding.EncodeToString(encodedClaims)
+
+	signature, err := signatureOf(algorithm, key, []byte(signingInput))
+	if err != nil {
+		return "", err
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+func signatureOf(algorithm string, key crypto.Signer, data []byte) ([]byte, error) {
+	switch algorithm {
+	case algorithmRS256, algorithmRS384, algorithmRS512, algorithmPS256, algorithmPS384, algorithmPS512:
+		rsaKey, ok := key.(*rsa.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("the %s algorithm requires an RSA key, got %T", algorithm, key)
+		}
+
+		hash := hashOf(algorithm)
+		digest := hash.New()
+		digest.Write(data)
+
+		if algorithm[0] == 'P' {
+			return rsa.SignPSS(rand.Reader, rsaKey, hash, digest.Sum(nil), &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+		}
+		return rsa.SignPKCS1v15(rand.Reader, rsaKey, hash, digest.Sum(nil))
+	case algorithmES256, algorithmES384, algorithmES512:
+		ecdsaKey, ok := key.(*ecdsa.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("the %s algorithm requires an ECDSA key, got %T", algorithm, key)
+		}
+
+		curve := map[string]elliptic.Curve{
+			algorithmES256: elliptic.P256(),
+			algorithmES384: elliptic.P384(),
+			algorithmES512: elliptic.P521(),
+		}[algorithm]
+		if ecdsaKey.Curve != curve {
+			return nil, fmt.Errorf("the %s algorithm requires a key on the %s curve, got %s", algorithm, curve.Params().Name, ecdsaKey.Curve.Params().Name)
+		}
+
+		digest := hashOf(algorithm).New()
+		digest.Write(data)
+
+		r, s, err := ecdsa.Sign(rand.Reader, ecdsaKey, digest.Sum(nil))
+		if err != nil {
+			return nil, err
+		}
+
+		// The signature is the concatenation of R and S, each padded to the
+		// size of the curve.
+		size := (curve.Params().BitSize + 7) / 8
+		signature := make([]byte, 2*size)
+		r.FillBytes(signature[:size])
+		s.FillBytes(signature[size:])
+		return signature, nil
+	case algorithmEdDSA:
+		ed25519Key, ok := key.(ed25519.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("the %s algorithm requires an Ed25519 key, got %T", algorithm, key)
+		}
+
+		return ed25519.Sign(ed25519Key, data), nil
+	default:
+		return nil, fmt.Errorf("unsupported algorithm %q", algorithm)
+	}
+}
+
+// hashOf returns the hash function of the RSA and ECDSA algorithms, given by
+// their size.
+func hashOf(algorithm string) crypto.Hash {
+	switch algorithm[2:] {
+	case "384":
+		return crypto.SHA384
+	case "512":
+		return crypto.SHA512
+	default:
+		return crypto.SHA256
+	}
+}
@@ -0,0 +1,265 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package jwt
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource               = (*jwtResource)(nil)
+	_ resource.ResourceWithModifyPlan = (*jwtResource)(nil)
+)
+
+func NewJwtResource() resource.Resource {
+	return &jwtResource{}
+}
+
+type jwtResource struct{}
+
+type jwtResourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	Claims         types.Map    `tfsdk:"claims"`
+	KeyPEM         types.String `tfsdk:"key_pem"`
+	KeyID          types.String `tfsdk:"key_id"`
+	Algorithm      types.String `tfsdk:"algorithm"`
+	Expiry         types.Int64  `tfsdk:"expiry_seconds"`
+	RotationWindow types.Int64  `tfsdk:"rotation_window_seconds"`
+	Token          types.String `tfsdk:"token"`
+	IssuedAt       types.String `tfsdk:"issued_at"`
+	ExpiresAt      types.String `tfsdk:"expires_at"`
+}
+
+func (r *jwtResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_jwt"
+}
+
+func (r *jwtResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: `
+The ` + "`jwt`" + ` resource signs a [JSON Web Token](https://datatracker.ietf.org/doc/html/rfc7519) with a private key.
+
+The token is issued again when its claims, key, algorithm or expiry change, and when it expires in less than
+` + "`rotation_window_seconds`" + ` at plan time, so that the services bootstrapped with it are given a new token before
+the previous one expires.
+`,
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The SHA-256 hash of the token.",
+				Computed:    true,
+			},
+
+			"claims": schema.MapAttribute{
+				Description: "The claims of the token, e.g. `iss`, `sub` or `aud`. " +
+					"The `iat` and `exp` claims are set to the issue and expiry times of the token.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+
+			"key_pem": schema.StringAttribute{
+				Description: "The PEM encoded private key signing the token, in the PKCS #8, PKCS #1 (RSA) or SEC 1 (ECDSA) format.",
+				Required:    true,
+				Sensitive:   true,
+			},
+
+			"key_id": schema.StringAttribute{
+				Description: "The identifier of the key, set in the `kid` header of the token.",
+				Optional:    true,
+			},
+
+			"algorithm": schema.StringAttribute{
+				Description: "The signing algorithm, one of `RS256`, `RS384`, `RS512`, `PS256`, `PS384`, `PS512` (RSA keys), " +
+					"`ES256`, `ES384`, `ES512` (ECDSA keys on the P-256, P-384 and P-521 curves) or `EdDSA` (Ed25519 keys).",
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(algorithms...),
+				},
+			},
+
+			"expiry_seconds": schema.Int64Attribute{
+				Description: "The lifetime of the token, in seconds.",
+				Required:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+
+			"rotation_window_seconds": schema.Int64Attribute{
+				Description: "The time before the expiry of the token from which it is issued again, in seconds. " +
+					"Defaults to `0`, the token being issued again once expired.",
+				Optional: true,
+				Computed: true,
+				Default:  int64default.StaticInt64(0),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+
+			"token": schema.StringAttribute{
+				Description: "The signed token.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+
+			"issued_at": schema.StringAttribute{
+				Description: "The time the token was issued at, in [RFC 3339](https://datatracker.ietf.org/doc/html/rfc3339) format.",
+				Computed:    true,
+			},
+
+			"expires_at": schema.StringAttribute{
+				Description: "The time the token expires at, in [RFC 3339](https://datatracker.ietf.org/doc/html/rfc3339) format.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *jwtResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var model jwtResourceModel
+	diags := req.State.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+// ModifyPlan issues the token again when it expires within the rotation
+// window.
+func (r *jwtResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to rotate on creation and destruction.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var model jwtResourceModel
+	diags := req.Plan.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || model.ExpiresAt.IsUnknown() || model.RotationWindow.IsUnknown() {
+		return
+	}
+
+	expiresAt, err := time.Parse(time.RFC3339, model.ExpiresAt.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("expires_at"),
+			"Invalid expiry time",
+			fmt.Sprintf("Error parsing the expiry time of the token: %s", err),
+		)
+		return
+	}
+
+	rotationWindow := time.Duration(model.RotationWindow.ValueInt64()) * time.Second
+	if time.Now().Before(expiresAt.Add(-rotationWindow)) {
+		return
+	}
+
+	model.ID = types.StringUnknown()
+	model.Token = types.StringUnknown()
+	model.IssuedAt = types.StringUnknown()
+	model.ExpiresAt = types.StringUnknown()
+
+	diags = resp.Plan.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *jwtResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var model jwtResourceModel
+	diags := req.Plan.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	model.issue(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *jwtResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var model jwtResourceModel
+	diags := req.Plan.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	model.issue(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *jwtResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// The token is only removed from the state.
+}
+
+// issue signs a new token, valid from now.
+func (model *jwtResourceModel) issue(ctx context.Context, diagnostics *diag.Diagnostics) {
+	key, err := parsePrivateKey(model.KeyPEM.ValueString())
+	if err != nil {
+		diagnostics.AddAttributeError(
+			path.Root("key_pem"),
+			"Invalid private key",
+			fmt.Sprintf("Error parsing the private key: %s", err),
+		)
+		return
+	}
+
+	var configured map[string]string
+	diags := model.Claims.ElementsAs(ctx, &configured, false)
+	diagnostics.Append(diags...)
+	if diagnostics.HasError() {
+		return
+	}
+
+	issuedAt := time.Now().Truncate(time.Second)
+	expiresAt := issuedAt.Add(time.Duration(model.Expiry.ValueInt64()) * time.Second)
+
+	claims := make(map[string]interface{}, len(configured)+2)
+	for name, value := range configured {
+		claims[name] = value
+	}
+	claims["iat"] = issuedAt.Unix()
+	claims["exp"] = expiresAt.Unix()
+
+	token, err := sign(model.Algorithm.ValueString(), key, model.KeyID.ValueString(), claims)
+	if err != nil {
+		diagnostics.AddError(
+			"Error signing token",
+			fmt.Sprintf("Error signing the token: %s", err),
+		)
+		return
+	}
+
+	checksum := sha256.Sum256([]byte(token))
+	model.ID = types.StringValue(hex.EncodeToString(checksum[:]))
+	model.Token = types.StringValue(token)
+	model.IssuedAt = types.StringValue(issuedAt.UTC().Format(time.RFC3339))
+	model.ExpiresAt = types.StringValue(expiresAt.UTC().Format(time.RFC3339))
+}
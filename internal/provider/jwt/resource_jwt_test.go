@@ -0,0 +1,209 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package jwt_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// verifyToken checks the signature of the token with the public key and
+// compares its claims with the expected ones.
+func verifyToken(verify func(signingInput, signature []byte) bool, expected map[string]interface{}) resource.CheckResourceAttrWithFunc {
+	return func(token string) error {
+		parts := strings.Split(token, ".")
+		if len(parts) != 3 {
+			return fmt.Errorf("expected 3 parts, got %d", len(parts))
+		}
+
+		signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+		if err != nil {
+			return err
+		}
+
+		if !verify([]byte(parts[0]+"."+parts[1]), signature) {
+			return fmt.Errorf("invalid signature")
+		}
+
+		payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+		if err != nil {
+			return err
+		}
+
+		var claims map[string]interface{}
+		if err := json.Unmarshal(payload, &claims); err != nil {
+			return err
+		}
+
+		for name, value := range expected {
+			if claims[name] != value {
+				return fmt.Errorf("expected the %s claim to be %v, got %v", name, value, claims[name])
+			}
+		}
+
+		issuedAt, _ := claims["iat"].(float64)
+		expiresAt, _ := claims["exp"].(float64)
+		if expiresAt-issuedAt != 3600 {
+			return fmt.Errorf("expected the token to expire in 3600 seconds, got %v", claims)
+		}
+
+		return nil
+	}
+}
+
+func TestJwtResource_ES256(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	verify := func(signingInput, signature []byte) bool {
+		digest := sha256.Sum256(signingInput)
+		r, s := new(big.Int).SetBytes(signature[:32]), new(big.Int).SetBytes(signature[32:])
+		return len(signature) == 64 && ecdsa.Verify(&key.PublicKey, digest[:], r, s)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							resource "utilities_jwt" "jwt_test" {
+								algorithm      = "ES256"
+								key_pem        = %q
+								expiry_seconds = 3600
+								claims = {
+									iss = "terraform"
+									sub = "bootstrap"
+								}
+							}`, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrWith("utilities_jwt.jwt_test", "token", verifyToken(verify, map[string]interface{}{
+						"iss": "terraform",
+						"sub": "bootstrap",
+					})),
+					resource.TestCheckResourceAttrSet("utilities_jwt.jwt_test", "issued_at"),
+					resource.TestCheckResourceAttrSet("utilities_jwt.jwt_test", "expires_at"),
+				),
+			},
+		},
+	})
+}
+
+func TestJwtResource_RS256(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	verify := func(signingInput, signature []byte) bool {
+		digest := sha256.Sum256(signingInput)
+		return rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], signature) == nil
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							resource "utilities_jwt" "jwt_test" {
+								algorithm      = "RS256"
+								key_pem        = %q
+								key_id         = "bootstrap-1"
+								expiry_seconds = 3600
+								claims = {
+									aud = "api"
+								}
+							}`, pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})),
+				Check: resource.TestCheckResourceAttrWith("utilities_jwt.jwt_test", "token", verifyToken(verify, map[string]interface{}{
+					"aud": "api",
+				})),
+			},
+		},
+	})
+}
+
+func TestJwtResource_RotationWindow(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := func(rotationWindow int) string {
+		return fmt.Sprintf(`
+							resource "utilities_jwt" "jwt_test" {
+								algorithm               = "ES256"
+								key_pem                 = %q
+								expiry_seconds          = 3600
+								rotation_window_seconds = %d
+							}`, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), rotationWindow)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: config(60),
+			},
+			{
+				// The token expires in an hour, within the rotation window, it
+				// is issued again on each plan.
+				Config:             config(7200),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestJwtResource_KeyMismatch(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							resource "utilities_jwt" "jwt_test" {
+								algorithm      = "ES256"
+								key_pem        = %q
+								expiry_seconds = 3600
+							}`, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})),
+				ExpectError: regexp.MustCompile(`the ES256 algorithm requires a key on the P-256 curve, got P-384`),
+			},
+		},
+	})
+}
@@ -0,0 +1,65 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package messaging_test
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAmqpPublishResource(t *testing.T) {
+	url := os.Getenv("UTILITIES_TEST_AMQP_URL")
+	if url == "" {
+		t.Skip("UTILITIES_TEST_AMQP_URL must be set to run this test against a broker")
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							resource "utilities_amqp_publish" "amqp_test" {
+								url          = %q
+								exchange     = "amq.topic"
+								routing_key  = "deployments.created"
+								body         = jsonencode({ version = "1.0.0" })
+								content_type = "application/json"
+								message_id   = "deployment-1"
+							}`, url),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("utilities_amqp_publish.amqp_test", "id", "deployment-1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAmqpPublishResource_ConnectionRefused(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("error listening: %s", err)
+	}
+	address := listener.Addr().String()
+	_ = listener.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							resource "utilities_amqp_publish" "amqp_test" {
+								url         = "amqp://guest:guest@%s/"
+								routing_key = "events"
+								body        = "test"
+							}`, address),
+				ExpectError: regexp.MustCompile(`Error connecting to broker`),
+			},
+		},
+	})
+}
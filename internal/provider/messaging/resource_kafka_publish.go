@@ -0,0 +1,344 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package messaging
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"terraform-provider-utilities/internal/provider/tlsclient"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/sasl"
+	"github.com/twmb/franz-go/pkg/sasl/plain"
+	"github.com/twmb/franz-go/pkg/sasl/scram"
+)
+
+const (
+	saslPlain       = "PLAIN"
+	saslScramSha256 = "SCRAM-SHA-256"
+	saslScramSha512 = "SCRAM-SHA-512"
+)
+
+var _ resource.Resource = (*kafkaPublishResource)(nil)
+
+func NewKafkaPublishResource() resource.Resource {
+	return &kafkaPublishResource{}
+}
+
+type kafkaPublishResource struct{}
+type kafkaPublishResourceModel struct {
+	tlsclient.Model
+
+	ID             types.String `tfsdk:"id"`
+	Brokers        types.List   `tfsdk:"brokers"`
+	Topic          types.String `tfsdk:"topic"`
+	Key            types.String `tfsdk:"key"`
+	Value          types.String `tfsdk:"value"`
+	Headers        types.Map    `tfsdk:"headers"`
+	TLS            types.Bool   `tfsdk:"tls"`
+	SASL           types.Object `tfsdk:"sasl"`
+	RequestTimeout types.Int64  `tfsdk:"request_timeout_ms"`
+	Keepers        types.Map    `tfsdk:"keepers"`
+	Partition      types.Int64  `tfsdk:"partition"`
+	Offset         types.Int64  `tfsdk:"offset"`
+}
+
+type saslModel struct {
+	Mechanism types.String `tfsdk:"mechanism"`
+	Username  types.String `tfsdk:"username"`
+	Password  types.String `tfsdk:"password"`
+}
+
+func (r *kafkaPublishResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_kafka_publish"
+}
+
+func (r *kafkaPublishResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	attributes := map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Description: "The location of the produced record, in the `topic/partition/offset` form.",
+			Computed:    true,
+		},
+
+		"brokers": schema.ListAttribute{
+			Description: "The list of seed brokers, in the `host:port` form.",
+			ElementType: types.StringType,
+			Required:    true,
+			Validators: []validator.List{
+				listvalidator.SizeAtLeast(1),
+			},
+		},
+
+		"topic": schema.StringAttribute{
+			Description: "The topic to produce the record to.",
+			Required:    true,
+		},
+
+		"key": schema.StringAttribute{
+			Description: "The key of the record.",
+			Optional:    true,
+		},
+
+		"value": schema.StringAttribute{
+			Description: "The value of the record.",
+			Required:    true,
+		},
+
+		"headers": schema.MapAttribute{
+			Description: "A map of record header names and values.",
+			ElementType: types.StringType,
+			Optional:    true,
+		},
+
+		"tls": schema.BoolAttribute{
+			Description: "Connect to the brokers using TLS. Defaults to `false`",
+			Optional:    true,
+		},
+
+		"request_timeout_ms": schema.Int64Attribute{
+			Description: fmt.Sprintf("The time allowed to produce the record in milliseconds. Defaults to `%d`.", defaultTimeout),
+			Optional:    true,
+			Computed:    true,
+			Default:     int64default.StaticInt64(defaultTimeout),
+			Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			},
+		},
+
+		"partition": schema.Int64Attribute{
+			Description: "The partition the record was produced to.",
+			Computed:    true,
+		},
+
+		"offset": schema.Int64Attribute{
+			Description: "The offset of the produced record.",
+			Computed:    true,
+		},
+
+		"keepers": schema.MapAttribute{
+			Description: "Arbitrary map of values that, when changed, will trigger recreation of " +
+				"resource.",
+			ElementType: types.StringType,
+			Optional:    true,
+			PlanModifiers: []planmodifier.Map{
+				mapplanmodifier.RequiresReplaceIfConfigured(),
+			},
+		},
+	}
+
+	for name, attribute := range tlsAttributes() {
+		attributes[name] = attribute
+	}
+
+	resp.Schema = schema.Schema{
+		Description: `
+The ` + "`kafka_publish`" + ` resource produces a record to a Kafka topic upon creation.
+
+The creation only succeeds once the record has been acknowledged by all in-sync replicas.
+`,
+		Attributes: attributes,
+
+		Blocks: map[string]schema.Block{
+			"sasl": schema.SingleNestedBlock{
+				Description: "SASL authentication configuration.",
+				Attributes: map[string]schema.Attribute{
+					"mechanism": schema.StringAttribute{
+						Description: fmt.Sprintf("The SASL mechanism, one of `%s`, `%s` or `%s`.", saslPlain, saslScramSha256, saslScramSha512),
+						Optional:    true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(saslPlain, saslScramSha256, saslScramSha512),
+							stringvalidator.AlsoRequires(
+								path.MatchRelative().AtParent().AtName("username"),
+								path.MatchRelative().AtParent().AtName("password"),
+							),
+						},
+					},
+					"username": schema.StringAttribute{
+						Description: "The SASL username.",
+						Optional:    true,
+					},
+					"password": schema.StringAttribute{
+						Description: "The SASL password.",
+						Optional:    true,
+						Sensitive:   true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *kafkaPublishResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+}
+
+func (r *kafkaPublishResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var model kafkaPublishResourceModel
+	diags := req.State.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *kafkaPublishResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var model kafkaPublishResourceModel
+	diags := req.Plan.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	model.publish(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *kafkaPublishResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var model kafkaPublishResourceModel
+	diags := req.Plan.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	model.publish(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *kafkaPublishResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data kafkaPublishResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (model *kafkaPublishResourceModel) publish(ctx context.Context, diagnostics *diag.Diagnostics) {
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(model.RequestTimeout.ValueInt64())*time.Millisecond)
+	defer cancel()
+
+	var brokers []string
+	diagnostics.Append(model.Brokers.ElementsAs(ctx, &brokers, false)...)
+	if diagnostics.HasError() {
+		return
+	}
+
+	headers := make(map[string]string)
+	if !model.Headers.IsNull() {
+		diagnostics.Append(model.Headers.ElementsAs(ctx, &headers, false)...)
+		if diagnostics.HasError() {
+			return
+		}
+	}
+
+	opts := []kgo.Opt{
+		kgo.SeedBrokers(brokers...),
+		kgo.RequiredAcks(kgo.AllISRAcks()),
+	}
+
+	if model.TLS.ValueBool() {
+		tlsConfig := model.Config(diagnostics)
+		if diagnostics.HasError() {
+			return
+		}
+		opts = append(opts, kgo.DialTLSConfig(tlsConfig))
+	}
+
+	var saslConfig saslModel
+	if !model.SASL.IsNull() && !model.SASL.IsUnknown() {
+		diagnostics.Append(model.SASL.As(ctx, &saslConfig, basetypes.ObjectAsOptions{})...)
+		if diagnostics.HasError() {
+			return
+		}
+	}
+
+	if !saslConfig.Mechanism.IsNull() {
+		var mechanism sasl.Mechanism
+		switch saslConfig.Mechanism.ValueString() {
+		case saslPlain:
+			mechanism = plain.Auth{
+				User: saslConfig.Username.ValueString(),
+				Pass: saslConfig.Password.ValueString(),
+			}.AsMechanism()
+		case saslScramSha256:
+			mechanism = scram.Auth{
+				User: saslConfig.Username.ValueString(),
+				Pass: saslConfig.Password.ValueString(),
+			}.AsSha256Mechanism()
+		case saslScramSha512:
+			mechanism = scram.Auth{
+				User: saslConfig.Username.ValueString(),
+				Pass: saslConfig.Password.ValueString(),
+			}.AsSha512Mechanism()
+		}
+		opts = append(opts, kgo.SASL(mechanism))
+	}
+
+	client, err := kgo.NewClient(opts...)
+	if err != nil {
+		diagnostics.AddError(
+			"Error creating Kafka client",
+			fmt.Sprintf("Error creating Kafka client: %s", err),
+		)
+		return
+	}
+	defer client.Close()
+
+	record := &kgo.Record{
+		Topic: model.Topic.ValueString(),
+		Value: []byte(model.Value.ValueString()),
+	}
+
+	if !model.Key.IsNull() {
+		record.Key = []byte(model.Key.ValueString())
+	}
+
+	for name, value := range headers {
+		record.Headers = append(record.Headers, kgo.RecordHeader{Key: name, Value: []byte(value)})
+	}
+
+	produced, err := client.ProduceSync(ctx, record).First()
+	if err != nil {
+		diagnostics.AddError(
+			"Error producing record",
+			fmt.Sprintf("Error producing record: %s", err),
+		)
+		return
+	}
+
+	model.ID = types.StringValue(fmt.Sprintf("%s/%d/%d", produced.Topic, produced.Partition, produced.Offset))
+	model.Partition = types.Int64Value(int64(produced.Partition))
+	model.Offset = types.Int64Value(produced.Offset)
+}
@@ -0,0 +1,89 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package messaging_test
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestKafkaPublishResource(t *testing.T) {
+	broker := os.Getenv("UTILITIES_TEST_KAFKA_BROKER")
+	if broker == "" {
+		t.Skip("UTILITIES_TEST_KAFKA_BROKER must be set to run this test against a broker")
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							resource "utilities_kafka_publish" "kafka_test" {
+								brokers = [%q]
+								topic   = "deployments"
+								key     = "service-a"
+								value   = jsonencode({ version = "1.0.0" })
+								headers = {
+									source = "terraform"
+								}
+							}`, broker),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("utilities_kafka_publish.kafka_test", "partition"),
+					resource.TestCheckResourceAttrSet("utilities_kafka_publish.kafka_test", "offset"),
+				),
+			},
+		},
+	})
+}
+
+func TestKafkaPublishResource_Unreachable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("error listening: %s", err)
+	}
+	address := listener.Addr().String()
+	_ = listener.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							resource "utilities_kafka_publish" "kafka_test" {
+								brokers            = [%q]
+								topic              = "deployments"
+								value              = "test"
+								request_timeout_ms = 500
+							}`, address),
+				ExpectError: regexp.MustCompile(`Error producing record`),
+			},
+		},
+	})
+}
+
+func TestKafkaPublishResource_SaslRequiresCredentials(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+							resource "utilities_kafka_publish" "kafka_test" {
+								brokers = ["localhost:9092"]
+								topic   = "deployments"
+								value   = "test"
+
+								sasl {
+									mechanism = "PLAIN"
+								}
+							}`,
+				ExpectError: regexp.MustCompile(`Attribute "sasl.username" must be specified`),
+			},
+		},
+	})
+}
@@ -0,0 +1,52 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package messaging
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+const defaultTimeout = 10000
+
+// tlsAttributes returns the TLS attributes shared by the messaging resources,
+// set in the embedded tlsclient.Model.
+func tlsAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"ca_cert_pem": schema.StringAttribute{
+			Description: "Certificate Authority (CA) " +
+				"in [PEM (RFC 1421)](https://datatracker.ietf.org/doc/html/rfc1421) format.",
+			Optional: true,
+			Validators: []validator.String{
+				stringvalidator.ConflictsWith(path.MatchRoot("insecure")),
+			},
+		},
+
+		"client_cert_pem": schema.StringAttribute{
+			Description: "Client certificate " +
+				"in [PEM (RFC 1421)](https://datatracker.ietf.org/doc/html/rfc1421) format.",
+			Optional: true,
+			Validators: []validator.String{
+				stringvalidator.AlsoRequires(path.MatchRoot("client_key_pem")),
+			},
+		},
+
+		"client_key_pem": schema.StringAttribute{
+			Description: "Client key " +
+				"in [PEM (RFC 1421)](https://datatracker.ietf.org/doc/html/rfc1421) format.",
+			Optional:  true,
+			Sensitive: true,
+			Validators: []validator.String{
+				stringvalidator.AlsoRequires(path.MatchRoot("client_cert_pem")),
+			},
+		},
+
+		"insecure": schema.BoolAttribute{
+			Description: "Disables verification of the server's certificate chain and hostname. Defaults to `false`",
+			Optional:    true,
+		},
+	}
+}
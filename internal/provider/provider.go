@@ -5,13 +5,36 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"terraform-provider-utilities/internal/provider/acme"
+	"terraform-provider-utilities/internal/provider/certificate"
+	"terraform-provider-utilities/internal/provider/database"
+	"terraform-provider-utilities/internal/provider/functions"
+	"terraform-provider-utilities/internal/provider/grpc"
 	"terraform-provider-utilities/internal/provider/http"
+	"terraform-provider-utilities/internal/provider/jwt"
+	"terraform-provider-utilities/internal/provider/messaging"
+	"terraform-provider-utilities/internal/provider/providerdata"
+	"terraform-provider-utilities/internal/provider/redis"
+	"terraform-provider-utilities/internal/provider/snmp"
+	"terraform-provider-utilities/internal/provider/socket"
+	"terraform-provider-utilities/internal/provider/sops"
+	"terraform-provider-utilities/internal/provider/websocket"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 )
 
 // Ensure NanoidProvider satisfies various provider interfaces.
@@ -27,9 +50,32 @@ type UtilitiesProvider struct {
 }
 
 // NanoidProviderModel describes the provider data model.
-type NanoidProviderModel struct{}
+type NanoidProviderModel struct {
+	MetricsFile     types.String `tfsdk:"metrics_file"`
+	HARFile         types.String `tfsdk:"har_file"`
+	RetryBudget     types.Int64  `tfsdk:"retry_budget"`
+	MaxConcurrent   types.Int64  `tfsdk:"max_concurrent_requests"`
+	SensitiveAudit  types.Bool   `tfsdk:"sensitive_audit"`
+	RequestIDHeader types.String `tfsdk:"request_id_header"`
+	CircuitBreaker  types.Object `tfsdk:"circuit_breaker"`
+}
+
+type circuitBreakerModel struct {
+	FailureThreshold types.Int64 `tfsdk:"failure_threshold"`
+	Cooldown         types.Int64 `tfsdk:"cooldown_ms"`
+}
 
-type UtilitiesProviderData struct{}
+// harFileEnv is the environment variable setting the HAR file when har_file
+// is not set, so that the requests of a pipeline can be captured without
+// changing its configuration.
+const harFileEnv = "TF_UTILITIES_HAR_PATH"
+
+const (
+	defaultCircuitBreakerFailureThreshold = 5
+	defaultCircuitBreakerCooldown         = 30000
+)
+
+type UtilitiesProviderData = providerdata.ProviderData
 
 func (p *UtilitiesProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
 	resp.TypeName = "utilities"
@@ -39,36 +85,210 @@ func (p *UtilitiesProvider) Metadata(ctx context.Context, req provider.MetadataR
 func (p *UtilitiesProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Various utilities for Terraform.",
+		Attributes: map[string]schema.Attribute{
+			"metrics_file": schema.StringAttribute{
+				MarkdownDescription: "The path of a JSON file summarizing, per resource and data source type, the requests made, " +
+					"the retries, the failures, the bytes received and the cache hits. It is rewritten after each request, " +
+					"and holds the summary of the run once Terraform exits. The summary is also logged at the `INFO` level.",
+				Optional: true,
+			},
+			"har_file": schema.StringAttribute{
+				MarkdownDescription: "The path of a [HAR](http://www.softwareishard.com/blog/har-12-spec/) file recording the " +
+					"`utilities_http` and `utilities_assert_http` requests and responses, retries included, for post-mortem " +
+					"debugging. It is rewritten after each request. The values of the sensitive headers and query parameters " +
+					"are redacted, but the bodies are recorded as is, up to 1 MiB each. Defaults to the " +
+					"`" + harFileEnv + "` environment variable, no file being written when it is not set either.",
+				Optional: true,
+			},
+			"retry_budget": schema.Int64Attribute{
+				MarkdownDescription: "The maximum number of retries of the `utilities_http` requests to each host during the run, " +
+					"shared by all the resources and data sources. Once the budget of a host is exhausted, its requests fail " +
+					"instead of being retried, so a host that is down does not receive the full retry schedule of every resource. " +
+					"By default the retries are not limited.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				MarkdownDescription: "The maximum number of `utilities_http` requests in flight at once, shared by all the resources " +
+					"and data sources. The other requests wait for a request to complete, so a high `-parallelism` does not " +
+					"overwhelm small services. By default the requests are not limited.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"request_id_header": schema.StringAttribute{
+				MarkdownDescription: "The name of a request header set to a new UUID for every attempt of the `utilities_http` requests, " +
+					"e.g. `X-Request-Id`, so that failed requests can be correlated with the logs of the server. The request ID of " +
+					"the last attempt is exported in `request_id` and reported in the errors. It is overridden by the " +
+					"`request_id_header` of the resources and data sources. By default no header is set.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"sensitive_audit": schema.BoolAttribute{
+				MarkdownDescription: "Whether to warn about the attributes of the resources and data sources which may hold " +
+					"sensitive fetched data, e.g. `utilities_http.response_body`, but are not marked sensitive, and so are " +
+					"stored in plaintext in the state. It helps to check that nothing secret is stored in the state unencrypted. " +
+					"Defaults to `false`.",
+				Optional: true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"circuit_breaker": schema.SingleNestedBlock{
+				MarkdownDescription: "Fails fast the `utilities_http` requests to a host after consecutive failures, " +
+					"so a host that is down does not multiply the retry delays of every resource targeting it. " +
+					"A request fails when it cannot be made or when the server replies with a 5xx status code.",
+				Attributes: map[string]schema.Attribute{
+					"failure_threshold": schema.Int64Attribute{
+						MarkdownDescription: fmt.Sprintf("The number of consecutive failures opening the circuit of a host. Defaults to `%d`.", defaultCircuitBreakerFailureThreshold),
+						Optional:            true,
+						Validators: []validator.Int64{
+							int64validator.AtLeast(1),
+						},
+					},
+					"cooldown_ms": schema.Int64Attribute{
+						MarkdownDescription: fmt.Sprintf("The time in milliseconds the requests to a host fail fast once its circuit is open. Defaults to `%d`.", defaultCircuitBreakerCooldown),
+						Optional:            true,
+						Validators: []validator.Int64{
+							int64validator.AtLeast(1),
+						},
+					},
+				},
+			},
+		},
 	}
 }
 
 func (p *UtilitiesProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	// Resources and data sources are deferred until the configuration of the
+	// provider is known, when Terraform supports deferred actions.
+	if req.ClientCapabilities.DeferralAllowed && !req.Config.Raw.IsFullyKnown() {
+		resp.Deferred = &provider.Deferred{
+			Reason: provider.DeferredReasonProviderConfigUnknown,
+		}
+		return
+	}
+
 	var data NanoidProviderModel
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	providerData := UtilitiesProviderData{}
+	harFile := data.HARFile.ValueString()
+	if data.HARFile.IsNull() {
+		harFile = os.Getenv(harFileEnv)
+	}
+
+	providerData := UtilitiesProviderData{
+		Metrics:         providerdata.NewMetrics(data.MetricsFile.ValueString()),
+		HAR:             providerdata.NewHAR(harFile, p.version),
+		RequestIDHeader: data.RequestIDHeader.ValueString(),
+		ResponseCache:   providerdata.NewResponseCache(),
+	}
+
+	if !data.CircuitBreaker.IsNull() && !data.CircuitBreaker.IsUnknown() {
+		var circuitBreaker circuitBreakerModel
+		resp.Diagnostics.Append(data.CircuitBreaker.As(ctx, &circuitBreaker, basetypes.ObjectAsOptions{})...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		threshold := int64(defaultCircuitBreakerFailureThreshold)
+		if !circuitBreaker.FailureThreshold.IsNull() && !circuitBreaker.FailureThreshold.IsUnknown() {
+			threshold = circuitBreaker.FailureThreshold.ValueInt64()
+		}
+
+		cooldown := int64(defaultCircuitBreakerCooldown)
+		if !circuitBreaker.Cooldown.IsNull() && !circuitBreaker.Cooldown.IsUnknown() {
+			cooldown = circuitBreaker.Cooldown.ValueInt64()
+		}
+
+		providerData.CircuitBreaker = providerdata.NewCircuitBreaker(int(threshold), time.Duration(cooldown)*time.Millisecond)
+	}
+
+	if !data.RetryBudget.IsNull() && !data.RetryBudget.IsUnknown() {
+		providerData.RetryBudget = providerdata.NewRetryBudget(int(data.RetryBudget.ValueInt64()))
+	}
+
+	if !data.MaxConcurrent.IsNull() && !data.MaxConcurrent.IsUnknown() {
+		providerData.Semaphore = providerdata.NewSemaphore(int(data.MaxConcurrent.ValueInt64()))
+	}
+
+	if data.SensitiveAudit.ValueBool() {
+		if names := p.auditSensitiveAttributes(ctx); len(names) > 0 {
+			resp.Diagnostics.AddWarning(
+				"Attributes holding fetched data are not sensitive",
+				"The following attributes may hold sensitive fetched data but are not marked sensitive, "+
+					"their values are stored in plaintext in the state:\n\n  - "+strings.Join(names, "\n  - "),
+			)
+		}
+	}
+
 	resp.DataSourceData = &providerData
 	resp.ResourceData = &providerData
 }
 
 func (p *UtilitiesProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
+		acme.NewAcmeHttpChallengeResource,
+		NewDelayDestroyResource,
+		grpc.NewGrpcResource,
+		grpc.NewGrpcHealthResource,
+		http.NewGraphQLResource,
 		http.NewHttpResource,
+		http.NewOpenAPIObjectResource,
+		http.NewWebhookResource,
+		jwt.NewJwtResource,
+		messaging.NewAmqpPublishResource,
+		messaging.NewKafkaPublishResource,
 		NewNanoIdResource,
+		NewOutputResource,
+		socket.NewTcpSendResource,
+		socket.NewUdpSendResource,
+		websocket.NewWebsocketResource,
 	}
 }
 
 func (p *UtilitiesProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
+		certificate.NewCertificateRevocationDataSource,
+		database.NewSqlQueryDataSource,
+		http.NewAssertHttpDataSource,
+		http.NewEndpointsHealthDataSource,
 		http.NewHttpDataSource,
+		redis.NewRedisDataSource,
+		snmp.NewSnmpGetDataSource,
+		sops.NewSopsFileDataSource,
 	}
 }
 
 func (p *UtilitiesProvider) Functions(ctx context.Context) []func() function.Function {
-	return []func() function.Function{}
+	return []func() function.Function{
+		functions.NewAESGCMDecryptFunction,
+		functions.NewAESGCMEncryptFunction,
+		functions.NewAgeDecryptFunction,
+		functions.NewAgeEncryptFunction,
+		functions.NewGunzipBase64Function,
+		functions.NewGzipBase64Function,
+		functions.NewIsValidEmailFunction,
+		functions.NewIsValidHostnameFunction,
+		functions.NewIsValidMACFunction,
+		functions.NewIsValidURLFunction,
+		functions.NewMACToEUI64Function,
+		functions.NewNormalizeHostnameFunction,
+		functions.NewNormalizeMACFunction,
+		functions.NewNormalizePhoneNumberFunction,
+		functions.NewParseKSUIDFunction,
+		functions.NewParseULIDFunction,
+		functions.NewParseUUIDFunction,
+		functions.NewUnzstdBase64Function,
+		functions.NewZstdBase64Function,
+	}
 }
 
 func New(version string) func() provider.Provider {
@@ -4,10 +4,13 @@
 package provider
 
 import (
+	"context"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-mux/tf6to5server"
 )
 
 // testAccProtoV6ProviderFactories are used to instantiate a provider during
@@ -23,3 +26,55 @@ func testAccPreCheck(t *testing.T) {
 	// about the appropriate environment variables being set are common to see in a pre-check
 	// function.
 }
+
+// TestProtocol5 ensures the provider can be served over protocol version 5,
+// which does not support nested attributes.
+func TestProtocol5(t *testing.T) {
+	ctx := context.Background()
+
+	server, err := tf6to5server.DowngradeServer(ctx, providerserver.NewProtocol6(New("test")()))
+	if err != nil {
+		t.Fatalf("error downgrading the provider: %s", err)
+	}
+
+	resp, err := server.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("error getting the provider schema: %s", err)
+	}
+
+	for _, diagnostic := range resp.Diagnostics {
+		if diagnostic.Severity == tfprotov5.DiagnosticSeverityError {
+			t.Errorf("%s: %s", diagnostic.Summary, diagnostic.Detail)
+		}
+	}
+}
+
+func TestAuditSensitiveAttributes(t *testing.T) {
+	p := &UtilitiesProvider{version: "test"}
+	names := p.auditSensitiveAttributes(context.Background())
+
+	expected := map[string]bool{
+		"data.utilities_http.response_body": true,
+		"utilities_http.response_headers":   true,
+		"data.utilities_redis.value":        true,
+	}
+	// The attributes marked sensitive, or set in the configuration, are not
+	// reported.
+	unexpected := map[string]bool{
+		"utilities_jwt.token":              true,
+		"data.utilities_sops_file.data":    true,
+		"utilities_websocket.max_messages": true,
+		"utilities_http.status_code":       true,
+	}
+
+	for _, name := range names {
+		delete(expected, name)
+		if unexpected[name] {
+			t.Errorf("unexpected attribute %s", name)
+		}
+	}
+
+	for name := range expected {
+		t.Errorf("missing attribute %s", name)
+	}
+}
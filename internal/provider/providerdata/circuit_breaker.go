@@ -0,0 +1,97 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package providerdata
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// circuitOpenError is returned for the requests to a host whose circuit is
+// open.
+type circuitOpenError struct {
+	host     string
+	failures int
+	until    time.Time
+}
+
+func (err circuitOpenError) Error() string {
+	return fmt.Sprintf(
+		"the circuit breaker is open for %s after %d consecutive failures, requests are allowed again at %s",
+		err.host, err.failures, err.until.Format(time.RFC3339),
+	)
+}
+
+type hostCircuit struct {
+	failures  int
+	openUntil time.Time
+}
+
+// CircuitBreaker counts the consecutive failures of the requests to each host.
+// Once a host reaches the threshold, its requests fail fast during the
+// cooldown. The requests are then allowed again, the first success closes the
+// circuit and any failure opens it for another cooldown.
+type CircuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu    sync.Mutex
+	hosts map[string]*hostCircuit
+}
+
+// NewCircuitBreaker returns a circuit breaker opening after threshold
+// consecutive failures, for the cooldown.
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		now:       time.Now,
+		hosts:     make(map[string]*hostCircuit),
+	}
+}
+
+// Allow returns an error when the circuit of the host is open.
+func (breaker *CircuitBreaker) Allow(host string) error {
+	if breaker == nil {
+		return nil
+	}
+
+	breaker.mu.Lock()
+	defer breaker.mu.Unlock()
+
+	circuit, ok := breaker.hosts[host]
+	if !ok || !breaker.now().Before(circuit.openUntil) {
+		return nil
+	}
+
+	return circuitOpenError{host: host, failures: circuit.failures, until: circuit.openUntil}
+}
+
+// Record records the outcome of a request to the host.
+func (breaker *CircuitBreaker) Record(host string, success bool) {
+	if breaker == nil {
+		return
+	}
+
+	breaker.mu.Lock()
+	defer breaker.mu.Unlock()
+
+	if success {
+		delete(breaker.hosts, host)
+		return
+	}
+
+	circuit, ok := breaker.hosts[host]
+	if !ok {
+		circuit = &hostCircuit{}
+		breaker.hosts[host] = circuit
+	}
+
+	circuit.failures++
+	if circuit.failures >= breaker.threshold {
+		circuit.openUntil = breaker.now().Add(breaker.cooldown)
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package providerdata
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	breaker := NewCircuitBreaker(2, time.Minute)
+	breaker.now = func() time.Time { return now }
+
+	breaker.Record("down.example.com", false)
+	if err := breaker.Allow("down.example.com"); err != nil {
+		t.Fatalf("expected the circuit to be closed below the threshold, got %s", err)
+	}
+
+	breaker.Record("down.example.com", false)
+	if err := breaker.Allow("down.example.com"); err == nil {
+		t.Fatalf("expected the circuit to be open at the threshold")
+	}
+	if err := breaker.Allow("up.example.com"); err != nil {
+		t.Fatalf("expected the circuit of other hosts to be closed, got %s", err)
+	}
+
+	now = now.Add(time.Minute)
+	if err := breaker.Allow("down.example.com"); err != nil {
+		t.Fatalf("expected the requests to be allowed after the cooldown, got %s", err)
+	}
+
+	breaker.Record("down.example.com", false)
+	if err := breaker.Allow("down.example.com"); err == nil {
+		t.Fatalf("expected a failure after the cooldown to open the circuit again")
+	}
+
+	now = now.Add(time.Minute)
+	breaker.Record("down.example.com", true)
+	breaker.Record("down.example.com", false)
+	if err := breaker.Allow("down.example.com"); err != nil {
+		t.Fatalf("expected a success to close the circuit, got %s", err)
+	}
+}
+
+func TestCircuitBreaker_Nil(t *testing.T) {
+	var breaker *CircuitBreaker
+
+	breaker.Record("example.com", false)
+	if err := breaker.Allow("example.com"); err != nil {
+		t.Fatalf("expected a nil circuit breaker to allow requests, got %s", err)
+	}
+}
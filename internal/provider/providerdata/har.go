@@ -0,0 +1,170 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package providerdata
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// HAREntry is an HTTP round trip, as defined by the HAR 1.2 specification
+// (http://www.softwareishard.com/blog/har-12-spec/#entries).
+type HAREntry struct {
+	StartedDateTime time.Time   `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         HARRequest  `json:"request"`
+	Response        HARResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         HARTimings  `json:"timings"`
+	// Comment holds the error of the round trips which failed without a
+	// response.
+	Comment string `json:"comment,omitempty"`
+}
+
+// HARRequest is the request of an entry.
+type HARRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []HARNameValue `json:"cookies"`
+	Headers     []HARNameValue `json:"headers"`
+	QueryString []HARNameValue `json:"queryString"`
+	PostData    *HARPostData   `json:"postData,omitempty"`
+	HeadersSize int64          `json:"headersSize"`
+	BodySize    int64          `json:"bodySize"`
+}
+
+// HARResponse is the response of an entry, its status is 0 when the round
+// trip failed.
+type HARResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []HARNameValue `json:"cookies"`
+	Headers     []HARNameValue `json:"headers"`
+	Content     HARContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int64          `json:"headersSize"`
+	BodySize    int64          `json:"bodySize"`
+}
+
+// HARNameValue is a header, a query parameter or a cookie.
+type HARNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// HARPostData is the body of a request.
+type HARPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+// HARContent is the body of a response, encoded as base64 when it is not
+// text.
+type HARContent struct {
+	Size     int64  `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+	Encoding string `json:"encoding,omitempty"`
+	Comment  string `json:"comment,omitempty"`
+}
+
+// HARTimings are the durations of the phases of a round trip, in
+// milliseconds, -1 when they are not measured.
+type HARTimings struct {
+	Blocked float64 `json:"blocked"`
+	DNS     float64 `json:"dns"`
+	Connect float64 `json:"connect"`
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+	SSL     float64 `json:"ssl"`
+}
+
+type harFile struct {
+	Log harLog `json:"log"`
+}
+
+type harLog struct {
+	Version string     `json:"version"`
+	Creator harCreator `json:"creator"`
+	Entries []HAREntry `json:"entries"`
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// HAR records the HTTP round trips made during a Terraform run, retries
+// included, in a HAR file for post-mortem debugging.
+type HAR struct {
+	path    string
+	version string
+
+	mu      sync.Mutex
+	entries []HAREntry
+}
+
+// NewHAR returns the recorder writing the HAR file at path after each
+// request, so it holds the round trips of the run once Terraform exits. It
+// returns nil, which records nothing, when path is empty.
+func NewHAR(path, version string) *HAR {
+	if path == "" {
+		return nil
+	}
+
+	return &HAR{
+		path:    path,
+		version: version,
+	}
+}
+
+// Record adds the round trips of a request to the HAR file.
+func (har *HAR) Record(ctx context.Context, entries ...HAREntry) {
+	if har == nil || len(entries) == 0 {
+		return
+	}
+
+	har.mu.Lock()
+	defer har.mu.Unlock()
+
+	har.entries = append(har.entries, entries...)
+
+	if err := har.write(); err != nil {
+		tflog.Warn(ctx, "Error writing the HAR file", map[string]interface{}{
+			"path":  har.path,
+			"error": err.Error(),
+		})
+	}
+}
+
+// Entries returns a copy of the round trips recorded.
+func (har *HAR) Entries() []HAREntry {
+	har.mu.Lock()
+	defer har.mu.Unlock()
+
+	return append([]HAREntry(nil), har.entries...)
+}
+
+// write replaces the HAR file, the lock must be held.
+func (har *HAR) write() error {
+	data, err := json.MarshalIndent(harFile{
+		Log: harLog{
+			Version: "1.2",
+			Creator: harCreator{Name: "terraform-provider-utilities", Version: har.version},
+			Entries: har.entries,
+		},
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return replaceFile(har.path, append(data, '\n'))
+}
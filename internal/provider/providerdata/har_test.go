@@ -0,0 +1,68 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package providerdata_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"terraform-provider-utilities/internal/provider/providerdata"
+)
+
+func TestHAR(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "requests.har")
+	har := providerdata.NewHAR(path, "1.2.3")
+
+	har.Record(ctx,
+		providerdata.HAREntry{Request: providerdata.HARRequest{Method: "GET", URL: "https://example.com/"}, Comment: "connection refused"},
+		providerdata.HAREntry{Request: providerdata.HARRequest{Method: "GET", URL: "https://example.com/"}, Response: providerdata.HARResponse{Status: 200}},
+	)
+	har.Record(ctx, providerdata.HAREntry{Request: providerdata.HARRequest{Method: "POST", URL: "https://example.com/items"}, Response: providerdata.HARResponse{Status: 201}})
+
+	if entries := har.Entries(); len(entries) != 3 {
+		t.Errorf("expected 3 entries, got %d", len(entries))
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("error reading the HAR file: %s", err)
+	}
+
+	var written struct {
+		Log struct {
+			Version string `json:"version"`
+			Creator struct {
+				Version string `json:"version"`
+			} `json:"creator"`
+			Entries []providerdata.HAREntry `json:"entries"`
+		} `json:"log"`
+	}
+	if err := json.Unmarshal(data, &written); err != nil {
+		t.Fatalf("error decoding the HAR file: %s", err)
+	}
+
+	if written.Log.Version != "1.2" || written.Log.Creator.Version != "1.2.3" {
+		t.Errorf("unexpected log %s", data)
+	}
+	if len(written.Log.Entries) != 3 {
+		t.Fatalf("expected the file to hold 3 entries, got %d", len(written.Log.Entries))
+	}
+	if entry := written.Log.Entries[2]; entry.Request.Method != "POST" || entry.Response.Status != 201 {
+		t.Errorf("unexpected last entry %+v", entry)
+	}
+}
+
+func TestHAR_Disabled(t *testing.T) {
+	har := providerdata.NewHAR("", "1.2.3")
+	if har != nil {
+		t.Fatalf("expected no recorder, got %v", har)
+	}
+
+	// A nil recorder records nothing.
+	har.Record(context.Background(), providerdata.HAREntry{})
+}
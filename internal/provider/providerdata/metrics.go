@@ -0,0 +1,147 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package providerdata
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Request is the outcome of a request made by a resource or data source.
+type Request struct {
+	// Attempts is the number of attempts, retries included.
+	Attempts int
+	// Bytes is the number of bytes of the response body read.
+	Bytes int64
+	// CacheHit is set when the server replied that the previous response has
+	// not changed, or when the response of an identical request is shared.
+	CacheHit bool
+	// Failed is set when the request failed.
+	Failed bool
+}
+
+// TypeMetrics summarizes the requests made by a resource or data source type.
+type TypeMetrics struct {
+	Requests  int64 `json:"requests"`
+	Retries   int64 `json:"retries"`
+	Failures  int64 `json:"failures"`
+	Bytes     int64 `json:"bytes"`
+	CacheHits int64 `json:"cache_hits"`
+}
+
+// Metrics summarizes the requests made during a Terraform run, per resource
+// and data source type. Data source types are prefixed with `data.`.
+type Metrics struct {
+	path string
+
+	mu    sync.Mutex
+	types map[string]*TypeMetrics
+}
+
+// NewMetrics returns the metrics of a run, written to the file at path after
+// each request when set, so it holds the summary of the run once Terraform
+// exits.
+func NewMetrics(path string) *Metrics {
+	return &Metrics{
+		path:  path,
+		types: make(map[string]*TypeMetrics),
+	}
+}
+
+// Record adds the request to the metrics of the type, and logs the updated
+// summary of the type.
+func (metrics *Metrics) Record(ctx context.Context, typeName string, request Request) {
+	if metrics == nil {
+		return
+	}
+
+	metrics.mu.Lock()
+	defer metrics.mu.Unlock()
+
+	summary, ok := metrics.types[typeName]
+	if !ok {
+		summary = &TypeMetrics{}
+		metrics.types[typeName] = summary
+	}
+
+	summary.Requests++
+	if request.Attempts > 1 {
+		summary.Retries += int64(request.Attempts - 1)
+	}
+	if request.Failed {
+		summary.Failures++
+	}
+	if request.CacheHit {
+		summary.CacheHits++
+	}
+	summary.Bytes += request.Bytes
+
+	tflog.Info(ctx, "Request metrics", map[string]interface{}{
+		"type":       typeName,
+		"requests":   summary.Requests,
+		"retries":    summary.Retries,
+		"failures":   summary.Failures,
+		"bytes":      summary.Bytes,
+		"cache_hits": summary.CacheHits,
+	})
+
+	if metrics.path == "" {
+		return
+	}
+
+	if err := metrics.write(); err != nil {
+		tflog.Warn(ctx, "Error writing the metrics file", map[string]interface{}{
+			"path":  metrics.path,
+			"error": err.Error(),
+		})
+	}
+}
+
+// Summary returns a copy of the metrics per type.
+func (metrics *Metrics) Summary() map[string]TypeMetrics {
+	metrics.mu.Lock()
+	defer metrics.mu.Unlock()
+
+	summary := make(map[string]TypeMetrics, len(metrics.types))
+	for typeName, typeMetrics := range metrics.types {
+		summary[typeName] = *typeMetrics
+	}
+
+	return summary
+}
+
+// write replaces the metrics file, the lock must be held.
+func (metrics *Metrics) write() error {
+	data, err := json.MarshalIndent(metrics.types, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return replaceFile(metrics.path, append(data, '\n'))
+}
+
+// replaceFile replaces the file at path atomically, so it is never read
+// partially written.
+func replaceFile(path string, data []byte) error {
+	file, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(file.Name(), path)
+}
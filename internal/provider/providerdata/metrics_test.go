@@ -0,0 +1,47 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package providerdata_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"terraform-provider-utilities/internal/provider/providerdata"
+)
+
+func TestMetrics(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "metrics.json")
+	metrics := providerdata.NewMetrics(path)
+
+	metrics.Record(ctx, "utilities_http", providerdata.Request{Attempts: 3, Bytes: 10})
+	metrics.Record(ctx, "utilities_http", providerdata.Request{Attempts: 1, CacheHit: true})
+	metrics.Record(ctx, "data.utilities_http", providerdata.Request{Attempts: 2, Failed: true})
+
+	expected := map[string]providerdata.TypeMetrics{
+		"utilities_http":      {Requests: 2, Retries: 2, Bytes: 10, CacheHits: 1},
+		"data.utilities_http": {Requests: 1, Retries: 1, Failures: 1},
+	}
+
+	if summary := metrics.Summary(); !reflect.DeepEqual(summary, expected) {
+		t.Errorf("expected %v, got %v", expected, summary)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("error reading the metrics file: %s", err)
+	}
+
+	var written map[string]providerdata.TypeMetrics
+	if err := json.Unmarshal(data, &written); err != nil {
+		t.Fatalf("error decoding the metrics file: %s", err)
+	}
+	if !reflect.DeepEqual(written, expected) {
+		t.Errorf("expected the file to hold %v, got %v", expected, written)
+	}
+}
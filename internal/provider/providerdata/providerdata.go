@@ -0,0 +1,30 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+// Package providerdata holds the state shared by the resources and data
+// sources of a configured provider.
+package providerdata
+
+// ProviderData is passed to the resources and data sources by the provider.
+type ProviderData struct {
+	// CircuitBreaker fails fast the HTTP requests to the hosts that are down,
+	// it is nil when disabled.
+	CircuitBreaker *CircuitBreaker
+	// RetryBudget limits the retries of the HTTP requests to each host, it is
+	// nil when disabled.
+	RetryBudget *RetryBudget
+	// Semaphore limits the HTTP requests in flight at once, it is nil when
+	// disabled.
+	Semaphore *Semaphore
+	// Metrics summarizes the requests made during the run.
+	Metrics *Metrics
+	// HAR records the round trips made during the run, it is nil when
+	// disabled.
+	HAR *HAR
+	// RequestIDHeader is the name of the header set to a new request ID for
+	// every attempt of the HTTP requests, it is empty when disabled.
+	RequestIDHeader string
+	// ResponseCache shares the responses of the identical HTTP requests
+	// during the run.
+	ResponseCache *ResponseCache
+}
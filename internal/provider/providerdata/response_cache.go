@@ -0,0 +1,96 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package providerdata
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"sync"
+)
+
+type cachedResponse struct {
+	done     chan struct{}
+	response *http.Response
+	body     []byte
+	err      error
+}
+
+// ResponseCache shares the responses of identical requests during the run.
+// The first request is made, the identical requests made meanwhile wait for
+// its response and the later ones get it from memory. Failed requests are not
+// kept, so the next identical request is made again.
+type ResponseCache struct {
+	mu        sync.Mutex
+	responses map[string]*cachedResponse
+}
+
+// NewResponseCache returns an empty response cache.
+func NewResponseCache() *ResponseCache {
+	return &ResponseCache{responses: make(map[string]*cachedResponse)}
+}
+
+// Do returns the response of the request identified by the key, made with do
+// unless an identical request was made. The response body is read in memory,
+// and each caller gets its own copy of the response. When maxBodyBytes is
+// positive, at most one byte more is read, for the caller to tell the larger
+// bodies. shared reports whether the response is the one of another request.
+func (cache *ResponseCache) Do(ctx context.Context, key string, maxBodyBytes int64, do func() (*http.Response, error)) (response *http.Response, shared bool, err error) {
+	if cache == nil {
+		response, err = do()
+		return response, false, err
+	}
+
+	cache.mu.Lock()
+	cached, ok := cache.responses[key]
+	if !ok {
+		cached = &cachedResponse{done: make(chan struct{})}
+		cache.responses[key] = cached
+	}
+	cache.mu.Unlock()
+
+	if ok {
+		select {
+		case <-cached.done:
+		case <-ctx.Done():
+			return nil, false, ctx.Err()
+		}
+
+		if cached.err != nil {
+			return nil, true, cached.err
+		}
+		return cached.copy(), true, nil
+	}
+
+	cached.response, cached.err = do()
+	if cached.err == nil {
+		var body io.Reader = cached.response.Body
+		if maxBodyBytes > 0 {
+			body = io.LimitReader(body, maxBodyBytes+1)
+		}
+		cached.body, cached.err = io.ReadAll(body)
+		cached.response.Body.Close()
+	}
+
+	if cached.err != nil {
+		cache.mu.Lock()
+		delete(cache.responses, key)
+		cache.mu.Unlock()
+	}
+	close(cached.done)
+
+	if cached.err != nil {
+		return nil, false, cached.err
+	}
+	return cached.copy(), false, nil
+}
+
+// copy returns a copy of the response reading the body from memory.
+func (cached *cachedResponse) copy() *http.Response {
+	response := *cached.response
+	response.Header = cached.response.Header.Clone()
+	response.Body = io.NopCloser(bytes.NewReader(cached.body))
+	return &response
+}
@@ -0,0 +1,114 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package providerdata
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestResponseCache(t *testing.T) {
+	cache := NewResponseCache()
+
+	var requests atomic.Int32
+	do := func() (*http.Response, error) {
+		requests.Add(1)
+		time.Sleep(10 * time.Millisecond)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": {"text/plain"}},
+			Body:       io.NopCloser(strings.NewReader("OK")),
+		}, nil
+	}
+
+	var wg sync.WaitGroup
+	var shared atomic.Int32
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			response, isShared, err := cache.Do(context.Background(), "GET /", 0, do)
+			if err != nil {
+				t.Errorf("expected a response, got %s", err)
+				return
+			}
+			if isShared {
+				shared.Add(1)
+			}
+
+			body, _ := io.ReadAll(response.Body)
+			if string(body) != "OK" {
+				t.Errorf("expected the body %q, got %q", "OK", body)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if requests.Load() != 1 || shared.Load() != 4 {
+		t.Fatalf("expected 1 request shared 4 times, got %d requests shared %d times", requests.Load(), shared.Load())
+	}
+
+	if _, isShared, _ := cache.Do(context.Background(), "GET /other", 0, do); isShared || requests.Load() != 2 {
+		t.Fatalf("expected a different request to be made")
+	}
+}
+
+func TestResponseCache_Failure(t *testing.T) {
+	cache := NewResponseCache()
+
+	failure := errors.New("connection refused")
+	if _, _, err := cache.Do(context.Background(), "GET /", 0, func() (*http.Response, error) { return nil, failure }); !errors.Is(err, failure) {
+		t.Fatalf("expected the error of the request, got %v", err)
+	}
+
+	response, isShared, err := cache.Do(context.Background(), "GET /", 0, func() (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(""))}, nil
+	})
+	if err != nil || isShared || response.StatusCode != http.StatusOK {
+		t.Fatalf("expected the failed request to be made again, got %v", err)
+	}
+}
+
+func TestResponseCache_Nil(t *testing.T) {
+	var cache *ResponseCache
+
+	var requests int
+	for range 2 {
+		_, isShared, err := cache.Do(context.Background(), "GET /", 0, func() (*http.Response, error) {
+			requests++
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(""))}, nil
+		})
+		if err != nil || isShared {
+			t.Fatalf("expected a nil cache to make the request, got %v", err)
+		}
+	}
+
+	if requests != 2 {
+		t.Fatalf("expected 2 requests, got %d", requests)
+	}
+}
+
+func TestResponseCache_MaxBodyBytes(t *testing.T) {
+	cache := NewResponseCache()
+
+	response, _, err := cache.Do(context.Background(), "GET /", 4, func() (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("too large"))}, nil
+	})
+	if err != nil {
+		t.Fatalf("expected a response, got %s", err)
+	}
+
+	// One byte more than the limit is read, for the caller to fail.
+	if body, _ := io.ReadAll(response.Body); string(body) != "too l" {
+		t.Fatalf("expected the body to be read up to the limit, got %q", body)
+	}
+}
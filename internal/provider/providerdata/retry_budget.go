@@ -0,0 +1,56 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package providerdata
+
+import (
+	"fmt"
+	"sync"
+)
+
+// retryBudgetExhaustedError is returned for the retries of the requests to a
+// host whose budget is spent.
+type retryBudgetExhaustedError struct {
+	host  string
+	limit int
+}
+
+func (err retryBudgetExhaustedError) Error() string {
+	return fmt.Sprintf("the retry budget of %s is exhausted after %d retries during the run", err.host, err.limit)
+}
+
+// RetryBudget limits the number of retries of the requests to each host during
+// the run, so that a host that is down is not retried by every resource on its
+// own retry schedule.
+type RetryBudget struct {
+	limit int
+
+	mu      sync.Mutex
+	retries map[string]int
+}
+
+// NewRetryBudget returns a retry budget allowing limit retries per host.
+func NewRetryBudget(limit int) *RetryBudget {
+	return &RetryBudget{
+		limit:   limit,
+		retries: make(map[string]int),
+	}
+}
+
+// Spend consumes a retry of the requests to the host, it returns an error
+// once the budget of the host is exhausted.
+func (budget *RetryBudget) Spend(host string) error {
+	if budget == nil {
+		return nil
+	}
+
+	budget.mu.Lock()
+	defer budget.mu.Unlock()
+
+	if budget.retries[host] >= budget.limit {
+		return retryBudgetExhaustedError{host: host, limit: budget.limit}
+	}
+
+	budget.retries[host]++
+	return nil
+}
@@ -0,0 +1,33 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package providerdata
+
+import (
+	"testing"
+)
+
+func TestRetryBudget(t *testing.T) {
+	budget := NewRetryBudget(2)
+
+	for i := 0; i < 2; i++ {
+		if err := budget.Spend("down.example.com"); err != nil {
+			t.Fatalf("expected retry %d to be allowed, got %s", i+1, err)
+		}
+	}
+
+	if err := budget.Spend("down.example.com"); err == nil {
+		t.Fatalf("expected the budget to be exhausted")
+	}
+	if err := budget.Spend("up.example.com"); err != nil {
+		t.Fatalf("expected the budget of other hosts to be available, got %s", err)
+	}
+}
+
+func TestRetryBudget_Nil(t *testing.T) {
+	var budget *RetryBudget
+
+	if err := budget.Spend("example.com"); err != nil {
+		t.Fatalf("expected a nil retry budget to allow retries, got %s", err)
+	}
+}
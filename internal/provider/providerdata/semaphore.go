@@ -0,0 +1,43 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package providerdata
+
+import (
+	"context"
+)
+
+// Semaphore limits the number of requests in flight at once across the
+// resources and data sources.
+type Semaphore struct {
+	slots chan struct{}
+}
+
+// NewSemaphore returns a semaphore allowing size requests at once.
+func NewSemaphore(size int) *Semaphore {
+	return &Semaphore{slots: make(chan struct{}, size)}
+}
+
+// Acquire waits for a free slot, it returns an error when the context is done
+// first.
+func (semaphore *Semaphore) Acquire(ctx context.Context) error {
+	if semaphore == nil {
+		return nil
+	}
+
+	select {
+	case semaphore.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Release frees a slot taken by Acquire.
+func (semaphore *Semaphore) Release() {
+	if semaphore == nil {
+		return
+	}
+
+	<-semaphore.slots
+}
@@ -0,0 +1,38 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package providerdata
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestSemaphore(t *testing.T) {
+	semaphore := NewSemaphore(1)
+
+	if err := semaphore.Acquire(context.Background()); err != nil {
+		t.Fatalf("expected a free slot, got %s", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := semaphore.Acquire(ctx); err == nil {
+		t.Fatalf("expected to wait for the slot until the context is done")
+	}
+
+	semaphore.Release()
+	if err := semaphore.Acquire(context.Background()); err != nil {
+		t.Fatalf("expected the released slot to be free, got %s", err)
+	}
+}
+
+func TestSemaphore_Nil(t *testing.T) {
+	var semaphore *Semaphore
+
+	if err := semaphore.Acquire(context.Background()); err != nil {
+		t.Fatalf("expected a nil semaphore to allow requests, got %s", err)
+	}
+	semaphore.Release()
+}
@@ -0,0 +1,313 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"terraform-provider-utilities/internal/provider/tlsclient"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	defaultTimeout = 10000
+
+	commandGet     = "GET"
+	commandHgetall = "HGETALL"
+	commandScan    = "SCAN"
+
+	// scanCount is the number of keys requested on each SCAN iteration.
+	scanCount = 1000
+)
+
+var _ datasource.DataSource = (*redisDataSource)(nil)
+
+func NewRedisDataSource() datasource.DataSource {
+	return &redisDataSource{}
+}
+
+type redisDataSource struct{}
+type redisDataSourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	Address        types.String `tfsdk:"address"`
+	Username       types.String `tfsdk:"username"`
+	Password       types.String `tfsdk:"password"`
+	Database       types.Int64  `tfsdk:"database"`
+	Command        types.String `tfsdk:"command"`
+	Key            types.String `tfsdk:"key"`
+	RequestTimeout types.Int64  `tfsdk:"request_timeout_ms"`
+	TLS            types.Bool   `tfsdk:"tls"`
+	CaCertificate  types.String `tfsdk:"ca_cert_pem"`
+	Insecure       types.Bool   `tfsdk:"insecure"`
+	Value          types.String `tfsdk:"value"`
+	Values         types.Map    `tfsdk:"values"`
+	Keys           types.List   `tfsdk:"keys"`
+}
+
+func (d *redisDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_redis"
+}
+
+func (d *redisDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: `
+The ` + "`redis`" + ` data source runs a read-only command against a Redis server and exports the result.
+
+The supported commands are:
+
+- ` + "`GET`" + ` returns the value of the string ` + "`key`" + ` in ` + "`value`" + `, or ` + "`null`" + ` when the key does not exist.
+- ` + "`HGETALL`" + ` returns the fields of the hash ` + "`key`" + ` in ` + "`values`" + `.
+- ` + "`SCAN`" + ` returns the keys starting with the ` + "`key`" + ` prefix in ` + "`keys`" + `, and the values of the
+  string keys among them in ` + "`values`" + `.
+`,
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The address and key used for the command.",
+				Computed:    true,
+			},
+
+			"address": schema.StringAttribute{
+				Description: "The address of the server, in the `host:port` form.",
+				Required:    true,
+			},
+
+			"username": schema.StringAttribute{
+				Description: "The username used to authenticate, when using Redis ACLs.",
+				Optional:    true,
+			},
+
+			"password": schema.StringAttribute{
+				Description: "The password used to authenticate.",
+				Optional:    true,
+				Sensitive:   true,
+			},
+
+			"database": schema.Int64Attribute{
+				Description: "The database to select. Defaults to `0`.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+
+			"command": schema.StringAttribute{
+				Description: fmt.Sprintf("The command to run, one of `%s`, `%s` or `%s`.", commandGet, commandHgetall, commandScan),
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(commandGet, commandHgetall, commandScan),
+				},
+			},
+
+			"key": schema.StringAttribute{
+				Description: "The key to read, or the prefix of the keys to list with `SCAN`.",
+				Required:    true,
+			},
+
+			"request_timeout_ms": schema.Int64Attribute{
+				Description: fmt.Sprintf("The time allowed to connect and run the command in milliseconds. Defaults to `%d`.", defaultTimeout),
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+
+			"tls": schema.BoolAttribute{
+				Description: "Secure the connection with TLS. Defaults to `false`",
+				Optional:    true,
+			},
+
+			"ca_cert_pem": schema.StringAttribute{
+				Description: "Certificate Authority (CA) " +
+					"in [PEM (RFC 1421)](https://datatracker.ietf.org/doc/html/rfc1421) format.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("insecure")),
+				},
+			},
+
+			"insecure": schema.BoolAttribute{
+				Description: "Disables verification of the server's certificate chain and hostname. Defaults to `false`",
+				Optional:    true,
+			},
+
+			"value": schema.StringAttribute{
+				Description: "The value returned by `GET`.",
+				Computed:    true,
+			},
+
+			"values": schema.MapAttribute{
+				Description: "The fields returned by `HGETALL`, or the values of the string keys returned by `SCAN`.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+
+			"keys": schema.ListAttribute{
+				Description: "The sorted keys returned by `SCAN`.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *redisDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+}
+
+func (d *redisDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model redisDataSourceModel
+	diags := req.Config.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	model.read(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (model *redisDataSourceModel) read(ctx context.Context, diagnostics *diag.Diagnostics) {
+	timeout := time.Duration(defaultTimeout) * time.Millisecond
+	if !model.RequestTimeout.IsNull() {
+		timeout = time.Duration(model.RequestTimeout.ValueInt64()) * time.Millisecond
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	options := &redis.Options{
+		Addr:        model.Address.ValueString(),
+		Username:    model.Username.ValueString(),
+		Password:    model.Password.ValueString(),
+		DB:          int(model.Database.ValueInt64()),
+		DialTimeout: timeout,
+		MaxRetries:  -1,
+	}
+
+	if model.TLS.ValueBool() {
+		tlsModel := tlsclient.Model{
+			CaCertificate: model.CaCertificate,
+			Insecure:      model.Insecure,
+		}
+		options.TLSConfig = tlsModel.Config(diagnostics)
+		if diagnostics.HasError() {
+			return
+		}
+	}
+
+	client := redis.NewClient(options)
+	defer client.Close()
+
+	key := model.Key.ValueString()
+	model.ID = types.StringValue(fmt.Sprintf("%s/%s", model.Address.ValueString(), key))
+	model.Value = types.StringNull()
+	model.Values = types.MapNull(types.StringType)
+	model.Keys = types.ListNull(types.StringType)
+
+	var diags diag.Diagnostics
+
+	switch model.Command.ValueString() {
+	case commandGet:
+		value, err := client.Get(ctx, key).Result()
+		if err != nil && !errors.Is(err, redis.Nil) {
+			addCommandError(diagnostics, err)
+			return
+		}
+
+		if err == nil {
+			model.Value = types.StringValue(value)
+		}
+
+	case commandHgetall:
+		values, err := client.HGetAll(ctx, key).Result()
+		if err != nil {
+			addCommandError(diagnostics, err)
+			return
+		}
+
+		model.Values, diags = types.MapValueFrom(ctx, types.StringType, values)
+		diagnostics.Append(diags...)
+
+	case commandScan:
+		var keys []string
+		iter := client.Scan(ctx, 0, escapePattern(key)+"*", scanCount).Iterator()
+		for iter.Next(ctx) {
+			keys = append(keys, iter.Val())
+		}
+		if err := iter.Err(); err != nil {
+			addCommandError(diagnostics, err)
+			return
+		}
+
+		// SCAN may return a key more than once.
+		slices.Sort(keys)
+		keys = slices.Compact(keys)
+
+		values := make(map[string]string)
+		for start := 0; start < len(keys); start += scanCount {
+			end := min(start+scanCount, len(keys))
+
+			results, err := client.MGet(ctx, keys[start:end]...).Result()
+			if err != nil {
+				addCommandError(diagnostics, err)
+				return
+			}
+
+			for i, result := range results {
+				// MGET returns nil for the keys that are not strings.
+				if value, ok := result.(string); ok {
+					values[keys[start+i]] = value
+				}
+			}
+		}
+
+		model.Keys, diags = types.ListValueFrom(ctx, types.StringType, keys)
+		diagnostics.Append(diags...)
+
+		model.Values, diags = types.MapValueFrom(ctx, types.StringType, values)
+		diagnostics.Append(diags...)
+	}
+}
+
+func addCommandError(diagnostics *diag.Diagnostics, err error) {
+	diagnostics.AddError(
+		"Error running command",
+		fmt.Sprintf("Error running command: %s", err),
+	)
+}
+
+// escapePattern escapes the glob-style special characters of a SCAN pattern.
+func escapePattern(pattern string) string {
+	var escaped strings.Builder
+	for _, r := range pattern {
+		if strings.ContainsRune(`*?[]\^`, r) {
+			escaped.WriteRune('\\')
+		}
+		escaped.WriteRune(r)
+	}
+	return escaped.String()
+}
@@ -0,0 +1,128 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package redis_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// startServer starts an in-memory Redis server requiring the given password
+// and holding a few feature flags.
+func startServer(t *testing.T) *miniredis.Miniredis {
+	server := miniredis.RunT(t)
+	server.RequireAuth("secret")
+
+	_ = server.Set("flags/checkout", "on")
+	_ = server.Set("flags/search", "off")
+	_ = server.Set("other", "ignored")
+	server.HSet("services/api", "host", "10.0.0.1", "port", "8080")
+	_, _ = server.Lpush("flags/list", "not-a-string")
+
+	return server
+}
+
+func TestRedisDataSource_Get(t *testing.T) {
+	server := startServer(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_redis" "redis_test" {
+								address  = "%s"
+								password = "secret"
+								command  = "GET"
+								key      = "flags/checkout"
+							}
+
+							data "utilities_redis" "missing" {
+								address  = "%s"
+								password = "secret"
+								command  = "GET"
+								key      = "flags/missing"
+							}`, server.Addr(), server.Addr()),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utilities_redis.redis_test", "value", "on"),
+					resource.TestCheckNoResourceAttr("data.utilities_redis.missing", "value"),
+				),
+			},
+		},
+	})
+}
+
+func TestRedisDataSource_Hgetall(t *testing.T) {
+	server := startServer(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_redis" "redis_test" {
+								address  = "%s"
+								password = "secret"
+								command  = "HGETALL"
+								key      = "services/api"
+							}`, server.Addr()),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utilities_redis.redis_test", "values.%", "2"),
+					resource.TestCheckResourceAttr("data.utilities_redis.redis_test", "values.host", "10.0.0.1"),
+					resource.TestCheckResourceAttr("data.utilities_redis.redis_test", "values.port", "8080"),
+				),
+			},
+		},
+	})
+}
+
+func TestRedisDataSource_Scan(t *testing.T) {
+	server := startServer(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_redis" "redis_test" {
+								address  = "%s"
+								password = "secret"
+								command  = "SCAN"
+								key      = "flags/"
+							}`, server.Addr()),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utilities_redis.redis_test", "keys.#", "3"),
+					resource.TestCheckResourceAttr("data.utilities_redis.redis_test", "keys.0", "flags/checkout"),
+					resource.TestCheckResourceAttr("data.utilities_redis.redis_test", "keys.1", "flags/list"),
+					resource.TestCheckResourceAttr("data.utilities_redis.redis_test", "values.%", "2"),
+					resource.TestCheckResourceAttr("data.utilities_redis.redis_test", "values.flags/search", "off"),
+				),
+			},
+		},
+	})
+}
+
+func TestRedisDataSource_WrongPassword(t *testing.T) {
+	server := startServer(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_redis" "redis_test" {
+								address  = "%s"
+								password = "wrong"
+								command  = "GET"
+								key      = "flags/checkout"
+							}`, server.Addr()),
+				ExpectError: regexp.MustCompile("Error running command"),
+			},
+		},
+	})
+}
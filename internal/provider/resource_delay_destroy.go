@@ -0,0 +1,124 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &DelayDestroyResource{}
+
+func NewDelayDestroyResource() resource.Resource {
+	return &DelayDestroyResource{}
+}
+
+// DelayDestroyResource defines the resource implementation.
+type DelayDestroyResource struct{}
+
+// DelayDestroyResourceModel describes the resource data model.
+type DelayDestroyResourceModel struct {
+	Id           types.String `tfsdk:"id"`
+	DelaySeconds types.Int64  `tfsdk:"delay_seconds"`
+	Keepers      types.Map    `tfsdk:"keepers"`
+}
+
+func (r *DelayDestroyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_delay_destroy"
+}
+
+func (r *DelayDestroyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The delay_destroy resource waits before it is destroyed, and only then, so that the resources it depends " +
+			"on are destroyed after a grace period, e.g. once a load balancer target is drained or a service is deregistered.\n\n" +
+			"The resources to tear down later are referenced by the resource, or listed in its `depends_on`, and the resources " +
+			"to tear down first depend on it. Creating and updating the resource do not wait.",
+		Attributes: map[string]schema.Attribute{
+			"delay_seconds": schema.Int64Attribute{
+				MarkdownDescription: "The number of seconds to wait when the resource is destroyed, or replaced. " +
+					"Changing it is applied in place, the last value being waited.",
+				Required: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+
+			"keepers": schema.MapAttribute{
+				Description: "Arbitrary map of values that, when changed, will trigger recreation of " +
+					"resource, waiting the delay. See [the main provider documentation](../index.html) for more information.",
+				ElementType: types.StringType,
+				Optional:    true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplaceIfConfigured(),
+				},
+			},
+
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The time the resource was created, in RFC 3339 format.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *DelayDestroyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data DelayDestroyResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Id = types.StringValue(time.Now().UTC().Format(time.RFC3339))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DelayDestroyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// The resource does not depend on any remote data, resp.State already
+	// holds the prior state.
+}
+
+func (r *DelayDestroyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data DelayDestroyResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DelayDestroyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data DelayDestroyResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	delay := time.Duration(data.DelaySeconds.ValueInt64()) * time.Second
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+		resp.Diagnostics.AddError(
+			"Destroy delay interrupted",
+			fmt.Sprintf("The destroy delay of %s was interrupted: %s", delay, ctx.Err()),
+		)
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+func TestAccDelayDestroyResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDelayDestroyResourceConfig(0),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utilities_delay_destroy.test", "delay_seconds", "0"),
+					resource.TestMatchResourceAttr("utilities_delay_destroy.test", "id", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T`)),
+				),
+			},
+			{
+				// The delay is updated in place.
+				Config: testAccDelayDestroyResourceConfig(1),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("utilities_delay_destroy.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.TestCheckResourceAttr("utilities_delay_destroy.test", "delay_seconds", "1"),
+			},
+		},
+	})
+}
+
+func TestDelayDestroyResource_Delete(t *testing.T) {
+	ctx := context.Background()
+	r := NewDelayDestroyResource()
+
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	diags := state.Set(ctx, &DelayDestroyResourceModel{
+		Id:           types.StringValue("2024-01-01T00:00:00Z"),
+		DelaySeconds: types.Int64Value(1),
+		Keepers:      types.MapNull(types.StringType),
+	})
+	if diags.HasError() {
+		t.Fatalf("error setting state: %v", diags)
+	}
+
+	t.Run("waits", func(t *testing.T) {
+		start := time.Now()
+		var resp fwresource.DeleteResponse
+		r.Delete(ctx, fwresource.DeleteRequest{State: state}, &resp)
+
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected error: %v", resp.Diagnostics)
+		}
+		if elapsed := time.Since(start); elapsed < time.Second {
+			t.Errorf("expected the destroy to wait at least 1s, waited %s", elapsed)
+		}
+	})
+
+	t.Run("interrupted", func(t *testing.T) {
+		cancelCtx, cancel := context.WithCancel(ctx)
+		cancel()
+
+		var resp fwresource.DeleteResponse
+		r.Delete(cancelCtx, fwresource.DeleteRequest{State: state}, &resp)
+
+		if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != "Destroy delay interrupted" {
+			t.Fatalf("expected the error %q, got %v", "Destroy delay interrupted", resp.Diagnostics)
+		}
+	})
+}
+
+func testAccDelayDestroyResourceConfig(delaySeconds int) string {
+	return fmt.Sprintf(`
+resource "utilities_delay_destroy" "test" {
+  delay_seconds = %d
+}
+`, delaySeconds)
+}
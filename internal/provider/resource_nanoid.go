@@ -6,6 +6,8 @@ package provider
 import (
 	"context"
 	"fmt"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
@@ -52,7 +54,9 @@ func (d *NanoIdResource) Schema(ctx context.Context, req resource.SchemaRequest,
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "The nanoid resource generates random strings that are intended to be used as unique identifiers for other resources.\n\n" +
 			"This resource can be used in conjunction with resources that have the `create_before_destroy` lifecycle flag set to avoid conflicts with " +
-			"unique names during the brief period where both the old and new resources exist concurrently.",
+			"unique names during the brief period where both the old and new resources exist concurrently.\n\n" +
+			"An id is imported with its id, or as `<alphabet>:<id>` when it was not generated with the default alphabet. " +
+			"The import fails when the id contains characters which are not in the alphabet.",
 		Attributes: map[string]schema.Attribute{
 			"alphabet": schema.StringAttribute{
 				MarkdownDescription: fmt.Sprintf("Supply your own list of characters to use for id generation.\n"+
@@ -152,13 +156,8 @@ func (r *NanoIdResource) Create(ctx context.Context, req resource.CreateRequest,
 }
 
 func (d *NanoIdResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var data NanoIdResourceModel
-	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	// The id does not depend on any remote data, resp.State already holds the
+	// prior state.
 }
 
 func (r *NanoIdResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -179,19 +178,41 @@ func (r *NanoIdResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	}
 }
 
+// ImportState imports an id generated with the default alphabet, or with
+// another alphabet when the import id is `<alphabet>:<id>`, the id being after
+// the last colon.
 func (r *NanoIdResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	id := req.ID
+	id, alphabet := req.ID, DEFAULT_ID_ALPHABET
+	if i := strings.LastIndex(req.ID, ":"); i >= 0 {
+		alphabet, id = req.ID[:i], req.ID[i+1:]
+		if alphabetLength := utf8.RuneCountInString(alphabet); alphabetLength < 1 || alphabetLength > 255 {
+			resp.Diagnostics.AddError("Invalid alphabet", "The alphabet must be between 1 and 255 characters long.")
+			return
+		}
+	}
+
 	length := len(id)
 	if length > 64 {
 		resp.Diagnostics.AddError("Invalid id", "The id must be at most 64 characters long.")
 		return
 	}
 
+	for _, c := range id {
+		if !strings.ContainsRune(alphabet, c) {
+			resp.Diagnostics.AddError(
+				"Invalid id",
+				fmt.Sprintf("The id %q contains the character %q, which is not in the alphabet %q. "+
+					"Import an id generated with another alphabet as `<alphabet>:<id>`.", id, c, alphabet),
+			)
+			return
+		}
+	}
+
 	state := &NanoIdResourceModel{
 		Id:       types.StringValue(id),
 		Length:   types.Int64Value(int64(length)),
 		Keepers:  types.MapNull(types.StringType),
-		Alphabet: types.StringValue(DEFAULT_ID_ALPHABET),
+		Alphabet: types.StringValue(alphabet),
 	}
 
 	diags := resp.State.Set(ctx, &state)
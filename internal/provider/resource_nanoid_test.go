@@ -4,10 +4,18 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"regexp"
+	"strings"
 	"testing"
 
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
 func testCheckLen(expectedLen int) func(input string) error {
@@ -64,6 +72,99 @@ func TestAccIdResource_WithLength(t *testing.T) {
 	})
 }
 
+func TestAccIdResource_WithAlphabet(t *testing.T) {
+	alphabet := "abc:"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIdResourceConfig(11, &alphabet),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utilities_nanoid.test", "alphabet", alphabet),
+					resource.TestCheckResourceAttrWith("utilities_nanoid.test", "id", testCheckLen(11)),
+				),
+			},
+			{
+				ResourceName:  "utilities_nanoid.test",
+				ImportState:   true,
+				ImportStateId: "abc:abcabc",
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if states[0].Attributes["alphabet"] != "abc" || states[0].ID != "abcabc" {
+						return fmt.Errorf("unexpected imported state: %v", states[0].Attributes)
+					}
+					return nil
+				},
+			},
+			{
+				ResourceName:  "utilities_nanoid.test",
+				ImportState:   true,
+				ImportStateId: "abc:abcd",
+				ExpectError:   regexp.MustCompile(`contains the character 'd', which is not in the alphabet "abc"`),
+			},
+		},
+	})
+}
+
+func TestNanoIdResource_ImportState(t *testing.T) {
+	ctx := context.Background()
+	r, ok := NewNanoIdResource().(fwresource.ResourceWithImportState)
+	if !ok {
+		t.Fatal("the resource does not support import")
+	}
+
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	tests := map[string]struct {
+		id               string
+		expectedID       string
+		expectedAlphabet string
+		expectedError    string
+	}{
+		"default alphabet":  {id: "V1StGXR8_Z5jdHi6B-myT", expectedID: "V1StGXR8_Z5jdHi6B-myT", expectedAlphabet: DEFAULT_ID_ALPHABET},
+		"custom alphabet":   {id: "0123456789:4815162342", expectedID: "4815162342", expectedAlphabet: "0123456789"},
+		"colon in alphabet": {id: "ab:c:a:b", expectedID: "b", expectedAlphabet: "ab:c:a"},
+		"not in alphabet":   {id: "V1StGXR8!Z5jdHi6B-myT", expectedError: "Invalid id"},
+		"not in custom":     {id: "0123456789:48151623a2", expectedError: "Invalid id"},
+		"empty alphabet":    {id: ":abc", expectedError: "Invalid alphabet"},
+		"too long":          {id: strings.Repeat("a", 65), expectedError: "Invalid id"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp := fwresource.ImportStateResponse{
+				State: tfsdk.State{
+					Schema: schemaResp.Schema,
+					Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+				},
+			}
+			r.ImportState(ctx, fwresource.ImportStateRequest{ID: test.id}, &resp)
+
+			if test.expectedError != "" {
+				if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != test.expectedError {
+					t.Fatalf("expected the error %q, got %v", test.expectedError, resp.Diagnostics)
+				}
+				return
+			}
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+
+			var state NanoIdResourceModel
+			if diags := resp.State.Get(ctx, &state); diags.HasError() {
+				t.Fatalf("error getting state: %v", diags)
+			}
+
+			if state.Id.ValueString() != test.expectedID || state.Alphabet.ValueString() != test.expectedAlphabet {
+				t.Errorf("expected id %q and alphabet %q, got %q and %q", test.expectedID, test.expectedAlphabet, state.Id.ValueString(), state.Alphabet.ValueString())
+			}
+		})
+	}
+}
+
 func testAccIdResourceConfig(length int, alphabet *string) string {
 	lengthStr := fmt.Sprintf("length = %d", length)
 	alphabetStr := ""
@@ -81,3 +182,35 @@ resource "utilities_nanoid" "test" {
 func testAccIdResourceConfigEmpty() string {
 	return `resource "utilities_nanoid" "test" {}`
 }
+
+func BenchmarkNanoIdResource_Read(b *testing.B) {
+	ctx := context.Background()
+	r := NewNanoIdResource()
+
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	if diags := state.Set(ctx, NanoIdResourceModel{
+		Id:       types.StringValue("V1StGXR8_Z5jdHi6B-myT"),
+		Length:   types.Int64Value(DEFAULT_ID_LENGTH),
+		Alphabet: types.StringValue(DEFAULT_ID_ALPHABET),
+		Keepers:  types.MapNull(types.StringType),
+	}); diags.HasError() {
+		b.Fatalf("error setting state: %v", diags)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		resp := fwresource.ReadResponse{State: state}
+		r.Read(ctx, fwresource.ReadRequest{State: state}, &resp)
+		if resp.Diagnostics.HasError() {
+			b.Fatalf("error reading: %v", resp.Diagnostics)
+		}
+	}
+}
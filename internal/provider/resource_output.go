@@ -0,0 +1,137 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/dynamicvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &OutputResource{}
+var _ resource.ResourceWithModifyPlan = &OutputResource{}
+
+func NewOutputResource() resource.Resource {
+	return &OutputResource{}
+}
+
+// OutputResource defines the resource implementation.
+type OutputResource struct{}
+
+// OutputResourceModel describes the resource data model.
+type OutputResourceModel struct {
+	Id             types.String  `tfsdk:"id"`
+	Value          types.Dynamic `tfsdk:"value"`
+	SensitiveValue types.Dynamic `tfsdk:"sensitive_value"`
+	Version        types.Int64   `tfsdk:"version"`
+}
+
+func (r *OutputResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_output"
+}
+
+func (r *OutputResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The output resource stores a value of any type in the state, as a stable hand-off point between modules.\n\n" +
+			"The `version` is bumped each time the value changes, so that the resource can be used in the `replace_triggered_by` " +
+			"of other resources, which are then only replaced when the value actually changes.",
+		Attributes: map[string]schema.Attribute{
+			"value": schema.DynamicAttribute{
+				MarkdownDescription: "The value to store, of any type.",
+				Optional:            true,
+				Validators: []validator.Dynamic{
+					dynamicvalidator.ExactlyOneOf(path.MatchRoot("value"), path.MatchRoot("sensitive_value")),
+				},
+			},
+
+			"sensitive_value": schema.DynamicAttribute{
+				MarkdownDescription: "The value to store, of any type, hidden from the plan and the output of Terraform.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+
+			"version": schema.Int64Attribute{
+				MarkdownDescription: "The version of the value, `1` when the resource is created and bumped each time the value changes.",
+				Computed:            true,
+			},
+
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The time the resource was created, in RFC 3339 format.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// ModifyPlan plans the version, bumped when the value is changed.
+func (r *OutputResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// The resource is destroyed.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan OutputResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	version := int64(1)
+	if !req.State.Raw.IsNull() {
+		var state OutputResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		version = state.Version.ValueInt64()
+		if !plan.Value.Equal(state.Value) || !plan.SensitiveValue.Equal(state.SensitiveValue) {
+			version++
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("version"), types.Int64Value(version))...)
+}
+
+func (r *OutputResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data OutputResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Id = types.StringValue(time.Now().UTC().Format(time.RFC3339))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *OutputResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// The value does not depend on any remote data, resp.State already holds
+	// the prior state.
+}
+
+func (r *OutputResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data OutputResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *OutputResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// The value is only stored in the state.
+}
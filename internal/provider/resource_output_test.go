@@ -0,0 +1,132 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+func TestAccOutputResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "utilities_output" "test" {
+  value = {
+    name  = "web"
+    ports = [80, 443]
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utilities_output.test", "value.name", "web"),
+					resource.TestCheckResourceAttr("utilities_output.test", "value.ports.1", "443"),
+					resource.TestCheckResourceAttr("utilities_output.test", "version", "1"),
+				),
+			},
+			{
+				Config: `
+resource "utilities_output" "test" {
+  value = {
+    name  = "web"
+    ports = [80, 443]
+  }
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			{
+				Config: `
+resource "utilities_output" "test" {
+  sensitive_value = "secret"
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("utilities_output.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utilities_output.test", "sensitive_value", "secret"),
+					resource.TestCheckNoResourceAttr("utilities_output.test", "value"),
+					resource.TestCheckResourceAttr("utilities_output.test", "version", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestOutputResource_ModifyPlan(t *testing.T) {
+	ctx := context.Background()
+	r, ok := NewOutputResource().(fwresource.ResourceWithModifyPlan)
+	if !ok {
+		t.Fatal("the resource does not modify the plan")
+	}
+
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	model := func(value string, version types.Int64) *OutputResourceModel {
+		return &OutputResourceModel{
+			Id:             types.StringValue("2024-01-01T00:00:00Z"),
+			Value:          types.DynamicValue(types.StringValue(value)),
+			SensitiveValue: types.DynamicNull(),
+			Version:        version,
+		}
+	}
+
+	tests := map[string]struct {
+		state           *OutputResourceModel
+		plan            *OutputResourceModel
+		expectedVersion int64
+	}{
+		"created":   {plan: model("a", types.Int64Unknown()), expectedVersion: 1},
+		"unchanged": {state: model("a", types.Int64Value(3)), plan: model("a", types.Int64Value(3)), expectedVersion: 3},
+		"changed":   {state: model("a", types.Int64Value(3)), plan: model("b", types.Int64Value(3)), expectedVersion: 4},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			state := tfsdk.State{Schema: schemaResp.Schema}
+			if test.state != nil {
+				if diags := state.Set(ctx, test.state); diags.HasError() {
+					t.Fatalf("error setting state: %v", diags)
+				}
+			}
+
+			plan := tfsdk.Plan{Schema: schemaResp.Schema}
+			if diags := plan.Set(ctx, test.plan); diags.HasError() {
+				t.Fatalf("error setting plan: %v", diags)
+			}
+
+			resp := fwresource.ModifyPlanResponse{Plan: plan}
+			r.ModifyPlan(ctx, fwresource.ModifyPlanRequest{State: state, Plan: plan}, &resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+
+			var planned OutputResourceModel
+			if diags := resp.Plan.Get(ctx, &planned); diags.HasError() {
+				t.Fatalf("error getting plan: %v", diags)
+			}
+
+			if planned.Version.ValueInt64() != test.expectedVersion {
+				t.Errorf("expected version %d, got %s", test.expectedVersion, planned.Version)
+			}
+		})
+	}
+}
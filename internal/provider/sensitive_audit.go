@@ -0,0 +1,76 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"regexp"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+// fetchedDataRegexp matches the names of the attributes holding the data
+// fetched by the resources and data sources, e.g. `response_body`.
+var fetchedDataRegexp = regexp.MustCompile(`(^|_)(body|content|data|errors|headers|json|messages|output|response|results?|rows|values?)(_|$)`)
+
+// auditedAttribute is the part of the attribute schemas of the resources and
+// data sources used by the audit.
+type auditedAttribute interface {
+	IsComputed() bool
+	IsOptional() bool
+	IsSensitive() bool
+}
+
+// auditSensitiveAttributes returns the computed attributes of the resources
+// and data sources which may hold sensitive fetched data but are not marked
+// sensitive, and so are stored in plaintext in the state. They are named
+// after their resource or data source type, e.g. `utilities_http.response_body`.
+func (p *UtilitiesProvider) auditSensitiveAttributes(ctx context.Context) []string {
+	var names []string
+	audit := func(typeName string, attributes map[string]auditedAttribute) {
+		for name, attribute := range attributes {
+			// The optional computed attributes are set in the configuration.
+			if attribute.IsComputed() && !attribute.IsOptional() && !attribute.IsSensitive() && fetchedDataRegexp.MatchString(name) {
+				names = append(names, typeName+"."+name)
+			}
+		}
+	}
+
+	for _, newResource := range p.Resources(ctx) {
+		r := newResource()
+
+		var metadata resource.MetadataResponse
+		r.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: "utilities"}, &metadata)
+
+		var schema resource.SchemaResponse
+		r.Schema(ctx, resource.SchemaRequest{}, &schema)
+
+		attributes := make(map[string]auditedAttribute, len(schema.Schema.Attributes))
+		for name, attribute := range schema.Schema.Attributes {
+			attributes[name] = attribute
+		}
+		audit(metadata.TypeName, attributes)
+	}
+
+	for _, newDataSource := range p.DataSources(ctx) {
+		d := newDataSource()
+
+		var metadata datasource.MetadataResponse
+		d.Metadata(ctx, datasource.MetadataRequest{ProviderTypeName: "utilities"}, &metadata)
+
+		var schema datasource.SchemaResponse
+		d.Schema(ctx, datasource.SchemaRequest{}, &schema)
+
+		attributes := make(map[string]auditedAttribute, len(schema.Schema.Attributes))
+		for name, attribute := range schema.Schema.Attributes {
+			attributes[name] = attribute
+		}
+		audit("data."+metadata.TypeName, attributes)
+	}
+
+	sort.Strings(names)
+	return names
+}
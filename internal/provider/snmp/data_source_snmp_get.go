@@ -0,0 +1,405 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package snmp
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gosnmp/gosnmp"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+const (
+	defaultPort    = 161
+	defaultTimeout = 10000
+
+	version2c = "2c"
+	version3  = "3"
+)
+
+var authProtocols = map[string]gosnmp.SnmpV3AuthProtocol{
+	"MD5":    gosnmp.MD5,
+	"SHA":    gosnmp.SHA,
+	"SHA224": gosnmp.SHA224,
+	"SHA256": gosnmp.SHA256,
+	"SHA384": gosnmp.SHA384,
+	"SHA512": gosnmp.SHA512,
+}
+
+var privProtocols = map[string]gosnmp.SnmpV3PrivProtocol{
+	"DES":     gosnmp.DES,
+	"AES":     gosnmp.AES,
+	"AES192":  gosnmp.AES192,
+	"AES256":  gosnmp.AES256,
+	"AES192C": gosnmp.AES192C,
+	"AES256C": gosnmp.AES256C,
+}
+
+var _ datasource.DataSource = (*snmpGetDataSource)(nil)
+
+func NewSnmpGetDataSource() datasource.DataSource {
+	return &snmpGetDataSource{}
+}
+
+type snmpGetDataSource struct{}
+type snmpGetDataSourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	Target         types.String `tfsdk:"target"`
+	Version        types.String `tfsdk:"version"`
+	Community      types.String `tfsdk:"community"`
+	USM            types.Object `tfsdk:"usm"`
+	OIDs           types.List   `tfsdk:"oids"`
+	RequestTimeout types.Int64  `tfsdk:"request_timeout_ms"`
+	Retries        types.Int64  `tfsdk:"retries"`
+	Values         types.Map    `tfsdk:"values"`
+}
+
+type usmModel struct {
+	Username       types.String `tfsdk:"username"`
+	AuthProtocol   types.String `tfsdk:"auth_protocol"`
+	AuthPassphrase types.String `tfsdk:"auth_passphrase"`
+	PrivProtocol   types.String `tfsdk:"priv_protocol"`
+	PrivPassphrase types.String `tfsdk:"priv_passphrase"`
+	ContextName    types.String `tfsdk:"context_name"`
+}
+
+func (d *snmpGetDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_snmp_get"
+}
+
+func (d *snmpGetDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: `
+The ` + "`snmp_get`" + ` data source sends an SNMP GET request for the given OIDs to an agent
+and exports the returned values.
+
+Both SNMP v2c, authenticated with a community string, and SNMP v3, authenticated with the
+user-based security model configured in the ` + "`usm`" + ` block, are supported.
+
+Octet strings that are not UTF-8 encoded are returned hex encoded, numeric values are returned
+in their decimal form and object identifiers in their dotted form.
+`,
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The target of the request.",
+				Computed:    true,
+			},
+
+			"target": schema.StringAttribute{
+				Description: fmt.Sprintf("The address of the agent, in the `host` or `host:port` form. The port defaults to `%d`.", defaultPort),
+				Required:    true,
+			},
+
+			"version": schema.StringAttribute{
+				Description: fmt.Sprintf("The SNMP version, either `%s` or `%s`. Defaults to `%s`.", version2c, version3, version2c),
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(version2c, version3),
+				},
+			},
+
+			"community": schema.StringAttribute{
+				Description: "The community string used with SNMP v2c. Defaults to `public`.",
+				Optional:    true,
+				Sensitive:   true,
+			},
+
+			"oids": schema.ListAttribute{
+				Description: "The list of OIDs to get, e.g. `1.3.6.1.2.1.1.5.0`.",
+				ElementType: types.StringType,
+				Required:    true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+
+			"request_timeout_ms": schema.Int64Attribute{
+				Description: fmt.Sprintf("The time allowed for each request in milliseconds. Defaults to `%d`.", defaultTimeout),
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+
+			"retries": schema.Int64Attribute{
+				Description: "The number of times a request is retried when no response is received. Defaults to `0`.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+
+			"values": schema.MapAttribute{
+				Description: "A map of the requested OIDs, as configured in `oids`, to their values.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"usm": schema.SingleNestedBlock{
+				Description: "The user-based security model configuration used with SNMP v3.",
+				Attributes: map[string]schema.Attribute{
+					"username": schema.StringAttribute{
+						Description: "The security name of the user.",
+						Optional:    true,
+					},
+					"auth_protocol": schema.StringAttribute{
+						Description: "The authentication protocol, one of `MD5`, `SHA`, `SHA224`, `SHA256`, `SHA384` or `SHA512`. " +
+							"When not set, requests are not authenticated.",
+						Optional: true,
+						Validators: []validator.String{
+							stringvalidator.OneOf("MD5", "SHA", "SHA224", "SHA256", "SHA384", "SHA512"),
+							stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("auth_passphrase")),
+						},
+					},
+					"auth_passphrase": schema.StringAttribute{
+						Description: "The authentication passphrase.",
+						Optional:    true,
+						Sensitive:   true,
+					},
+					"priv_protocol": schema.StringAttribute{
+						Description: "The privacy protocol, one of `DES`, `AES`, `AES192`, `AES256`, `AES192C` or `AES256C`. " +
+							"When not set, requests are not encrypted.",
+						Optional: true,
+						Validators: []validator.String{
+							stringvalidator.OneOf("DES", "AES", "AES192", "AES256", "AES192C", "AES256C"),
+							stringvalidator.AlsoRequires(
+								path.MatchRelative().AtParent().AtName("priv_passphrase"),
+								path.MatchRelative().AtParent().AtName("auth_protocol"),
+							),
+						},
+					},
+					"priv_passphrase": schema.StringAttribute{
+						Description: "The privacy passphrase.",
+						Optional:    true,
+						Sensitive:   true,
+					},
+					"context_name": schema.StringAttribute{
+						Description: "The context name of the request.",
+						Optional:    true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *snmpGetDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+}
+
+func (d *snmpGetDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model snmpGetDataSourceModel
+	diags := req.Config.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	model.read(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (model *snmpGetDataSourceModel) read(ctx context.Context, diagnostics *diag.Diagnostics) {
+	var oids []string
+	diagnostics.Append(model.OIDs.ElementsAs(ctx, &oids, false)...)
+	if diagnostics.HasError() {
+		return
+	}
+
+	client := model.client(ctx, diagnostics)
+	if diagnostics.HasError() {
+		return
+	}
+
+	if err := client.Connect(); err != nil {
+		diagnostics.AddError(
+			"Error connecting to agent",
+			fmt.Sprintf("Error connecting to agent: %s", err),
+		)
+		return
+	}
+	defer client.Conn.Close()
+
+	values := make(map[string]string, len(oids))
+	for start := 0; start < len(oids); start += client.MaxOids {
+		end := min(start+client.MaxOids, len(oids))
+
+		result, err := client.Get(oids[start:end])
+		if err != nil {
+			diagnostics.AddError(
+				"Error making request",
+				fmt.Sprintf("Error making request: %s", err),
+			)
+			return
+		}
+
+		if result.Error != gosnmp.NoError {
+			diagnostics.AddError(
+				"Error making request",
+				fmt.Sprintf("The agent returned the %s error for the OID at index %d.", result.Error, start+int(result.ErrorIndex)-1),
+			)
+			return
+		}
+
+		for i, variable := range result.Variables {
+			oid := oids[start+i]
+
+			value, err := formatValue(variable)
+			if err != nil {
+				diagnostics.AddAttributeError(
+					path.Root("oids").AtListIndex(start+i),
+					"Error reading value",
+					fmt.Sprintf("Error reading the value of %s: %s", oid, err),
+				)
+				return
+			}
+
+			values[oid] = value
+		}
+	}
+
+	valuesMap, diags := types.MapValueFrom(ctx, types.StringType, values)
+	diagnostics.Append(diags...)
+	if diagnostics.HasError() {
+		return
+	}
+
+	model.ID = model.Target
+	model.Values = valuesMap
+}
+
+func (model *snmpGetDataSourceModel) client(ctx context.Context, diagnostics *diag.Diagnostics) *gosnmp.GoSNMP {
+	host, port := model.Target.ValueString(), uint16(defaultPort)
+	if h, p, err := net.SplitHostPort(host); err == nil {
+		parsed, err := strconv.ParseUint(p, 10, 16)
+		if err != nil {
+			diagnostics.AddAttributeError(
+				path.Root("target"),
+				"Invalid target",
+				fmt.Sprintf("The port of the target is not valid: %s", err),
+			)
+			return nil
+		}
+		host, port = h, uint16(parsed)
+	}
+
+	timeout := time.Duration(defaultTimeout) * time.Millisecond
+	if !model.RequestTimeout.IsNull() {
+		timeout = time.Duration(model.RequestTimeout.ValueInt64()) * time.Millisecond
+	}
+
+	client := &gosnmp.GoSNMP{
+		Context:   ctx,
+		Target:    host,
+		Port:      port,
+		Transport: "udp",
+		Community: "public",
+		Version:   gosnmp.Version2c,
+		Timeout:   timeout,
+		Retries:   int(model.Retries.ValueInt64()),
+		MaxOids:   gosnmp.MaxOids,
+	}
+
+	if !model.Community.IsNull() {
+		client.Community = model.Community.ValueString()
+	}
+
+	if model.Version.ValueString() != version3 {
+		return client
+	}
+
+	var usm usmModel
+	if !model.USM.IsNull() && !model.USM.IsUnknown() {
+		diagnostics.Append(model.USM.As(ctx, &usm, basetypes.ObjectAsOptions{})...)
+		if diagnostics.HasError() {
+			return nil
+		}
+	}
+
+	if usm.Username.IsNull() {
+		diagnostics.AddAttributeError(
+			path.Root("usm").AtName("username"),
+			"Missing username",
+			"The usm block must set the username when using SNMP v3.",
+		)
+		return nil
+	}
+
+	params := &gosnmp.UsmSecurityParameters{
+		UserName:               usm.Username.ValueString(),
+		AuthenticationProtocol: gosnmp.NoAuth,
+		PrivacyProtocol:        gosnmp.NoPriv,
+	}
+
+	client.Version = gosnmp.Version3
+	client.SecurityModel = gosnmp.UserSecurityModel
+	client.SecurityParameters = params
+	client.ContextName = usm.ContextName.ValueString()
+	client.MsgFlags = gosnmp.NoAuthNoPriv
+
+	if !usm.AuthProtocol.IsNull() {
+		params.AuthenticationProtocol = authProtocols[usm.AuthProtocol.ValueString()]
+		params.AuthenticationPassphrase = usm.AuthPassphrase.ValueString()
+		client.MsgFlags = gosnmp.AuthNoPriv
+	}
+
+	if !usm.PrivProtocol.IsNull() {
+		params.PrivacyProtocol = privProtocols[usm.PrivProtocol.ValueString()]
+		params.PrivacyPassphrase = usm.PrivPassphrase.ValueString()
+		client.MsgFlags = gosnmp.AuthPriv
+	}
+
+	return client
+}
+
+// formatValue returns the string representation of the value of a variable.
+func formatValue(variable gosnmp.SnmpPDU) (string, error) {
+	switch variable.Type {
+	case gosnmp.NoSuchObject, gosnmp.NoSuchInstance, gosnmp.EndOfMibView:
+		return "", fmt.Errorf("the agent returned %s", variable.Type)
+	case gosnmp.Null:
+		return "", nil
+	case gosnmp.OctetString, gosnmp.Opaque, gosnmp.BitString:
+		value, _ := variable.Value.([]byte)
+		if utf8.Valid(value) {
+			return string(value), nil
+		}
+		return hex.EncodeToString(value), nil
+	case gosnmp.ObjectIdentifier, gosnmp.IPAddress:
+		value, _ := variable.Value.(string)
+		return strings.TrimPrefix(value, "."), nil
+	case gosnmp.Integer, gosnmp.Counter32, gosnmp.Gauge32, gosnmp.TimeTicks, gosnmp.Counter64, gosnmp.Uinteger32:
+		return gosnmp.ToBigInt(variable.Value).String(), nil
+	default:
+		return fmt.Sprint(variable.Value), nil
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package snmp_test
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/gosnmp/gosnmp"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// startAgent starts a SNMP v2c agent answering GET requests sent with the
+// "public" community from the given variables, and returns its address.
+func startAgent(t *testing.T, variables map[string]gosnmp.SnmpPDU) string {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("error listening: %s", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	decoder := &gosnmp.GoSNMP{Version: gosnmp.Version2c, Logger: gosnmp.NewLogger(nil)}
+
+	go func() {
+		buf := make([]byte, 65535)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+
+			packet, err := decoder.SnmpDecodePacket(buf[:n])
+			if err != nil || packet.Community != "public" {
+				continue
+			}
+
+			packet.PDUType = gosnmp.GetResponse
+			for i, requested := range packet.Variables {
+				variable, ok := variables[strings.TrimPrefix(requested.Name, ".")]
+				if !ok {
+					variable = gosnmp.SnmpPDU{Type: gosnmp.NoSuchObject}
+				}
+				variable.Name = requested.Name
+				packet.Variables[i] = variable
+			}
+
+			response, err := packet.MarshalMsg()
+			if err != nil {
+				continue
+			}
+
+			_, _ = conn.WriteTo(response, addr)
+		}
+	}()
+
+	return conn.LocalAddr().String()
+}
+
+func TestSnmpGetDataSource(t *testing.T) {
+	address := startAgent(t, map[string]gosnmp.SnmpPDU{
+		"1.3.6.1.2.1.1.5.0": {Type: gosnmp.OctetString, Value: []byte("switch-01")},
+		"1.3.6.1.2.1.2.1.0": {Type: gosnmp.Integer, Value: 48},
+		"1.3.6.1.2.1.1.2.0": {Type: gosnmp.ObjectIdentifier, Value: ".1.3.6.1.4.1.9"},
+		"1.3.6.1.2.1.1.3.0": {Type: gosnmp.TimeTicks, Value: uint32(123456)},
+	})
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_snmp_get" "snmp_test" {
+								target = "%s"
+								oids   = [
+									"1.3.6.1.2.1.1.5.0",
+									"1.3.6.1.2.1.2.1.0",
+									"1.3.6.1.2.1.1.2.0",
+									".1.3.6.1.2.1.1.3.0",
+								]
+							}`, address),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utilities_snmp_get.snmp_test", "id", address),
+					resource.TestCheckResourceAttr("data.utilities_snmp_get.snmp_test", "values.1.3.6.1.2.1.1.5.0", "switch-01"),
+					resource.TestCheckResourceAttr("data.utilities_snmp_get.snmp_test", "values.1.3.6.1.2.1.2.1.0", "48"),
+					resource.TestCheckResourceAttr("data.utilities_snmp_get.snmp_test", "values.1.3.6.1.2.1.1.2.0", "1.3.6.1.4.1.9"),
+					resource.TestCheckResourceAttr("data.utilities_snmp_get.snmp_test", "values..1.3.6.1.2.1.1.3.0", "123456"),
+				),
+			},
+		},
+	})
+}
+
+func TestSnmpGetDataSource_NoSuchObject(t *testing.T) {
+	address := startAgent(t, map[string]gosnmp.SnmpPDU{})
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_snmp_get" "snmp_test" {
+								target = "%s"
+								oids   = ["1.3.6.1.2.1.1.5.0"]
+							}`, address),
+				ExpectError: regexp.MustCompile("the agent returned NoSuchObject"),
+			},
+		},
+	})
+}
+
+func TestSnmpGetDataSource_Timeout(t *testing.T) {
+	address := startAgent(t, map[string]gosnmp.SnmpPDU{})
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_snmp_get" "snmp_test" {
+								target             = "%s"
+								community          = "private"
+								oids               = ["1.3.6.1.2.1.1.5.0"]
+								request_timeout_ms = 100
+							}`, address),
+				ExpectError: regexp.MustCompile("Error making request"),
+			},
+		},
+	})
+}
+
+func TestSnmpGetDataSource_V3MissingUsername(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+							data "utilities_snmp_get" "snmp_test" {
+								target  = "127.0.0.1"
+								version = "3"
+								oids    = ["1.3.6.1.2.1.1.5.0"]
+							}`,
+				ExpectError: regexp.MustCompile("The usm block must set the username when using SNMP v3."),
+			},
+		},
+	})
+}
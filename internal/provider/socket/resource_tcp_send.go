@@ -0,0 +1,334 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package socket
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"time"
+	"unicode/utf8"
+
+	"terraform-provider-utilities/internal/provider/tlsclient"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const defaultTimeout = 10000
+
+var _ resource.Resource = (*tcpSendResource)(nil)
+
+func NewTcpSendResource() resource.Resource {
+	return &tcpSendResource{}
+}
+
+type tcpSendResource struct{}
+type tcpSendResourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	Address        types.String `tfsdk:"address"`
+	Payload        types.String `tfsdk:"payload"`
+	PayloadHex     types.String `tfsdk:"payload_hex"`
+	ReadUntil      types.String `tfsdk:"read_until"`
+	RequestTimeout types.Int64  `tfsdk:"request_timeout_ms"`
+	TLS            types.Bool   `tfsdk:"tls"`
+	CaCertificate  types.String `tfsdk:"ca_cert_pem"`
+	Insecure       types.Bool   `tfsdk:"insecure"`
+	Keepers        types.Map    `tfsdk:"keepers"`
+	Response       types.String `tfsdk:"response"`
+	ResponseHex    types.String `tfsdk:"response_hex"`
+}
+
+func (r *tcpSendResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tcp_send"
+}
+
+func (r *tcpSendResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: `
+The ` + "`tcp_send`" + ` resource opens a TCP connection, optionally secured with TLS, upon creation,
+sends the given payload and reads the response until the ` + "`read_until`" + ` delimiter is received,
+the server closes the connection or the timeout expires.
+
+This resource will issue a warning if the response is not UTF-8 encoded, use ` + "`response_hex`" + `
+to consume binary responses.
+`,
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The address used for the connection.",
+				Computed:    true,
+			},
+
+			"address": schema.StringAttribute{
+				Description: "The address to connect to, in the `host:port` form.",
+				Required:    true,
+			},
+
+			"payload": schema.StringAttribute{
+				Description: "The payload sent once connected, as a string.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("payload_hex")),
+				},
+			},
+
+			"payload_hex": schema.StringAttribute{
+				Description: "The payload sent once connected, hex encoded.",
+				Optional:    true,
+			},
+
+			"read_until": schema.StringAttribute{
+				Description: "Stop reading once this delimiter has been received. The delimiter is included in the response. " +
+					"When not set, the response is read until the server closes the connection or the timeout expires.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"request_timeout_ms": schema.Int64Attribute{
+				Description: fmt.Sprintf("The time allowed to connect, send the payload and read the response in milliseconds. Defaults to `%d`.", defaultTimeout),
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(defaultTimeout),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+
+			"tls": schema.BoolAttribute{
+				Description: "Secure the connection with TLS. Defaults to `false`",
+				Optional:    true,
+			},
+
+			"ca_cert_pem": schema.StringAttribute{
+				Description: "Certificate Authority (CA) " +
+					"in [PEM (RFC 1421)](https://datatracker.ietf.org/doc/html/rfc1421) format.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("insecure")),
+				},
+			},
+
+			"insecure": schema.BoolAttribute{
+				Description: "Disables verification of the server's certificate chain and hostname. Defaults to `false`",
+				Optional:    true,
+			},
+
+			"response": schema.StringAttribute{
+				Description: "The response returned as a string.",
+				Computed:    true,
+			},
+
+			"response_hex": schema.StringAttribute{
+				Description: "The response hex encoded.",
+				Computed:    true,
+			},
+
+			"keepers": schema.MapAttribute{
+				Description: "Arbitrary map of values that, when changed, will trigger recreation of " +
+					"resource.",
+				ElementType: types.StringType,
+				Optional:    true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplaceIfConfigured(),
+				},
+			},
+		},
+	}
+}
+
+func (r *tcpSendResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+}
+
+func (r *tcpSendResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var model tcpSendResourceModel
+	diags := req.State.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *tcpSendResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var model tcpSendResourceModel
+	diags := req.Plan.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	model.send(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *tcpSendResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var model tcpSendResourceModel
+	diags := req.Plan.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	model.send(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *tcpSendResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data tcpSendResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (model *tcpSendResourceModel) send(ctx context.Context, diagnostics *diag.Diagnostics) {
+	payload := []byte(model.Payload.ValueString())
+	if !model.PayloadHex.IsNull() {
+		var err error
+		payload, err = hex.DecodeString(model.PayloadHex.ValueString())
+		if err != nil {
+			diagnostics.AddAttributeError(
+				path.Root("payload_hex"),
+				"Invalid payload",
+				fmt.Sprintf("The payload is not valid hex: %s", err),
+			)
+			return
+		}
+	}
+
+	timeout := time.Duration(model.RequestTimeout.ValueInt64()) * time.Millisecond
+	deadline := time.Now().Add(timeout)
+
+	ctx, cancel := context.WithDeadline(ctx, deadline)
+	defer cancel()
+
+	var conn net.Conn
+	var err error
+
+	if model.TLS.ValueBool() {
+		tlsModel := tlsclient.Model{
+			CaCertificate: model.CaCertificate,
+			Insecure:      model.Insecure,
+		}
+		tlsConfig := tlsModel.Config(diagnostics)
+		if diagnostics.HasError() {
+			return
+		}
+
+		dialer := &tls.Dialer{Config: tlsConfig}
+		conn, err = dialer.DialContext(ctx, "tcp", model.Address.ValueString())
+	} else {
+		dialer := &net.Dialer{}
+		conn, err = dialer.DialContext(ctx, "tcp", model.Address.ValueString())
+	}
+	if err != nil {
+		diagnostics.AddError(
+			"Error opening connection",
+			fmt.Sprintf("Error opening connection: %s", err),
+		)
+		return
+	}
+	defer conn.Close()
+
+	_ = conn.SetDeadline(deadline)
+
+	if len(payload) > 0 {
+		if _, err := conn.Write(payload); err != nil {
+			diagnostics.AddError(
+				"Error sending payload",
+				fmt.Sprintf("Error sending payload: %s", err),
+			)
+			return
+		}
+	}
+
+	delimiter := []byte(model.ReadUntil.ValueString())
+	response, err := readResponse(conn, delimiter)
+	if err != nil {
+		var netErr net.Error
+		if errors.As(err, &netErr) && netErr.Timeout() {
+			err = fmt.Errorf("timed out after %s waiting for %q", timeout, delimiter)
+		}
+
+		diagnostics.AddError(
+			"Error reading response",
+			fmt.Sprintf("Error reading response: %s", err),
+		)
+		return
+	}
+
+	if !utf8.Valid(response) {
+		diagnostics.AddWarning(
+			"Response is not recognized as UTF-8",
+			"Terraform may not properly handle the response if the contents are binary, use response_hex instead.",
+		)
+	}
+
+	model.ID = model.Address
+	model.Response = types.StringValue(string(response))
+	model.ResponseHex = types.StringValue(hex.EncodeToString(response))
+}
+
+// readResponse reads from the connection until the delimiter is received. When
+// no delimiter is given, the end of the stream and the deadline of the
+// connection both mark the end of the response.
+func readResponse(conn net.Conn, delimiter []byte) ([]byte, error) {
+	var response []byte
+	buf := make([]byte, 4096)
+
+	for {
+		n, err := conn.Read(buf)
+		response = append(response, buf[:n]...)
+
+		if len(delimiter) > 0 {
+			if i := bytes.Index(response, delimiter); i >= 0 {
+				return response[:i+len(delimiter)], nil
+			}
+		}
+
+		if err != nil {
+			if len(delimiter) == 0 {
+				var netErr net.Error
+				if errors.Is(err, io.EOF) || (errors.As(err, &netErr) && netErr.Timeout()) {
+					return response, nil
+				}
+			}
+
+			return nil, err
+		}
+	}
+}
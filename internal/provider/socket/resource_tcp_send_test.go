@@ -0,0 +1,115 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package socket_test
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// startLineServer starts a server answering each received line with its upper
+// case version, and returns its address.
+func startLineServer(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("error listening: %s", err)
+	}
+	t.Cleanup(func() { _ = listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go func() {
+				defer conn.Close()
+
+				reader := bufio.NewReader(conn)
+				for {
+					line, err := reader.ReadString('\n')
+					if err != nil {
+						return
+					}
+
+					if _, err := conn.Write([]byte(strings.ToUpper(line))); err != nil {
+						return
+					}
+				}
+			}()
+		}
+	}()
+
+	return listener.Addr().String()
+}
+
+func TestTcpSendResource_ReadUntil(t *testing.T) {
+	address := startLineServer(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							resource "utilities_tcp_send" "tcp_test" {
+								address    = "%s"
+								payload    = "status\n"
+								read_until = "\n"
+							}`, address),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("utilities_tcp_send.tcp_test", "response", "STATUS\n"),
+					resource.TestCheckResourceAttr("utilities_tcp_send.tcp_test", "response_hex", "5354415455530a"),
+				),
+			},
+		},
+	})
+}
+
+func TestTcpSendResource_PayloadHex(t *testing.T) {
+	address := startLineServer(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							resource "utilities_tcp_send" "tcp_test" {
+								address            = "%s"
+								payload_hex        = "6f6b0a"
+								request_timeout_ms = 200
+							}`, address),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("utilities_tcp_send.tcp_test", "response", "OK\n"),
+				),
+			},
+		},
+	})
+}
+
+func TestTcpSendResource_DelimiterTimeout(t *testing.T) {
+	address := startLineServer(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							resource "utilities_tcp_send" "tcp_test" {
+								address            = "%s"
+								payload            = "status\n"
+								read_until         = "END"
+								request_timeout_ms = 200
+							}`, address),
+				ExpectError: regexp.MustCompile(`timed out after 200ms waiting for "END"`),
+			},
+		},
+	})
+}
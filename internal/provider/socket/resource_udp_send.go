@@ -0,0 +1,262 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package socket
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// maxDatagramSize is the largest payload a UDP datagram can carry.
+const maxDatagramSize = 65535
+
+var _ resource.Resource = (*udpSendResource)(nil)
+
+func NewUdpSendResource() resource.Resource {
+	return &udpSendResource{}
+}
+
+type udpSendResource struct{}
+type udpSendResourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	Address        types.String `tfsdk:"address"`
+	Payload        types.String `tfsdk:"payload"`
+	PayloadHex     types.String `tfsdk:"payload_hex"`
+	ExpectResponse types.Bool   `tfsdk:"expect_response"`
+	RequestTimeout types.Int64  `tfsdk:"request_timeout_ms"`
+	Keepers        types.Map    `tfsdk:"keepers"`
+	Response       types.String `tfsdk:"response"`
+	ResponseHex    types.String `tfsdk:"response_hex"`
+}
+
+func (r *udpSendResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_udp_send"
+}
+
+func (r *udpSendResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: `
+The ` + "`udp_send`" + ` resource sends a single UDP datagram upon creation and optionally
+waits for a response datagram, e.g. for syslog test messages, wake-on-LAN packets or statsd metrics.
+
+Broadcast addresses are supported. This resource will issue a warning if the response is not UTF-8 encoded,
+use ` + "`response_hex`" + ` to consume binary responses.
+`,
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The address the datagram was sent to.",
+				Computed:    true,
+			},
+
+			"address": schema.StringAttribute{
+				Description: "The address to send the datagram to, in the `host:port` form.",
+				Required:    true,
+			},
+
+			"payload": schema.StringAttribute{
+				Description: "The payload of the datagram, as a string.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("payload_hex")),
+				},
+			},
+
+			"payload_hex": schema.StringAttribute{
+				Description: "The payload of the datagram, hex encoded.",
+				Optional:    true,
+			},
+
+			"expect_response": schema.BoolAttribute{
+				Description: "Wait for a response datagram and raise an error if none is received before the timeout. Defaults to `false`",
+				Optional:    true,
+			},
+
+			"request_timeout_ms": schema.Int64Attribute{
+				Description: fmt.Sprintf("The time allowed to send the datagram and receive the response in milliseconds. Defaults to `%d`.", defaultTimeout),
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(defaultTimeout),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+
+			"response": schema.StringAttribute{
+				Description: "The response datagram returned as a string, when `expect_response` is set.",
+				Computed:    true,
+			},
+
+			"response_hex": schema.StringAttribute{
+				Description: "The response datagram hex encoded, when `expect_response` is set.",
+				Computed:    true,
+			},
+
+			"keepers": schema.MapAttribute{
+				Description: "Arbitrary map of values that, when changed, will trigger recreation of " +
+					"resource.",
+				ElementType: types.StringType,
+				Optional:    true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplaceIfConfigured(),
+				},
+			},
+		},
+	}
+}
+
+func (r *udpSendResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+}
+
+func (r *udpSendResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var model udpSendResourceModel
+	diags := req.State.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *udpSendResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var model udpSendResourceModel
+	diags := req.Plan.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	model.send(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *udpSendResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var model udpSendResourceModel
+	diags := req.Plan.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	model.send(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *udpSendResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data udpSendResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (model *udpSendResourceModel) send(ctx context.Context, diagnostics *diag.Diagnostics) {
+	payload := []byte(model.Payload.ValueString())
+	if !model.PayloadHex.IsNull() {
+		var err error
+		payload, err = hex.DecodeString(model.PayloadHex.ValueString())
+		if err != nil {
+			diagnostics.AddAttributeError(
+				path.Root("payload_hex"),
+				"Invalid payload",
+				fmt.Sprintf("The payload is not valid hex: %s", err),
+			)
+			return
+		}
+	}
+
+	timeout := time.Duration(model.RequestTimeout.ValueInt64()) * time.Millisecond
+	deadline := time.Now().Add(timeout)
+
+	ctx, cancel := context.WithDeadline(ctx, deadline)
+	defer cancel()
+
+	dialer := &net.Dialer{}
+	conn, err := dialer.DialContext(ctx, "udp", model.Address.ValueString())
+	if err != nil {
+		diagnostics.AddError(
+			"Error opening socket",
+			fmt.Sprintf("Error opening socket: %s", err),
+		)
+		return
+	}
+	defer conn.Close()
+
+	_ = conn.SetDeadline(deadline)
+
+	if _, err := conn.Write(payload); err != nil {
+		diagnostics.AddError(
+			"Error sending datagram",
+			fmt.Sprintf("Error sending datagram: %s", err),
+		)
+		return
+	}
+
+	model.ID = model.Address
+	model.Response = types.StringNull()
+	model.ResponseHex = types.StringNull()
+
+	if !model.ExpectResponse.ValueBool() {
+		return
+	}
+
+	buf := make([]byte, maxDatagramSize)
+	n, err := conn.Read(buf)
+	if err != nil {
+		var netErr net.Error
+		if errors.As(err, &netErr) && netErr.Timeout() {
+			err = fmt.Errorf("no response received after %s", timeout)
+		}
+
+		diagnostics.AddError(
+			"Error receiving response",
+			fmt.Sprintf("Error receiving response: %s", err),
+		)
+		return
+	}
+
+	response := buf[:n]
+	if !utf8.Valid(response) {
+		diagnostics.AddWarning(
+			"Response is not recognized as UTF-8",
+			"Terraform may not properly handle the response if the contents are binary, use response_hex instead.",
+		)
+	}
+
+	model.Response = types.StringValue(string(response))
+	model.ResponseHex = types.StringValue(hex.EncodeToString(response))
+}
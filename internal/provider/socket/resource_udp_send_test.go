@@ -0,0 +1,102 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package socket_test
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// startUdpServer starts a server answering datagrams starting with "ping"
+// with "pong", and ignoring all others. It returns its address.
+func startUdpServer(t *testing.T) string {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("error listening: %s", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	go func() {
+		buf := make([]byte, 1024)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+
+			if strings.HasPrefix(string(buf[:n]), "ping") {
+				_, _ = conn.WriteTo([]byte("pong"), addr)
+			}
+		}
+	}()
+
+	return conn.LocalAddr().String()
+}
+
+func TestUdpSendResource_ExpectResponse(t *testing.T) {
+	address := startUdpServer(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							resource "utilities_udp_send" "udp_test" {
+								address         = "%s"
+								payload         = "ping"
+								expect_response = true
+							}`, address),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("utilities_udp_send.udp_test", "response", "pong"),
+					resource.TestCheckResourceAttr("utilities_udp_send.udp_test", "response_hex", "706f6e67"),
+				),
+			},
+		},
+	})
+}
+
+func TestUdpSendResource_FireAndForget(t *testing.T) {
+	address := startUdpServer(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							resource "utilities_udp_send" "udp_test" {
+								address     = "%s"
+								payload_hex = "6d6574726963"
+							}`, address),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr("utilities_udp_send.udp_test", "response"),
+				),
+			},
+		},
+	})
+}
+
+func TestUdpSendResource_NoResponse(t *testing.T) {
+	address := startUdpServer(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							resource "utilities_udp_send" "udp_test" {
+								address            = "%s"
+								payload            = "metric:1|c"
+								expect_response    = true
+								request_timeout_ms = 200
+							}`, address),
+				ExpectError: regexp.MustCompile(`no response received after 200ms`),
+			},
+		},
+	})
+}
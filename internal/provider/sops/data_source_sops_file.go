@@ -0,0 +1,229 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package sops
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = (*sopsFileDataSource)(nil)
+
+func NewSopsFileDataSource() datasource.DataSource {
+	return &sopsFileDataSource{}
+}
+
+type sopsFileDataSource struct{}
+
+type sopsFileDataSourceModel struct {
+	ID          types.String  `tfsdk:"id"`
+	Path        types.String  `tfsdk:"path"`
+	Content     types.String  `tfsdk:"content"`
+	AgeIdentity types.String  `tfsdk:"age_identity"`
+	Data        types.Dynamic `tfsdk:"data"`
+	JSON        types.String  `tfsdk:"json"`
+}
+
+func (d *sopsFileDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_sops_file"
+}
+
+func (d *sopsFileDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: `
+The ` + "`sops_file`" + ` data source decrypts a YAML or JSON file encrypted by [SOPS](https://getsops.io) and exports its data.
+
+The data key of the file is decrypted with its age or AWS KMS master keys, the first one available being used:
+
+- The age identities are ` + "`age_identity`" + `, or the ones SOPS uses: the ` + "`SOPS_AGE_KEY`" + ` environment variable,
+  the file at ` + "`SOPS_AGE_KEY_FILE`" + `, or the ` + "`sops/age/keys.txt`" + ` file in the user configuration directory.
+- The AWS credentials are loaded from the environment and the shared configuration files, as the AWS CLI does,
+  the ` + "`aws_profile`" + ` and ` + "`role`" + ` of the key being honored.
+
+The values are checked against the MAC of the file, so that a file tampered with is rejected. Files whose data key
+is split across several key groups are not supported.
+`,
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The SHA-256 hash of the encrypted file.",
+				Computed:    true,
+			},
+
+			"path": schema.StringAttribute{
+				Description: "The path of the encrypted file.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("path"), path.MatchRoot("content")),
+				},
+			},
+
+			"content": schema.StringAttribute{
+				Description: "The content of the encrypted file.",
+				Optional:    true,
+			},
+
+			"age_identity": schema.StringAttribute{
+				Description: "The age identities in the format of an age identity file: `AGE-SECRET-KEY-1` keys, " +
+					"one per line, `#` comments being ignored.",
+				Optional:  true,
+				Sensitive: true,
+			},
+
+			"data": schema.DynamicAttribute{
+				Description: "The decrypted data, without the SOPS metadata, as returned by `yamldecode`.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+
+			"json": schema.StringAttribute{
+				Description: "The decrypted data encoded in JSON.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+		},
+	}
+}
+
+func (d *sopsFileDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model sopsFileDataSourceModel
+	diags := req.Config.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	model.read(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (model *sopsFileDataSourceModel) read(ctx context.Context, diagnostics *diag.Diagnostics) {
+	content := []byte(model.Content.ValueString())
+	if !model.Path.IsNull() {
+		var err error
+		content, err = os.ReadFile(model.Path.ValueString())
+		if err != nil {
+			diagnostics.AddAttributeError(
+				path.Root("path"),
+				"Error reading file",
+				fmt.Sprintf("Error reading the encrypted file: %s", err),
+			)
+			return
+		}
+	}
+
+	checksum := sha256.Sum256(content)
+	model.ID = types.StringValue(hex.EncodeToString(checksum[:]))
+
+	doc, err := parseDocument(content)
+	if err != nil {
+		addDecryptError(diagnostics, err)
+		return
+	}
+
+	dataKey, err := doc.dataKey(ctx, model.AgeIdentity.ValueString())
+	if err != nil {
+		addDecryptError(diagnostics, err)
+		return
+	}
+
+	values, err := doc.decrypt(dataKey)
+	if err != nil {
+		addDecryptError(diagnostics, err)
+		return
+	}
+
+	data, err := toValue(values)
+	if err != nil {
+		addDecryptError(diagnostics, err)
+		return
+	}
+	model.Data = types.DynamicValue(data)
+
+	encoded, err := json.Marshal(values)
+	if err != nil {
+		addDecryptError(diagnostics, err)
+		return
+	}
+	model.JSON = types.StringValue(string(encoded))
+}
+
+func addDecryptError(diagnostics *diag.Diagnostics, err error) {
+	diagnostics.AddError(
+		"Error decrypting file",
+		fmt.Sprintf("Error decrypting the SOPS file: %s", err),
+	)
+}
+
+// toValue converts the decrypted values into a Terraform value, mirroring the
+// types produced by the `yamldecode` function.
+func toValue(raw interface{}) (attr.Value, error) {
+	switch v := raw.(type) {
+	case nil:
+		return types.DynamicNull(), nil
+	case bool:
+		return types.BoolValue(v), nil
+	case string:
+		return types.StringValue(v), nil
+	case int:
+		return types.NumberValue(new(big.Float).SetInt64(int64(v))), nil
+	case float64:
+		return types.NumberValue(big.NewFloat(v)), nil
+	case []interface{}:
+		elemTypes := make([]attr.Type, 0, len(v))
+		elems := make([]attr.Value, 0, len(v))
+		for _, item := range v {
+			elem, err := toValue(item)
+			if err != nil {
+				return nil, err
+			}
+			elemTypes = append(elemTypes, elem.Type(nil))
+			elems = append(elems, elem)
+		}
+
+		tuple, diags := types.TupleValue(elemTypes, elems)
+		if diags.HasError() {
+			return nil, fmt.Errorf("invalid list: %v", diags)
+		}
+		return tuple, nil
+	case map[string]interface{}:
+		attrTypes := make(map[string]attr.Type, len(v))
+		attrs := make(map[string]attr.Value, len(v))
+		for key, item := range v {
+			value, err := toValue(item)
+			if err != nil {
+				return nil, err
+			}
+			attrTypes[key] = value.Type(nil)
+			attrs[key] = value
+		}
+
+		object, diags := types.ObjectValue(attrTypes, attrs)
+		if diags.HasError() {
+			return nil, fmt.Errorf("invalid object: %v", diags)
+		}
+		return object, nil
+	default:
+		return nil, fmt.Errorf("unsupported value of type %T", raw)
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package sops_test
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// ageIdentity is the identity the files of the testdata directory are
+// encrypted for.
+const ageIdentity = "AGE-SECRET-KEY-1KFNQ5GXR26PWZGM94ZLHG0QM8TZSD8SR3RYD0DTGJ70UQ4ELEW8QQADJT5"
+
+func TestSopsFileDataSource_YAML(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_sops_file" "sops_test" {
+								path         = "testdata/secrets.enc.yaml"
+								age_identity = "%s"
+							}`, ageIdentity),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utilities_sops_file.sops_test", "data.database.user", "admin"),
+					resource.TestCheckResourceAttr("data.utilities_sops_file.sops_test", "data.database.password", "s3cr3t"),
+					resource.TestCheckResourceAttr("data.utilities_sops_file.sops_test", "data.database.port", "5432"),
+					resource.TestCheckResourceAttr("data.utilities_sops_file.sops_test", "data.database.tls", "true"),
+					resource.TestCheckResourceAttr("data.utilities_sops_file.sops_test", "data.description_unencrypted", "hello"),
+					resource.TestCheckResourceAttr("data.utilities_sops_file.sops_test", "data.hosts.#", "2"),
+					resource.TestCheckResourceAttr("data.utilities_sops_file.sops_test", "data.hosts.1", "db2.example.com"),
+					resource.TestCheckResourceAttrSet("data.utilities_sops_file.sops_test", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestSopsFileDataSource_JSON(t *testing.T) {
+	t.Setenv("SOPS_AGE_KEY", ageIdentity)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+							data "utilities_sops_file" "sops_test" {
+								content = file("testdata/secrets.enc.json")
+							}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utilities_sops_file.sops_test", "data.database.password", "s3cr3t"),
+					resource.TestCheckResourceAttr("data.utilities_sops_file.sops_test", "json",
+						`{"database":{"password":"s3cr3t","port":5432,"ratio":0.75,"tls":true,"user":"admin"},`+
+							`"description_unencrypted":"hello","empty":"","hosts":["db1.example.com","db2.example.com"]}`),
+				),
+			},
+		},
+	})
+}
+
+func TestSopsFileDataSource_Tampered(t *testing.T) {
+	content, err := os.ReadFile("testdata/secrets.enc.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tampered := strings.Replace(string(content), "description_unencrypted: hello", "description_unencrypted: goodbye", 1)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "utilities_sops_file" "sops_test" {
+								content      = %q
+								age_identity = "%s"
+							}`, tampered, ageIdentity),
+				ExpectError: regexp.MustCompile("MAC mismatch"),
+			},
+		},
+	})
+}
+
+func TestSopsFileDataSource_WrongIdentity(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+							data "utilities_sops_file" "sops_test" {
+								path         = "testdata/secrets.enc.yaml"
+								age_identity = "AGE-SECRET-KEY-1SCL998E3779RADPW00YRXN0CHAWTHJJNR9Y6L296HDJSSM4LL8KQVPV2C5"
+							}`,
+				ExpectError: regexp.MustCompile("no identity matched any of the recipients"),
+			},
+		},
+	})
+}
@@ -0,0 +1,152 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package sops
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+const (
+	// ageKeyEnv holds the age identities, as SOPS does.
+	ageKeyEnv = "SOPS_AGE_KEY"
+	// ageKeyFileEnv holds the path of the age identity file, as SOPS does.
+	ageKeyFileEnv = "SOPS_AGE_KEY_FILE"
+)
+
+// dataKey returns the data key of the document, decrypted with the first of
+// its master keys available: the age identities then the AWS KMS keys.
+func (doc *document) dataKey(ctx context.Context, ageIdentities string) ([]byte, error) {
+	ageKeys, kmsKeys := doc.metadata.AgeKeys, doc.metadata.KMSKeys
+	switch len(doc.metadata.KeyGroups) {
+	case 0:
+	case 1:
+		ageKeys = append(ageKeys, doc.metadata.KeyGroups[0].AgeKeys...)
+		kmsKeys = append(kmsKeys, doc.metadata.KeyGroups[0].KMSKeys...)
+	default:
+		return nil, fmt.Errorf("the data key is split across %d key groups, Shamir secret sharing is not supported", len(doc.metadata.KeyGroups))
+	}
+
+	if len(ageKeys) == 0 && len(kmsKeys) == 0 {
+		return nil, fmt.Errorf("the file is not encrypted with age or AWS KMS keys, the only ones supported")
+	}
+
+	var errs []error
+
+	if len(ageKeys) > 0 {
+		identities, err := loadAgeIdentities(ageIdentities)
+		if err == nil {
+			for _, key := range ageKeys {
+				dataKey, err := decryptAgeDataKey(key, identities)
+				if err == nil {
+					return dataKey, nil
+				}
+				errs = append(errs, fmt.Errorf("age recipient %s: %w", key.Recipient, err))
+			}
+		} else {
+			errs = append(errs, err)
+		}
+	}
+
+	for _, key := range kmsKeys {
+		dataKey, err := decryptKMSDataKey(ctx, key)
+		if err == nil {
+			return dataKey, nil
+		}
+		errs = append(errs, fmt.Errorf("AWS KMS key %s: %w", key.ARN, err))
+	}
+
+	return nil, fmt.Errorf("cannot decrypt the data key: %w", errors.Join(errs...))
+}
+
+// loadAgeIdentities returns the given age identities, or the ones SOPS would
+// use: the SOPS_AGE_KEY environment variable, the file at SOPS_AGE_KEY_FILE,
+// or the sops/age/keys.txt file in the user configuration directory.
+func loadAgeIdentities(identities string) ([]age.Identity, error) {
+	if identities == "" {
+		identities = os.Getenv(ageKeyEnv)
+	}
+
+	if identities == "" {
+		filename := os.Getenv(ageKeyFileEnv)
+		if filename == "" {
+			configDir, err := os.UserConfigDir()
+			if err != nil {
+				return nil, fmt.Errorf("no age identity found: %w", err)
+			}
+			filename = filepath.Join(configDir, "sops", "age", "keys.txt")
+		}
+
+		data, err := os.ReadFile(filename)
+		if err != nil {
+			return nil, fmt.Errorf("no age identity found: %w", err)
+		}
+		identities = string(data)
+	}
+
+	return age.ParseIdentities(strings.NewReader(identities))
+}
+
+func decryptAgeDataKey(key ageKey, identities []age.Identity) ([]byte, error) {
+	reader, err := age.Decrypt(armor.NewReader(strings.NewReader(strings.TrimSpace(key.EncryptedDataKey))), identities...)
+	if err != nil {
+		return nil, err
+	}
+
+	return io.ReadAll(reader)
+}
+
+// decryptKMSDataKey decrypts the data key with AWS KMS, the credentials being
+// loaded from the environment as the AWS CLI does.
+func decryptKMSDataKey(ctx context.Context, key kmsKey) ([]byte, error) {
+	keyARN, err := arn.Parse(key.ARN)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ARN: %w", err)
+	}
+
+	options := []func(*config.LoadOptions) error{config.WithRegion(keyARN.Region)}
+	if key.AWSProfile != "" {
+		options = append(options, config.WithSharedConfigProfile(key.AWSProfile))
+	}
+
+	cfg, err := config.LoadDefaultConfig(ctx, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	if key.Role != "" {
+		cfg.Credentials = aws.NewCredentialsCache(stscreds.NewAssumeRoleProvider(sts.NewFromConfig(cfg), key.Role))
+	}
+
+	ciphertext, err := base64.StdEncoding.DecodeString(key.EncryptedDataKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base64 data key: %w", err)
+	}
+
+	output, err := kms.NewFromConfig(cfg).Decrypt(ctx, &kms.DecryptInput{
+		KeyId:             aws.String(key.ARN),
+		CiphertextBlob:    ciphertext,
+		EncryptionContext: key.Context,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return output.Plaintext, nil
+}
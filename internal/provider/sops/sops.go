@@ -0,0 +1,292 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+// Package sops decrypts the files encrypted by SOPS (https://getsops.io).
+package sops
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha512"
+	"encoding/base64"
+	"fmt"
+	"hash"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// metadataKey is the key of the document holding the SOPS metadata.
+const metadataKey = "sops"
+
+// macOnlyEncryptedInitialization is hashed first when only the encrypted
+// values are authenticated, so that the MAC differs from the one of all the
+// values.
+var macOnlyEncryptedInitialization = []byte{
+	0x8a, 0x3f, 0xd2, 0xad, 0x54, 0xce, 0x66, 0x52, 0x7b, 0x10, 0x34, 0xf3, 0xd1, 0x47, 0xbe, 0x0b,
+	0x0b, 0x97, 0x5b, 0x3b, 0xf4, 0x4f, 0x72, 0xc6, 0xfd, 0xad, 0xec, 0x81, 0x76, 0xf2, 0x7d, 0x69,
+}
+
+// encryptedValueRegexp matches the values encrypted by SOPS.
+var encryptedValueRegexp = regexp.MustCompile(`^ENC\[AES256_GCM,data:(.+),iv:(.+),tag:(.+),type:(.+)\]`)
+
+type metadata struct {
+	KeyGroups        []keyGroup `yaml:"key_groups"`
+	KMSKeys          []kmsKey   `yaml:"kms"`
+	AgeKeys          []ageKey   `yaml:"age"`
+	LastModified     string     `yaml:"lastmodified"`
+	MAC              string     `yaml:"mac"`
+	MACOnlyEncrypted bool       `yaml:"mac_only_encrypted"`
+}
+
+type keyGroup struct {
+	KMSKeys []kmsKey `yaml:"kms"`
+	AgeKeys []ageKey `yaml:"age"`
+}
+
+type kmsKey struct {
+	ARN              string            `yaml:"arn"`
+	Role             string            `yaml:"role"`
+	Context          map[string]string `yaml:"context"`
+	EncryptedDataKey string            `yaml:"enc"`
+	AWSProfile       string            `yaml:"aws_profile"`
+}
+
+type ageKey struct {
+	Recipient        string `yaml:"recipient"`
+	EncryptedDataKey string `yaml:"enc"`
+}
+
+// document is a SOPS encrypted YAML or JSON document.
+type document struct {
+	metadata metadata
+	root     *yaml.Node
+}
+
+// parseDocument parses the encrypted file, the JSON documents being parsed as
+// YAML flow documents.
+func parseDocument(data []byte) (*document, error) {
+	var file yaml.Node
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("invalid document: %w", err)
+	}
+
+	if len(file.Content) != 1 || file.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("the document must be a single YAML or JSON object")
+	}
+
+	root := file.Content[0]
+	doc := &document{root: &yaml.Node{Kind: yaml.MappingNode}}
+	found := false
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == metadataKey {
+			if err := root.Content[i+1].Decode(&doc.metadata); err != nil {
+				return nil, fmt.Errorf("invalid SOPS metadata: %w", err)
+			}
+			found = true
+			continue
+		}
+		doc.root.Content = append(doc.root.Content, root.Content[i], root.Content[i+1])
+	}
+
+	if !found {
+		return nil, fmt.Errorf("the document has no SOPS metadata, it is not encrypted with SOPS")
+	}
+
+	return doc, nil
+}
+
+// decrypt returns the decrypted values of the document, checking them against
+// its MAC.
+func (doc *document) decrypt(dataKey []byte) (interface{}, error) {
+	gcm, err := newCipher(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	mac := sha512.New()
+	if doc.metadata.MACOnlyEncrypted {
+		mac.Write(macOnlyEncryptedInitialization)
+	}
+
+	walker := &walker{gcm: gcm, mac: mac, macOnlyEncrypted: doc.metadata.MACOnlyEncrypted}
+	values, err := walker.walk(doc.root, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := doc.checkMAC(gcm, fmt.Sprintf("%X", mac.Sum(nil))); err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
+
+// checkMAC compares the computed MAC with the one of the metadata, encrypted
+// with the last modification time as additional data.
+func (doc *document) checkMAC(gcm func(int) (cipher.AEAD, error), computed string) error {
+	lastModified, err := time.Parse(time.RFC3339, doc.metadata.LastModified)
+	if err != nil {
+		return fmt.Errorf("invalid lastmodified in the SOPS metadata: %w", err)
+	}
+
+	expected, err := decryptValue(gcm, doc.metadata.MAC, lastModified.Format(time.RFC3339))
+	if err != nil {
+		return fmt.Errorf("cannot decrypt the MAC: %w", err)
+	}
+
+	if expected != computed {
+		return fmt.Errorf("MAC mismatch, the file was tampered with or not saved by SOPS")
+	}
+
+	return nil
+}
+
+// newCipher returns the AES-GCM constructor of the data key, the nonce size
+// of each value being given by its IV.
+func newCipher(dataKey []byte) (func(int) (cipher.AEAD, error), error) {
+	block, err := aes.NewCipher(dataKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid data key: %w", err)
+	}
+
+	return func(nonceSize int) (cipher.AEAD, error) {
+		return cipher.NewGCMWithNonceSize(block, nonceSize)
+	}, nil
+}
+
+// walker decrypts the values of the document in order, hashing them for the
+// MAC.
+type walker struct {
+	gcm              func(int) (cipher.AEAD, error)
+	mac              hash.Hash
+	macOnlyEncrypted bool
+}
+
+func (w *walker) walk(node *yaml.Node, path []string) (interface{}, error) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		values := make(map[string]interface{}, len(node.Content)/2)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i].Value
+			value, err := w.walk(node.Content[i+1], append(path, key))
+			if err != nil {
+				return nil, err
+			}
+			values[key] = value
+		}
+		return values, nil
+	case yaml.SequenceNode:
+		values := make([]interface{}, 0, len(node.Content))
+		for _, item := range node.Content {
+			// The items of a sequence share the path of the sequence.
+			value, err := w.walk(item, path)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		return values, nil
+	case yaml.AliasNode:
+		return w.walk(node.Alias, path)
+	case yaml.ScalarNode:
+		return w.leaf(node, path)
+	default:
+		return nil, fmt.Errorf("unsupported YAML node at %s", strings.Join(path, "."))
+	}
+}
+
+func (w *walker) leaf(node *yaml.Node, path []string) (interface{}, error) {
+	var value interface{}
+	if err := node.Decode(&value); err != nil {
+		return nil, fmt.Errorf("invalid value at %s: %w", strings.Join(path, "."), err)
+	}
+
+	if value == nil {
+		return nil, nil
+	}
+
+	encrypted := false
+	if s, ok := value.(string); ok && encryptedValueRegexp.MatchString(s) {
+		decrypted, err := decryptValue(w.gcm, s, strings.Join(path, ":")+":")
+		if err != nil {
+			return nil, fmt.Errorf("cannot decrypt the value at %s: %w", strings.Join(path, "."), err)
+		}
+		value, encrypted = decrypted, true
+	}
+
+	if !w.macOnlyEncrypted || encrypted {
+		data, err := macBytes(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value at %s: %w", strings.Join(path, "."), err)
+		}
+		w.mac.Write(data)
+	}
+
+	return value, nil
+}
+
+// decryptValue decrypts an `ENC[AES256_GCM,...]` value, the path of the value
+// being the additional data.
+func decryptValue(gcm func(int) (cipher.AEAD, error), value, additionalData string) (interface{}, error) {
+	matches := encryptedValueRegexp.FindStringSubmatch(value)
+	if matches == nil {
+		return nil, fmt.Errorf("the value is not encrypted by SOPS")
+	}
+
+	parts := make([][]byte, 3)
+	for i, name := range []string{"data", "iv", "tag"} {
+		part, err := base64.StdEncoding.DecodeString(matches[i+1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid base64 %s: %w", name, err)
+		}
+		parts[i] = part
+	}
+	data, iv, tag := parts[0], parts[1], parts[2]
+
+	aead, err := gcm(len(iv))
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := aead.Open(nil, iv, append(data, tag...), []byte(additionalData))
+	if err != nil {
+		return nil, fmt.Errorf("authentication failed, the data key does not match")
+	}
+
+	switch datatype := matches[4]; datatype {
+	case "str", "bytes":
+		return string(plaintext), nil
+	case "int":
+		return strconv.Atoi(string(plaintext))
+	case "float":
+		return strconv.ParseFloat(string(plaintext), 64)
+	case "bool":
+		return strconv.ParseBool(string(plaintext))
+	default:
+		return nil, fmt.Errorf("unsupported type %q", datatype)
+	}
+}
+
+// macBytes returns the bytes of the value hashed for the MAC, matching the
+// representation used by SOPS.
+func macBytes(value interface{}) ([]byte, error) {
+	switch v := value.(type) {
+	case string:
+		return []byte(v), nil
+	case int:
+		return []byte(strconv.Itoa(v)), nil
+	case float64:
+		return []byte(strconv.FormatFloat(v, 'f', -1, 64)), nil
+	case bool:
+		if v {
+			return []byte("True"), nil
+		}
+		return []byte("False"), nil
+	default:
+		return nil, fmt.Errorf("unsupported value of type %T", value)
+	}
+}
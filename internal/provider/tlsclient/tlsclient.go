@@ -0,0 +1,60 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package tlsclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Model holds the TLS settings of the clients connecting to a server.
+type Model struct {
+	CaCertificate types.String `tfsdk:"ca_cert_pem"`
+	ClientCert    types.String `tfsdk:"client_cert_pem"`
+	ClientKey     types.String `tfsdk:"client_key_pem"`
+	Insecure      types.Bool   `tfsdk:"insecure"`
+}
+
+// Config returns the TLS configuration of the client, verifying the server
+// with `ca_cert_pem` and presenting the client certificate when set. It
+// returns nil when the certificates can't be loaded.
+func (model *Model) Config(diagnostics *diag.Diagnostics) *tls.Config {
+	tlsConfig := &tls.Config{}
+
+	if !model.Insecure.IsNull() {
+		tlsConfig.InsecureSkipVerify = model.Insecure.ValueBool()
+	}
+
+	// Use `ca_cert_pem` cert pool
+	if !model.CaCertificate.IsNull() {
+		caCertPool := x509.NewCertPool()
+		if ok := caCertPool.AppendCertsFromPEM([]byte(model.CaCertificate.ValueString())); !ok {
+			diagnostics.AddError(
+				"Error configuring TLS client",
+				"Error tls: Can't add the CA certificate to certificate pool. Only PEM encoded certificates are supported.",
+			)
+			return nil
+		}
+
+		tlsConfig.RootCAs = caCertPool
+	}
+
+	if !model.ClientCert.IsNull() && !model.ClientKey.IsNull() {
+		cert, err := tls.X509KeyPair([]byte(model.ClientCert.ValueString()), []byte(model.ClientKey.ValueString()))
+		if err != nil {
+			diagnostics.AddError(
+				"error creating x509 key pair",
+				fmt.Sprintf("error creating x509 key pair from provided pem blocks\n\nError: %s", err),
+			)
+			return nil
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig
+}
@@ -0,0 +1,54 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package tlsclient
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestModel_Config(t *testing.T) {
+	var diagnostics diag.Diagnostics
+	model := Model{Insecure: types.BoolValue(true)}
+
+	tlsConfig := model.Config(&diagnostics)
+	if diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", diagnostics)
+	}
+	if !tlsConfig.InsecureSkipVerify {
+		t.Fatalf("expected the verification of the server to be disabled")
+	}
+	if tlsConfig.RootCAs != nil || len(tlsConfig.Certificates) != 0 {
+		t.Fatalf("expected no CA certificate nor client certificate")
+	}
+}
+
+func TestModel_ConfigInvalidCaCertificate(t *testing.T) {
+	var diagnostics diag.Diagnostics
+	model := Model{CaCertificate: types.StringValue("not a certificate")}
+
+	if tlsConfig := model.Config(&diagnostics); tlsConfig != nil {
+		t.Fatalf("expected no TLS configuration, got %v", tlsConfig)
+	}
+	if !diagnostics.HasError() {
+		t.Fatalf("expected an error for the invalid CA certificate")
+	}
+}
+
+func TestModel_ConfigInvalidClientCert(t *testing.T) {
+	var diagnostics diag.Diagnostics
+	model := Model{
+		ClientCert: types.StringValue("not a certificate"),
+		ClientKey:  types.StringValue("not a key"),
+	}
+
+	if tlsConfig := model.Config(&diagnostics); tlsConfig != nil {
+		t.Fatalf("expected no TLS configuration, got %v", tlsConfig)
+	}
+	if !diagnostics.HasError() {
+		t.Fatalf("expected an error for the invalid client certificate")
+	}
+}
@@ -0,0 +1,405 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package websocket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"terraform-provider-utilities/internal/provider/tlsclient"
+
+	"github.com/gorilla/websocket"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	defaultMaxMessages = 1
+	defaultTimeout     = 10000
+)
+
+var _ resource.Resource = (*websocketResource)(nil)
+
+func NewWebsocketResource() resource.Resource {
+	return &websocketResource{}
+}
+
+type websocketResource struct{}
+type websocketResourceModel struct {
+	ID              types.String `tfsdk:"id"`
+	URL             types.String `tfsdk:"url"`
+	RequestHeaders  types.Map    `tfsdk:"request_headers"`
+	Message         types.String `tfsdk:"message"`
+	MaxMessages     types.Int64  `tfsdk:"max_messages"`
+	ResponseRegex   types.String `tfsdk:"response_regex"`
+	RequestTimeout  types.Int64  `tfsdk:"request_timeout_ms"`
+	CaCertificate   types.String `tfsdk:"ca_cert_pem"`
+	ClientCert      types.String `tfsdk:"client_cert_pem"`
+	ClientKey       types.String `tfsdk:"client_key_pem"`
+	Insecure        types.Bool   `tfsdk:"insecure"`
+	Keepers         types.Map    `tfsdk:"keepers"`
+	Messages        types.List   `tfsdk:"messages"`
+	MatchedMessage  types.String `tfsdk:"matched_message"`
+	StatusCode      types.Int64  `tfsdk:"status_code"`
+	ResponseHeaders types.Map    `tfsdk:"response_headers"`
+}
+
+func (r *websocketResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_websocket"
+}
+
+func (r *websocketResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: `
+The ` + "`websocket`" + ` resource opens a WebSocket connection to the given URL upon creation,
+optionally sends a message, captures the first received messages and closes the connection.
+When ` + "`response_regex`" + ` is set, the connection is kept open until a received message
+matches it, e.g. to wait for the acknowledgement of a realtime gateway.
+
+The given URL may be either a ` + "`ws`" + ` or ` + "`wss`" + ` URL. This resource
+will issue a warning if a received message is not UTF-8 encoded.
+`,
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The URL used for the connection.",
+				Computed:    true,
+			},
+
+			"url": schema.StringAttribute{
+				Description: "The URL to connect to. Supported schemes are `ws` and `wss`.",
+				Required:    true,
+			},
+
+			"request_headers": schema.MapAttribute{
+				Description: "A map of header field names and values sent with the opening handshake.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+
+			"message": schema.StringAttribute{
+				Description: "A text message sent once the connection is established.",
+				Optional:    true,
+			},
+
+			"max_messages": schema.Int64Attribute{
+				Description: fmt.Sprintf("The number of messages to capture before closing the connection. "+
+					"Set to `0` to only verify the opening handshake. Defaults to `%d`.", defaultMaxMessages),
+				Optional: true,
+				Computed: true,
+				Default:  int64default.StaticInt64(defaultMaxMessages),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+
+			"response_regex": schema.StringAttribute{
+				Description: "A regular expression a received message must match. The messages are received until one matches, " +
+					"otherwise an error is raised once `request_timeout_ms` has elapsed. The matching message is exported in " +
+					"`matched_message`, `messages` still capturing the first `max_messages` received messages. " +
+					"The syntax is described in the [RE2 documentation](https://github.com/google/re2/wiki/Syntax).",
+				Optional: true,
+			},
+
+			"request_timeout_ms": schema.Int64Attribute{
+				Description: fmt.Sprintf("The time allowed to connect and receive the messages in milliseconds. Defaults to `%d`.", defaultTimeout),
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(defaultTimeout),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+
+			"ca_cert_pem": schema.StringAttribute{
+				Description: "Certificate Authority (CA) " +
+					"in [PEM (RFC 1421)](https://datatracker.ietf.org/doc/html/rfc1421) format.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("insecure")),
+				},
+			},
+
+			"client_cert_pem": schema.StringAttribute{
+				Description: "Client certificate " +
+					"in [PEM (RFC 1421)](https://datatracker.ietf.org/doc/html/rfc1421) format.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("client_key_pem")),
+				},
+			},
+
+			"client_key_pem": schema.StringAttribute{
+				Description: "Client key " +
+					"in [PEM (RFC 1421)](https://datatracker.ietf.org/doc/html/rfc1421) format.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("client_cert_pem")),
+				},
+			},
+
+			"insecure": schema.BoolAttribute{
+				Description: "Disables verification of the server's certificate chain and hostname. Defaults to `false`",
+				Optional:    true,
+			},
+
+			"messages": schema.ListAttribute{
+				Description: "The captured messages, in the order they were received.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+
+			"matched_message": schema.StringAttribute{
+				Description: "The first received message matching `response_regex`, when set.",
+				Computed:    true,
+			},
+
+			"status_code": schema.Int64Attribute{
+				Description: "The HTTP status code of the opening handshake response.",
+				Computed:    true,
+			},
+
+			"response_headers": schema.MapAttribute{
+				Description: "A map of the opening handshake response header field names and values.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+
+			"keepers": schema.MapAttribute{
+				Description: "Arbitrary map of values that, when changed, will trigger recreation of " +
+					"resource.",
+				ElementType: types.StringType,
+				Optional:    true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplaceIfConfigured(),
+				},
+			},
+		},
+	}
+}
+
+func (r *websocketResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+}
+
+func (r *websocketResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var model websocketResourceModel
+	diags := req.State.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *websocketResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var model websocketResourceModel
+	diags := req.Plan.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	model.probe(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *websocketResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var model websocketResourceModel
+	diags := req.Plan.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	model.probe(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *websocketResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data websocketResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (model *websocketResourceModel) probe(ctx context.Context, diagnostics *diag.Diagnostics) {
+	timeout := time.Duration(model.RequestTimeout.ValueInt64()) * time.Millisecond
+	deadline := time.Now().Add(timeout)
+
+	ctx, cancel := context.WithDeadline(ctx, deadline)
+	defer cancel()
+
+	var re *regexp.Regexp
+	if !model.ResponseRegex.IsNull() {
+		var err error
+		re, err = regexp.Compile(model.ResponseRegex.ValueString())
+		if err != nil {
+			diagnostics.AddAttributeError(
+				path.Root("response_regex"),
+				"Invalid regular expression",
+				fmt.Sprintf("The regular expression could not be compiled: %s", err),
+			)
+			return
+		}
+	}
+
+	tlsModel := tlsclient.Model{
+		CaCertificate: model.CaCertificate,
+		ClientCert:    model.ClientCert,
+		ClientKey:     model.ClientKey,
+		Insecure:      model.Insecure,
+	}
+	tlsConfig := tlsModel.Config(diagnostics)
+	if diagnostics.HasError() {
+		return
+	}
+
+	dialer := websocket.Dialer{
+		Proxy:            http.ProxyFromEnvironment,
+		TLSClientConfig:  tlsConfig,
+		HandshakeTimeout: timeout,
+	}
+
+	requestHeaders := http.Header{}
+	for name, value := range model.RequestHeaders.Elements() {
+		var header string
+		diags := tfsdk.ValueAs(ctx, value, &header)
+		diagnostics.Append(diags...)
+		if diagnostics.HasError() {
+			return
+		}
+
+		requestHeaders.Set(name, header)
+	}
+
+	conn, response, err := dialer.DialContext(ctx, model.URL.ValueString(), requestHeaders)
+	if err != nil {
+		detail := fmt.Sprintf("Error opening connection: %s", err)
+		if response != nil {
+			detail = fmt.Sprintf("Error opening connection, the server responded with HTTP status %s: %s", response.Status, err)
+		}
+
+		diagnostics.AddError("Error opening connection", detail)
+		return
+	}
+	defer conn.Close()
+
+	if !model.Message.IsNull() {
+		_ = conn.SetWriteDeadline(deadline)
+		if err := conn.WriteMessage(websocket.TextMessage, []byte(model.Message.ValueString())); err != nil {
+			diagnostics.AddError(
+				"Error sending message",
+				fmt.Sprintf("Error sending message: %s", err),
+			)
+			return
+		}
+	}
+
+	_ = conn.SetReadDeadline(deadline)
+
+	maxMessages := int(model.MaxMessages.ValueInt64())
+	messages := make([]string, 0, maxMessages)
+	matchedMessage := types.StringNull()
+	received := 0
+	for len(messages) < maxMessages || (re != nil && matchedMessage.IsNull()) {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				err = fmt.Errorf("timed out after %s", timeout)
+			}
+
+			if len(messages) >= maxMessages {
+				diagnostics.AddAttributeError(
+					path.Root("response_regex"),
+					"Error receiving message",
+					fmt.Sprintf("None of the %d received messages matches the regular expression %q: %s", received, re.String(), err),
+				)
+				return
+			}
+
+			diagnostics.AddError(
+				"Error receiving message",
+				fmt.Sprintf("Error receiving message %d of %d: %s", len(messages)+1, maxMessages, err),
+			)
+			return
+		}
+		received++
+
+		if !utf8.Valid(data) {
+			diagnostics.AddWarning(
+				"Message is not recognized as UTF-8",
+				"Terraform may not properly handle the messages if their contents are binary.",
+			)
+		}
+
+		if len(messages) < maxMessages {
+			messages = append(messages, string(data))
+		}
+
+		if re != nil && matchedMessage.IsNull() && re.Match(data) {
+			matchedMessage = types.StringValue(string(data))
+		}
+	}
+
+	_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), deadline)
+
+	responseHeaders := make(map[string]string)
+	for k, v := range response.Header {
+		// Concatenate according to RFC9110 https://www.rfc-editor.org/rfc/rfc9110.html#section-5.2
+		responseHeaders[k] = strings.Join(v, ", ")
+	}
+
+	respHeadersState, diags := types.MapValueFrom(ctx, types.StringType, responseHeaders)
+	diagnostics.Append(diags...)
+	if diagnostics.HasError() {
+		return
+	}
+
+	messagesState, diags := types.ListValueFrom(ctx, types.StringType, messages)
+	diagnostics.Append(diags...)
+	if diagnostics.HasError() {
+		return
+	}
+
+	model.ID = model.URL
+	model.Messages = messagesState
+	model.MatchedMessage = matchedMessage
+	model.StatusCode = types.Int64Value(int64(response.StatusCode))
+	model.ResponseHeaders = respHeadersState
+}
@@ -0,0 +1,168 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package websocket_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// newEchoServer starts a server greeting every client and then echoing each
+// received message back.
+func newEchoServer(t *testing.T) *httptest.Server {
+	upgrader := websocket.Upgrader{}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, http.Header{"X-Greeting": []string{"hello"}})
+		if err != nil {
+			t.Errorf("error upgrading connection: %s", err)
+			return
+		}
+		defer conn.Close()
+
+		if err := conn.WriteMessage(websocket.TextMessage, []byte("welcome")); err != nil {
+			return
+		}
+
+		for {
+			messageType, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+
+			if err := conn.WriteMessage(messageType, data); err != nil {
+				return
+			}
+		}
+	}))
+}
+
+func TestResource_Messages(t *testing.T) {
+	svr := newEchoServer(t)
+	defer svr.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							resource "utilities_websocket" "websocket_test" {
+								url          = "%s"
+								message      = "ping"
+								max_messages = 2
+							}`, strings.Replace(svr.URL, "http", "ws", 1)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("utilities_websocket.websocket_test", "messages.#", "2"),
+					resource.TestCheckResourceAttr("utilities_websocket.websocket_test", "messages.0", "welcome"),
+					resource.TestCheckResourceAttr("utilities_websocket.websocket_test", "messages.1", "ping"),
+					resource.TestCheckResourceAttr("utilities_websocket.websocket_test", "status_code", "101"),
+					resource.TestCheckResourceAttr("utilities_websocket.websocket_test", "response_headers.X-Greeting", "hello"),
+				),
+			},
+		},
+	})
+}
+
+func TestResource_HandshakeOnly(t *testing.T) {
+	svr := newEchoServer(t)
+	defer svr.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							resource "utilities_websocket" "websocket_test" {
+								url          = "%s"
+								max_messages = 0
+							}`, strings.Replace(svr.URL, "http", "ws", 1)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("utilities_websocket.websocket_test", "messages.#", "0"),
+					resource.TestCheckResourceAttr("utilities_websocket.websocket_test", "status_code", "101"),
+				),
+			},
+		},
+	})
+}
+
+func TestResource_Timeout(t *testing.T) {
+	svr := newEchoServer(t)
+	defer svr.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							resource "utilities_websocket" "websocket_test" {
+								url                = "%s"
+								max_messages       = 2
+								request_timeout_ms = 100
+							}`, strings.Replace(svr.URL, "http", "ws", 1)),
+				ExpectError: regexp.MustCompile(`Error receiving message 2 of 2: timed out`),
+			},
+		},
+	})
+}
+
+func TestResource_ResponseRegex(t *testing.T) {
+	svr := newEchoServer(t)
+	defer svr.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							resource "utilities_websocket" "websocket_test" {
+								url            = "%s"
+								message        = "{\"type\":\"ack\"}"
+								response_regex = "\"type\":\"ack\""
+							}`, strings.Replace(svr.URL, "http", "ws", 1)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("utilities_websocket.websocket_test", "messages.#", "1"),
+					resource.TestCheckResourceAttr("utilities_websocket.websocket_test", "messages.0", "welcome"),
+					resource.TestCheckResourceAttr("utilities_websocket.websocket_test", "matched_message", `{"type":"ack"}`),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+							resource "utilities_websocket" "websocket_test" {
+								url                = "%s"
+								message            = "ping"
+								response_regex     = "pong"
+								request_timeout_ms = 100
+							}`, strings.Replace(svr.URL, "http", "ws", 1)),
+				ExpectError: regexp.MustCompile(`None of the 2 received messages matches the regular expression "pong"`),
+			},
+		},
+	})
+}
+
+func TestResource_NotWebsocket(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer svr.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							resource "utilities_websocket" "websocket_test" {
+								url = "%s"
+							}`, strings.Replace(svr.URL, "http", "ws", 1)),
+				ExpectError: regexp.MustCompile(`the server responded with HTTP status 404`),
+			},
+		},
+	})
+}
@@ -0,0 +1,141 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package testserver
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/ocsp"
+)
+
+// Certificate is a self-signed certificate for 127.0.0.1 and localhost, valid
+// for server and client authentication.
+type Certificate struct {
+	// CertPEM is the PEM encoded certificate.
+	CertPEM string
+	// KeyPEM is the PEM encoded private key.
+	KeyPEM string
+	// CertFile is the path of a file holding CertPEM.
+	CertFile string
+	// KeyFile is the path of a file holding KeyPEM.
+	KeyFile string
+
+	keyPair tls.Certificate
+}
+
+// NewCertificate generates a certificate, its files are removed at the end of
+// the test.
+func NewCertificate(t testing.TB) *Certificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate private key: %v", err)
+	}
+
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		t.Fatalf("failed to generate serial number: %v", err)
+	}
+
+	template := x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject: pkix.Name{
+			Organization: []string{"Utilities Provider Test"},
+			CommonName:   "localhost",
+		},
+		DNSNames:              []string{"localhost"},
+		IPAddresses:           []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback},
+		NotBefore:             time.Now().Add(-time.Minute),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	certDER, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("failed to marshal private key: %v", err)
+	}
+
+	cert := &Certificate{
+		CertPEM: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER})),
+		KeyPEM:  string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})),
+	}
+
+	dir := t.TempDir()
+	cert.CertFile = filepath.Join(dir, "cert.pem")
+	cert.KeyFile = filepath.Join(dir, "key.pem")
+
+	if err := os.WriteFile(cert.CertFile, []byte(cert.CertPEM), 0o600); err != nil {
+		t.Fatalf("failed to write certificate: %v", err)
+	}
+	if err := os.WriteFile(cert.KeyFile, []byte(cert.KeyPEM), 0o600); err != nil {
+		t.Fatalf("failed to write private key: %v", err)
+	}
+
+	cert.keyPair, err = tls.X509KeyPair([]byte(cert.CertPEM), []byte(cert.KeyPEM))
+	if err != nil {
+		t.Fatalf("failed to load certificate: %v", err)
+	}
+	cert.keyPair.Leaf, err = x509.ParseCertificate(certDER)
+	if err != nil {
+		t.Fatalf("failed to parse certificate: %v", err)
+	}
+
+	return cert
+}
+
+// TLSCertificate returns the certificate and its private key, e.g. to be
+// presented by a client.
+func (cert *Certificate) TLSCertificate() tls.Certificate {
+	return cert.keyPair
+}
+
+// OCSPResponse returns an OCSP response for the certificate, signed by
+// itself, with the status ocsp.Good or ocsp.Revoked.
+func (cert *Certificate) OCSPResponse(t testing.TB, status int) []byte {
+	t.Helper()
+
+	template := ocsp.Response{
+		Status:       status,
+		SerialNumber: cert.keyPair.Leaf.SerialNumber,
+		ThisUpdate:   time.Now().Add(-time.Minute),
+		NextUpdate:   time.Now().Add(time.Hour),
+	}
+	if status == ocsp.Revoked {
+		template.RevokedAt = time.Now().Add(-time.Minute)
+		template.RevocationReason = ocsp.KeyCompromise
+	}
+
+	signer, ok := cert.keyPair.PrivateKey.(*ecdsa.PrivateKey)
+	if !ok {
+		t.Fatalf("unexpected private key of type %T", cert.keyPair.PrivateKey)
+	}
+
+	response, err := ocsp.CreateResponse(cert.keyPair.Leaf, cert.keyPair.Leaf, template, signer)
+	if err != nil {
+		t.Fatalf("failed to create OCSP response: %v", err)
+	}
+
+	return response
+}
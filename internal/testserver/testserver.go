@@ -0,0 +1,159 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+// Package testserver provides HTTP and TLS servers for acceptance tests, with
+// configurable routes, latency injection, flaky routes and mutual TLS, so the
+// retry and TLS features can be tested deterministically and offline.
+package testserver
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Route is the response of the server to the requests matching a pattern.
+type Route struct {
+	// Status is the status code of the response, 200 if unset.
+	Status int
+	// Headers are the headers of the response.
+	Headers map[string]string
+	// Body is the body of the response.
+	Body string
+	// Latency delays the response, unless the request is canceled first.
+	Latency time.Duration
+	// FailFirst is the number of requests answered with FailStatus before the
+	// route succeeds.
+	FailFirst int
+	// FailStatus is the status code of the failed requests, 503 if unset.
+	FailStatus int
+	// Handler replaces the response described by the fields above, Latency
+	// and FailFirst still apply.
+	Handler http.HandlerFunc
+}
+
+// Config is the configuration of a Server.
+type Config struct {
+	// Routes maps the patterns of an http.ServeMux, e.g. `GET /items/{id}`, to
+	// their route.
+	Routes map[string]Route
+	// TLS serves HTTPS with a self-signed certificate for 127.0.0.1 and
+	// localhost.
+	TLS bool
+	// ClientAuth requires and verifies a client certificate, signed by
+	// ClientCertificate. It implies TLS.
+	ClientAuth bool
+	// OCSPStatus staples an OCSP response with the status, ocsp.Good or
+	// ocsp.Revoked, to the TLS handshakes. It implies TLS.
+	OCSPStatus *int
+}
+
+// Server is a started test server, closed at the end of the test.
+type Server struct {
+	*httptest.Server
+
+	// Certificate is the certificate of the server, when serving TLS.
+	Certificate *Certificate
+	// ClientCertificate is the certificate clients must present, when
+	// ClientAuth is set.
+	ClientCertificate *Certificate
+
+	mu       sync.Mutex
+	requests map[string]int
+}
+
+// New starts a test server with the configuration.
+func New(t testing.TB, config Config) *Server {
+	t.Helper()
+
+	server := &Server{requests: make(map[string]int)}
+
+	mux := http.NewServeMux()
+	for pattern, route := range config.Routes {
+		mux.Handle(pattern, server.handler(pattern, route))
+	}
+
+	server.Server = httptest.NewUnstartedServer(mux)
+
+	if config.TLS || config.ClientAuth || config.OCSPStatus != nil {
+		server.Certificate = NewCertificate(t)
+		keyPair := server.Certificate.keyPair
+		if config.OCSPStatus != nil {
+			keyPair.OCSPStaple = server.Certificate.OCSPResponse(t, *config.OCSPStatus)
+		}
+		server.TLS = &tls.Config{Certificates: []tls.Certificate{keyPair}}
+
+		if config.ClientAuth {
+			server.ClientCertificate = NewCertificate(t)
+
+			clientCAs := x509.NewCertPool()
+			clientCAs.AddCert(server.ClientCertificate.keyPair.Leaf)
+			server.TLS.ClientCAs = clientCAs
+			server.TLS.ClientAuth = tls.RequireAndVerifyClientCert
+		}
+
+		server.StartTLS()
+	} else {
+		server.Start()
+	}
+
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// Requests returns the number of requests received by the route of the
+// pattern, including the failed ones.
+func (server *Server) Requests(pattern string) int {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+
+	return server.requests[pattern]
+}
+
+func (server *Server) handler(pattern string, route Route) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		server.mu.Lock()
+		server.requests[pattern]++
+		count := server.requests[pattern]
+		server.mu.Unlock()
+
+		if route.Latency > 0 {
+			select {
+			case <-time.After(route.Latency):
+			case <-r.Context().Done():
+				return
+			}
+		}
+
+		if count <= route.FailFirst {
+			status := route.FailStatus
+			if status == 0 {
+				status = http.StatusServiceUnavailable
+			}
+			w.WriteHeader(status)
+			return
+		}
+
+		if route.Handler != nil {
+			route.Handler(w, r)
+			return
+		}
+
+		for name, value := range route.Headers {
+			w.Header().Set(name, value)
+		}
+
+		status := route.Status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		w.WriteHeader(status)
+
+		_, _ = w.Write([]byte(route.Body))
+	}
+}
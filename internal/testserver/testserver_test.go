@@ -0,0 +1,151 @@
+// Copyright (c) The Utilities Provider for Terraform Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package testserver_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"terraform-provider-utilities/internal/testserver"
+
+	"golang.org/x/crypto/ocsp"
+)
+
+func get(t *testing.T, client *http.Client, url string) (int, string) {
+	t.Helper()
+
+	response, err := client.Get(url)
+	if err != nil {
+		t.Fatalf("error making request: %s", err)
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		t.Fatalf("error reading body: %s", err)
+	}
+
+	return response.StatusCode, string(body)
+}
+
+func TestServer_Routes(t *testing.T) {
+	server := testserver.New(t, testserver.Config{
+		Routes: map[string]testserver.Route{
+			"GET /ok": {
+				Headers: map[string]string{"Content-Type": "text/plain"},
+				Body:    "OK",
+			},
+			"GET /flaky": {
+				FailFirst:  2,
+				FailStatus: http.StatusBadGateway,
+				Body:       "recovered",
+			},
+		},
+	})
+
+	if status, body := get(t, server.Client(), server.URL+"/ok"); status != http.StatusOK || body != "OK" {
+		t.Errorf("expected 200 OK, got %d %q", status, body)
+	}
+
+	for _, expected := range []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusOK} {
+		if status, _ := get(t, server.Client(), server.URL+"/flaky"); status != expected {
+			t.Errorf("expected %d, got %d", expected, status)
+		}
+	}
+
+	if requests := server.Requests("GET /flaky"); requests != 3 {
+		t.Errorf("expected 3 requests, got %d", requests)
+	}
+
+	if status, _ := get(t, server.Client(), server.URL+"/unknown"); status != http.StatusNotFound {
+		t.Errorf("expected unknown routes not to be found, got %d", status)
+	}
+}
+
+func TestServer_Latency(t *testing.T) {
+	server := testserver.New(t, testserver.Config{
+		Routes: map[string]testserver.Route{
+			"GET /slow": {Latency: time.Second},
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/slow", nil)
+	if err != nil {
+		t.Fatalf("error creating request: %s", err)
+	}
+
+	_, err = server.Client().Do(request)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the request to time out, got %v", err)
+	}
+}
+
+func TestServer_ClientAuth(t *testing.T) {
+	server := testserver.New(t, testserver.Config{
+		ClientAuth: true,
+		Routes: map[string]testserver.Route{
+			"GET /": {Body: "OK"},
+		},
+	})
+
+	rootCAs := x509.NewCertPool()
+	rootCAs.AppendCertsFromPEM([]byte(server.Certificate.CertPEM))
+
+	client := &http.Client{Transport: &http.Transport{
+		TLSClientConfig: &tls.Config{
+			RootCAs:      rootCAs,
+			Certificates: []tls.Certificate{server.ClientCertificate.TLSCertificate()},
+		},
+	}}
+	if status, body := get(t, client, server.URL); status != http.StatusOK || body != "OK" {
+		t.Errorf("expected 200 OK, got %d %q", status, body)
+	}
+
+	client = &http.Client{Transport: &http.Transport{
+		TLSClientConfig: &tls.Config{RootCAs: rootCAs},
+	}}
+	if _, err := client.Get(server.URL); err == nil {
+		t.Errorf("expected the request without client certificate to fail")
+	}
+}
+
+func TestServer_OCSPStatus(t *testing.T) {
+	status := ocsp.Revoked
+	server := testserver.New(t, testserver.Config{
+		Routes: map[string]testserver.Route{
+			"GET /": {Body: "OK"},
+		},
+		OCSPStatus: &status,
+	})
+
+	rootCAs := x509.NewCertPool()
+	rootCAs.AppendCertsFromPEM([]byte(server.Certificate.CertPEM))
+
+	client := &http.Client{Transport: &http.Transport{
+		TLSClientConfig: &tls.Config{RootCAs: rootCAs},
+	}}
+	response, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("error making request: %s", err)
+	}
+	defer response.Body.Close()
+
+	cert := server.Certificate.TLSCertificate().Leaf
+	parsed, err := ocsp.ParseResponseForCert(response.TLS.OCSPResponse, cert, cert)
+	if err != nil {
+		t.Fatalf("error parsing the stapled OCSP response: %s", err)
+	}
+	if parsed.Status != ocsp.Revoked {
+		t.Errorf("expected the certificate to be revoked, got status %d", parsed.Status)
+	}
+}
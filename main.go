@@ -10,7 +10,13 @@ import (
 
 	"terraform-provider-utilities/internal/provider"
 
+	"github.com/hashicorp/go-plugin"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5/tf5server"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6/tf6server"
+	"github.com/hashicorp/terraform-plugin-mux/tf6to5server"
+	"google.golang.org/grpc"
 )
 
 // Generate copyright headers
@@ -33,6 +39,10 @@ var (
 	// https://goreleaser.com/cookbooks/using-main.version/
 )
 
+// grpcMaxMessageSize is the maximum size of the messages exchanged with
+// Terraform, the one of terraform-plugin-go.
+const grpcMaxMessageSize = 256 << 20
+
 func main() {
 	var debug bool
 
@@ -47,9 +57,51 @@ func main() {
 		Debug:   debug,
 	}
 
-	err := providerserver.Serve(context.Background(), provider.New(version), opts)
+	var err error
+	if debug {
+		err = providerserver.Serve(context.Background(), provider.New(version), opts)
+	} else {
+		err = serve(context.Background(), opts.Address)
+	}
 
 	if err != nil {
 		log.Fatal(err.Error())
 	}
 }
+
+// serve serves the provider over protocol version 6, and over protocol version
+// 5 for the Terraform versions not supporting 6. The version is negotiated
+// with Terraform when it starts the provider.
+func serve(ctx context.Context, address string) error {
+	v5Server, err := tf6to5server.DowngradeServer(ctx, providerserver.NewProtocol6(provider.New(version)()))
+	if err != nil {
+		return err
+	}
+
+	plugin.Serve(&plugin.ServeConfig{
+		HandshakeConfig: plugin.HandshakeConfig{
+			MagicCookieKey:   "TF_PLUGIN_MAGIC_COOKIE",
+			MagicCookieValue: "d602bf8f470bc67ca7faa0386276bbdd4330efaf76d1a219cb4d6991ca9872b2",
+		},
+		VersionedPlugins: map[int]plugin.PluginSet{
+			5: {
+				"provider": &tf5server.GRPCProviderPlugin{
+					Name:         address,
+					GRPCProvider: func() tfprotov5.ProviderServer { return v5Server },
+				},
+			},
+			6: {
+				"provider": &tf6server.GRPCProviderPlugin{
+					Name:         address,
+					GRPCProvider: providerserver.NewProtocol6(provider.New(version)()),
+				},
+			},
+		},
+		GRPCServer: func(opts []grpc.ServerOption) *grpc.Server {
+			opts = append(opts, grpc.MaxRecvMsgSize(grpcMaxMessageSize), grpc.MaxSendMsgSize(grpcMaxMessageSize))
+			return grpc.NewServer(opts...)
+		},
+	})
+
+	return nil
+}